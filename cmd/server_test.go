@@ -56,6 +56,7 @@ var testFlags = map[string]any{
 	AtlantisURLFlag:                  "url",
 	AutoplanModules:                  false,
 	AutoplanModulesFromProjects:      "",
+	AutoplanSupersedeFlag:            true,
 	AllowCommandsFlag:                "version,plan,apply,unlock,import,approve_policies",
 	BlockedExtraArgsFlag:             "-chdir,--chdir,-plugin-dir,--plugin-dir",
 	AllowForkPRsFlag:                 true,
@@ -70,6 +71,9 @@ var testFlags = map[string]any{
 	BitbucketUserFlag:                "bitbucket-user",
 	BitbucketWebhookSecretFlag:       "bitbucket-secret",
 	CheckoutStrategyFlag:             CheckoutStrategyMerge,
+	CancelGracePeriodSecondsFlag:     30,
+	ApplyTimeoutSecondsFlag:          3600,
+	PlanTimeoutSecondsFlag:           1800,
 	CheckoutDepthFlag:                0,
 	DataDirFlag:                      "/path",
 	DefaultTFDistributionFlag:        "terraform",
@@ -83,7 +87,10 @@ var testFlags = map[string]any{
 	ExecutableName:                   "atlantis",
 	FailOnPreWorkflowHookError:       false,
 	GHAllowMergeableBypassApply:      false,
+	GHCheckRunsFlag:                  false,
 	GHHostnameFlag:                   "ghhostname",
+	GHMergeQueueFlag:                 false,
+	GHMergeQueueApplyFlag:            false,
 	GHTeamAllowlistFlag:              "",
 	GHTokenFlag:                      "token",
 	GHTokenFileFlag:                  "",
@@ -119,7 +126,10 @@ var testFlags = map[string]any{
 	AllowDraftPRs:                    true,
 	EnableExternalStoresFlag:         false,
 	PortFlag:                         8181,
+	PostgresMaxConns:                 20,
+	PostgresURL:                      "postgres://localhost:5432/atlantis",
 	ParallelPoolSize:                 100,
+	PlanSigningKeyFileFlag:           "/plan-signing.key",
 	SharePlanDirFlag:                 "/plans",
 	ParallelPlanFlag:                 true,
 	ParallelApplyFlag:                true,
@@ -157,6 +167,7 @@ var testFlags = map[string]any{
 	VCSStatusName:                    "my-status",
 	IgnoreVCSStatusNames:             "",
 	WebhookHttpHeaders:               `{"Authorization":"Bearer some-token","X-Custom-Header":["value1","value2"]}`,
+	WebhookHttpMaxRetriesFlag:        5,
 	WebBasicAuthFlag:                 false,
 	WebPasswordFlag:                  "atlantis",
 	WebUsernameFlag:                  "atlantis",
@@ -171,6 +182,14 @@ var testFlags = map[string]any{
 	EnableDiffMarkdownFormat:         false,
 	EnableDriftDetectionFlag:         true,
 	EnableDriftRemediationFlag:       true,
+	DriftRetentionDaysFlag:           7,
+	DriftRemediationRetentionFlag:    14,
+	EnableDistributedExecutionFlag:   false,
+	DistributedWorkerConcurrencyFlag: 4,
+	EnableLockQueueFlag:              true,
+	EnablePlanGCFlag:                 true,
+	PlanGCDryRunFlag:                 true,
+	PlanGCRetentionDaysFlag:          10,
 	EnableProfilingAPI:               false,
 }
 
@@ -618,6 +637,55 @@ func TestExecute_ValidateCheckoutStrategy(t *testing.T) {
 	ErrEquals(t, "invalid checkout strategy: not one of branch or merge", err)
 }
 
+func TestExecute_ValidatePostgresURL(t *testing.T) {
+	c := setupWithDefaults(map[string]any{
+		LockingDBType: "postgres",
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--postgres-url must be set when --locking-db-type is postgres", err)
+}
+
+func TestExecute_ValidateDistributedExecution(t *testing.T) {
+	c := setupWithDefaults(map[string]any{
+		EnableDistributedExecutionFlag: true,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--enable-distributed-execution requires --locking-db-type=redis", err)
+}
+
+func TestExecute_ValidateDistributedExecutionPlans(t *testing.T) {
+	c := setupWithDefaults(map[string]any{
+		EnableDistributedExecutionFlag: true,
+		LockingDBType:                  "redis",
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--enable-distributed-execution requires --enable-external-stores or --share-plan-dir", err)
+}
+
+func TestExecute_ValidateDistributedWorkerConcurrency(t *testing.T) {
+	c := setupWithDefaults(map[string]any{
+		DistributedWorkerConcurrencyFlag: -1,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--distributed-worker-concurrency must be greater than or equal to 0", err)
+}
+
+func TestExecute_ValidateGithubCheckRuns(t *testing.T) {
+	c := setupWithDefaults(map[string]any{
+		GHCheckRunsFlag: true,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--gh-check-runs requires --gh-app-id because check runs can only be created by GitHub apps", err)
+}
+
+func TestExecute_ValidateGithubMergeQueueApply(t *testing.T) {
+	c := setupWithDefaults(map[string]any{
+		GHMergeQueueApplyFlag: true,
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--gh-merge-queue-apply requires --gh-merge-queue", err)
+}
+
 func TestExecute_ValidateAutomergeMethod(t *testing.T) {
 	cases := []struct {
 		description string
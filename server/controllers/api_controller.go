@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -78,8 +79,16 @@ type APIController struct {
 	// DriftWebhookSender sends webhook notifications when drift is detected.
 	// Nil when no drift webhooks are configured.
 	DriftWebhookSender *webhooks.DriftWebhookSender
+	// WebhookDeadLetters lists HTTP webhooks that failed every delivery
+	// attempt. Nil disables GET /api/webhooks/dead-letters.
+	WebhookDeadLetters webhooks.DeadLetterStore
+	// DriftMetrics records drift detection metrics. Nil disables them.
+	DriftMetrics *drift.Metrics
 	// SilenceVCSStatusNoProjects is whether API should set commit status if no projects are found
 	SilenceVCSStatusNoProjects bool
+	// MergeQueueApply is whether RunMergeGroup applies the plans of GitHub
+	// merge queue groups instead of only planning them.
+	MergeQueueApply bool
 
 	// apiMiddleware provides common authentication and response utilities.
 	// Initialized lazily via getAPIMiddleware() with sync.Once for thread safety.
@@ -140,6 +149,9 @@ type APIRequest struct {
 	// DiscoverProjects enables all-project discovery when no projects or paths
 	// are specified. Only drift detection and remediation set this.
 	DiscoverProjects bool `json:"-"`
+	// Flags are extra arguments passed to each Terraform command, like the
+	// extra args of a comment command. Only drift detection sets this.
+	Flags []string `json:"-"`
 }
 
 type APIRequestPath struct {
@@ -155,6 +167,7 @@ func (a *APIRequest) getCommands(ctx *command.Context, cmdName command.Name, cmd
 		cc = append(cc, &events.CommentCommand{
 			Name:        cmdName,
 			ProjectName: project,
+			Flags:       a.Flags,
 		})
 	}
 	for _, path := range a.Paths {
@@ -163,6 +176,7 @@ func (a *APIRequest) getCommands(ctx *command.Context, cmdName command.Name, cmd
 			ProjectName: path.ProjectName,
 			RepoRelDir:  strings.TrimRight(path.Directory, "/"),
 			Workspace:   path.Workspace,
+			Flags:       a.Flags,
 		})
 	}
 
@@ -172,6 +186,7 @@ func (a *APIRequest) getCommands(ctx *command.Context, cmdName command.Name, cmd
 		cc = append(cc, &events.CommentCommand{
 			Name:                cmdName,
 			DiscoverAllProjects: true,
+			Flags:               a.Flags,
 		})
 	}
 
@@ -360,6 +375,219 @@ func (a *APIController) Apply(w http.ResponseWriter, r *http.Request) {
 	responder.writeJSON(w, statusCode, result)
 }
 
+// mergeQueueLockDir is the directory the apply locks of merge groups are
+// taken under. The queued pull requests usually still hold the locks of the
+// projects a merge group applies, so merge groups lock their own copy of each
+// project, which only serializes the applies of merge groups.
+const mergeQueueLockDir = ".merge-queue"
+
+// RunMergeGroup plans all projects of a GitHub merge queue group against its
+// temporary branch and reports the plan, policy check and apply statuses on
+// the merge group head commit. Planning doesn't take any locks. If
+// MergeQueueApply is set the planned projects are locked for the merge group
+// and then applied. Otherwise the apply status is only successful when none of
+// the projects have changes, i.e. they were all applied before being queued.
+func (a *APIController) RunMergeGroup(logger logging.SimpleLogging, mergeGroup models.MergeGroup) {
+	request := &APIRequest{
+		Repository:       mergeGroup.BaseRepo.FullName,
+		Ref:              mergeGroup.HeadBranch,
+		BaseBranch:       mergeGroup.BaseBranch,
+		Type:             mergeGroup.BaseRepo.VCSHost.Type.String(),
+		DiscoverProjects: true,
+	}
+	ctx := &command.Context{
+		HeadRepo: mergeGroup.BaseRepo,
+		Pull: models.PullRequest{
+			Num:                      nextNonPRPullNum(), // Synthetic non-PR workflow ID.
+			BaseBranch:               mergeGroup.BaseBranch,
+			HeadBranch:               mergeGroup.HeadBranch,
+			HeadCommit:               mergeGroup.HeadCommit,
+			BaseRepo:                 mergeGroup.BaseRepo,
+			HardenedNonPRRefCheckout: true,
+		},
+		Scope:                a.Scope,
+		Log:                  logger,
+		API:                  true,
+		MergeGroup:           true,
+		SkipPRModifiedFiles:  true,
+		SkipPRRequirements:   true,
+		RunPolicyChecks:      true,
+		SortByExecutionOrder: true,
+	}
+
+	if err := a.apiSetup(ctx, command.Plan); err != nil {
+		logger.Err("setting up merge group: %s", err)
+		a.updateMergeGroupStatus(ctx, models.FailedCommitStatus, command.Plan)
+		return
+	}
+	defer a.cleanupNonPRWorkingDir(ctx)
+
+	// Run pre-workflow hooks before project discovery so hooks can
+	// dynamically generate atlantis.yaml or other config files.
+	preHookCmd := &events.CommentCommand{Name: command.Plan}
+	if err := a.PreWorkflowHooksCommandRunner.RunPreHooks(ctx, preHookCmd); err != nil {
+		if a.FailOnPreWorkflowHookError {
+			logger.Err("pre-workflow hook failed: %s", err)
+			a.updateMergeGroupStatus(ctx, models.FailedCommitStatus, command.Plan)
+			return
+		}
+		logger.Warn("pre-workflow hook error (continuing): %v", err)
+	}
+	ctx.PreWorkflowHooksAlreadyRun = true
+
+	result, err := a.apiPlan(request, ctx)
+	if err != nil {
+		logger.Err("planning merge group: %s", err)
+		a.updateMergeGroupStatus(ctx, models.FailedCommitStatus, command.Plan)
+		return
+	}
+	if ctx.CommandSkipped {
+		// All changed directories are ignored, which doesn't block the queue
+		// any more than a merge group without projects.
+		a.updateNoProjectsStatuses(ctx)
+		return
+	}
+	if len(result.ProjectResults) == 0 {
+		// apiPlan already set the statuses for a merge group without projects.
+		return
+	}
+	defer a.Locker.UnlockByPull(ctx.HeadRepo.FullName, ctx.Pull.Num) // nolint: errcheck
+
+	counts := mergeGroupProjectCounts(result)
+	planStatus := models.SuccessCommitStatus
+	if counts.planErrored > 0 {
+		planStatus = models.FailedCommitStatus
+	}
+	a.updateMergeGroupStatusCount(ctx, planStatus, command.Plan, models.ProjectCounts{
+		Success: counts.plans - counts.planErrored,
+		Total:   counts.plans,
+		Errored: counts.planErrored,
+	})
+	if counts.policyChecks > 0 {
+		policyStatus := models.SuccessCommitStatus
+		if counts.policyErrored > 0 {
+			policyStatus = models.FailedCommitStatus
+		}
+		a.updateMergeGroupStatusCount(ctx, policyStatus, command.PolicyCheck, models.ProjectCounts{
+			Success: counts.policyChecks - counts.policyErrored,
+			Total:   counts.policyChecks,
+			Errored: counts.policyErrored,
+		})
+	}
+	if result.HasErrors() {
+		return
+	}
+
+	if !a.MergeQueueApply {
+		// The merge group can only be merged without applying if the plans of
+		// its pull requests were all applied before they were queued.
+		applyStatus := models.SuccessCommitStatus
+		if counts.noChanges < counts.plans {
+			applyStatus = models.FailedCommitStatus
+		}
+		a.updateMergeGroupStatusCount(ctx, applyStatus, command.Apply, models.ProjectCounts{
+			Success:   counts.noChanges,
+			Total:     counts.plans,
+			Errored:   counts.plans - counts.noChanges,
+			NoChanges: counts.noChanges,
+		})
+		return
+	}
+
+	if err := a.lockMergeGroupProjects(ctx, result); err != nil {
+		logger.Err("locking merge group: %s", err)
+		a.updateMergeGroupStatus(ctx, models.FailedCommitStatus, command.Apply)
+		return
+	}
+	seedPullStatusFromPlanResult(ctx, result)
+	result, err = a.apiApply(request, ctx)
+	if err != nil {
+		logger.Err("applying merge group: %s", err)
+		a.updateMergeGroupStatus(ctx, models.FailedCommitStatus, command.Apply)
+		return
+	}
+	var applyErrored int
+	for _, projectResult := range result.ProjectResults {
+		if projectResult.Error != nil || projectResult.Failure != "" {
+			applyErrored++
+		}
+	}
+	applyStatus := models.SuccessCommitStatus
+	if applyErrored > 0 {
+		applyStatus = models.FailedCommitStatus
+	}
+	a.updateMergeGroupStatusCount(ctx, applyStatus, command.Apply, models.ProjectCounts{
+		Success:   counts.plans - applyErrored,
+		Total:     counts.plans,
+		Errored:   applyErrored,
+		NoChanges: counts.noChanges,
+	})
+}
+
+// mergeGroupCounts counts the project results of a merge group plan.
+type mergeGroupCounts struct {
+	plans         int
+	planErrored   int
+	noChanges     int
+	policyChecks  int
+	policyErrored int
+}
+
+func mergeGroupProjectCounts(result *command.Result) mergeGroupCounts {
+	var counts mergeGroupCounts
+	for _, projectResult := range result.ProjectResults {
+		failed := projectResult.Error != nil || projectResult.Failure != ""
+		switch projectResult.Command {
+		case command.Plan:
+			counts.plans++
+			if failed {
+				counts.planErrored++
+			} else if projectResult.PlanStatus() == models.PlannedNoChangesPlanStatus {
+				counts.noChanges++
+			}
+		case command.PolicyCheck:
+			counts.policyChecks++
+			if failed {
+				counts.policyErrored++
+			}
+		}
+	}
+	return counts
+}
+
+// lockMergeGroupProjects locks every planned project and workspace of the
+// merge group so that two merge groups never apply the same project at once.
+// The locks are released with the other locks of the merge group once it's
+// done.
+func (a *APIController) lockMergeGroupProjects(ctx *command.Context, result *command.Result) error {
+	for _, projectResult := range result.ProjectResults {
+		if projectResult.PlanSuccess == nil {
+			continue
+		}
+		project := models.NewProject(ctx.HeadRepo.FullName, mergeQueueLockDir+"/"+projectResult.RepoRelDir, projectResult.ProjectName)
+		resp, err := a.Locker.TryLock(project, projectResult.Workspace, ctx.Pull, ctx.User)
+		if err != nil {
+			return fmt.Errorf("locking dir %q workspace %q: %w", projectResult.RepoRelDir, projectResult.Workspace, err)
+		}
+		if !resp.LockAcquired {
+			return fmt.Errorf("dir %q workspace %q is being applied by another merge group", projectResult.RepoRelDir, projectResult.Workspace)
+		}
+	}
+	return nil
+}
+
+func (a *APIController) updateMergeGroupStatus(ctx *command.Context, status models.CommitStatus, cmdName command.Name) {
+	if err := a.CommitStatusUpdater.UpdateCombined(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, status, cmdName); err != nil {
+		ctx.Log.Warn("unable to update %s commit status: %s", cmdName, err)
+	}
+}
+
+func (a *APIController) updateMergeGroupStatusCount(ctx *command.Context, status models.CommitStatus, cmdName command.Name, counts models.ProjectCounts) {
+	if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, status, cmdName, counts); err != nil {
+		ctx.Log.Warn("unable to update %s commit status: %s", cmdName, err)
+	}
+}
+
 // LockDetail is deprecated - use LockDetailAPI instead.
 // Kept for backwards compatibility during migration.
 type LockDetail struct {
@@ -408,6 +636,32 @@ func (a *APIController) ListLocks(w http.ResponseWriter, r *http.Request) {
 	responder.writeJSON(w, http.StatusOK, result)
 }
 
+// ListWebhookDeadLetters returns the HTTP webhooks that failed every
+// delivery attempt, newest first.
+// This is an authenticated endpoint that requires the API secret.
+func (a *APIController) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	middleware := a.getAPIMiddleware()
+	responder := middleware.Responder
+
+	if !middleware.RequireAuth(w, r) {
+		return
+	}
+	if a.WebhookDeadLetters == nil {
+		responder.ServiceUnavailable(w, r, "webhook dead letters are not enabled")
+		return
+	}
+
+	letters, err := a.WebhookDeadLetters.List()
+	if err != nil {
+		responder.InternalError(w, r, err)
+		return
+	}
+	responder.Success(w, r, http.StatusOK, WebhookDeadLettersAPI{
+		Count:       len(letters),
+		DeadLetters: letters,
+	})
+}
+
 // DriftStatus returns cached drift detection results for a repository.
 // This is an authenticated endpoint that requires the API secret.
 // Query parameters:
@@ -623,7 +877,9 @@ func checkedOutAPICommit(repoDir string, ref string) (string, error) {
 }
 
 func verifyNonPRBaseBranchReachability(ctx *command.Context, repoDir string) error {
-	if ctx.Pull.Num > 0 || repoDir == "" {
+	// Merge groups come from signed GitHub webhooks and their head commit is
+	// by design not yet part of the base branch.
+	if ctx.Pull.Num > 0 || repoDir == "" || ctx.MergeGroup {
 		return nil
 	}
 	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err != nil {
@@ -698,6 +954,26 @@ func checkedOutCommitReachableFromAPIBase(repoDir string, headCommit string, rem
 	return string(output), err
 }
 
+// updateNoProjectsStatuses sets the plan, policy check and apply statuses to
+// success when no projects were found.
+func (a *APIController) updateNoProjectsStatuses(ctx *command.Context) {
+	// When silence is enabled and no projects are found, don't set any VCS status
+	if a.SilenceVCSStatusNoProjects || ctx.SuppressVCSStatus {
+		ctx.Log.Debug("silence enabled and no projects found - not setting any VCS status")
+		return
+	}
+	ctx.Log.Debug("setting VCS status to success with no projects found")
+	if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.Plan, models.ProjectCounts{}); err != nil {
+		ctx.Log.Warn("unable to update plan status: %s", err)
+	}
+	if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.PolicyCheck, models.ProjectCounts{}); err != nil {
+		ctx.Log.Warn("unable to update policy check status: %s", err)
+	}
+	if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.Apply, models.ProjectCounts{}); err != nil {
+		ctx.Log.Warn("unable to update apply status: %s", err)
+	}
+}
+
 func (a *APIController) apiPlan(request *APIRequest, ctx *command.Context) (*command.Result, error) {
 	cmds, cc, err := request.getCommands(ctx, command.Plan, a.ProjectCommandBuilder.BuildPlanCommands)
 	if events.IsIgnoredTargetedDir(err) {
@@ -713,21 +989,7 @@ func (a *APIController) apiPlan(request *APIRequest, ctx *command.Context) (*com
 			return nil, err
 		}
 		ctx.Log.Info("determined there was no project to run plan in")
-		// When silence is enabled and no projects are found, don't set any VCS status
-		if !a.SilenceVCSStatusNoProjects && !ctx.SuppressVCSStatus {
-			ctx.Log.Debug("setting VCS status to success with no projects found")
-			if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.Plan, models.ProjectCounts{}); err != nil {
-				ctx.Log.Warn("unable to update plan status: %s", err)
-			}
-			if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.PolicyCheck, models.ProjectCounts{}); err != nil {
-				ctx.Log.Warn("unable to update policy check status: %s", err)
-			}
-			if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.Apply, models.ProjectCounts{}); err != nil {
-				ctx.Log.Warn("unable to update apply status: %s", err)
-			}
-		} else {
-			ctx.Log.Debug("silence enabled and no projects found - not setting any VCS status")
-		}
+		a.updateNoProjectsStatuses(ctx)
 		return &command.Result{ProjectResults: []command.ProjectResult{}}, nil
 	}
 
@@ -800,21 +1062,7 @@ func (a *APIController) apiApply(request *APIRequest, ctx *command.Context) (*co
 			return nil, err
 		}
 		ctx.Log.Info("determined there was no project to run apply in")
-		// When silence is enabled and no projects are found, don't set any VCS status
-		if !a.SilenceVCSStatusNoProjects && !ctx.SuppressVCSStatus {
-			ctx.Log.Debug("setting VCS status to success with no projects found")
-			if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.Plan, models.ProjectCounts{}); err != nil {
-				ctx.Log.Warn("unable to update plan status: %s", err)
-			}
-			if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.PolicyCheck, models.ProjectCounts{}); err != nil {
-				ctx.Log.Warn("unable to update policy check status: %s", err)
-			}
-			if err := a.CommitStatusUpdater.UpdateCombinedCount(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull, models.SuccessCommitStatus, command.Apply, models.ProjectCounts{}); err != nil {
-				ctx.Log.Warn("unable to update apply status: %s", err)
-			}
-		} else {
-			ctx.Log.Debug("silence enabled and no projects found - not setting any VCS status")
-		}
+		a.updateNoProjectsStatuses(ctx)
 		return &command.Result{ProjectResults: []command.ProjectResult{}}, nil
 	}
 
@@ -1410,6 +1658,11 @@ func (e *apiRemediationExecutor) ExecuteApplyProjects(repository, ref, vcsType s
 	return remediationResults, nil
 }
 
+// OpenPullRequest opens a pull request against the repository being remediated.
+func (e *apiRemediationExecutor) OpenPullRequest(opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	return e.controller.VCSClient.CreatePullRequest(e.logger, e.baseRepo, opts)
+}
+
 // ExecuteApply runs an apply for the given project using the API infrastructure.
 func (e *apiRemediationExecutor) ExecuteApply(repository, ref, vcsType, projectName, path, workspace string) (string, error) {
 	// Create a minimal API request for the apply
@@ -1913,12 +2166,26 @@ func (a *APIController) ListRemediationResults(w http.ResponseWriter, r *http.Re
 		limit = min(parsedLimit, 100)
 	}
 
+	opts, fieldErr := parseRemediationListFilters(r.URL.Query())
+	if fieldErr != nil {
+		responder.ValidationFailed(w, r, fmt.Sprintf("invalid %s parameter", fieldErr.Field), *fieldErr)
+		return
+	}
+	// Ask for one extra result so we know whether another page exists.
+	opts.Limit = limit + 1
+
 	// Get results
-	results, err := a.RemediationService.ListResults(baseRepo.ID(), limit)
+	results, err := a.RemediationService.ListResults(baseRepo.ID(), opts)
 	if err != nil {
 		responder.InternalError(w, r, err)
 		return
 	}
+	var nextOffset *int
+	if len(results) > limit {
+		results = results[:limit]
+		next := opts.Offset + limit
+		nextOffset = &next
+	}
 
 	// Convert to API DTOs
 	apiResults := make([]RemediationResultAPI, 0, len(results))
@@ -1930,12 +2197,81 @@ func (a *APIController) ListRemediationResults(w http.ResponseWriter, r *http.Re
 	response := RemediationListAPI{
 		Repository: repository,
 		Count:      len(apiResults),
+		Offset:     opts.Offset,
+		NextOffset: nextOffset,
 		Results:    apiResults,
 	}
 
 	responder.Success(w, r, http.StatusOK, response)
 }
 
+// parseRemediationListFilters parses the offset, status, action, since and
+// until query parameters of GET /api/drift/remediate. Status and action
+// accept comma-separated lists; since and until are RFC 3339 timestamps
+// bounding the remediation start time.
+func parseRemediationListFilters(query url.Values) (drift.ListResultsOptions, *ValidationError) {
+	var opts drift.ListResultsOptions
+	if values, ok := query["offset"]; ok {
+		offsetStr := ""
+		if len(values) > 0 {
+			offsetStr = values[0]
+		}
+		offset, err := strconv.Atoi(strings.TrimSpace(offsetStr))
+		if err != nil || offset < 0 {
+			return opts, &ValidationError{Field: "offset", Message: "must be a non-negative integer"}
+		}
+		opts.Offset = offset
+	}
+	for _, status := range splitQueryList(query["status"]) {
+		switch s := models.RemediationStatus(status); s {
+		case models.RemediationStatusPending, models.RemediationStatusRunning, models.RemediationStatusSuccess,
+			models.RemediationStatusFailed, models.RemediationStatusPartial:
+			opts.Statuses = append(opts.Statuses, s)
+		default:
+			return opts, &ValidationError{Field: "status", Message: "must be one of: pending, running, success, failed, partial"}
+		}
+	}
+	for _, action := range splitQueryList(query["action"]) {
+		a := models.RemediationAction(action)
+		if !a.IsValid() {
+			return opts, &ValidationError{Field: "action", Message: "must be one of: plan, apply, pr"}
+		}
+		opts.Actions = append(opts.Actions, a)
+	}
+	for _, bound := range []struct {
+		field string
+		dest  *time.Time
+	}{{"since", &opts.StartedAfter}, {"until", &opts.StartedBefore}} {
+		value := strings.TrimSpace(query.Get(bound.field))
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return opts, &ValidationError{Field: bound.field, Message: "must be an RFC 3339 timestamp"}
+		}
+		*bound.dest = parsed
+	}
+	if !opts.StartedAfter.IsZero() && !opts.StartedBefore.IsZero() && !opts.StartedAfter.Before(opts.StartedBefore) {
+		return opts, &ValidationError{Field: "until", Message: "must be after since"}
+	}
+	return opts, nil
+}
+
+// splitQueryList flattens repeated and comma-separated query values, dropping
+// empty entries.
+func splitQueryList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
 type driftProjectIdentity struct {
 	projectName string
 	path        string
@@ -2018,6 +2354,15 @@ func newProjectDriftFromResult(pr command.ProjectResult, ref, baseBranch, resolv
 	} else if pr.PlanSuccess != nil {
 		projectDrift.Drift = models.NewDriftSummaryFromPlanSuccess(pr.PlanSuccess)
 		projectDrift.PlanOutput = pr.PlanSuccess.TerraformOutput
+		if pr.PlanSuccess.PlanJSON != "" {
+			// Resource details are best effort; the drift counts above come
+			// from the plan output and are still accurate without them.
+			resources, err := drift.NewParser().ParsePlanJSON([]byte(pr.PlanSuccess.PlanJSON))
+			if err == nil {
+				projectDrift.Resources = resources
+			}
+		}
+		projectDrift.Drift, projectDrift.Resources = drift.ApplyIgnoreRules(projectDrift.Drift, projectDrift.Resources, pr.DriftIgnore)
 	}
 
 	return projectDrift
@@ -2076,6 +2421,25 @@ func (a *APIController) reconcileDriftStorage(repository, ref, baseBranch string
 	return nil
 }
 
+// driftDetectionError is returned by RunDriftDetection when a detection was
+// rejected before it ran. It carries the response DetectDrift sends.
+type driftDetectionError struct {
+	status int
+	apiErr *APIError
+}
+
+func (e *driftDetectionError) Error() string {
+	return e.apiErr.Message
+}
+
+func newDriftValidationError(message string, fields ...ValidationError) error {
+	return &driftDetectionError{status: http.StatusBadRequest, apiErr: NewValidationError(message, fields...)}
+}
+
+func newDriftForbiddenError(message string) error {
+	return &driftDetectionError{status: http.StatusForbidden, apiErr: NewAPIError(ErrCodeForbidden, message)}
+}
+
 // DetectDrift handles POST /api/drift/detect requests.
 // It triggers drift detection by running plans for the specified projects.
 // This is an authenticated endpoint that requires the API secret.
@@ -2107,38 +2471,77 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	detectionResult, err := a.RunDriftDetection(request)
+	if err != nil {
+		var detectionErr *driftDetectionError
+		if errors.As(err, &detectionErr) {
+			responder.Error(w, r, detectionErr.status, detectionErr.apiErr)
+			return
+		}
+		responder.InternalError(w, r, err)
+		return
+	}
+
+	// Convert to API DTO and return
+	apiResult := NewDriftDetectionResultAPI(detectionResult, request.IncludePlanOutput)
+
+	code := http.StatusOK
+	if driftDetectionHasErrors(detectionResult) {
+		code = http.StatusMultiStatus // 207 - some projects may have failed
+	}
+	responder.Success(w, r, code, apiResult)
+}
+
+var _ drift.Detector = (*APIController)(nil)
+
+// RunDriftDetection runs plans for the projects selected by request, stores
+// the resulting drift and sends drift webhooks. It backs both DetectDrift and
+// scheduled drift detection.
+// Requests that are invalid or not allowed return a *driftDetectionError.
+func (a *APIController) RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error) {
+	startedAt := time.Now()
+	result, err := a.runDriftDetection(request)
+	// Rejected requests aren't detection runs, and their repository may not
+	// even be valid, so they aren't recorded.
+	var detectionErr *driftDetectionError
+	if !errors.As(err, &detectionErr) {
+		a.DriftMetrics.RecordDetection(request.Repository, result, time.Since(startedAt), err)
+	}
+	return result, err
+}
+
+func (a *APIController) runDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error) {
+	if a.DriftStorage == nil {
+		return nil, errors.New("drift detection is not enabled")
+	}
+
 	// Validate required fields using the model's Validate method
 	if validationErrors := request.Validate(); len(validationErrors) > 0 {
 		fields := make([]ValidationError, 0, len(validationErrors))
 		for _, fe := range validationErrors {
 			fields = append(fields, ValidationError{Field: fe.Field, Message: fe.Message})
 		}
-		responder.ValidationFailed(w, r, "validation failed", fields...)
-		return
+		return nil, newDriftValidationError("validation failed", fields...)
 	}
 
 	// Check if the repo is allowlisted
 	VCSHostType, err := models.NewVCSHostType(request.Type)
 	if err != nil {
-		responder.ValidationFailed(w, r, "invalid VCS type",
+		return nil, newDriftValidationError("invalid VCS type",
 			ValidationError{Field: "type", Message: err.Error()})
-		return
 	}
 	cloneURL, err := a.VCSClient.GetCloneURL(a.Logger, VCSHostType, request.Repository)
 	if err != nil {
-		responder.InternalError(w, r, fmt.Errorf("failed to get clone URL: %w", err))
-		return
+		return nil, fmt.Errorf("failed to get clone URL: %w", err)
 	}
 
 	baseRepo, err := a.Parser.ParseAPIPlanRequest(VCSHostType, request.Repository, cloneURL)
 	if err != nil {
-		responder.ValidationFailed(w, r, fmt.Sprintf("failed to parse repository: %v", err))
-		return
+		return nil, newDriftValidationError(fmt.Sprintf("failed to parse repository: %v", err))
 	}
 
 	if !a.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
-		responder.Forbidden(w, r, "repository is not in the allowlist")
-		return
+		return nil, newDriftForbiddenError("repository is not in the allowlist")
 	}
 	normalizedRef := apiRequestStorageRef(request.Ref)
 	normalizedBaseBranch := apiRequestBaseBranch(request.Ref, request.BaseBranch)
@@ -2157,6 +2560,9 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 		Type:             request.Type,
 		DiscoverProjects: true, // Enable auto-discovery when no projects/paths specified
 	}
+	if request.RefreshOnly {
+		apiRequest.Flags = []string{"-refresh-only"}
+	}
 
 	if len(request.Projects) > 0 {
 		apiRequest.Projects = request.Projects
@@ -2184,6 +2590,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 		SkipPRModifiedFiles:       true,
 		SuppressVCSStatus:         true,
 		SuppressJobOutput:         true,
+		CapturePlanJSON:           true,
 		RunPolicyChecks:           true,
 		FailOnTeamAllowlistDenied: true,
 		ExactProjectNameMatching:  true,
@@ -2192,8 +2599,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 
 	// Setup working directory
 	if err := a.apiSetup(ctx, command.Plan); err != nil {
-		responder.InternalError(w, r, fmt.Errorf("setup failed: %w", err))
-		return
+		return nil, fmt.Errorf("setup failed: %w", err)
 	}
 	defer a.cleanupNonPRWorkingDir(ctx)
 
@@ -2204,8 +2610,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 	if err := a.PreWorkflowHooksCommandRunner.RunPreHooks(ctx, preHookCmd); err != nil {
 		preHookFailed = true
 		if a.FailOnPreWorkflowHookError {
-			responder.InternalError(w, r, fmt.Errorf("pre-workflow hook failed: %w", err))
-			return
+			return nil, fmt.Errorf("pre-workflow hook failed: %w", err)
 		}
 		a.Logger.Warn("pre-workflow hook error (continuing): %v", err)
 	}
@@ -2214,11 +2619,9 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 	result, err := a.apiPlan(apiRequest, ctx)
 	if err != nil {
 		if errors.Is(err, events.ErrTeamAllowlistDenied) {
-			responder.Forbidden(w, r, err.Error())
-			return
+			return nil, newDriftForbiddenError(err.Error())
 		}
-		responder.InternalError(w, r, err)
-		return
+		return nil, err
 	}
 	defer a.Locker.UnlockByPull(ctx.HeadRepo.FullName, ctx.Pull.Num) // nolint: errcheck
 
@@ -2253,15 +2656,7 @@ func (a *APIController) DetectDrift(w http.ResponseWriter, r *http.Request) {
 			a.Logger.Warn("failed to send drift webhook: %v", err)
 		}
 	}
-
-	// Convert to API DTO and return
-	apiResult := NewDriftDetectionResultAPI(detectionResult, request.IncludePlanOutput)
-
-	code := http.StatusOK
-	if driftDetectionHasErrors(detectionResult) {
-		code = http.StatusMultiStatus // 207 - some projects may have failed
-	}
-	responder.Success(w, r, code, apiResult)
+	return detectionResult, nil
 }
 
 // convertToDriftWebhookResult converts a DriftDetectionResult to a webhook DriftResult.
@@ -2279,6 +2674,8 @@ func convertToDriftWebhookResult(dr *models.DriftDetectionResult, requestRef str
 			ToImport:    p.Drift.ToImport,
 			ToForget:    p.Drift.ToForget,
 			Summary:     p.Drift.Summary,
+			Ignored:     p.Drift.Ignored,
+			Resources:   convertToDriftWebhookResources(p.Resources),
 			Error:       p.Error,
 		})
 	}
@@ -2295,3 +2692,20 @@ func convertToDriftWebhookResult(dr *models.DriftDetectionResult, requestRef str
 		Projects:          projects,
 	}
 }
+
+func convertToDriftWebhookResources(resources []models.ResourceDrift) []webhooks.DriftResource {
+	if len(resources) == 0 {
+		return nil
+	}
+	converted := make([]webhooks.DriftResource, 0, len(resources))
+	for _, r := range resources {
+		converted = append(converted, webhooks.DriftResource{
+			Address:           r.Address,
+			Action:            r.Action,
+			ChangedAttributes: r.ChangedAttributes,
+			Outside:           r.Outside,
+			Ignored:           r.Ignored,
+		})
+	}
+	return converted
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/gorilla/mux"
 	. "github.com/petergtz/pegomock/v4"
 	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
 	"github.com/runatlantis/atlantis/server/core/drift"
 	driftmocks "github.com/runatlantis/atlantis/server/core/drift/mocks"
+	"github.com/runatlantis/atlantis/server/core/locking"
 	. "github.com/runatlantis/atlantis/server/core/locking/mocks"
 	"github.com/runatlantis/atlantis/server/events"
 	"github.com/runatlantis/atlantis/server/events/command"
@@ -2204,6 +2206,168 @@ type apiControllerTestConfig struct {
 	allowUnlockByPull bool
 }
 
+func TestAPIController_RunMergeGroupPlansMergeGroupHead(t *testing.T) {
+	ac, projectCommandBuilder, projectCommandRunner := setup(t)
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	repoDir, _ := initAPIControllerGitRepoWithOrigin(t)
+	headBranch := "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b"
+	createAPIControllerGitBranch(t, repoDir, headBranch)
+	// The merge group head commit is not reachable from the base branch.
+	headCommit := advanceAPIControllerGitBranch(t, repoDir, headBranch)
+	workingDir := ac.WorkingDir.(*MockWorkingDir)
+	When(workingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[string]())).
+		ThenReturn(repoDir, nil)
+	var capturedCtx *command.Context
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		Then(func(args []Param) ReturnValues {
+			capturedCtx = args[0].(*command.Context)
+			return ReturnValues{[]command.ProjectContext{{CommandName: command.Plan}}, nil}
+		})
+
+	repo := models.Repo{FullName: "owner/repo", VCSHost: models.VCSHost{Type: models.Github, Hostname: "github.com"}}
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseRepo:   repo,
+		BaseBranch: "main",
+		HeadBranch: headBranch,
+		HeadCommit: headCommit,
+	})
+
+	Assert(t, capturedCtx != nil, "expected plan command builder to be called")
+	Assert(t, capturedCtx.Pull.Num < 0, "expected merge group to use a synthetic pull number")
+	Assert(t, capturedCtx.MergeGroup, "expected merge group context")
+	Assert(t, capturedCtx.SkipPRRequirements, "expected merge group to skip PR-only requirements")
+	Equals(t, headCommit, capturedCtx.Pull.HeadCommit)
+	Equals(t, headBranch, capturedCtx.Pull.HeadBranch)
+	Equals(t, "main", capturedCtx.Pull.BaseBranch)
+	commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Eq(repo), Any[models.PullRequest](),
+		Eq(models.SuccessCommitStatus), Eq(command.Plan), Eq(models.ProjectCounts{Success: 1, Total: 1}))
+	// The plan has changes that were not applied before the merge group was
+	// queued, so the apply status fails.
+	commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Eq(repo), Any[models.PullRequest](),
+		Eq(models.FailedCommitStatus), Eq(command.Apply), Eq(models.ProjectCounts{Total: 1, Errored: 1}))
+	projectCommandRunner.VerifyWasCalled(Never()).Apply(Any[command.ProjectContext]())
+}
+
+func TestAPIController_RunMergeGroupNoChangesSucceedsApplyStatus(t *testing.T) {
+	ac, _, projectCommandRunner := setup(t)
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	When(projectCommandRunner.Plan(Any[command.ProjectContext]())).ThenReturn(command.ProjectCommandOutput{
+		PlanSuccess: &models.PlanSuccess{TerraformOutput: "No changes. Your infrastructure matches the configuration."},
+	})
+
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	})
+
+	commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Eq(models.SuccessCommitStatus), Eq(command.Apply), Eq(models.ProjectCounts{Success: 1, Total: 1, NoChanges: 1}))
+	projectCommandRunner.VerifyWasCalled(Never()).Apply(Any[command.ProjectContext]())
+}
+
+func TestAPIController_RunMergeGroupApply(t *testing.T) {
+	ac, projectCommandBuilder, projectCommandRunner := setup(t)
+	ac.MergeQueueApply = true
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		ThenReturn([]command.ProjectContext{{CommandName: command.Plan, RepoRelDir: "app", Workspace: "default"}}, nil)
+	// The merge group locks its own copy of the project, not the project
+	// lock the queued pull request still holds.
+	ac.Locker.(*MockLocker).EXPECT().TryLock(models.NewProject("owner/repo", ".merge-queue/app", ""), "default", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ models.Project, _ string, pull models.PullRequest, _ models.User) (locking.TryLockResponse, error) {
+			Assert(t, pull.Num < 0, "expected the lock to be owned by the merge group")
+			return locking.TryLockResponse{LockAcquired: true}, nil
+		})
+
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseRepo:   models.Repo{FullName: "owner/repo"},
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	})
+
+	projectCommandRunner.VerifyWasCalledOnce().Apply(Any[command.ProjectContext]())
+	commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Eq(models.SuccessCommitStatus), Eq(command.Apply), Eq(models.ProjectCounts{Success: 1, Total: 1}))
+}
+
+func TestAPIController_RunMergeGroupApplyLocked(t *testing.T) {
+	ac, _, projectCommandRunner := setup(t)
+	ac.MergeQueueApply = true
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	ac.Locker.(*MockLocker).EXPECT().TryLock(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(locking.TryLockResponse{LockAcquired: false, CurrLock: models.ProjectLock{Pull: models.PullRequest{Num: -2}}}, nil)
+
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	})
+
+	projectCommandRunner.VerifyWasCalled(Never()).Apply(Any[command.ProjectContext]())
+	commitStatusUpdater.VerifyWasCalledOnce().UpdateCombined(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Eq(models.FailedCommitStatus), Eq(command.Apply))
+}
+
+func TestAPIController_RunMergeGroupNoProjects(t *testing.T) {
+	ac, projectCommandBuilder, projectCommandRunner := setup(t)
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		ThenReturn([]command.ProjectContext{}, nil)
+
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	})
+
+	projectCommandRunner.VerifyWasCalled(Never()).Plan(Any[command.ProjectContext]())
+	for _, name := range []command.Name{command.Plan, command.PolicyCheck, command.Apply} {
+		commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+			Eq(models.SuccessCommitStatus), Eq(name), Eq(models.ProjectCounts{}))
+	}
+}
+
+func TestAPIController_RunMergeGroupIgnoredDirsSetsStatuses(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		ThenReturn(nil, events.ErrIgnoredTargetedDir)
+
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	})
+
+	for _, name := range []command.Name{command.Plan, command.PolicyCheck, command.Apply} {
+		commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+			Eq(models.SuccessCommitStatus), Eq(name), Eq(models.ProjectCounts{}))
+	}
+}
+
+func TestAPIController_RunMergeGroupPlanFailure(t *testing.T) {
+	ac, _, projectCommandRunner := setup(t)
+	ac.MergeQueueApply = true
+	commitStatusUpdater := ac.CommitStatusUpdater.(*MockCommitStatusUpdater)
+	When(projectCommandRunner.Plan(Any[command.ProjectContext]())).ThenReturn(command.ProjectCommandOutput{
+		Error: errors.New("plan failed"),
+	})
+
+	ac.RunMergeGroup(logging.NewNoopLogger(t), models.MergeGroup{
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	})
+
+	commitStatusUpdater.VerifyWasCalledOnce().UpdateCombinedCount(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Eq(models.FailedCommitStatus), Eq(command.Plan), Eq(models.ProjectCounts{Total: 1, Errored: 1}))
+	commitStatusUpdater.VerifyWasCalled(Never()).UpdateCombinedCount(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Any[models.CommitStatus](), Eq(command.Apply), Any[models.ProjectCounts]())
+	projectCommandRunner.VerifyWasCalled(Never()).Apply(Any[command.ProjectContext]())
+}
+
 func setup(t *testing.T, options ...func(*apiControllerTestConfig)) (*controllers.APIController, *MockProjectCommandBuilder, *MockProjectCommandRunner) {
 	RegisterMockTestingT(t)
 	config := &apiControllerTestConfig{
@@ -2738,7 +2902,7 @@ func TestAPIController_DriftReadEndpointsRejectNonAllowlistedRepos(t *testing.T)
 
 			Equals(t, http.StatusForbidden, w.Code)
 			driftStorage.VerifyWasCalled(Never()).Get(Any[string](), Any[drift.GetOptions]())
-			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.ListResultsOptions]())
 		})
 	}
 }
@@ -2775,7 +2939,7 @@ func TestAPIController_RemediateReadEndpointsRejectInvalidRepositoryBeforeRead(t
 			Equals(t, http.StatusBadRequest, w.Code)
 			vcsClient.VerifyWasCalled(Never()).GetCloneURL(Any[logging.SimpleLogging](), Any[models.VCSHostType](), Any[string]())
 			remediationService.VerifyWasCalled(Never()).GetResult(Any[string]())
-			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.ListResultsOptions]())
 		})
 	}
 }
@@ -3630,7 +3794,7 @@ func TestAPIController_ListRemediationResults(t *testing.T) {
 			FailureCount:  1,
 		},
 	}
-	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(10))).ThenReturn(mockResults, nil)
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(drift.ListResultsOptions{Limit: 11}))).ThenReturn(mockResults, nil)
 	ac.RemediationService = remediationService
 
 	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github", nil)
@@ -3658,7 +3822,7 @@ func TestAPIController_ListRemediationResults_WithLimit(t *testing.T) {
 			Status:     models.RemediationStatusSuccess,
 		},
 	}
-	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(5))).ThenReturn(mockResults, nil)
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(drift.ListResultsOptions{Limit: 6}))).ThenReturn(mockResults, nil)
 	ac.RemediationService = remediationService
 
 	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&limit=5", nil)
@@ -3686,7 +3850,7 @@ func TestAPIController_ListRemediationResults_InvalidLimit(t *testing.T) {
 			response, _ := io.ReadAll(w.Result().Body)
 			apiErr := parseAPIError(t, response)
 			Equals(t, controllers.ErrCodeValidation, apiErr.Code)
-			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.ListResultsOptions]())
 		})
 	}
 }
@@ -3716,7 +3880,7 @@ func TestAPIController_ListRemediationResults_MissingType(t *testing.T) {
 	response, _ := io.ReadAll(w.Result().Body)
 	apiErr := parseAPIError(t, response)
 	Equals(t, controllers.ErrCodeValidation, apiErr.Code)
-	remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[int]())
+	remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.ListResultsOptions]())
 }
 
 func TestAPIController_ListRemediationResults_MissingRepository(t *testing.T) {
@@ -3750,7 +3914,7 @@ func TestAPIController_ListRemediationResults_Empty(t *testing.T) {
 	ac, _, _ := setup(t)
 
 	remediationService := driftmocks.NewMockRemediationService()
-	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(10))).ThenReturn([]*models.RemediationResult{}, nil)
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(drift.ListResultsOptions{Limit: 11}))).ThenReturn([]*models.RemediationResult{}, nil)
 	ac.RemediationService = remediationService
 
 	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github", nil)
@@ -3766,6 +3930,71 @@ func TestAPIController_ListRemediationResults_Empty(t *testing.T) {
 	Equals(t, 0, listResponse.Count)
 }
 
+func TestAPIController_ListRemediationResults_FiltersAndPagination(t *testing.T) {
+	ac, _, _ := setup(t)
+
+	remediationService := driftmocks.NewMockRemediationService()
+	expOpts := drift.ListResultsOptions{
+		Limit:         3,
+		Offset:        4,
+		Statuses:      []models.RemediationStatus{models.RemediationStatusFailed, models.RemediationStatusPartial},
+		Actions:       []models.RemediationAction{models.RemediationAutoApply},
+		StartedAfter:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		StartedBefore: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mockResults := []*models.RemediationResult{
+		{ID: "result-1", Repository: "owner/repo", Action: models.RemediationAutoApply, Status: models.RemediationStatusFailed},
+		{ID: "result-2", Repository: "owner/repo", Action: models.RemediationAutoApply, Status: models.RemediationStatusPartial},
+		{ID: "result-3", Repository: "owner/repo", Action: models.RemediationAutoApply, Status: models.RemediationStatusFailed},
+	}
+	When(remediationService.ListResults(Eq("github.com/owner/repo"), Eq(expOpts))).ThenReturn(mockResults, nil)
+	ac.RemediationService = remediationService
+
+	req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&limit=2&offset=4"+
+		"&status=failed,partial&action=apply&since=2025-01-01T00:00:00Z&until=2025-02-01T00:00:00Z", nil)
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.ListRemediationResults(w, req)
+
+	Equals(t, http.StatusOK, w.Code)
+
+	response, _ := io.ReadAll(w.Result().Body)
+	var listResponse controllers.RemediationListAPI
+	parseAPIResponse(t, response, &listResponse)
+	Equals(t, 2, listResponse.Count)
+	Equals(t, 4, listResponse.Offset)
+	Assert(t, listResponse.NextOffset != nil, "expected next_offset to be set")
+	Equals(t, 6, *listResponse.NextOffset)
+}
+
+func TestAPIController_ListRemediationResults_InvalidFilters(t *testing.T) {
+	cases := map[string]string{
+		"negative offset": "offset=-1",
+		"unknown status":  "status=done",
+		"unknown action":  "action=destroy",
+		"bad since":       "since=yesterday",
+		"until not after": "since=2025-02-01T00:00:00Z&until=2025-01-01T00:00:00Z",
+	}
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			ac, _, _ := setup(t)
+			remediationService := driftmocks.NewMockRemediationService()
+			ac.RemediationService = remediationService
+
+			req, _ := http.NewRequest("GET", "/api/drift/remediate?repository=owner/repo&type=Github&"+query, nil)
+			req.Header.Set(atlantisTokenHeader, atlantisToken)
+			w := httptest.NewRecorder()
+			ac.ListRemediationResults(w, req)
+
+			Equals(t, http.StatusBadRequest, w.Code)
+			response, _ := io.ReadAll(w.Result().Body)
+			apiErr := parseAPIError(t, response)
+			Equals(t, controllers.ErrCodeValidation, apiErr.Code)
+			remediationService.VerifyWasCalled(Never()).ListResults(Any[string](), Any[drift.ListResultsOptions]())
+		})
+	}
+}
+
 // Phase 5: DetectDrift tests
 
 func TestAPIController_DetectDrift(t *testing.T) {
@@ -3804,6 +4033,31 @@ func TestAPIController_DetectDrift(t *testing.T) {
 	Equals(t, "Repo", result.Repository)
 }
 
+func TestAPIController_DetectDrift_RefreshOnly(t *testing.T) {
+	ac, projectCommandBuilder, _ := setup(t)
+	var capturedCmd *events.CommentCommand
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		Then(func(args []Param) ReturnValues {
+			capturedCmd = args[1].(*events.CommentCommand)
+			return ReturnValues{[]command.ProjectContext{{CommandName: command.Plan}}, nil}
+		})
+
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Store(Any[string](), Any[models.ProjectDrift]())).ThenReturn(nil)
+	ac.DriftStorage = driftStorage
+
+	_, err := ac.RunDriftDetection(models.DriftDetectionRequest{
+		Repository:  "Repo",
+		Ref:         "main",
+		Type:        "Gitlab",
+		Projects:    []string{"default"},
+		RefreshOnly: true,
+	})
+	Ok(t, err)
+	Assert(t, capturedCmd != nil, "expected BuildPlanCommands to be called")
+	Equals(t, []string{"-refresh-only"}, capturedCmd.Flags)
+}
+
 func TestAPIController_DetectDrift_TeamAllowlistDeniedReturnsForbidden(t *testing.T) {
 	ac, projectCommandBuilder, _ := setup(t)
 	driftStorage := driftmocks.NewMockStorage()
@@ -3896,6 +4150,89 @@ func TestAPIController_DetectDriftSendsWebhookWhenDriftDetected(t *testing.T) {
 	Equals(t, events.DefaultWorkspace, sender.results[0].Projects[0].Workspace)
 }
 
+func TestAPIController_DetectDriftIncludesResourcesFromPlanJSON(t *testing.T) {
+	ac, projectCommandBuilder, projectCommandRunner := setup(t)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		Then(func(args []Param) ReturnValues {
+			ctx := args[0].(*command.Context)
+			Assert(t, ctx.CapturePlanJSON, "drift detection should capture plan JSON")
+			return ReturnValues{[]command.ProjectContext{{
+				CommandName: command.Plan,
+				ProjectName: "app",
+				RepoRelDir:  "app",
+				Workspace:   events.DefaultWorkspace,
+			}}, nil}
+		})
+	When(projectCommandRunner.Plan(Any[command.ProjectContext]())).ThenReturn(command.ProjectCommandOutput{
+		PlanSuccess: &models.PlanSuccess{
+			TerraformOutput: "Plan: 0 to add, 1 to change, 0 to destroy.",
+			PlanJSON: `{"resource_changes": [{"address": "aws_security_group.web", "change": {
+				"actions": ["update"], "before": {"ingress": [22]}, "after": {"ingress": []}}}]}`,
+		},
+	})
+
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Store(Any[string](), Any[models.ProjectDrift]())).ThenReturn(nil)
+	ac.DriftStorage = driftStorage
+	sender := &recordingDriftSender{}
+	ac.DriftWebhookSender = &webhooks.DriftWebhookSender{Webhooks: []webhooks.DriftSender{sender}}
+
+	result, err := ac.RunDriftDetection(models.DriftDetectionRequest{
+		Repository: "Repo",
+		Ref:        "main",
+		Type:       "Gitlab",
+		Projects:   []string{"app"},
+	})
+	Ok(t, err)
+
+	expected := []models.ResourceDrift{{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"ingress"}, ChangedPaths: []string{"ingress.0"}}}
+	Equals(t, 1, len(result.Projects))
+	Equals(t, expected, result.Projects[0].Resources)
+	_, stored := driftStorage.VerifyWasCalledOnce().Store(Any[string](), Any[models.ProjectDrift]()).GetCapturedArguments()
+	Equals(t, expected, stored.Resources)
+	Equals(t, 1, len(sender.results))
+	Equals(t, []webhooks.DriftResource{{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"ingress"}}},
+		sender.results[0].Projects[0].Resources)
+}
+
+func TestAPIController_DetectDriftAppliesIgnoreRules(t *testing.T) {
+	ac, projectCommandBuilder, projectCommandRunner := setup(t)
+	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
+		ThenReturn([]command.ProjectContext{{
+			CommandName: command.Plan,
+			ProjectName: "app",
+			RepoRelDir:  "app",
+			Workspace:   events.DefaultWorkspace,
+			DriftIgnore: []valid.DriftIgnoreRule{{Resource: "aws_autoscaling_group.*", Attributes: []string{"desired_capacity"}}},
+		}}, nil)
+	When(projectCommandRunner.Plan(Any[command.ProjectContext]())).ThenReturn(command.ProjectCommandOutput{
+		PlanSuccess: &models.PlanSuccess{
+			TerraformOutput: "Plan: 0 to add, 1 to change, 0 to destroy.",
+			PlanJSON: `{"resource_changes": [{"address": "aws_autoscaling_group.web", "change": {
+				"actions": ["update"], "before": {"desired_capacity": 2}, "after": {"desired_capacity": 3}}}]}`,
+		},
+	})
+
+	driftStorage := driftmocks.NewMockStorage()
+	When(driftStorage.Store(Any[string](), Any[models.ProjectDrift]())).ThenReturn(nil)
+	ac.DriftStorage = driftStorage
+
+	result, err := ac.RunDriftDetection(models.DriftDetectionRequest{
+		Repository: "Repo",
+		Ref:        "main",
+		Type:       "Gitlab",
+		Projects:   []string{"app"},
+	})
+	Ok(t, err)
+
+	Equals(t, 1, len(result.Projects))
+	Equals(t, 0, result.ProjectsWithDrift)
+	Equals(t, false, result.Projects[0].Drift.HasDrift)
+	Equals(t, 0, result.Projects[0].Drift.ToChange)
+	Equals(t, 1, result.Projects[0].Drift.Ignored)
+	Equals(t, true, result.Projects[0].Resources[0].Ignored)
+}
+
 func TestAPIController_DetectDrift_IncludesPlanOutput(t *testing.T) {
 	ac, projectCommandBuilder, projectCommandRunner := setup(t)
 	When(projectCommandBuilder.BuildPlanCommands(Any[*command.Context](), Any[*events.CommentCommand]())).
@@ -5460,3 +5797,52 @@ func TestAPIController_RejectsWorkspaceUnsafeForPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIController_ListWebhookDeadLetters(t *testing.T) {
+	ac, _, _ := setup(t)
+	deadLetters := webhooks.NewInMemoryDeadLetterStore()
+	failedAt := time.Date(2025, 1, 21, 10, 30, 0, 0, time.UTC)
+	first := webhooks.DeadLetter{ID: "first", Event: "apply", URL: "https://example.com", Payload: "{}", Attempts: 4, LastError: "status 500", FailedAt: failedAt}
+	second := webhooks.DeadLetter{ID: "second", Event: "drift", URL: "https://example.com", Payload: "{}", Attempts: 1, LastError: "status 400", FailedAt: failedAt.Add(time.Minute)}
+	Ok(t, deadLetters.Add(first))
+	Ok(t, deadLetters.Add(second))
+	ac.WebhookDeadLetters = deadLetters
+
+	req, _ := http.NewRequest("GET", "/api/webhooks/dead-letters", nil)
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.ListWebhookDeadLetters(w, req)
+
+	Equals(t, http.StatusOK, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	var result controllers.WebhookDeadLettersAPI
+	parseAPIResponse(t, response, &result)
+	Equals(t, 2, result.Count)
+	Equals(t, []webhooks.DeadLetter{second, first}, result.DeadLetters)
+}
+
+func TestAPIController_ListWebhookDeadLetters_Unauthorized(t *testing.T) {
+	ac, _, _ := setup(t)
+	ac.WebhookDeadLetters = webhooks.NewInMemoryDeadLetterStore()
+
+	req, _ := http.NewRequest("GET", "/api/webhooks/dead-letters", nil)
+	w := httptest.NewRecorder()
+	ac.ListWebhookDeadLetters(w, req)
+
+	Equals(t, http.StatusUnauthorized, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	Equals(t, controllers.ErrCodeUnauthorized, parseAPIError(t, response).Code)
+}
+
+func TestAPIController_ListWebhookDeadLetters_NotEnabled(t *testing.T) {
+	ac, _, _ := setup(t)
+
+	req, _ := http.NewRequest("GET", "/api/webhooks/dead-letters", nil)
+	req.Header.Set(atlantisTokenHeader, atlantisToken)
+	w := httptest.NewRecorder()
+	ac.ListWebhookDeadLetters(w, req)
+
+	Equals(t, http.StatusServiceUnavailable, w.Code)
+	response, _ := io.ReadAll(w.Result().Body)
+	Equals(t, controllers.ErrCodeServiceUnavailable, parseAPIError(t, response).Code)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
 )
 
 // API DTOs (Data Transfer Objects)
@@ -162,6 +163,8 @@ type DriftProjectAPI struct {
 	HasDrift bool `json:"has_drift"`
 	// Drift contains drift details if drift was detected.
 	Drift *DriftDetailsAPI `json:"drift,omitempty"`
+	// Resources lists the resources that drifted, if known.
+	Resources []DriftResourceAPI `json:"resources,omitempty"`
 	// PlanOutput contains the Terraform plan output, if detection ran a
 	// plan successfully. Only populated on the detect response; omitted from
 	// status responses to reduce payload size. It is never persisted in
@@ -192,6 +195,24 @@ type DriftDetailsAPI struct {
 	Summary string `json:"summary,omitempty"`
 	// ChangesOutside indicates if changes were made outside Terraform.
 	ChangesOutside bool `json:"changes_outside"`
+	// Ignored is the number of drifted resources excluded from the counts
+	// above by drift ignore rules.
+	Ignored int `json:"ignored,omitempty"`
+}
+
+// DriftResourceAPI is the API representation of a single drifted resource.
+type DriftResourceAPI struct {
+	// Address is the resource address.
+	Address string `json:"address"`
+	// Action is one of create, update, delete, replace, import or forget.
+	Action string `json:"action"`
+	// ChangedAttributes lists the top-level attributes that changed.
+	ChangedAttributes []string `json:"changed_attributes,omitempty"`
+	// Outside is true if the resource was changed outside of Terraform and
+	// the plan won't change it back.
+	Outside bool `json:"outside,omitempty"`
+	// Ignored is true if a drift ignore rule matched the resource.
+	Ignored bool `json:"ignored,omitempty"`
 }
 
 // NewDriftProjectAPI converts an internal ProjectDrift to its API representation.
@@ -212,7 +233,9 @@ func NewDriftProjectAPI(pd models.ProjectDrift) DriftProjectAPI {
 		Error:          pd.Error,
 	}
 
-	if pd.Drift.HasDrift {
+	// Drift details are kept when all drift was ignored so the ignored count
+	// is still reported.
+	if pd.Drift.HasDrift || pd.Drift.Ignored > 0 {
 		result.Drift = &DriftDetailsAPI{
 			ToAdd:          pd.Drift.ToAdd,
 			ToChange:       pd.Drift.ToChange,
@@ -222,8 +245,18 @@ func NewDriftProjectAPI(pd models.ProjectDrift) DriftProjectAPI {
 			TotalChanges:   pd.Drift.TotalChanges(),
 			Summary:        pd.Drift.Summary,
 			ChangesOutside: pd.Drift.ChangesOutside,
+			Ignored:        pd.Drift.Ignored,
 		}
 	}
+	for _, r := range pd.Resources {
+		result.Resources = append(result.Resources, DriftResourceAPI{
+			Address:           r.Address,
+			Action:            r.Action,
+			ChangedAttributes: r.ChangedAttributes,
+			Outside:           r.Outside,
+			Ignored:           r.Ignored,
+		})
+	}
 
 	return result
 }
@@ -351,6 +384,10 @@ type RemediationResultAPI struct {
 	Projects []RemediationProjectAPI `json:"projects"`
 	// Summary provides aggregate statistics.
 	Summary RemediationSummaryAPI `json:"summary"`
+	// PullRequestNum is the number of the pull request opened by a "pr" remediation.
+	PullRequestNum int `json:"pull_request_num,omitempty"`
+	// PullRequestURL is the URL of the pull request opened by a "pr" remediation.
+	PullRequestURL string `json:"pull_request_url,omitempty"`
 	// Error contains any top-level error.
 	Error string `json:"error,omitempty"`
 }
@@ -393,6 +430,10 @@ type RemediationListAPI struct {
 	Repository string `json:"repository"`
 	// Count is the number of results returned.
 	Count int `json:"count"`
+	// Offset is the number of matching results skipped before this page.
+	Offset int `json:"offset"`
+	// NextOffset is the offset of the next page, or nil if this is the last page.
+	NextOffset *int `json:"next_offset,omitempty"`
 	// Results contains the remediation results.
 	Results []RemediationResultAPI `json:"results"`
 }
@@ -409,6 +450,8 @@ func NewRemediationResultAPI(rr *models.RemediationResult) RemediationResultAPI
 		Projects:   make([]RemediationProjectAPI, 0, len(rr.Projects)),
 		Error:      rr.Error,
 	}
+	result.PullRequestNum = rr.PullRequestNum
+	result.PullRequestURL = rr.PullRequestURL
 
 	// Only include completed time if it's set.
 	if rr.CompletedAt != nil {
@@ -518,3 +561,11 @@ func NewListLocksResultAPI(locks map[string]models.ProjectLock) ListLocksResultA
 	result.TotalCount = len(result.Locks)
 	return result
 }
+
+// WebhookDeadLettersAPI is the API response for listing webhook dead letters.
+type WebhookDeadLettersAPI struct {
+	// Count is the number of dead letters returned.
+	Count int `json:"count"`
+	// DeadLetters contains the webhooks that failed, newest first.
+	DeadLetters []webhooks.DeadLetter `json:"dead_letters"`
+}
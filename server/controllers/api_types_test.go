@@ -48,3 +48,16 @@ func TestNewDriftProjectAPI_NeverCopiesPlanOutput(t *testing.T) {
 	result := controllers.NewDriftProjectAPI(pd)
 	Equals(t, "", result.PlanOutput)
 }
+
+func TestNewDriftProjectAPI_IncludesResources(t *testing.T) {
+	result := controllers.NewDriftProjectAPI(models.ProjectDrift{
+		Drift: models.DriftSummary{HasDrift: true, ToChange: 1},
+		Resources: []models.ResourceDrift{
+			{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"ingress"}},
+		},
+	})
+
+	Equals(t, []controllers.DriftResourceAPI{
+		{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"ingress"}},
+	}, result.Resources)
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/runatlantis/atlantis/server/controllers/web_templates"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	// driftDashboardRemediations is how many of the most recent remediation
+	// runs the drift dashboard shows.
+	driftDashboardRemediations = 50
+	driftDashboardTimeFormat   = "2006-01-02 15:04:05"
+)
+
+// DriftController renders the drift dashboard pages of the web UI.
+type DriftController struct {
+	AtlantisVersion     string                       `validate:"required"`
+	AtlantisURL         *url.URL                     `validate:"required"`
+	Logger              logging.SimpleLogging        `validate:"required"`
+	DriftStorage        drift.Storage                `validate:"required"`
+	RemediationService  drift.RemediationService     `validate:"required"`
+	DriftTemplate       web_templates.TemplateWriter `validate:"required"`
+	RemediationTemplate web_templates.TemplateWriter `validate:"required"`
+}
+
+// Get is the GET /drift route. It lists projects with drift and the most
+// recent remediation runs across all repositories. Remediation runs are kept
+// longer than drift results, so they're listed for every repository with
+// runs, not just those that still have drift results.
+func (d *DriftController) Get(w http.ResponseWriter, _ *http.Request) {
+	all, err := d.DriftStorage.GetAll()
+	if err != nil {
+		d.respond(w, logging.Error, http.StatusServiceUnavailable, "Could not retrieve drift results: %s", err)
+		return
+	}
+
+	data := web_templates.DriftIndexData{
+		AtlantisVersion: d.AtlantisVersion,
+		CleanedBasePath: d.AtlantisURL.Path,
+	}
+	for repository, projects := range all {
+		data.TotalProjects += len(projects)
+		for _, p := range projects {
+			if !p.Drift.HasDrift && p.Error == "" {
+				continue
+			}
+			data.DriftedProjects = append(data.DriftedProjects, web_templates.DriftProjectData{
+				Repository:           repository,
+				Project:              p,
+				LastCheckedFormatted: p.LastChecked.Format(driftDashboardTimeFormat),
+			})
+		}
+	}
+
+	repositories, err := d.RemediationService.ListRepositories()
+	if err != nil {
+		d.respond(w, logging.Error, http.StatusServiceUnavailable, "Could not retrieve remediation results: %s", err)
+		return
+	}
+	var remediations []*models.RemediationResult
+	for _, repository := range repositories {
+		results, err := d.RemediationService.ListResults(repository, drift.ListResultsOptions{Limit: driftDashboardRemediations})
+		if err != nil {
+			d.respond(w, logging.Error, http.StatusServiceUnavailable, "Could not retrieve remediation results: %s", err)
+			return
+		}
+		remediations = append(remediations, results...)
+	}
+
+	// Sort by repository then project so the page is stable between loads.
+	sort.SliceStable(data.DriftedProjects, func(i, j int) bool {
+		a, b := data.DriftedProjects[i], data.DriftedProjects[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		if a.Project.Path != b.Project.Path {
+			return a.Project.Path < b.Project.Path
+		}
+		return a.Project.Workspace < b.Project.Workspace
+	})
+
+	// Sort by date - newest to oldest.
+	sort.SliceStable(remediations, func(i, j int) bool { return remediations[i].StartedAt.After(remediations[j].StartedAt) })
+	if len(remediations) > driftDashboardRemediations {
+		remediations = remediations[:driftDashboardRemediations]
+	}
+	for _, r := range remediations {
+		data.Remediations = append(data.Remediations, web_templates.RemediationRunData{
+			Result:             r,
+			DetailPath:         "/drift/remediations/" + url.PathEscape(r.ID),
+			StartedAtFormatted: r.StartedAt.Format(driftDashboardTimeFormat),
+		})
+	}
+
+	if err := d.DriftTemplate.Execute(w, data); err != nil {
+		d.Logger.Err("%s", err.Error())
+	}
+}
+
+// GetRemediation is the GET /drift/remediations/{id} route. It renders a
+// remediation run with the plan and apply output of each project.
+func (d *DriftController) GetRemediation(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok || id == "" {
+		d.respond(w, logging.Warn, http.StatusBadRequest, "No remediation id in request")
+		return
+	}
+
+	result, err := d.RemediationService.GetResult(id)
+	if err != nil {
+		d.respond(w, logging.Info, http.StatusNotFound, "Could not find remediation run '%s': %s", id, err)
+		return
+	}
+
+	data := web_templates.RemediationDetailData{
+		Result:             result,
+		StartedAtFormatted: result.StartedAt.Format(driftDashboardTimeFormat),
+		AtlantisVersion:    d.AtlantisVersion,
+		CleanedBasePath:    d.AtlantisURL.Path,
+	}
+	if result.CompletedAt != nil {
+		data.CompletedAtFormatted = result.CompletedAt.Format(driftDashboardTimeFormat)
+	}
+	if err := d.RemediationTemplate.Execute(w, data); err != nil {
+		d.Logger.Err("%s", err.Error())
+	}
+}
+
+// respond is a helper function to respond and log the response. lvl is the log
+// level to log at, code is the HTTP response code.
+func (d *DriftController) respond(w http.ResponseWriter, lvl logging.LogLevel, responseCode int, format string, args ...any) {
+	response := fmt.Sprintf(format, args...)
+	d.Logger.Log(lvl, response)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(responseCode)
+	fmt.Fprintln(w, response) // #nosec G705 -- response body is served as text/plain, not interpreted as HTML
+}
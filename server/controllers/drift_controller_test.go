@@ -0,0 +1,136 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/runatlantis/atlantis/server/controllers"
+	"github.com/runatlantis/atlantis/server/controllers/web_templates"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newDriftController(t *testing.T) (*controllers.DriftController, *drift.InMemoryStorage, *drift.InMemoryRemediationStore) {
+	t.Helper()
+	atlantisURL, err := url.Parse("https://example.com/basepath")
+	Ok(t, err)
+	storage := drift.NewInMemoryStorage()
+	store := drift.NewInMemoryRemediationStore()
+	return &controllers.DriftController{
+		AtlantisVersion:     "1300135",
+		AtlantisURL:         atlantisURL,
+		Logger:              logging.NewNoopLogger(t),
+		DriftStorage:        storage,
+		RemediationService:  drift.NewRemediationService(storage, store, nil, nil),
+		DriftTemplate:       web_templates.DriftTemplate,
+		RemediationTemplate: web_templates.DriftRemediationTemplate,
+	}, storage, store
+}
+
+func TestDriftController_Get(t *testing.T) {
+	dc, storage, store := newDriftController(t)
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "drifted",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "main",
+		Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1, ToChange: 2, ToDestroy: 3},
+		LastChecked: time.Now(),
+	}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "clean",
+		Path:        "clean",
+		Workspace:   "default",
+		Ref:         "main",
+		LastChecked: time.Now(),
+	}))
+	result := models.NewRemediationResult("run-1", "owner/repo", "main", models.RemediationPlanOnly)
+	result.Complete()
+	Ok(t, store.Save(result))
+
+	req, _ := http.NewRequest("GET", "/drift", nil)
+	w := httptest.NewRecorder()
+	dc.Get(w, req)
+	Equals(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	Assert(t, strings.Contains(body, "1 of 2 checked projects have drift"), "exp drift count in %q", body)
+	Assert(t, strings.Contains(body, "drifted"), "exp drifted project in %q", body)
+	Assert(t, strings.Contains(body, "/basepath/drift/remediations/run-1"), "exp remediation link in %q", body)
+	Assert(t, !strings.Contains(body, "<code>clean</code>"), "project without drift should not be listed")
+}
+
+func TestDriftController_Get_RemediationsWithoutDrift(t *testing.T) {
+	dc, _, store := newDriftController(t)
+	// The repository's drift results have expired, its remediations haven't.
+	result := models.NewRemediationResult("run-1", "owner/repo", "main", models.RemediationPlanOnly)
+	result.Complete()
+	Ok(t, store.Save(result))
+
+	req, _ := http.NewRequest("GET", "/drift", nil)
+	w := httptest.NewRecorder()
+	dc.Get(w, req)
+	Equals(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	Assert(t, strings.Contains(body, "/basepath/drift/remediations/run-1"), "exp remediation link in %q", body)
+}
+
+func TestDriftController_GetEmpty(t *testing.T) {
+	dc, _, _ := newDriftController(t)
+	req, _ := http.NewRequest("GET", "/drift", nil)
+	w := httptest.NewRecorder()
+	dc.Get(w, req)
+	Equals(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	Assert(t, strings.Contains(body, "No drift found in 0 checked projects."), "exp empty drift message in %q", body)
+	Assert(t, strings.Contains(body, "No remediation runs found."), "exp empty remediation message in %q", body)
+}
+
+func TestDriftController_GetRemediation(t *testing.T) {
+	dc, _, store := newDriftController(t)
+	result := models.NewRemediationResult("run-1", "owner/repo", "main", models.RemediationAutoApply)
+	result.Projects = append(result.Projects, models.ProjectRemediationResult{
+		Path:        "app",
+		Workspace:   "default",
+		Status:      models.RemediationStatusSuccess,
+		PlanOutput:  "Plan: 1 to add",
+		ApplyOutput: "Apply complete!",
+	})
+	result.Complete()
+	Ok(t, store.Save(result))
+
+	req, _ := http.NewRequest("GET", "", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "run-1"})
+	w := httptest.NewRecorder()
+	dc.GetRemediation(w, req)
+	Equals(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	Assert(t, strings.Contains(body, "Plan: 1 to add"), "exp plan output in %q", body)
+	Assert(t, strings.Contains(body, "Apply complete!"), "exp apply output in %q", body)
+}
+
+func TestDriftController_GetRemediation_NoID(t *testing.T) {
+	dc, _, _ := newDriftController(t)
+	req, _ := http.NewRequest("GET", "", nil)
+	w := httptest.NewRecorder()
+	dc.GetRemediation(w, req)
+	ResponseContains(t, w, http.StatusBadRequest, "No remediation id in request")
+}
+
+func TestDriftController_GetRemediation_NotFound(t *testing.T) {
+	dc, _, _ := newDriftController(t)
+	req, _ := http.NewRequest("GET", "", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+	w := httptest.NewRecorder()
+	dc.GetRemediation(w, req)
+	ResponseContains(t, w, http.StatusNotFound, "Could not find remediation run 'missing'")
+}
@@ -80,6 +80,9 @@ type VCSEventsController struct {
 	SupportedVCSHosts []models.VCSHostType `validate:"required"`
 	VCSClient         vcs.Client           `validate:"required"`
 	TestingMode       bool
+	// MergeGroupRunner runs Atlantis for GitHub merge queue groups. If nil,
+	// merge_group events are ignored.
+	MergeGroupRunner events.MergeGroupRunner
 	// BitbucketWebhookSecret is the secret added to this webhook via the Bitbucket
 	// UI that identifies this call as coming from Bitbucket. If empty, no
 	// request validation is done.
@@ -193,6 +196,14 @@ func (e *VCSEventsController) handleGithubPost(w http.ResponseWriter, r *http.Re
 		resp = e.HandleGithubPullRequestEvent(logger, event, githubReqID)
 		scope = scope.SubScope(fmt.Sprintf("pr_%s", *event.Action))
 		scope = common.SetGitScopeTags(scope, event.GetRepo().GetFullName(), event.GetNumber())
+	case *github.CheckRunEvent:
+		resp = e.HandleGithubCheckRunEvent(event, githubReqID, logger)
+		scope = scope.SubScope(fmt.Sprintf("check_run_%s", event.GetAction()))
+		scope = common.SetGitScopeTags(scope, event.GetRepo().GetFullName(), 0)
+	case *github.MergeGroupEvent:
+		resp = e.HandleGithubMergeGroupEvent(event, githubReqID, logger)
+		scope = scope.SubScope(fmt.Sprintf("merge_group_%s", event.GetAction()))
+		scope = common.SetGitScopeTags(scope, event.GetRepo().GetFullName(), 0)
 	default:
 		resp = HTTPResponse{
 			body: fmt.Sprintf("Ignoring unsupported event %s", githubReqID),
@@ -432,6 +443,113 @@ func (e *VCSEventsController) HandleGithubCommentEvent(event *github.IssueCommen
 	return e.handleCommentEvent(logger, baseRepo, nil, nil, user, pullNum, comment.GetBody(), comment.GetID(), models.Github)
 }
 
+// HandleGithubCheckRunEvent runs the commands of the actions requested on the
+// project check runs created by Atlantis.
+func (e *VCSEventsController) HandleGithubCheckRunEvent(event *github.CheckRunEvent, githubReqID string, logger logging.SimpleLogging) HTTPResponse {
+	if event.GetAction() != "requested_action" {
+		return HTTPResponse{
+			body: fmt.Sprintf("Ignoring check run event since action was not requested_action %s", githubReqID),
+		}
+	}
+
+	baseRepo, user, pullNum, cmd, err := e.Parser.ParseGithubCheckRunEvent(event)
+	if err != nil {
+		wrapped := fmt.Errorf("parsing event: %s: %w", githubReqID, err)
+		return HTTPResponse{
+			body: wrapped.Error(),
+			err: HTTPError{
+				code:       http.StatusBadRequest,
+				err:        wrapped,
+				isSilenced: false,
+			},
+		}
+	}
+	logger = logger.WithHistory(
+		"repo", baseRepo.FullName,
+		"pull", pullNum,
+	)
+
+	if !e.RepoAllowlistChecker.IsAllowlisted(baseRepo.FullName, baseRepo.VCSHost.Hostname) {
+		err := errors.New("repo not allowlisted")
+		return HTTPResponse{
+			body: err.Error(),
+			err: HTTPError{
+				err:        err,
+				code:       http.StatusForbidden,
+				isSilenced: e.SilenceAllowlistErrors,
+			},
+		}
+	}
+
+	logger.Info("Running check run action '%v' for user '%v'.", cmd.Name, user.Username)
+	if !e.TestingMode {
+		go e.CommandRunner.RunCommentCommand(baseRepo, nil, nil, user, pullNum, cmd)
+	} else {
+		e.CommandRunner.RunCommentCommand(baseRepo, nil, nil, user, pullNum, cmd)
+	}
+
+	return HTTPResponse{
+		body: "Processing...",
+	}
+}
+
+// HandleGithubMergeGroupEvent handles merge_group events from GitHub. Atlantis
+// runs for merge groups that request checks so the merge queue gets the
+// statuses required by branch protection on the merge group head commit.
+// The runner is invoked asynchronously unless TestingMode is set.
+func (e *VCSEventsController) HandleGithubMergeGroupEvent(event *github.MergeGroupEvent, githubReqID string, logger logging.SimpleLogging) HTTPResponse {
+	if e.MergeGroupRunner == nil {
+		return HTTPResponse{
+			body: fmt.Sprintf("Ignoring merge group event since merge queue support is disabled %s", githubReqID),
+		}
+	}
+	if event.GetAction() != "checks_requested" {
+		return HTTPResponse{
+			body: fmt.Sprintf("Ignoring merge group event since action was not checks_requested %s", githubReqID),
+		}
+	}
+
+	mergeGroup, user, err := e.Parser.ParseGithubMergeGroupEvent(event)
+	if err != nil {
+		wrapped := fmt.Errorf("parsing event: %s: %w", githubReqID, err)
+		return HTTPResponse{
+			body: wrapped.Error(),
+			err: HTTPError{
+				code:       http.StatusBadRequest,
+				err:        wrapped,
+				isSilenced: false,
+			},
+		}
+	}
+	logger = logger.WithHistory(
+		"repo", mergeGroup.BaseRepo.FullName,
+		"merge-group", mergeGroup.HeadBranch,
+	)
+
+	if !e.RepoAllowlistChecker.IsAllowlisted(mergeGroup.BaseRepo.FullName, mergeGroup.BaseRepo.VCSHost.Hostname) {
+		err := errors.New("repo not allowlisted")
+		return HTTPResponse{
+			body: err.Error(),
+			err: HTTPError{
+				err:        err,
+				code:       http.StatusForbidden,
+				isSilenced: e.SilenceAllowlistErrors,
+			},
+		}
+	}
+
+	logger.Info("Running merge group %s at %s for user '%v'.", mergeGroup.HeadBranch, mergeGroup.HeadCommit, user.Username)
+	if !e.TestingMode {
+		go e.MergeGroupRunner.RunMergeGroup(logger, mergeGroup)
+	} else {
+		e.MergeGroupRunner.RunMergeGroup(logger, mergeGroup)
+	}
+
+	return HTTPResponse{
+		body: "Processing...",
+	}
+}
+
 // HandleBitbucketCloudCommentEvent handles comment events from Bitbucket.
 func (e *VCSEventsController) HandleBitbucketCloudCommentEvent(w http.ResponseWriter, body []byte, reqID string) {
 	pull, baseRepo, headRepo, user, comment, err := e.Parser.ParseBitbucketCloudPullCommentEvent(body)
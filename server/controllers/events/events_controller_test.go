@@ -417,6 +417,114 @@ func TestPost_GithubCommentSuccess(t *testing.T) {
 	cr.VerifyWasCalledOnce().RunCommentCommand(baseRepo, nil, nil, user, 1, &cmd)
 }
 
+func TestPost_GithubCheckRunNotRequestedAction(t *testing.T) {
+	t.Log("when the event is a github check run event but not a requested action we ignore it")
+	e, v, _, _, _, cr, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "rerequested"}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Ignoring check run event since action was not requested_action")
+	cr.VerifyWasCalled(Never()).RunCommentCommand(Any[models.Repo](), Any[*models.Repo](), Any[*models.PullRequest](), Any[models.User](), Any[int](), Any[*events.CommentCommand]())
+}
+
+func TestPost_GithubCheckRunInvalid(t *testing.T) {
+	t.Log("when the event is a github check run event without all expected data we return a 400")
+	e, v, _, _, p, _, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "requested_action"}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	When(p.ParseGithubCheckRunEvent(Any[*github.CheckRunEvent]())).ThenReturn(models.Repo{}, models.User{}, 0, nil, errors.New("err"))
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusBadRequest, "parsing event")
+}
+
+func TestPost_GithubCheckRunRequestedAction(t *testing.T) {
+	t.Log("when the event is a github check run requested action we run its command")
+	e, v, _, _, p, cr, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "check_run")
+	event := `{"action": "requested_action", "requested_action": {"identifier": "apply"}}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	baseRepo := models.Repo{}
+	user := models.User{Username: "user"}
+	cmd := events.CommentCommand{Name: command.Apply, RepoRelDir: "dir", Workspace: "default"}
+	When(p.ParseGithubCheckRunEvent(Any[*github.CheckRunEvent]())).ThenReturn(baseRepo, user, 1, &cmd, nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Processing...")
+
+	cr.VerifyWasCalledOnce().RunCommentCommand(baseRepo, nil, nil, user, 1, &cmd)
+}
+
+func TestPost_GithubMergeGroupDisabled(t *testing.T) {
+	t.Log("when merge queue support is disabled we ignore github merge group events")
+	e, v, _, _, p, _, _, _, _ := setup(t)
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "merge_group")
+	event := `{"action": "checks_requested"}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Ignoring merge group event since merge queue support is disabled")
+	p.VerifyWasCalled(Never()).ParseGithubMergeGroupEvent(Any[*github.MergeGroupEvent]())
+}
+
+func TestPost_GithubMergeGroupNotChecksRequested(t *testing.T) {
+	t.Log("when the event is a github merge group event but checks were not requested we ignore it")
+	e, v, _, _, _, _, _, _, _ := setup(t)
+	runner := emocks.NewMockMergeGroupRunner()
+	e.MergeGroupRunner = runner
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "merge_group")
+	event := `{"action": "destroyed"}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Ignoring merge group event since action was not checks_requested")
+	runner.VerifyWasCalled(Never()).RunMergeGroup(Any[logging.SimpleLogging](), Any[models.MergeGroup]())
+}
+
+func TestPost_GithubMergeGroupInvalid(t *testing.T) {
+	t.Log("when the event is a github merge group event without all expected data we return a 400")
+	e, v, _, _, p, _, _, _, _ := setup(t)
+	e.MergeGroupRunner = emocks.NewMockMergeGroupRunner()
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "merge_group")
+	event := `{"action": "checks_requested"}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	When(p.ParseGithubMergeGroupEvent(Any[*github.MergeGroupEvent]())).ThenReturn(models.MergeGroup{}, models.User{}, errors.New("err"))
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusBadRequest, "parsing event")
+}
+
+func TestPost_GithubMergeGroupChecksRequested(t *testing.T) {
+	t.Log("when checks are requested for a github merge group we run the merge group")
+	e, v, _, _, p, _, _, _, _ := setup(t)
+	runner := emocks.NewMockMergeGroupRunner()
+	e.MergeGroupRunner = runner
+	req, _ := http.NewRequest("GET", "", bytes.NewBuffer(nil))
+	req.Header.Set(githubHeader, "merge_group")
+	event := `{"action": "checks_requested"}`
+	When(v.Validate(req, secret)).ThenReturn([]byte(event), nil)
+	mergeGroup := models.MergeGroup{
+		BaseBranch: "main",
+		HeadBranch: "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b",
+		HeadCommit: "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+	}
+	When(p.ParseGithubMergeGroupEvent(Any[*github.MergeGroupEvent]())).ThenReturn(mergeGroup, models.User{Username: "user"}, nil)
+	w := httptest.NewRecorder()
+	e.Post(w, req)
+	ResponseContains(t, w, http.StatusOK, "Processing...")
+
+	runner.VerifyWasCalledOnce().RunMergeGroup(Any[logging.SimpleLogging](), Eq(mergeGroup))
+}
+
 func TestPost_GithubCommentReaction(t *testing.T) {
 	t.Log("when the event is a github comment with a valid command we call the ReactToComment handler")
 	e, v, _, _, p, _, _, vcsClient, cp := setup(t)
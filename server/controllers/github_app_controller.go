@@ -112,6 +112,7 @@ func (g *GithubAppController) New(w http.ResponseWriter, _ *http.Request) {
 			"delete",
 			"issue_comment",
 			"issues",
+			"merge_group",
 			"pull_request_review_comment",
 			"pull_request_review",
 			"pull_request",
@@ -121,6 +122,7 @@ func (g *GithubAppController) New(w http.ResponseWriter, _ *http.Request) {
 			"checks":           "write",
 			"contents":         "write",
 			"issues":           "write",
+			"merge_queues":     "read",
 			"pull_requests":    "write",
 			"repository_hooks": "write",
 			"statuses":         "write",
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/jobs"
 )
 
@@ -28,6 +29,8 @@ var templateFileNames = map[string]string{
 	"project-jobs":       "project-jobs.html.tmpl",
 	"project-jobs-error": "project-jobs-error.html.tmpl",
 	"github-app":         "github-app.html.tmpl",
+	"drift":              "drift.html.tmpl",
+	"drift-remediation":  "drift-remediation.html.tmpl",
 }
 
 // TemplateWriter is an interface over html/template that's used to enable
@@ -65,6 +68,8 @@ type IndexData struct {
 
 	ApplyLock       ApplyLockData
 	AtlantisVersion string
+	// DriftEnabled is whether to link to the drift dashboard.
+	DriftEnabled bool
 	// CleanedBasePath is the path Atlantis is accessible at externally. If
 	// not using a path-based proxy, this will be an empty string. Never ends
 	// in a '/' (hence "cleaned").
@@ -120,3 +125,44 @@ type GithubSetupData struct {
 }
 
 var GithubAppSetupTemplate = templates.Lookup(templateFileNames["github-app"])
+
+// DriftProjectData holds the fields needed to display a project's drift.
+type DriftProjectData struct {
+	// Repository is the VCS-host-qualified repository, ex. github.com/owner/repo.
+	Repository           string
+	Project              models.ProjectDrift
+	LastCheckedFormatted string
+}
+
+// RemediationRunData holds the fields needed to display a remediation run in
+// the drift dashboard.
+type RemediationRunData struct {
+	Result             *models.RemediationResult
+	DetailPath         string
+	StartedAtFormatted string
+}
+
+// DriftIndexData holds the data for rendering the drift dashboard.
+type DriftIndexData struct {
+	// DriftedProjects are the projects with drift or a detection error.
+	DriftedProjects []DriftProjectData
+	// TotalProjects is how many projects have a stored detection result.
+	TotalProjects   int
+	Remediations    []RemediationRunData
+	AtlantisVersion string
+	CleanedBasePath string
+}
+
+var DriftTemplate = templates.Lookup(templateFileNames["drift"])
+
+// RemediationDetailData holds the data for rendering a single remediation
+// run and its project output.
+type RemediationDetailData struct {
+	Result               *models.RemediationResult
+	StartedAtFormatted   string
+	CompletedAtFormatted string
+	AtlantisVersion      string
+	CleanedBasePath      string
+}
+
+var DriftRemediationTemplate = templates.Lookup(templateFileNames["drift-remediation"])
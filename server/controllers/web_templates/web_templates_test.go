@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/jobs"
 	. "github.com/runatlantis/atlantis/testing"
 )
@@ -96,3 +97,64 @@ func TestGithubAppSetupTemplate(t *testing.T) {
 	})
 	Ok(t, err)
 }
+
+func TestDriftTemplate(t *testing.T) {
+	err := DriftTemplate.Execute(io.Discard, DriftIndexData{
+		DriftedProjects: []DriftProjectData{
+			{
+				Repository: "github.com/owner/repo",
+				Project: models.ProjectDrift{
+					ProjectName: "project",
+					Path:        "path",
+					Workspace:   "default",
+					Ref:         "main",
+					Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1, ToChange: 2, ToDestroy: 3},
+				},
+				LastCheckedFormatted: "2006-01-02 15:04:05",
+			},
+			{
+				Repository:           "github.com/owner/repo",
+				Project:              models.ProjectDrift{Path: "broken", Workspace: "default", Ref: "main", Error: "plan failed"},
+				LastCheckedFormatted: "2006-01-02 15:04:05",
+			},
+		},
+		TotalProjects: 3,
+		Remediations: []RemediationRunData{
+			{
+				Result:             models.NewRemediationResult("id", "owner/repo", "main", models.RemediationPlanOnly),
+				DetailPath:         "/drift/remediations/id",
+				StartedAtFormatted: "2006-01-02 15:04:05",
+			},
+		},
+		AtlantisVersion: "v0.0.0",
+		CleanedBasePath: "/path",
+	})
+	Ok(t, err)
+}
+
+func TestDriftRemediationTemplate(t *testing.T) {
+	result := models.NewRemediationResult("id", "owner/repo", "main", models.RemediationAutoApply)
+	result.Projects = []models.ProjectRemediationResult{
+		{
+			ProjectName: "project",
+			Path:        "path",
+			Workspace:   "default",
+			Status:      models.RemediationStatusSuccess,
+			PlanOutput:  "plan output",
+			ApplyOutput: "apply output",
+			DriftBefore: &models.DriftSummary{HasDrift: true, Summary: "1 to add"},
+			DriftAfter:  &models.DriftSummary{},
+		},
+		{Path: "other", Workspace: "default", Status: models.RemediationStatusFailed, Error: "apply failed"},
+	}
+	result.PullRequestNum = 1
+	result.PullRequestURL = "https://github.com/owner/repo/pull/1"
+	err := DriftRemediationTemplate.Execute(io.Discard, RemediationDetailData{
+		Result:               result,
+		StartedAtFormatted:   "2006-01-02 15:04:05",
+		CompletedAtFormatted: "2006-01-02 15:05:05",
+		AtlantisVersion:      "v0.0.0",
+		CleanedBasePath:      "/path",
+	})
+	Ok(t, err)
+}
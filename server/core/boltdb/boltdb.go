@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
@@ -27,12 +28,14 @@ type BoltDB struct {
 	locksBucketName       []byte
 	pullsBucketName       []byte
 	globalLocksBucketName []byte
+	lockQueuesBucketName  []byte
 }
 
 const (
 	locksBucketName       = "runLocks"
 	pullsBucketName       = "pulls"
 	globalLocksBucketName = "globalLocks"
+	lockQueuesBucketName  = "lockQueues"
 	pullKeySeparator      = "::"
 )
 
@@ -61,6 +64,9 @@ func New(dataDir string) (*BoltDB, error) {
 		if _, err = tx.CreateBucketIfNotExists([]byte(globalLocksBucketName)); err != nil {
 			return fmt.Errorf("creating bucket %q: %w", globalLocksBucketName, err)
 		}
+		if _, err = tx.CreateBucketIfNotExists([]byte(lockQueuesBucketName)); err != nil {
+			return fmt.Errorf("creating bucket %q: %w", lockQueuesBucketName, err)
+		}
 		return nil
 	})
 	if err != nil {
@@ -124,6 +130,7 @@ func New(dataDir string) (*BoltDB, error) {
 		locksBucketName:       []byte(locksBucketName),
 		pullsBucketName:       []byte(pullsBucketName),
 		globalLocksBucketName: []byte(globalLocksBucketName),
+		lockQueuesBucketName:  []byte(lockQueuesBucketName),
 	}, nil
 }
 
@@ -134,6 +141,7 @@ func NewWithDB(db *bolt.DB, bucket string, globalBucket string) (*BoltDB, error)
 		locksBucketName:       []byte(bucket),
 		pullsBucketName:       []byte(pullsBucketName),
 		globalLocksBucketName: []byte(globalBucket),
+		lockQueuesBucketName:  []byte(lockQueuesBucketName),
 	}, nil
 }
 
@@ -374,6 +382,118 @@ func (b *BoltDB) UnlockByPull(repoFullName string, pullNum int) ([]models.Projec
 	return locks, nil
 }
 
+// EnqueueLockWaiter appends waiter to the queue for its project and
+// workspace, unless its pull request is already queued, and returns its
+// 1-based position in the queue.
+func (b *BoltDB) EnqueueLockWaiter(waiter models.LockWaiter) (int, error) {
+	key := []byte(b.lockKey(waiter.Project, waiter.Workspace))
+	var position int
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(b.lockQueuesBucketName)
+		if err != nil {
+			return err
+		}
+		queue, err := b.getQueueFromBucket(bucket, key)
+		if err != nil {
+			return err
+		}
+		for i, w := range queue {
+			if w.Pull.Num == waiter.Pull.Num {
+				position = i + 1
+				return nil
+			}
+		}
+		queue = append(queue, waiter)
+		position = len(queue)
+		return b.writeQueueToBucket(bucket, key, queue)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return position, nil
+}
+
+// DequeueLockWaiter removes and returns the first waiter in the queue for
+// project and workspace. If the queue is empty, it returns a nil pointer.
+func (b *BoltDB) DequeueLockWaiter(p models.Project, workspace string) (*models.LockWaiter, error) {
+	key := []byte(b.lockKey(p, workspace))
+	var waiter *models.LockWaiter
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(b.lockQueuesBucketName)
+		if err != nil {
+			return err
+		}
+		queue, err := b.getQueueFromBucket(bucket, key)
+		if err != nil || len(queue) == 0 {
+			return err
+		}
+		waiter = &queue[0]
+		return b.writeQueueToBucket(bucket, key, queue[1:])
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return waiter, nil
+}
+
+// ListLockWaiters returns the queue for project and workspace in order.
+func (b *BoltDB) ListLockWaiters(p models.Project, workspace string) ([]models.LockWaiter, error) {
+	key := []byte(b.lockKey(p, workspace))
+	var queue []models.LockWaiter
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.lockQueuesBucketName)
+		if bucket == nil {
+			return nil
+		}
+		var err error
+		queue, err = b.getQueueFromBucket(bucket, key)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return queue, nil
+}
+
+// RemoveLockWaitersByPull removes the pull request from every queue in its
+// repo.
+func (b *BoltDB) RemoveLockWaitersByPull(repoFullName string, pullNum int) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(b.lockQueuesBucketName)
+		if err != nil {
+			return err
+		}
+		updated := make(map[string][]models.LockWaiter)
+		c := bucket.Cursor()
+		// As with locks, the repoFullName is a prefix of the queue's key.
+		for k, v := c.Seek([]byte(repoFullName)); k != nil && bytes.HasPrefix(k, []byte(repoFullName)); k, v = c.Next() {
+			var queue []models.LockWaiter
+			if err := json.Unmarshal(v, &queue); err != nil {
+				return fmt.Errorf("deserializing lock queue at key %q: %w", string(k), err)
+			}
+			var kept []models.LockWaiter
+			for _, w := range queue {
+				if w.Project.RepoFullName != repoFullName || w.Pull.Num != pullNum {
+					kept = append(kept, w)
+				}
+			}
+			if len(kept) != len(queue) {
+				updated[string(k)] = kept
+			}
+		}
+		for k, queue := range updated {
+			if err := b.writeQueueToBucket(bucket, []byte(k), queue); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
 // GetLock returns a pointer to the lock for that project and workspace.
 // If there is no lock, it returns a nil pointer.
 func (b *BoltDB) GetLock(p models.Project, workspace string) (*models.ProjectLock, error) {
@@ -422,79 +542,7 @@ func (b *BoltDB) UpdatePullWithResults(pull models.PullRequest, newResults []com
 			currStatus = nil
 		}
 
-		// If there is no pull OR if the pull we have is out of date, we
-		// just write a new pull.
-		if currStatus == nil || pullStatusOutdatedForPull(currStatus.Pull, pull) {
-			var statuses []models.ProjectStatus
-			for _, r := range newResults {
-				statuses = append(statuses, b.projectResultToProject(r))
-			}
-			// Preserve policy status from the previous commit so approvals
-			// survive between the plan DB write and the subsequent policy
-			// check DB write. doPolicyCheck applies sticky filtering and
-			// overwrites these when it writes its own results.
-			if currStatus != nil {
-				for i := range statuses {
-					for _, old := range currStatus.Projects {
-						if statuses[i].Workspace == old.Workspace &&
-							statuses[i].RepoRelDir == old.RepoRelDir &&
-							statuses[i].ProjectName == old.ProjectName &&
-							len(old.PolicyStatus) > 0 {
-							statuses[i].PolicyStatus = old.PolicyStatus
-							break
-						}
-					}
-				}
-			}
-			newStatus = models.PullStatus{
-				Pull:     pull,
-				Projects: statuses,
-			}
-		} else {
-			// If there's an existing pull at the right commit then we have to
-			// merge our project results with the existing ones. We do a merge
-			// because it's possible a user is just applying a single project
-			// in this command and so we don't want to delete our data about
-			// other projects that aren't affected by this command.
-			newStatus = *currStatus
-			for _, res := range newResults {
-				// First, check if we should update any existing projects.
-				updatedExisting := false
-				for i := range newStatus.Projects {
-					// NOTE: We're using a reference here because we are
-					// in-place updating its Status field.
-					proj := &newStatus.Projects[i]
-					if res.Workspace == proj.Workspace &&
-						res.RepoRelDir == proj.RepoRelDir &&
-						res.ProjectName == proj.ProjectName {
-
-						proj.Status = res.PlanStatus()
-
-						// Updating only policy sets which are included in results; keeping the rest.
-						if len(proj.PolicyStatus) > 0 {
-							for i, oldPolicySet := range proj.PolicyStatus {
-								for _, newPolicySet := range res.PolicyStatus() {
-									if oldPolicySet.PolicySetName == newPolicySet.PolicySetName {
-										proj.PolicyStatus[i] = newPolicySet
-									}
-								}
-							}
-						} else {
-							proj.PolicyStatus = res.PolicyStatus()
-						}
-
-						updatedExisting = true
-						break
-					}
-				}
-
-				if !updatedExisting {
-					// If we didn't update an existing project, then we need to
-					// add this because it's a new one.
-					newStatus.Projects = append(newStatus.Projects, b.projectResultToProject(res))
-				}
-			}
-		}
+		newStatus = db.MergePullStatus(currStatus, pull, newResults)
 
 		// Now, we overwrite the key with our new status.
 		return b.writePullToBucket(bucket, key, newStatus)
@@ -505,16 +553,6 @@ func (b *BoltDB) UpdatePullWithResults(pull models.PullRequest, newResults []com
 	return newStatus, nil
 }
 
-func pullStatusOutdatedForPull(statusPull models.PullRequest, pull models.PullRequest) bool {
-	if statusPull.HeadCommit != pull.HeadCommit {
-		return true
-	}
-	if pull.BaseBranch == "" {
-		return false
-	}
-	return statusPull.BaseBranch == "" || statusPull.BaseBranch != pull.BaseBranch
-}
-
 // GetPullStatus returns the status for pull.
 // If there is no status, returns a nil pointer.
 func (b *BoltDB) GetPullStatus(pull models.PullRequest) (*models.PullStatus, error) {
@@ -608,6 +646,30 @@ func (b *BoltDB) lockKey(p models.Project, workspace string) string {
 	return models.GenerateLockKey(p, workspace)
 }
 
+func (b *BoltDB) getQueueFromBucket(bucket *bolt.Bucket, key []byte) ([]models.LockWaiter, error) {
+	serialized := bucket.Get(key)
+	if serialized == nil {
+		return nil, nil
+	}
+	var queue []models.LockWaiter
+	if err := json.Unmarshal(serialized, &queue); err != nil {
+		return nil, fmt.Errorf("deserializing lock queue at key %q: %w", string(key), err)
+	}
+	return queue, nil
+}
+
+// writeQueueToBucket writes queue at key, deleting the key if queue is empty.
+func (b *BoltDB) writeQueueToBucket(bucket *bolt.Bucket, key []byte, queue []models.LockWaiter) error {
+	if len(queue) == 0 {
+		return bucket.Delete(key)
+	}
+	serialized, err := json.Marshal(queue)
+	if err != nil {
+		return fmt.Errorf("serializing lock queue: %w", err)
+	}
+	return bucket.Put(key, serialized)
+}
+
 func (b *BoltDB) getPullFromBucket(bucket *bolt.Bucket, key []byte) (*models.PullStatus, error) {
 	serialized := bucket.Get(key)
 	if serialized == nil {
@@ -629,16 +691,6 @@ func (b *BoltDB) writePullToBucket(bucket *bolt.Bucket, key []byte, pull models.
 	return bucket.Put(key, serialized)
 }
 
-func (b *BoltDB) projectResultToProject(p command.ProjectResult) models.ProjectStatus {
-	return models.ProjectStatus{
-		Workspace:    p.Workspace,
-		RepoRelDir:   p.RepoRelDir,
-		ProjectName:  p.ProjectName,
-		PolicyStatus: p.PolicyStatus(),
-		Status:       p.PlanStatus(),
-	}
-}
-
 // Ping checks the database connection health.
 func (b *BoltDB) Ping() error {
 	return b.db.View(func(tx *bolt.Tx) error {
@@ -586,6 +586,75 @@ func TestUnlockByPullMatching(t *testing.T) {
 	Equals(t, 0, len(ls))
 }
 
+func TestLockQueue(t *testing.T) {
+	t.Log("waiting pull requests should be queued in order...")
+	db, b := newTestDB()
+	defer cleanupDB(db)
+	waiter := func(num int) models.LockWaiter {
+		return models.LockWaiter{
+			Project:   project,
+			Workspace: workspace,
+			Pull:      models.PullRequest{Num: num},
+			User:      models.User{Username: "user"},
+			Time:      time.Now(),
+		}
+	}
+	for i, num := range []int{2, 3, 2} {
+		pos, err := b.EnqueueLockWaiter(waiter(num))
+		Ok(t, err)
+		Equals(t, []int{1, 2, 1}[i], pos)
+	}
+
+	t.Log("...listed in order")
+	queue, err := b.ListLockWaiters(project, workspace)
+	Ok(t, err)
+	Equals(t, 2, len(queue))
+	Equals(t, 2, queue[0].Pull.Num)
+	Equals(t, 3, queue[1].Pull.Num)
+
+	t.Log("...and dequeued from the front")
+	next, err := b.DequeueLockWaiter(project, workspace)
+	Ok(t, err)
+	Equals(t, 2, next.Pull.Num)
+	Equals(t, "user", next.User.Username)
+	next, err = b.DequeueLockWaiter(project, workspace)
+	Ok(t, err)
+	Equals(t, 3, next.Pull.Num)
+	next, err = b.DequeueLockWaiter(project, workspace)
+	Ok(t, err)
+	Equals(t, (*models.LockWaiter)(nil), next)
+}
+
+func TestRemoveLockWaitersByPull(t *testing.T) {
+	t.Log("RemoveLockWaitersByPull should remove the pull from every queue in its repo")
+	db, b := newTestDB()
+	defer cleanupDB(db)
+	otherProject := models.NewProject(project.RepoFullName, "other/path", "")
+	otherRepoProject := models.NewProject("owner/repo2", "parent/child", "")
+	for _, w := range []models.LockWaiter{
+		{Project: project, Workspace: workspace, Pull: models.PullRequest{Num: 2}},
+		{Project: project, Workspace: workspace, Pull: models.PullRequest{Num: 3}},
+		{Project: otherProject, Workspace: workspace, Pull: models.PullRequest{Num: 2}},
+		{Project: otherRepoProject, Workspace: workspace, Pull: models.PullRequest{Num: 2}},
+	} {
+		_, err := b.EnqueueLockWaiter(w)
+		Ok(t, err)
+	}
+
+	Ok(t, b.RemoveLockWaitersByPull(project.RepoFullName, 2))
+
+	queue, err := b.ListLockWaiters(project, workspace)
+	Ok(t, err)
+	Equals(t, 1, len(queue))
+	Equals(t, 3, queue[0].Pull.Num)
+	queue, err = b.ListLockWaiters(otherProject, workspace)
+	Ok(t, err)
+	Equals(t, 0, len(queue))
+	queue, err = b.ListLockWaiters(otherRepoProject, workspace)
+	Ok(t, err)
+	Equals(t, 1, len(queue))
+}
+
 func TestGetLockNotThere(t *testing.T) {
 	t.Log("getting a lock that doesn't exist should return a nil pointer")
 	db, b := newTestDB()
@@ -0,0 +1,270 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+const driftBucketName = "drift"
+
+// DriftStorage is a drift.Storage backed by the same BoltDB file used for
+// locks, so drift results survive restarts.
+// Records live in a nested bucket per repository under the drift bucket,
+// keyed by the JSON-encoded drift.RecordKey.
+type DriftStorage struct {
+	db         *bolt.DB
+	bucketName []byte
+	// retention is how long a record is kept after LastChecked. Zero keeps
+	// records forever.
+	retention time.Duration
+}
+
+var _ drift.Storage = (*DriftStorage)(nil)
+var _ drift.Compactor = (*DriftStorage)(nil)
+
+// NewDriftStorage returns a drift storage that shares b's database file.
+func NewDriftStorage(b *BoltDB, retention time.Duration) (*DriftStorage, error) {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte(driftBucketName)); err != nil {
+			return fmt.Errorf("creating bucket %q: %w", driftBucketName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starting BoltDB drift storage: %w", err)
+	}
+	return &DriftStorage{
+		db:         b.db,
+		bucketName: []byte(driftBucketName),
+		retention:  retention,
+	}, nil
+}
+
+// Store saves a drift result for a project. PlanOutput is stripped before
+// persisting. Expired records for the repository are pruned in the same
+// transaction.
+func (d *DriftStorage) Store(repository string, projectDrift models.ProjectDrift) error {
+	projectDrift.PlanOutput = ""
+	key, err := json.Marshal(drift.KeyFor(projectDrift))
+	if err != nil {
+		return fmt.Errorf("serializing drift key: %w", err)
+	}
+	serialized, err := json.Marshal(projectDrift)
+	if err != nil {
+		return fmt.Errorf("serializing drift: %w", err)
+	}
+
+	err = d.db.Update(func(tx *bolt.Tx) error {
+		repoBucket, err := tx.Bucket(d.bucketName).CreateBucketIfNotExists([]byte(repository))
+		if err != nil {
+			return fmt.Errorf("creating drift bucket for %q: %w", repository, err)
+		}
+		if _, err := d.pruneExpired(repoBucket, time.Now()); err != nil {
+			return err
+		}
+		return repoBucket.Put(key, serialized)
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves drift results for a repository with optional filtering.
+// Records older than the retention period are never returned.
+func (d *DriftStorage) Get(repository string, opts drift.GetOptions) ([]models.ProjectDrift, error) {
+	result := make([]models.ProjectDrift, 0)
+	now := time.Now()
+	err := d.db.View(func(tx *bolt.Tx) error {
+		repoBucket := tx.Bucket(d.bucketName).Bucket([]byte(repository))
+		if repoBucket == nil {
+			return nil
+		}
+		return repoBucket.ForEach(func(k, v []byte) error {
+			projectDrift, err := decodeDrift(k, v)
+			if err != nil {
+				return err
+			}
+			if d.expired(projectDrift, now) || !drift.MatchesGetOptions(projectDrift, opts, now) {
+				return nil
+			}
+			result = append(result, projectDrift)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return result, nil
+}
+
+// Delete removes drift results for a repository.
+// If projectName is empty, all drift results for the repository are removed.
+func (d *DriftStorage) Delete(repository string, projectName string) error {
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(d.bucketName)
+		if projectName == "" {
+			if err := bucket.DeleteBucket([]byte(repository)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+			return nil
+		}
+		return d.deleteWhere(bucket.Bucket([]byte(repository)), func(projectDrift models.ProjectDrift) bool {
+			return projectDrift.ProjectName == projectName
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteMatching removes drift results for a repository that match the given filters.
+func (d *DriftStorage) DeleteMatching(repository string, opts drift.GetOptions) error {
+	if opts == (drift.GetOptions{}) {
+		return errors.New("at least one drift delete filter is required")
+	}
+	now := time.Now()
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		return d.deleteWhere(tx.Bucket(d.bucketName).Bucket([]byte(repository)), func(projectDrift models.ProjectDrift) bool {
+			return drift.MatchesDeleteOptions(projectDrift, opts, now)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// GetAll retrieves all unexpired drift results across all repositories.
+func (d *DriftStorage) GetAll() (map[string][]models.ProjectDrift, error) {
+	result := make(map[string][]models.ProjectDrift)
+	now := time.Now()
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(d.bucketName).ForEachBucket(func(repository []byte) error {
+			drifts := make([]models.ProjectDrift, 0)
+			err := tx.Bucket(d.bucketName).Bucket(repository).ForEach(func(k, v []byte) error {
+				projectDrift, err := decodeDrift(k, v)
+				if err != nil {
+					return err
+				}
+				if !d.expired(projectDrift, now) {
+					drifts = append(drifts, projectDrift)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			result[string(repository)] = drifts
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return result, nil
+}
+
+// Compact removes all records older than the retention period and drops
+// repository buckets left empty.
+func (d *DriftStorage) Compact() (int, error) {
+	if d.retention <= 0 {
+		return 0, nil
+	}
+	removed := 0
+	now := time.Now()
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(d.bucketName)
+		var emptyRepos [][]byte
+		err := bucket.ForEachBucket(func(repository []byte) error {
+			repoBucket := bucket.Bucket(repository)
+			n, err := d.pruneExpired(repoBucket, now)
+			if err != nil {
+				return err
+			}
+			removed += n
+			if k, _ := repoBucket.Cursor().First(); k == nil {
+				emptyRepos = append(emptyRepos, append([]byte(nil), repository...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, repository := range emptyRepos {
+			if err := bucket.DeleteBucket(repository); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return removed, nil
+}
+
+func (d *DriftStorage) expired(projectDrift models.ProjectDrift, now time.Time) bool {
+	return d.retention > 0 && now.Sub(projectDrift.LastChecked) > d.retention
+}
+
+func (d *DriftStorage) pruneExpired(repoBucket *bolt.Bucket, now time.Time) (int, error) {
+	if d.retention <= 0 {
+		return 0, nil
+	}
+	removed := 0
+	err := d.deleteWhere(repoBucket, func(projectDrift models.ProjectDrift) bool {
+		if d.expired(projectDrift, now) {
+			removed++
+			return true
+		}
+		return false
+	})
+	return removed, err
+}
+
+// deleteWhere deletes every record in repoBucket matching match. Keys are
+// collected first because bolt does not allow mutation during ForEach.
+func (d *DriftStorage) deleteWhere(repoBucket *bolt.Bucket, match func(models.ProjectDrift) bool) error {
+	if repoBucket == nil {
+		return nil
+	}
+	var keys [][]byte
+	err := repoBucket.ForEach(func(k, v []byte) error {
+		projectDrift, err := decodeDrift(k, v)
+		if err != nil {
+			return err
+		}
+		if match(projectDrift) {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := repoBucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeDrift(k, v []byte) (models.ProjectDrift, error) {
+	var projectDrift models.ProjectDrift
+	if err := json.Unmarshal(v, &projectDrift); err != nil {
+		return projectDrift, fmt.Errorf("deserializing drift at key %q: %w", string(k), err)
+	}
+	return projectDrift, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/boltdb"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newTestDriftStorage(t *testing.T, retention time.Duration) *boltdb.DriftStorage {
+	t.Helper()
+	b := newTestDB2(t)
+	t.Cleanup(func() { b.Close() }) // nolint: errcheck
+	s, err := boltdb.NewDriftStorage(b, retention)
+	Ok(t, err)
+	return s
+}
+
+func TestDriftStorage_StoreAndGet(t *testing.T) {
+	s := newTestDriftStorage(t, 0)
+
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "main",
+		PlanOutput:  "secret plan output",
+		Drift:       models.DriftSummary{HasDrift: true, ToChange: 2},
+		LastChecked: time.Now(),
+	}))
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "feature",
+		LastChecked: time.Now(),
+	}))
+
+	results, err := s.Get("owner/repo", drift.GetOptions{Ref: "main"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, 2, results[0].Drift.ToChange)
+	Equals(t, "", results[0].PlanOutput)
+
+	all, err := s.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 2, len(all))
+
+	missing, err := s.Get("owner/other", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(missing))
+}
+
+func TestDriftStorage_StoreOverwritesSameIdentity(t *testing.T) {
+	s := newTestDriftStorage(t, 0)
+	d := models.ProjectDrift{ProjectName: "app", Workspace: "default", Ref: "main", LastChecked: time.Now()}
+
+	Ok(t, s.Store("owner/repo", d))
+	d.Drift.HasDrift = true
+	Ok(t, s.Store("owner/repo", d))
+
+	results, err := s.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, true, results[0].Drift.HasDrift)
+}
+
+func TestDriftStorage_PersistsAcrossReopen(t *testing.T) {
+	tmp := t.TempDir()
+	b, err := boltdb.New(tmp)
+	Ok(t, err)
+	s, err := boltdb.NewDriftStorage(b, 0)
+	Ok(t, err)
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{ProjectName: "app", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, b.Close())
+
+	b, err = boltdb.New(tmp)
+	Ok(t, err)
+	defer b.Close() // nolint: errcheck
+	s, err = boltdb.NewDriftStorage(b, 0)
+	Ok(t, err)
+	results, err := s.Get("owner/repo", drift.GetOptions{ProjectName: "app"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+}
+
+func TestDriftStorage_Delete(t *testing.T) {
+	s := newTestDriftStorage(t, 0)
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{ProjectName: "a", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{ProjectName: "b", Ref: "main", LastChecked: time.Now()}))
+
+	Ok(t, s.Delete("owner/repo", "a"))
+	results, err := s.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "b", results[0].ProjectName)
+
+	Ok(t, s.Delete("owner/repo", ""))
+	results, err = s.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+
+	// Deleting a repository that was never stored is not an error.
+	Ok(t, s.Delete("owner/unknown", ""))
+}
+
+func TestDriftStorage_DeleteMatchingExact(t *testing.T) {
+	s := newTestDriftStorage(t, 0)
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{Path: "app", Workspace: "default", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{ProjectName: "named", Path: "app", Workspace: "default", Ref: "main", LastChecked: time.Now()}))
+
+	ErrContains(t, "at least one drift delete filter is required", s.DeleteMatching("owner/repo", drift.GetOptions{}))
+
+	Ok(t, s.DeleteMatching("owner/repo", drift.GetOptions{Path: "app", Workspace: "default", Ref: "main", Exact: true}))
+	results, err := s.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "named", results[0].ProjectName)
+}
+
+func TestDriftStorage_GetAll(t *testing.T) {
+	s := newTestDriftStorage(t, 0)
+	Ok(t, s.Store("owner/repo1", models.ProjectDrift{ProjectName: "a", LastChecked: time.Now()}))
+	Ok(t, s.Store("owner/repo2", models.ProjectDrift{ProjectName: "b", LastChecked: time.Now()}))
+
+	all, err := s.GetAll()
+	Ok(t, err)
+	Equals(t, 2, len(all))
+	Equals(t, "a", all["owner/repo1"][0].ProjectName)
+	Equals(t, "b", all["owner/repo2"][0].ProjectName)
+}
+
+func TestDriftStorage_RetentionAndCompact(t *testing.T) {
+	s := newTestDriftStorage(t, time.Hour)
+	Ok(t, s.Store("owner/old", models.ProjectDrift{ProjectName: "stale", LastChecked: time.Now().Add(-2 * time.Hour)}))
+	Ok(t, s.Store("owner/repo", models.ProjectDrift{ProjectName: "fresh", LastChecked: time.Now()}))
+
+	results, err := s.Get("owner/old", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+
+	removed, err := s.Compact()
+	Ok(t, err)
+	Equals(t, 1, removed)
+
+	all, err := s.GetAll()
+	Ok(t, err)
+	Equals(t, 1, len(all))
+	Equals(t, "fresh", all["owner/repo"][0].ProjectName)
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	remediationsBucketName   = "remediations"
+	remediationIDsBucketName = "remediationIDs"
+)
+
+// RemediationStore is a drift.RemediationStore backed by the same BoltDB
+// file used for locks.
+// Results live in a nested bucket per repository under the remediations
+// bucket, keyed by start time then ID so a reverse cursor walk yields the
+// newest results first. The remediationIDs bucket maps an ID back to its
+// repository and key.
+type RemediationStore struct {
+	db            *bolt.DB
+	resultsBucket []byte
+	idsBucket     []byte
+	// retention is how long a result is kept after it started. Zero keeps
+	// results forever.
+	retention time.Duration
+}
+
+var _ drift.RemediationStore = (*RemediationStore)(nil)
+var _ drift.Compactor = (*RemediationStore)(nil)
+
+// remediationRecord is the value stored for each result. The repository is
+// stored explicitly because RemediationResult.StorageRepository is not
+// serialized.
+type remediationRecord struct {
+	Repository string                    `json:"repository"`
+	Result     *models.RemediationResult `json:"result"`
+}
+
+// remediationIndexEntry locates a result by ID.
+type remediationIndexEntry struct {
+	Repository string `json:"repository"`
+	Key        string `json:"key"`
+}
+
+// NewRemediationStore returns a remediation store that shares b's database file.
+func NewRemediationStore(b *BoltDB, retention time.Duration) (*RemediationStore, error) {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{remediationsBucketName, remediationIDsBucketName} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return fmt.Errorf("creating bucket %q: %w", name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starting BoltDB remediation store: %w", err)
+	}
+	return &RemediationStore{
+		db:            b.db,
+		resultsBucket: []byte(remediationsBucketName),
+		idsBucket:     []byte(remediationIDsBucketName),
+		retention:     retention,
+	}, nil
+}
+
+// Save creates or replaces the result with the same ID.
+func (r *RemediationStore) Save(result *models.RemediationResult) error {
+	repository := result.StorageRepository
+	if repository == "" {
+		repository = result.Repository
+	}
+	key := remediationKey(result)
+	serialized, err := json.Marshal(remediationRecord{Repository: repository, Result: result})
+	if err != nil {
+		return fmt.Errorf("serializing remediation result: %w", err)
+	}
+	index, err := json.Marshal(remediationIndexEntry{Repository: repository, Key: key})
+	if err != nil {
+		return fmt.Errorf("serializing remediation index: %w", err)
+	}
+
+	err = r.db.Update(func(tx *bolt.Tx) error {
+		repoBucket, err := tx.Bucket(r.resultsBucket).CreateBucketIfNotExists([]byte(repository))
+		if err != nil {
+			return fmt.Errorf("creating remediation bucket for %q: %w", repository, err)
+		}
+		if err := repoBucket.Put([]byte(key), serialized); err != nil {
+			return err
+		}
+		return tx.Bucket(r.idsBucket).Put([]byte(result.ID), index)
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get returns the result with the given ID, or nil if it does not exist.
+func (r *RemediationStore) Get(id string) (*models.RemediationResult, error) {
+	var result *models.RemediationResult
+	err := r.db.View(func(tx *bolt.Tx) error {
+		serializedIndex := tx.Bucket(r.idsBucket).Get([]byte(id))
+		if serializedIndex == nil {
+			return nil
+		}
+		var index remediationIndexEntry
+		if err := json.Unmarshal(serializedIndex, &index); err != nil {
+			return fmt.Errorf("deserializing remediation index %q: %w", id, err)
+		}
+		repoBucket := tx.Bucket(r.resultsBucket).Bucket([]byte(index.Repository))
+		if repoBucket == nil {
+			return nil
+		}
+		serialized := repoBucket.Get([]byte(index.Key))
+		if serialized == nil {
+			return nil
+		}
+		var err error
+		result, err = decodeRemediationRecord([]byte(index.Key), serialized)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return result, nil
+}
+
+// List returns results for a repository, newest first.
+func (r *RemediationStore) List(repository string, opts drift.ListResultsOptions) ([]*models.RemediationResult, error) {
+	collector := drift.NewResultCollector(opts)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		repoBucket := tx.Bucket(r.resultsBucket).Bucket([]byte(repository))
+		if repoBucket == nil {
+			return nil
+		}
+		c := repoBucket.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			result, err := decodeRemediationRecord(k, v)
+			if err != nil {
+				return err
+			}
+			if collector.Add(result) {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return collector.Results, nil
+}
+
+// Repositories returns the repositories that have results.
+func (r *RemediationStore) Repositories() ([]string, error) {
+	var repositories []string
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(r.resultsBucket).ForEachBucket(func(repository []byte) error {
+			repositories = append(repositories, string(repository))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return repositories, nil
+}
+
+// Compact removes results that started before the retention period.
+// Because keys are ordered by start time, each repository bucket is walked
+// from the oldest result until the first one still within retention.
+func (r *RemediationStore) Compact() (int, error) {
+	if r.retention <= 0 {
+		return 0, nil
+	}
+	cutoff := remediationKeyPrefix(time.Now().Add(-r.retention))
+	removed := 0
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		results := tx.Bucket(r.resultsBucket)
+		ids := tx.Bucket(r.idsBucket)
+		return results.ForEachBucket(func(repository []byte) error {
+			repoBucket := results.Bucket(repository)
+			var expired [][]byte
+			c := repoBucket.Cursor()
+			for k, v := c.First(); k != nil && string(k) < cutoff; k, v = c.Next() {
+				result, err := decodeRemediationRecord(k, v)
+				if err != nil {
+					return err
+				}
+				if err := ids.Delete([]byte(result.ID)); err != nil {
+					return err
+				}
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			for _, k := range expired {
+				if err := repoBucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			removed += len(expired)
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return removed, nil
+}
+
+// remediationKey orders results by start time. The ID suffix keeps keys
+// unique for results started in the same nanosecond.
+func remediationKey(result *models.RemediationResult) string {
+	return remediationKeyPrefix(result.StartedAt) + "/" + result.ID
+}
+
+func remediationKeyPrefix(startedAt time.Time) string {
+	return fmt.Sprintf("%020d", startedAt.UnixNano())
+}
+
+func decodeRemediationRecord(k, v []byte) (*models.RemediationResult, error) {
+	var record remediationRecord
+	if err := json.Unmarshal(v, &record); err != nil {
+		return nil, fmt.Errorf("deserializing remediation result at key %q: %w", string(k), err)
+	}
+	if record.Result == nil {
+		return nil, fmt.Errorf("remediation result at key %q is empty", string(k))
+	}
+	record.Result.StorageRepository = record.Repository
+	return record.Result, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/boltdb"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newTestRemediation(id string, status models.RemediationStatus, startedAt time.Time) *models.RemediationResult {
+	result := models.NewRemediationResult(id, "acme/infra", "main", models.RemediationAutoApply)
+	result.StorageRepository = "github.com/acme/infra"
+	result.Status = status
+	result.StartedAt = startedAt
+	return result
+}
+
+func TestRemediationStore_SaveGetList(t *testing.T) {
+	b := newTestDB2(t)
+	defer b.Close() // nolint: errcheck
+	store, err := boltdb.NewRemediationStore(b, 0)
+	Ok(t, err)
+
+	now := time.Now()
+	first := newTestRemediation("first", models.RemediationStatusRunning, now.Add(-time.Minute))
+	Ok(t, store.Save(first))
+	Ok(t, store.Save(newTestRemediation("second", models.RemediationStatusFailed, now)))
+
+	// Saving again with the same ID replaces the earlier record.
+	first.Status = models.RemediationStatusSuccess
+	Ok(t, store.Save(first))
+
+	got, err := store.Get("first")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusSuccess, got.Status)
+	Equals(t, "github.com/acme/infra", got.StorageRepository)
+
+	missing, err := store.Get("missing")
+	Ok(t, err)
+	Assert(t, missing == nil, "expected nil result")
+
+	results, err := store.List("github.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 2, len(results))
+	Equals(t, "second", results[0].ID)
+	Equals(t, "first", results[1].ID)
+
+	failed, err := store.List("github.com/acme/infra", drift.ListResultsOptions{Statuses: []models.RemediationStatus{models.RemediationStatusFailed}})
+	Ok(t, err)
+	Equals(t, 1, len(failed))
+	Equals(t, "second", failed[0].ID)
+
+	page, err := store.List("github.com/acme/infra", drift.ListResultsOptions{Limit: 1, Offset: 1})
+	Ok(t, err)
+	Equals(t, 1, len(page))
+	Equals(t, "first", page[0].ID)
+
+	repositories, err := store.Repositories()
+	Ok(t, err)
+	Equals(t, []string{"github.com/acme/infra"}, repositories)
+}
+
+func TestRemediationStore_Compact(t *testing.T) {
+	b := newTestDB2(t)
+	defer b.Close() // nolint: errcheck
+	store, err := boltdb.NewRemediationStore(b, 24*time.Hour)
+	Ok(t, err)
+
+	Ok(t, store.Save(newTestRemediation("old", models.RemediationStatusSuccess, time.Now().Add(-48*time.Hour))))
+	Ok(t, store.Save(newTestRemediation("new", models.RemediationStatusSuccess, time.Now())))
+
+	removed, err := store.Compact()
+	Ok(t, err)
+	Equals(t, 1, removed)
+
+	old, err := store.Get("old")
+	Ok(t, err)
+	Assert(t, old == nil, "expected expired result to be removed")
+	results, err := store.List("github.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "new", results[0].ID)
+}
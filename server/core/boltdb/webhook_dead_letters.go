@@ -0,0 +1,98 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package boltdb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	bolt "go.etcd.io/bbolt"
+)
+
+const webhookDeadLettersBucketName = "webhookDeadLetters"
+
+// WebhookDeadLetterStore is a webhooks.DeadLetterStore backed by the same
+// BoltDB file used for locks.
+// Dead letters are keyed by failure time then ID so a reverse cursor walk
+// yields the newest first.
+type WebhookDeadLetterStore struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+var _ webhooks.DeadLetterStore = (*WebhookDeadLetterStore)(nil)
+
+// NewWebhookDeadLetterStore returns a dead letter store that shares b's
+// database file.
+func NewWebhookDeadLetterStore(b *BoltDB) (*WebhookDeadLetterStore, error) {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte(webhookDeadLettersBucketName)); err != nil {
+			return fmt.Errorf("creating bucket %q: %w", webhookDeadLettersBucketName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starting BoltDB webhook dead letter store: %w", err)
+	}
+	return &WebhookDeadLetterStore{
+		db:     b.db,
+		bucket: []byte(webhookDeadLettersBucketName),
+	}, nil
+}
+
+// Add records a dead letter and drops the oldest ones beyond
+// webhooks.MaxDeadLetters.
+func (w *WebhookDeadLetterStore) Add(letter webhooks.DeadLetter) error {
+	serialized, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("serializing webhook dead letter: %w", err)
+	}
+	key := fmt.Sprintf("%020d/%s", letter.FailedAt.UnixNano(), letter.ID)
+
+	err = w.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(w.bucket)
+		if err := bucket.Put([]byte(key), serialized); err != nil {
+			return err
+		}
+		var keys [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		if len(keys) <= webhooks.MaxDeadLetters {
+			return nil
+		}
+		for _, k := range keys[:len(keys)-webhooks.MaxDeadLetters] {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return nil
+}
+
+// List returns the dead letters, newest first.
+func (w *WebhookDeadLetterStore) List() ([]webhooks.DeadLetter, error) {
+	letters := []webhooks.DeadLetter{}
+	err := w.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(w.bucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var letter webhooks.DeadLetter
+			if err := json.Unmarshal(v, &letter); err != nil {
+				return fmt.Errorf("deserializing webhook dead letter at key %q: %w", string(k), err)
+			}
+			letters = append(letters, letter)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DB transaction failed: %w", err)
+	}
+	return letters, nil
+}
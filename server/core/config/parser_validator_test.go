@@ -1310,6 +1310,54 @@ func TestParseGlobalCfg(t *testing.T) {
 			input:  "invalid: key",
 			expErr: "yaml: construct errors: line 1: field invalid not found in type raw.GlobalCfg",
 		},
+		"unsupported plan store type": {
+			input: `external_stores:
+  plan_store:
+    type: ftp`,
+			expErr: "unsupported plan store type \"ftp\": must be one of 's3', 'gcs' or 'azure'",
+		},
+		"gcs plan store without bucket": {
+			input: `external_stores:
+  plan_store:
+    type: gcs`,
+			expErr: "external_stores.plan_store.gcs.bucket is required when type is 'gcs'",
+		},
+		"azure plan store without container": {
+			input: `external_stores:
+  plan_store:
+    type: azure
+    azure:
+      account: plans`,
+			expErr: "external_stores.plan_store.azure.container is required when type is 'azure'",
+		},
+		"plan store encryption without plan store": {
+			input: `external_stores:
+  plan_store:
+    encryption:
+      key_provider: local
+      key_file: /etc/atlantis/plan.key`,
+			expErr: "external_stores.plan_store.encryption requires external_stores.plan_store.type to be set",
+		},
+		"plan store encryption without key file": {
+			input: `external_stores:
+  plan_store:
+    type: gcs
+    gcs:
+      bucket: plans
+    encryption:
+      key_provider: local`,
+			expErr: "external_stores.plan_store.encryption.key_file is required when key_provider is 'local'",
+		},
+		"unsupported plan store encryption key provider": {
+			input: `external_stores:
+  plan_store:
+    type: gcs
+    gcs:
+      bucket: plans
+    encryption:
+      key_provider: vault`,
+			expErr: "unsupported plan store encryption key_provider \"vault\": must be one of 'local' or 'aws_kms'",
+		},
 		"no id specified": {
 			input: `repos:
 - apply_requirements: []`,
@@ -1420,6 +1468,38 @@ func TestParseGlobalCfg(t *testing.T) {
 			input: `workflows:`,
 			exp:   defaultCfg,
 		},
+		"encrypted azure plan store": {
+			input: `external_stores:
+  plan_store:
+    type: azure
+    azure:
+      account: plans
+      container: atlantis
+      prefix: pfx
+    encryption:
+      key_provider: aws_kms
+      kms_key_id: alias/atlantis
+      kms_region: us-east-1`,
+			exp: func() valid.GlobalCfg {
+				cfg := defaultCfg
+				cfg.ExternalStores = valid.ExternalStores{
+					PlanStore: valid.PlanStoreConfig{
+						Type: "azure",
+						Azure: valid.AzureStoreConfig{
+							Account:   "plans",
+							Container: "atlantis",
+							Prefix:    "pfx",
+						},
+						Encryption: valid.PlanStoreEncryption{
+							KeyProvider: "aws_kms",
+							KMSKeyID:    "alias/atlantis",
+							KMSRegion:   "us-east-1",
+						},
+					},
+				}
+				return cfg
+			}(),
+		},
 		"workflow name but the rest is empty": {
 			input: `
 workflows:
@@ -0,0 +1,245 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/scheduled"
+)
+
+// driftVCSTypes maps the vcs values accepted in drift_detection to the VCS
+// host type names used by the drift API.
+var driftVCSTypes = map[string]string{
+	"github": "Github",
+	"gitlab": "Gitlab",
+	"gitea":  "Gitea",
+}
+
+// DriftDetection is the raw schema for the top-level drift_detection section,
+// which controls how all scheduled drift detections are run.
+type DriftDetection struct {
+	MaxConcurrency *int   `yaml:"max_concurrency,omitempty" json:"max_concurrency,omitempty"`
+	Jitter         string `yaml:"jitter,omitempty" json:"jitter,omitempty"`
+}
+
+// RepoDriftDetection is the raw schema for a repo's drift_detection section.
+type RepoDriftDetection struct {
+	// VCS is the repo's VCS provider. It can be omitted for repos hosted on
+	// github.com or gitlab.com.
+	VCS       string          `yaml:"vcs,omitempty" json:"vcs,omitempty"`
+	Schedules []DriftSchedule `yaml:"schedules" json:"schedules"`
+}
+
+// DriftSchedule is the raw schema for a single drift detection schedule.
+type DriftSchedule struct {
+	Cron     string               `yaml:"cron" json:"cron"`
+	Branch   string               `yaml:"branch" json:"branch"`
+	Projects []string             `yaml:"projects,omitempty" json:"projects,omitempty"`
+	Paths    []DriftScheduledPath `yaml:"paths,omitempty" json:"paths,omitempty"`
+}
+
+// DriftScheduledPath selects a project by directory and workspace.
+type DriftScheduledPath struct {
+	Dir       string `yaml:"dir" json:"dir"`
+	Workspace string `yaml:"workspace,omitempty" json:"workspace,omitempty"`
+}
+
+func (d DriftDetection) Validate() error {
+	maxConcurrencyValid := func(value any) error {
+		maxConcurrency := value.(*int)
+		if maxConcurrency != nil && *maxConcurrency < 1 {
+			return errors.New("must be at least 1")
+		}
+		return nil
+	}
+	jitterValid := func(value any) error {
+		jitter := value.(string)
+		if jitter == "" {
+			return nil
+		}
+		parsed, err := time.ParseDuration(jitter)
+		if err != nil {
+			return err
+		}
+		if parsed < 0 {
+			return errors.New("must not be negative")
+		}
+		return nil
+	}
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.MaxConcurrency, validation.By(maxConcurrencyValid)),
+		validation.Field(&d.Jitter, validation.By(jitterValid)),
+	)
+}
+
+func (d DriftDetection) ToValid() valid.DriftDetection {
+	var v valid.DriftDetection
+	if d.MaxConcurrency != nil {
+		v.MaxConcurrency = *d.MaxConcurrency
+	}
+	if d.Jitter != "" {
+		// Safe to ignore the error because we test it in Validate().
+		v.Jitter, _ = time.ParseDuration(d.Jitter)
+	}
+	return v
+}
+
+// validate checks the section for the repo with the given id.
+func (d RepoDriftDetection) validate(id string) error {
+	if strings.HasPrefix(id, "/") && strings.HasSuffix(id, "/") {
+		return errors.New("requires an exact repo id, not a regex")
+	}
+	host, _, _ := strings.Cut(id, "/")
+	if strings.Count(id, "/") < 2 {
+		return fmt.Errorf("repo id %q must be of the form {hostname}/{owner}/{repo}", id)
+	}
+	if d.vcsType(host) == "" {
+		if d.VCS == "" {
+			return fmt.Errorf("vcs is required for repos hosted on %q", host)
+		}
+		return fmt.Errorf("vcs %q is not supported, supported values are [github, gitlab, gitea]", d.VCS)
+	}
+	if len(d.Schedules) == 0 {
+		return errors.New("at least one schedule is required")
+	}
+	for i, s := range d.Schedules {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("schedules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (d RepoDriftDetection) vcsType(host string) string {
+	if d.VCS != "" {
+		return driftVCSTypes[d.VCS]
+	}
+	switch host {
+	case "github.com":
+		return driftVCSTypes["github"]
+	case "gitlab.com":
+		return driftVCSTypes["gitlab"]
+	}
+	return ""
+}
+
+// ToValid converts the section for the repo with the given id. The id must
+// already have been validated.
+func (d RepoDriftDetection) ToValid(id string) *valid.RepoDriftDetection {
+	host, repository, _ := strings.Cut(id, "/")
+	v := valid.RepoDriftDetection{
+		Repository: repository,
+		VCSType:    d.vcsType(host),
+	}
+	for _, s := range d.Schedules {
+		v.Schedules = append(v.Schedules, s.ToValid())
+	}
+	return &v
+}
+
+func (s DriftSchedule) Validate() error {
+	cronValid := func(value any) error {
+		_, err := scheduled.ParseCron(value.(string))
+		return err
+	}
+	branchValid := func(value any) error {
+		branch := value.(string)
+		if strings.HasPrefix(branch, "/") && strings.HasSuffix(branch, "/") {
+			return errors.New("must be a branch name, not a regex")
+		}
+		return nil
+	}
+	pathsValid := func(value any) error {
+		paths := value.([]DriftScheduledPath)
+		if len(paths) > 0 && len(s.Projects) > 0 {
+			return errors.New("projects and paths cannot both be set")
+		}
+		for _, p := range paths {
+			if p.Dir == "" {
+				return errors.New("dir is required")
+			}
+		}
+		return nil
+	}
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Cron, validation.Required, validation.By(cronValid)),
+		validation.Field(&s.Branch, validation.Required, validation.By(branchValid)),
+		validation.Field(&s.Paths, validation.By(pathsValid)),
+	)
+}
+
+func (s DriftSchedule) ToValid() valid.DriftSchedule {
+	v := valid.DriftSchedule{
+		Cron:     s.Cron,
+		Branch:   s.Branch,
+		Projects: s.Projects,
+	}
+	for _, p := range s.Paths {
+		v.Paths = append(v.Paths, valid.DriftScheduledPath{Dir: p.Dir, Workspace: p.Workspace})
+	}
+	return v
+}
+
+// Drift is the raw schema for the drift section of a repo or project in
+// atlantis.yaml.
+type Drift struct {
+	Ignore []DriftIgnoreRule `yaml:"ignore,omitempty"`
+}
+
+// DriftIgnoreRule excludes resources, or some of their attributes, from
+// drift.
+type DriftIgnoreRule struct {
+	// Resource is a glob matched against resource addresses. If omitted the
+	// rule applies to all resources.
+	Resource string `yaml:"resource,omitempty"`
+	// Attributes are globs matched against the paths of changed attributes,
+	// e.g. tags.CostCenter, and their parents. If omitted the whole resource
+	// is ignored.
+	Attributes []string `yaml:"attributes,omitempty"`
+}
+
+func (d Drift) Validate() error {
+	for i, r := range d.Ignore {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("ignore[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (d *Drift) ToValid() []valid.DriftIgnoreRule {
+	if d == nil {
+		return nil
+	}
+	var rules []valid.DriftIgnoreRule
+	for _, r := range d.Ignore {
+		rules = append(rules, valid.DriftIgnoreRule{
+			Resource:   r.Resource,
+			Attributes: r.Attributes,
+		})
+	}
+	return rules
+}
+
+func (r DriftIgnoreRule) Validate() error {
+	if r.Resource == "" && len(r.Attributes) == 0 {
+		return errors.New("resource or attributes is required")
+	}
+	if r.Resource != "" && !doublestar.ValidatePattern(r.Resource) {
+		return fmt.Errorf("resource: invalid glob %q", r.Resource)
+	}
+	for _, a := range r.Attributes {
+		if a == "" || !doublestar.ValidatePattern(a) {
+			return fmt.Errorf("attributes: invalid glob %q", a)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package raw_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/raw"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestDriftDetection_ValidateAndToValid(t *testing.T) {
+	var d raw.DriftDetection
+	Ok(t, unmarshalString(`
+max_concurrency: 4
+jitter: 15m
+`, &d))
+	Ok(t, d.Validate())
+	Equals(t, valid.DriftDetection{MaxConcurrency: 4, Jitter: 15 * time.Minute}, d.ToValid())
+
+	Equals(t, valid.DriftDetection{}, raw.DriftDetection{}.ToValid())
+
+	zero := 0
+	ErrContains(t, "max_concurrency: must be at least 1", raw.DriftDetection{MaxConcurrency: &zero}.Validate())
+	ErrContains(t, "jitter: time: invalid duration", raw.DriftDetection{Jitter: "soon"}.Validate())
+	ErrContains(t, "jitter: must not be negative", raw.DriftDetection{Jitter: "-1m"}.Validate())
+}
+
+func TestRepo_DriftDetection(t *testing.T) {
+	var r raw.Repo
+	Ok(t, unmarshalString(`
+id: github.com/owner/repo
+drift_detection:
+  schedules:
+  - cron: "0 2 * * *"
+    branch: main
+  - cron: "@hourly"
+    branch: release
+    paths:
+    - dir: env/prod
+      workspace: prod
+`, &r))
+	Ok(t, r.Validate())
+	Equals(t, &valid.RepoDriftDetection{
+		Repository: "owner/repo",
+		VCSType:    "Github",
+		Schedules: []valid.DriftSchedule{
+			{Cron: "0 2 * * *", Branch: "main"},
+			{Cron: "@hourly", Branch: "release", Paths: []valid.DriftScheduledPath{{Dir: "env/prod", Workspace: "prod"}}},
+		},
+	}, r.ToValid(nil, nil, nil, nil).DriftDetection)
+}
+
+func TestRepo_DriftDetectionValidate(t *testing.T) {
+	schedule := raw.DriftSchedule{Cron: "@daily", Branch: "main"}
+	cases := []struct {
+		description string
+		id          string
+		input       raw.RepoDriftDetection
+		errContains string
+	}{
+		{
+			description: "vcs inferred from gitlab.com",
+			id:          "gitlab.com/group/sub/repo",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{schedule}},
+		},
+		{
+			description: "explicit vcs for self-hosted",
+			id:          "git.example.com/owner/repo",
+			input:       raw.RepoDriftDetection{VCS: "gitea", Schedules: []raw.DriftSchedule{schedule}},
+		},
+		{
+			description: "regex id",
+			id:          "/.*/",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{schedule}},
+			errContains: "requires an exact repo id",
+		},
+		{
+			description: "id without owner",
+			id:          "github.com/repo",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{schedule}},
+			errContains: "must be of the form {hostname}/{owner}/{repo}",
+		},
+		{
+			description: "missing vcs",
+			id:          "git.example.com/owner/repo",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{schedule}},
+			errContains: `vcs is required for repos hosted on "git.example.com"`,
+		},
+		{
+			description: "unsupported vcs",
+			id:          "github.com/owner/repo",
+			input:       raw.RepoDriftDetection{VCS: "svn", Schedules: []raw.DriftSchedule{schedule}},
+			errContains: `vcs "svn" is not supported`,
+		},
+		{
+			description: "no schedules",
+			id:          "github.com/owner/repo",
+			errContains: "at least one schedule is required",
+		},
+		{
+			description: "bad cron",
+			id:          "github.com/owner/repo",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{{Cron: "0 25 * * *", Branch: "main"}}},
+			errContains: "schedules[0]: cron: hour: value 25 out of range",
+		},
+		{
+			description: "missing branch",
+			id:          "github.com/owner/repo",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{{Cron: "@daily"}}},
+			errContains: "branch: cannot be blank",
+		},
+		{
+			description: "regex branch",
+			id:          "github.com/owner/repo",
+			input:       raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{{Cron: "@daily", Branch: "/main/"}}},
+			errContains: "branch: must be a branch name, not a regex",
+		},
+		{
+			description: "projects and paths",
+			id:          "github.com/owner/repo",
+			input: raw.RepoDriftDetection{Schedules: []raw.DriftSchedule{{
+				Cron:     "@daily",
+				Branch:   "main",
+				Projects: []string{"app"},
+				Paths:    []raw.DriftScheduledPath{{Dir: "app"}},
+			}}},
+			errContains: "projects and paths cannot both be set",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			input := c.input
+			err := raw.Repo{ID: c.id, DriftDetection: &input}.Validate()
+			if c.errContains == "" {
+				Ok(t, err)
+				return
+			}
+			ErrContains(t, c.errContains, err)
+		})
+	}
+}
+
+func TestRepoCfg_DriftIgnore(t *testing.T) {
+	var r raw.RepoCfg
+	Ok(t, unmarshalString(`
+version: 3
+drift:
+  ignore:
+  - resource: aws_autoscaling_group.*
+    attributes: [desired_capacity]
+  - attributes: [tags, tags_all]
+projects:
+- dir: legacy
+  drift:
+    ignore:
+    - resource: module.legacy.**
+`, &r))
+	Ok(t, r.Validate())
+	v := r.ToValid()
+	Equals(t, []valid.DriftIgnoreRule{
+		{Resource: "aws_autoscaling_group.*", Attributes: []string{"desired_capacity"}},
+		{Attributes: []string{"tags", "tags_all"}},
+	}, v.DriftIgnore)
+	Equals(t, []valid.DriftIgnoreRule{{Resource: "module.legacy.**"}}, v.Projects[0].DriftIgnore)
+}
+
+func TestDrift_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		input       raw.Drift
+		errContains string
+	}{
+		{
+			description: "empty",
+		},
+		{
+			description: "resource only",
+			input:       raw.Drift{Ignore: []raw.DriftIgnoreRule{{Resource: "aws_instance.*"}}},
+		},
+		{
+			description: "escaped brackets and nested attributes",
+			input:       raw.Drift{Ignore: []raw.DriftIgnoreRule{{Resource: `aws_instance.web\["a"\]`, Attributes: []string{"tags.CostCenter"}}}},
+		},
+		{
+			description: "empty rule",
+			input:       raw.Drift{Ignore: []raw.DriftIgnoreRule{{Resource: "aws_instance.*"}, {}}},
+			errContains: "ignore[1]: resource or attributes is required",
+		},
+		{
+			description: "invalid resource glob",
+			input:       raw.Drift{Ignore: []raw.DriftIgnoreRule{{Resource: "aws_instance.[web"}}},
+			errContains: `ignore[0]: resource: invalid glob "aws_instance.[web"`,
+		},
+		{
+			description: "invalid attribute glob",
+			input:       raw.Drift{Ignore: []raw.DriftIgnoreRule{{Attributes: []string{"tags", ""}}}},
+			errContains: `ignore[0]: attributes: invalid glob ""`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.input.Validate()
+			if c.errContains == "" {
+				Ok(t, err)
+			} else {
+				ErrContains(t, c.errContains, err)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ type GlobalCfg struct {
 	Metrics        Metrics             `yaml:"metrics" json:"metrics"`
 	TeamAuthz      TeamAuthz           `yaml:"team_authz" json:"team_authz"`
 	ExternalStores ExternalStores      `yaml:"external_stores" json:"external_stores"`
+	DriftDetection DriftDetection      `yaml:"drift_detection" json:"drift_detection"`
 }
 
 // ExternalStores is the raw schema for external storage backends.
@@ -31,8 +32,11 @@ type ExternalStores struct {
 
 // PlanStoreConfig is the raw schema for plan storage configuration.
 type PlanStoreConfig struct {
-	Type string        `yaml:"type" json:"type"`
-	S3   S3StoreConfig `yaml:"s3" json:"s3"`
+	Type       string              `yaml:"type" json:"type"`
+	S3         S3StoreConfig       `yaml:"s3" json:"s3"`
+	GCS        GCSStoreConfig      `yaml:"gcs" json:"gcs"`
+	Azure      AzureStoreConfig    `yaml:"azure" json:"azure"`
+	Encryption PlanStoreEncryption `yaml:"encryption" json:"encryption"`
 }
 
 // S3StoreConfig is the raw schema for S3 plan store configuration.
@@ -45,22 +49,84 @@ type S3StoreConfig struct {
 	Profile        string `yaml:"profile" json:"profile"`
 }
 
+// GCSStoreConfig is the raw schema for GCS plan store configuration.
+type GCSStoreConfig struct {
+	Bucket   string `yaml:"bucket" json:"bucket"`
+	Prefix   string `yaml:"prefix" json:"prefix"`
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+}
+
+// AzureStoreConfig is the raw schema for Azure Blob Storage plan store
+// configuration.
+type AzureStoreConfig struct {
+	Account   string `yaml:"account" json:"account"`
+	Container string `yaml:"container" json:"container"`
+	Prefix    string `yaml:"prefix" json:"prefix"`
+	Endpoint  string `yaml:"endpoint" json:"endpoint"`
+}
+
+// PlanStoreEncryption is the raw schema for client-side encryption of plans
+// in the plan store.
+type PlanStoreEncryption struct {
+	KeyProvider string `yaml:"key_provider" json:"key_provider"`
+	KeyFile     string `yaml:"key_file" json:"key_file"`
+	KMSKeyID    string `yaml:"kms_key_id" json:"kms_key_id"`
+	KMSRegion   string `yaml:"kms_region" json:"kms_region"`
+}
+
 func (e ExternalStores) Validate() error {
 	return e.PlanStore.Validate()
 }
 
 func (p PlanStoreConfig) Validate() error {
 	if p.Type == "" {
+		if p.Encryption.KeyProvider != "" {
+			return fmt.Errorf("external_stores.plan_store.encryption requires external_stores.plan_store.type to be set")
+		}
 		return nil
 	}
-	if p.Type != "s3" {
-		return fmt.Errorf("unsupported plan store type %q: only 's3' is supported", p.Type)
+	if err := p.Encryption.Validate(); err != nil {
+		return err
 	}
-	if p.S3.Bucket == "" {
-		return fmt.Errorf("external_stores.plan_store.s3.bucket is required when type is 's3'")
+	switch p.Type {
+	case "s3":
+		if p.S3.Bucket == "" {
+			return fmt.Errorf("external_stores.plan_store.s3.bucket is required when type is 's3'")
+		}
+		if p.S3.Region == "" {
+			return fmt.Errorf("external_stores.plan_store.s3.region is required when type is 's3'")
+		}
+	case "gcs":
+		if p.GCS.Bucket == "" {
+			return fmt.Errorf("external_stores.plan_store.gcs.bucket is required when type is 'gcs'")
+		}
+	case "azure":
+		if p.Azure.Account == "" {
+			return fmt.Errorf("external_stores.plan_store.azure.account is required when type is 'azure'")
+		}
+		if p.Azure.Container == "" {
+			return fmt.Errorf("external_stores.plan_store.azure.container is required when type is 'azure'")
+		}
+	default:
+		return fmt.Errorf("unsupported plan store type %q: must be one of 's3', 'gcs' or 'azure'", p.Type)
 	}
-	if p.S3.Region == "" {
-		return fmt.Errorf("external_stores.plan_store.s3.region is required when type is 's3'")
+	return nil
+}
+
+func (p PlanStoreEncryption) Validate() error {
+	switch p.KeyProvider {
+	case "":
+		return nil
+	case "local":
+		if p.KeyFile == "" {
+			return fmt.Errorf("external_stores.plan_store.encryption.key_file is required when key_provider is 'local'")
+		}
+	case "aws_kms":
+		if p.KMSKeyID == "" {
+			return fmt.Errorf("external_stores.plan_store.encryption.kms_key_id is required when key_provider is 'aws_kms'")
+		}
+	default:
+		return fmt.Errorf("unsupported plan store encryption key_provider %q: must be one of 'local' or 'aws_kms'", p.KeyProvider)
 	}
 	return nil
 }
@@ -77,6 +143,23 @@ func (e ExternalStores) ToValid() valid.ExternalStores {
 				ForcePathStyle: e.PlanStore.S3.ForcePathStyle,
 				Profile:        e.PlanStore.S3.Profile,
 			},
+			GCS: valid.GCSStoreConfig{
+				Bucket:   e.PlanStore.GCS.Bucket,
+				Prefix:   e.PlanStore.GCS.Prefix,
+				Endpoint: e.PlanStore.GCS.Endpoint,
+			},
+			Azure: valid.AzureStoreConfig{
+				Account:   e.PlanStore.Azure.Account,
+				Container: e.PlanStore.Azure.Container,
+				Prefix:    e.PlanStore.Azure.Prefix,
+				Endpoint:  e.PlanStore.Azure.Endpoint,
+			},
+			Encryption: valid.PlanStoreEncryption{
+				KeyProvider: e.PlanStore.Encryption.KeyProvider,
+				KeyFile:     e.PlanStore.Encryption.KeyFile,
+				KMSKeyID:    e.PlanStore.Encryption.KMSKeyID,
+				KMSRegion:   e.PlanStore.Encryption.KMSRegion,
+			},
 		},
 	}
 }
@@ -102,6 +185,9 @@ type Repo struct {
 	CustomPolicyCheck         *bool          `yaml:"custom_policy_check,omitempty" json:"custom_policy_check,omitempty"`
 	AutoDiscover              *AutoDiscover  `yaml:"autodiscover,omitempty" json:"autodiscover,omitempty"`
 	SilencePRComments         []string       `yaml:"silence_pr_comments,omitempty" json:"silence_pr_comments,omitempty"`
+
+	// DriftDetection schedules drift detection for this repo.
+	DriftDetection *RepoDriftDetection `yaml:"drift_detection,omitempty" json:"drift_detection,omitempty"`
 }
 
 func (g GlobalCfg) Validate() error {
@@ -109,6 +195,7 @@ func (g GlobalCfg) Validate() error {
 		validation.Field(&g.Repos),
 		validation.Field(&g.Workflows),
 		validation.Field(&g.Metrics),
+		validation.Field(&g.DriftDetection),
 	)
 	if err != nil {
 		return err
@@ -229,6 +316,7 @@ func (g GlobalCfg) ToValid(defaultCfg valid.GlobalCfg) valid.GlobalCfg {
 		Metrics:        g.Metrics.ToValid(),
 		TeamAuthz:      g.TeamAuthz.ToValid(),
 		ExternalStores: g.ExternalStores.ToValid(),
+		DriftDetection: g.DriftDetection.ToValid(),
 	}
 }
 
@@ -315,6 +403,14 @@ func (r Repo) Validate() error {
 		return nil
 	}
 
+	driftDetectionValid := func(value any) error {
+		driftDetection := value.(*RepoDriftDetection)
+		if driftDetection != nil {
+			return driftDetection.validate(r.ID)
+		}
+		return nil
+	}
+
 	repoLocksValid := func(value any) error {
 		repoLocks := value.(*RepoLocks)
 		if repoLocks != nil {
@@ -335,6 +431,7 @@ func (r Repo) Validate() error {
 		validation.Field(&r.DeleteSourceBranchOnMerge, validation.By(deleteSourceBranchOnMergeValid)),
 		validation.Field(&r.AutoDiscover, validation.By(autoDiscoverValid)),
 		validation.Field(&r.RepoLocks, validation.By(repoLocksValid)),
+		validation.Field(&r.DriftDetection, validation.By(driftDetectionValid)),
 	)
 }
 
@@ -439,6 +536,11 @@ OuterGlobalImportReqs:
 		repoLocks = r.RepoLocks.ToValid()
 	}
 
+	var driftDetection *valid.RepoDriftDetection
+	if r.DriftDetection != nil {
+		driftDetection = r.DriftDetection.ToValid(r.ID)
+	}
+
 	return valid.Repo{
 		ID:                        id,
 		IDRegex:                   idRegex,
@@ -460,5 +562,6 @@ OuterGlobalImportReqs:
 		CustomPolicyCheck:         r.CustomPolicyCheck,
 		AutoDiscover:              autoDiscover,
 		SilencePRComments:         r.SilencePRComments,
+		DriftDetection:            driftDetection,
 	}
 }
@@ -67,6 +67,7 @@ type Project struct {
 	PolicyCheck               *bool      `yaml:"policy_check,omitempty"`
 	CustomPolicyCheck         *bool      `yaml:"custom_policy_check,omitempty"`
 	SilencePRComments         []string   `yaml:"silence_pr_comments,omitempty"`
+	Drift                     *Drift     `yaml:"drift,omitempty"`
 }
 
 // IsTerraformProjectDir returns true if the directory contains files that make it look like a Terraform project
@@ -166,6 +167,8 @@ func (p Project) Validate() error {
 		validation.Field(&p.DependsOn, validation.By(DependsOn)),
 		validation.Field(&p.Name, validation.By(validName)),
 		validation.Field(&p.Branch, validation.By(branchValid)),
+		validation.Field(&p.RepoLocks, validation.By(repoLocksWithoutTTL)),
+		validation.Field(&p.Drift),
 	)
 }
 
@@ -240,6 +243,8 @@ func (p Project) ToValid() valid.Project {
 		v.SilencePRComments = p.SilencePRComments
 	}
 
+	v.DriftIgnore = p.Drift.ToValid()
+
 	return v
 }
 
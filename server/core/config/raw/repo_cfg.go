@@ -32,6 +32,7 @@ type RepoCfg struct {
 	AbortOnExecutionOrderFail *bool               `yaml:"abort_on_execution_order_fail,omitempty"`
 	RepoLocks                 *RepoLocks          `yaml:"repo_locks,omitempty"`
 	SilencePRComments         []string            `yaml:"silence_pr_comments,omitempty"`
+	Drift                     *Drift              `yaml:"drift,omitempty"`
 }
 
 func (r RepoCfg) Validate() error {
@@ -49,6 +50,8 @@ func (r RepoCfg) Validate() error {
 		validation.Field(&r.Version, validation.By(equals2)),
 		validation.Field(&r.Projects),
 		validation.Field(&r.Workflows),
+		validation.Field(&r.RepoLocks, validation.By(repoLocksWithoutTTL)),
+		validation.Field(&r.Drift),
 	)
 }
 
@@ -101,5 +104,6 @@ func (r RepoCfg) ToValid() valid.RepoCfg {
 		AbortOnExecutionOrderFail: abortOnExecutionOrderFail,
 		RepoLocks:                 repoLocks,
 		SilencePRComments:         r.SilencePRComments,
+		DriftIgnore:               r.Drift.ToValid(),
 	}
 }
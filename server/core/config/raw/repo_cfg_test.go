@@ -236,6 +236,14 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expErr: "version: only versions 2 and 3 are supported.",
 		},
+		{
+			description: "repo_locks ttl set",
+			input: raw.RepoCfg{
+				Version:   Int(3),
+				RepoLocks: &raw.RepoLocks{TTL: "24h"},
+			},
+			expErr: "repo_locks: ttl can only be set in the server-side repo config.",
+		},
 	}
 	validation.ErrorTag = "yaml"
 	for _, c := range cases {
@@ -4,12 +4,18 @@
 package raw
 
 import (
+	"errors"
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 )
 
 type RepoLocks struct {
 	Mode *valid.RepoLocksMode `yaml:"mode,omitempty"`
+	// TTL is how long a lock can be held before it expires, e.g. "168h".
+	// It is only read from the server-side repo config.
+	TTL string `yaml:"ttl,omitempty"`
 }
 
 func (a RepoLocks) ToValid() *valid.RepoLocks {
@@ -20,14 +26,44 @@ func (a RepoLocks) ToValid() *valid.RepoLocks {
 	} else {
 		v.Mode = valid.DefaultRepoLocksMode
 	}
+	if a.TTL != "" {
+		// Safe to ignore the error because we test it in Validate().
+		v.TTL, _ = time.ParseDuration(a.TTL)
+	}
 
 	return &v
 }
 
 func (a RepoLocks) Validate() error {
+	ttlValid := func(value any) error {
+		ttl := value.(string)
+		if ttl == "" {
+			return nil
+		}
+		parsed, err := time.ParseDuration(ttl)
+		if err != nil {
+			return err
+		}
+		if parsed <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
 	res := validation.ValidateStruct(&a,
 		// If a.Mode is nil, this should still pass validation.
 		validation.Field(&a.Mode, validation.In(valid.RepoLocksDisabledMode, valid.RepoLocksOnPlanMode, valid.RepoLocksOnApplyMode)),
+		validation.Field(&a.TTL, validation.By(ttlValid)),
 	)
 	return res
 }
+
+// repoLocksWithoutTTL rejects a ttl in repo_locks outside the server-side
+// repo config. Expired locks are found without reading each repo's config,
+// so a ttl there would be silently ignored.
+func repoLocksWithoutTTL(value any) error {
+	repoLocks := value.(*RepoLocks)
+	if repoLocks != nil && repoLocks.TTL != "" {
+		return errors.New("ttl can only be set in the server-side repo config")
+	}
+	return nil
+}
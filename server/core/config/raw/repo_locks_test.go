@@ -5,6 +5,7 @@ package raw_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/core/config/raw"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -29,9 +30,11 @@ func TestRepoLocks_UnmarshalYAML(t *testing.T) {
 			description: "all fields set",
 			input: `
 mode: on_plan
+ttl: 168h
 `,
 			exp: raw.RepoLocks{
 				Mode: &repoLocksOnPlan,
+				TTL:  "168h",
 			},
 		},
 	}
@@ -89,6 +92,27 @@ func TestRepoLocks_Validate(t *testing.T) {
 			},
 			errContains: String("valid value"),
 		},
+		{
+			description: "ttl set",
+			input: raw.RepoLocks{
+				TTL: "72h",
+			},
+			errContains: nil,
+		},
+		{
+			description: "ttl not a duration",
+			input: raw.RepoLocks{
+				TTL: "three days",
+			},
+			errContains: String("ttl: time: invalid duration"),
+		},
+		{
+			description: "ttl not positive",
+			input: raw.RepoLocks{
+				TTL: "0s",
+			},
+			errContains: String("ttl: must be positive"),
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.description, func(t *testing.T) {
@@ -122,6 +146,16 @@ func TestRepoLocks_ToValid(t *testing.T) {
 				Mode: valid.RepoLocksOnApplyMode,
 			},
 		},
+		{
+			description: "ttl set",
+			input: raw.RepoLocks{
+				TTL: "90m",
+			},
+			exp: &valid.RepoLocks{
+				Mode: valid.DefaultRepoLocksMode,
+				TTL:  90 * time.Minute,
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.description, func(t *testing.T) {
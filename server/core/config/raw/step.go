@@ -12,6 +12,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -35,6 +36,7 @@ const (
 	StateRmStepName     = "state_rm"
 	ShellArgKey         = "shell"
 	ShellArgsArgKey     = "shellArgs"
+	TimeoutArgKey       = "timeout"
 )
 
 /*
@@ -67,6 +69,9 @@ Step represents a single action/command to perform. In YAML, it can be set as
   - run:
     command: my custom command
     output: ["strip_refreshing", {"filter_regex": "((?i)secret:\\s\")[^\"]*"}]
+  - run:
+    command: my custom command
+    timeout: 10m
 
 3. A map for a built-in command and extra_args:
   - plan:
@@ -287,6 +292,16 @@ func (s Step) Validate() error {
 				return fmt.Errorf("%q step must have a %q key set", stepName, CommandArgKey)
 			}
 			delete(argMap, CommandArgKey)
+			if timeout, ok := argMap[TimeoutArgKey]; ok {
+				timeoutStr, isStr := timeout.(string)
+				if !isStr {
+					return fmt.Errorf("run step %q option must be a duration, e.g. \"10m\", found %v", TimeoutArgKey, timeout)
+				}
+				if err := timeoutValid(timeoutStr); err != nil {
+					return fmt.Errorf("run step %q option %q is invalid: %w", TimeoutArgKey, timeoutStr, err)
+				}
+			}
+			delete(argMap, TimeoutArgKey)
 			if v, ok := argMap[OutputArgKey].(string); ok {
 				switch v {
 				case valid.PostProcessRunOutputShow,
@@ -354,6 +369,10 @@ func (s Step) Validate() error {
 			}
 			// Sort so tests can be deterministic.
 			sort.Strings(argKeys)
+			if stepName == RunStepName {
+				return fmt.Errorf("%q steps only support keys %q, %q, %q, %q and %q, found extra keys %q",
+					stepName, CommandArgKey, OutputArgKey, ShellArgKey, ShellArgsArgKey, TimeoutArgKey, strings.Join(argKeys, ","))
+			}
 			return fmt.Errorf("%q steps only support keys %q, %q, %q and %q, found extra keys %q",
 				stepName, CommandArgKey, OutputArgKey, ShellArgKey, ShellArgsArgKey, strings.Join(argKeys, ","))
 		}
@@ -463,6 +482,11 @@ func (s Step) ToValid() valid.Step {
 				}
 			}
 
+			if timeout, ok := stepArgs[TimeoutArgKey].(string); ok {
+				// Safe to ignore the error because we test it in Validate().
+				step.Timeout, _ = time.ParseDuration(timeout)
+			}
+
 			if step.StepName == RunStepName && len(step.Output) == 0 {
 				step.Output = append(step.Output, valid.PostProcessRunOutputShow)
 			}
@@ -6,6 +6,7 @@ package raw_test
 import (
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/core/config/raw"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -455,6 +456,55 @@ func TestStep_Validate(t *testing.T) {
 			},
 			expErr: "workflow steps only support \"shellArgs\" key in combination with \"shell\" key",
 		},
+		{
+			description: "run step with timeout",
+			input: raw.Step{
+				CommandMap: RunType{
+					"run": {
+						"command": "echo",
+						"timeout": "10m",
+					},
+				},
+			},
+			expErr: "",
+		},
+		{
+			description: "run step with invalid timeout",
+			input: raw.Step{
+				CommandMap: RunType{
+					"run": {
+						"command": "echo",
+						"timeout": "-1m",
+					},
+				},
+			},
+			expErr: "run step \"timeout\" option \"-1m\" is invalid: must be positive",
+		},
+		{
+			description: "run step with non-string timeout",
+			input: raw.Step{
+				CommandMap: RunType{
+					"run": {
+						"command": "echo",
+						"timeout": 600,
+					},
+				},
+			},
+			expErr: "run step \"timeout\" option must be a duration, e.g. \"10m\", found 600",
+		},
+		{
+			description: "env step with timeout",
+			input: raw.Step{
+				CommandMap: EnvType{
+					"env": {
+						"name":    "name",
+						"command": "echo",
+						"timeout": "10m",
+					},
+				},
+			},
+			expErr: "env steps only support keys \"name\", \"value\", \"command\", \"shell\" and \"shellArgs\", found key \"timeout\"",
+		},
 		{
 			description: "run step with shellArgs is not list of strings",
 			input: raw.Step{
@@ -676,6 +726,25 @@ func TestStep_ToValid(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "run step with timeout",
+			input: raw.Step{
+				CommandMap: RunType{
+					"run": {
+						"command": "my 'run command'",
+						"timeout": "90s",
+					},
+				},
+			},
+			exp: valid.Step{
+				StepName:   "run",
+				RunCommand: "my 'run command'",
+				Output: []valid.PostProcessRunOutputOption{
+					"show",
+				},
+				Timeout: 90 * time.Second,
+			},
+		},
 		{
 			description: "run step with duplicated values",
 			input: raw.Step{
@@ -4,6 +4,9 @@
 package raw
 
 import (
+	"errors"
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 )
@@ -14,6 +17,10 @@ type Workflow struct {
 	PolicyCheck *Stage `yaml:"policy_check,omitempty" json:"policy_check,omitempty"`
 	Import      *Stage `yaml:"import,omitempty" json:"import,omitempty"`
 	StateRm     *Stage `yaml:"state_rm,omitempty" json:"state_rm,omitempty"`
+	// PlanTimeout and ApplyTimeout are how long the whole plan and apply stages may
+	// take before they're terminated, e.g. "30m".
+	PlanTimeout  string `yaml:"plan_timeout,omitempty" json:"plan_timeout,omitempty"`
+	ApplyTimeout string `yaml:"apply_timeout,omitempty" json:"apply_timeout,omitempty"`
 }
 
 func (w Workflow) Validate() error {
@@ -23,9 +30,27 @@ func (w Workflow) Validate() error {
 		validation.Field(&w.PolicyCheck),
 		validation.Field(&w.Import),
 		validation.Field(&w.StateRm),
+		validation.Field(&w.PlanTimeout, validation.By(timeoutValid)),
+		validation.Field(&w.ApplyTimeout, validation.By(timeoutValid)),
 	)
 }
 
+// timeoutValid checks that value is empty or a positive duration.
+func timeoutValid(value any) error {
+	timeout := value.(string)
+	if timeout == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(timeout)
+	if err != nil {
+		return err
+	}
+	if parsed <= 0 {
+		return errors.New("must be positive")
+	}
+	return nil
+}
+
 func (w Workflow) toValidStage(stage *Stage, defaultStage valid.Stage) valid.Stage {
 	if stage == nil || stage.Steps == nil {
 		return defaultStage
@@ -44,6 +69,13 @@ func (w Workflow) ToValid(name string) valid.Workflow {
 	v.PolicyCheck = w.toValidStage(w.PolicyCheck, valid.DefaultPolicyCheckStage)
 	v.Import = w.toValidStage(w.Import, valid.DefaultImportStage)
 	v.StateRm = w.toValidStage(w.StateRm, valid.DefaultStateRmStage)
+	// Safe to ignore the errors because we test them in Validate().
+	if w.PlanTimeout != "" {
+		v.PlanTimeout, _ = time.ParseDuration(w.PlanTimeout)
+	}
+	if w.ApplyTimeout != "" {
+		v.ApplyTimeout, _ = time.ParseDuration(w.ApplyTimeout)
+	}
 
 	return v
 }
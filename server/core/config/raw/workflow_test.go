@@ -5,6 +5,7 @@ package raw_test
 
 import (
 	"testing"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/runatlantis/atlantis/server/core/config/raw"
@@ -135,6 +136,10 @@ func TestWorkflow_Validate(t *testing.T) {
 
 	// Unset keys should validate.
 	Ok(t, (raw.Workflow{}).Validate())
+
+	ErrEquals(t, "plan_timeout: time: invalid duration \"soon\".", (raw.Workflow{PlanTimeout: "soon"}).Validate())
+	ErrEquals(t, "apply_timeout: must be positive.", (raw.Workflow{ApplyTimeout: "0s"}).Validate())
+	Ok(t, (raw.Workflow{PlanTimeout: "30m", ApplyTimeout: "1h"}).Validate())
 }
 
 func TestWorkflow_ToValid(t *testing.T) {
@@ -154,6 +159,22 @@ func TestWorkflow_ToValid(t *testing.T) {
 				StateRm:     valid.DefaultStateRmStage,
 			},
 		},
+		{
+			description: "timeouts set",
+			input: raw.Workflow{
+				PlanTimeout:  "30m",
+				ApplyTimeout: "1h30m",
+			},
+			exp: valid.Workflow{
+				Apply:        valid.DefaultApplyStage,
+				Plan:         valid.DefaultPlanStage,
+				PolicyCheck:  valid.DefaultPolicyCheckStage,
+				Import:       valid.DefaultImportStage,
+				StateRm:      valid.DefaultStateRmStage,
+				PlanTimeout:  30 * time.Minute,
+				ApplyTimeout: 90 * time.Minute,
+			},
+		},
 		{
 			description: "fields set",
 			input: raw.Workflow{
@@ -0,0 +1,55 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package valid
+
+import "time"
+
+// DefaultDriftDetectionMaxConcurrency is how many scheduled drift detections
+// may run at once when max_concurrency is not set.
+const DefaultDriftDetectionMaxConcurrency = 2
+
+// DriftDetection holds the server-wide settings for scheduled drift detection.
+type DriftDetection struct {
+	// MaxConcurrency caps how many scheduled detections run at the same time
+	// across all repos. Zero means DefaultDriftDetectionMaxConcurrency.
+	MaxConcurrency int
+	// Jitter is the upper bound of a random delay added before each scheduled
+	// detection starts so repos sharing a schedule don't all clone at once.
+	Jitter time.Duration
+}
+
+// RepoDriftDetection is the drift detection configuration for a single repo.
+type RepoDriftDetection struct {
+	// Repository is the repo's full name without the hostname, ex. owner/repo.
+	Repository string
+	// VCSType is the VCS host type name, ex. Github.
+	VCSType   string
+	Schedules []DriftSchedule
+}
+
+// DriftSchedule runs drift detection for a branch on a cron schedule.
+type DriftSchedule struct {
+	Cron   string
+	Branch string
+	// Projects and Paths limit detection to some projects. When both are
+	// empty all projects are checked.
+	Projects []string
+	Paths    []DriftScheduledPath
+}
+
+// DriftScheduledPath selects a project by directory and workspace.
+type DriftScheduledPath struct {
+	Dir       string
+	Workspace string
+}
+
+// DriftIgnoreRule excludes resources, or some of their attributes, from drift.
+type DriftIgnoreRule struct {
+	// Resource is a glob matched against resource addresses. Empty matches
+	// all resources.
+	Resource string
+	// Attributes are globs matched against the paths of changed attributes
+	// and their parents. When empty the whole resource is ignored.
+	Attributes []string
+}
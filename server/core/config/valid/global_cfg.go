@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	version "github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/logging"
@@ -52,6 +53,7 @@ type GlobalCfg struct {
 	Metrics        Metrics
 	TeamAuthz      TeamAuthz
 	ExternalStores ExternalStores
+	DriftDetection DriftDetection
 }
 
 // ExternalStores holds configuration for external storage backends.
@@ -61,8 +63,11 @@ type ExternalStores struct {
 
 // PlanStoreConfig holds the type and backend-specific config for plan storage.
 type PlanStoreConfig struct {
-	Type string
-	S3   S3StoreConfig
+	Type       string
+	S3         S3StoreConfig
+	GCS        GCSStoreConfig
+	Azure      AzureStoreConfig
+	Encryption PlanStoreEncryption
 }
 
 // S3StoreConfig holds S3-specific configuration for the plan store.
@@ -75,6 +80,31 @@ type S3StoreConfig struct {
 	Profile        string
 }
 
+// GCSStoreConfig holds GCS-specific configuration for the plan store.
+type GCSStoreConfig struct {
+	Bucket   string
+	Prefix   string
+	Endpoint string
+}
+
+// AzureStoreConfig holds Azure Blob Storage-specific configuration for the
+// plan store.
+type AzureStoreConfig struct {
+	Account   string
+	Container string
+	Prefix    string
+	Endpoint  string
+}
+
+// PlanStoreEncryption holds configuration for client-side encryption of plans
+// in the plan store. An empty KeyProvider disables encryption.
+type PlanStoreEncryption struct {
+	KeyProvider string
+	KeyFile     string
+	KMSKeyID    string
+	KMSRegion   string
+}
+
 type Metrics struct {
 	Statsd     *Statsd
 	Prometheus *Prometheus
@@ -115,6 +145,7 @@ type Repo struct {
 	CustomPolicyCheck         *bool
 	AutoDiscover              *AutoDiscover
 	SilencePRComments         []string
+	DriftDetection            *RepoDriftDetection
 }
 
 type MergedProjectCfg struct {
@@ -141,6 +172,9 @@ type MergedProjectCfg struct {
 	PolicyCheck               bool
 	CustomPolicyCheck         bool
 	SilencePRComments         []string
+	// DriftIgnore holds the repo's drift ignore rules followed by the
+	// project's.
+	DriftIgnore []DriftIgnoreRule
 }
 
 // WorkflowHook is a map of custom run commands to run before or after workflows.
@@ -450,12 +484,15 @@ func (g GlobalCfg) MergeProjectCfg(log logging.SimpleLogging, repoID string, pro
 		PolicyCheck:               policyCheck,
 		CustomPolicyCheck:         customPolicyCheck,
 		SilencePRComments:         silencePRComments,
+		DriftIgnore:               slices.Concat(rCfg.DriftIgnore, proj.DriftIgnore),
 	}
 }
 
 // DefaultProjCfg returns the default project config for all projects under the
-// repo with id repoID. It is used when there is no repo config.
-func (g GlobalCfg) DefaultProjCfg(log logging.SimpleLogging, repoID string, repoRelDir string, workspace string) MergedProjectCfg {
+// repo with id repoID. It is used when there is no repo config or the project
+// is not configured in it, ex. autodiscovered projects. driftIgnore are the
+// repo-level drift ignore rules of the repo config, if any.
+func (g GlobalCfg) DefaultProjCfg(log logging.SimpleLogging, repoID string, repoRelDir string, workspace string, driftIgnore []DriftIgnoreRule) MergedProjectCfg {
 	log.Debug("building config based on server-side config")
 	planReqs, applyReqs, importReqs, workflow, _, _, deleteSourceBranchOnMerge, repoLocks, policyCheck, customPolicyCheck, _, silencePRComments := g.getMatchingCfg(log, repoID)
 	return MergedProjectCfg{
@@ -476,6 +513,7 @@ func (g GlobalCfg) DefaultProjCfg(log logging.SimpleLogging, repoID string, repo
 		PolicyCheck:               policyCheck,
 		CustomPolicyCheck:         customPolicyCheck,
 		SilencePRComments:         silencePRComments,
+		DriftIgnore:               driftIgnore,
 	}
 }
 
@@ -737,6 +775,36 @@ func (g GlobalCfg) MatchingRepo(repoID string) *Repo {
 	return nil
 }
 
+// LockTTL returns how long locks in the repo with repoID can be held before
+// they expire, from the last matching server-side repo config that sets
+// repo_locks. Zero means locks never expire.
+func (g GlobalCfg) LockTTL(repoID string) time.Duration {
+	repo := g.matchingRepoLocks(repoID)
+	if repo == nil {
+		return 0
+	}
+	return repo.TTL
+}
+
+// HasLockTTL returns whether any server-side repo config sets a lock TTL.
+func (g GlobalCfg) HasLockTTL() bool {
+	for _, repo := range g.Repos {
+		if repo.RepoLocks != nil && repo.RepoLocks.TTL > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g GlobalCfg) matchingRepoLocks(repoID string) *RepoLocks {
+	for _, repo := range slices.Backward(g.Repos) {
+		if repo.IDMatches(repoID) && repo.RepoLocks != nil {
+			return repo.RepoLocks
+		}
+	}
+	return nil
+}
+
 // RepoConfigFile returns a repository specific file path
 // If not defined, return atlantis.yaml as default
 func (g GlobalCfg) RepoConfigFile(repoID string) string {
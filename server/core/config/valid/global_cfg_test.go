@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/mohae/deepcopy"
@@ -1087,6 +1088,28 @@ repos:
 	}
 }
 
+func TestGlobalCfg_MergeProjectCfgDriftIgnore(t *testing.T) {
+	global := valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{})
+	repoRule := valid.DriftIgnoreRule{Attributes: []string{"tags"}}
+	projectRule := valid.DriftIgnoreRule{Resource: "aws_autoscaling_group.*"}
+
+	merged := global.MergeProjectCfg(logging.NewNoopLogger(t), "github.com/owner/repo",
+		valid.Project{Dir: ".", Workspace: "default", DriftIgnore: []valid.DriftIgnoreRule{projectRule}},
+		valid.RepoCfg{DriftIgnore: []valid.DriftIgnoreRule{repoRule}})
+
+	Equals(t, []valid.DriftIgnoreRule{repoRule, projectRule}, merged.DriftIgnore)
+}
+
+func TestGlobalCfg_DefaultProjCfgDriftIgnore(t *testing.T) {
+	global := valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{})
+	repoRule := valid.DriftIgnoreRule{Attributes: []string{"tags"}}
+
+	merged := global.DefaultProjCfg(logging.NewNoopLogger(t), "github.com/owner/repo", "dir", "default",
+		[]valid.DriftIgnoreRule{repoRule})
+
+	Equals(t, []valid.DriftIgnoreRule{repoRule}, merged.DriftIgnore)
+}
+
 func TestRepo_IDMatches(t *testing.T) {
 	// Test exact matches.
 	Equals(t, false, (valid.Repo{ID: "github.com/owner/repo"}).IDMatches("github.com/runatlantis/atlantis"))
@@ -1189,6 +1212,29 @@ func TestGlobalCfg_MatchingRepo(t *testing.T) {
 	}
 }
 
+func TestGlobalCfg_LockTTL(t *testing.T) {
+	defaultRepo := valid.Repo{
+		IDRegex:   regexp.MustCompile(".*"),
+		RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnPlanMode, TTL: 72 * time.Hour},
+	}
+	overrideWithoutRepoLocks := valid.Repo{
+		ID: "github.com/owner/no-repo-locks",
+	}
+	overrideWithoutTTL := valid.Repo{
+		ID:        "github.com/owner/no-ttl",
+		RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnApplyMode},
+	}
+	gCfg := valid.GlobalCfg{
+		Repos: []valid.Repo{defaultRepo, overrideWithoutRepoLocks, overrideWithoutTTL},
+	}
+
+	Equals(t, 72*time.Hour, gCfg.LockTTL("github.com/owner/repo"))
+	Equals(t, 72*time.Hour, gCfg.LockTTL("github.com/owner/no-repo-locks"))
+	Equals(t, time.Duration(0), gCfg.LockTTL("github.com/owner/no-ttl"))
+	Assert(t, gCfg.HasLockTTL(), "expected a lock TTL")
+	Assert(t, !valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{}).HasLockTTL(), "expected no lock TTL by default")
+}
+
 func TestGlobalCfg_RepoAutoDiscoverCfg(t *testing.T) {
 	inheritedAutoDiscover := &valid.AutoDiscover{
 		Mode:        valid.AutoDiscoverEnabledMode,
@@ -10,6 +10,7 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/bmatcuk/doublestar/v4"
 	version "github.com/hashicorp/go-version"
@@ -34,6 +35,7 @@ type RepoCfg struct {
 	AllowedRegexpPrefixes     []string
 	AbortOnExecutionOrderFail bool
 	SilencePRComments         []string
+	DriftIgnore               []DriftIgnoreRule
 }
 
 func (r RepoCfg) FindProjectsByDirWorkspace(repoRelDir string, workspace string) []Project {
@@ -198,6 +200,7 @@ type Project struct {
 	PolicyCheck               *bool
 	CustomPolicyCheck         *bool
 	SilencePRComments         []string
+	DriftIgnore               []DriftIgnoreRule
 }
 
 // GetName returns the name of the project or an empty string if there is no
@@ -257,6 +260,9 @@ type Step struct {
 	// FilterRegex is a list of regexes for post-processing a RunCommand output
 	// these will be executed in the received order
 	FilterRegexes []*regexp.Regexp
+	// Timeout is how long a run step may take before it's terminated. Zero
+	// means no limit.
+	Timeout time.Duration
 }
 
 type Workflow struct {
@@ -266,4 +272,8 @@ type Workflow struct {
 	PolicyCheck Stage
 	Import      Stage
 	StateRm     Stage
+	// PlanTimeout and ApplyTimeout are how long the whole plan and apply stages may
+	// take before they're terminated. Zero means the server default is used.
+	PlanTimeout  time.Duration
+	ApplyTimeout time.Duration
 }
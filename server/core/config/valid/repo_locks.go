@@ -3,6 +3,8 @@
 
 package valid
 
+import "time"
+
 // RepoLocksMode enum
 type RepoLocksMode string
 
@@ -19,4 +21,7 @@ const (
 
 type RepoLocks struct {
 	Mode RepoLocksMode
+	// TTL is how long a lock can be held before the lock reaper releases it.
+	// Zero means locks never expire.
+	TTL time.Duration
 }
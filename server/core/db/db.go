@@ -27,6 +27,18 @@ type Database interface {
 	DeletePullStatus(pull models.PullRequest) error
 	UpdatePullWithResults(pull models.PullRequest, newResults []command.ProjectResult) (models.PullStatus, error)
 
+	// EnqueueLockWaiter appends waiter to the queue for its project and
+	// workspace and returns its 1-based position. If waiter's pull request is
+	// already queued, its position is returned and the queue is unchanged.
+	EnqueueLockWaiter(waiter models.LockWaiter) (int, error)
+	// DequeueLockWaiter removes and returns the first waiter in the queue for
+	// project and workspace, or nil if the queue is empty.
+	DequeueLockWaiter(project models.Project, workspace string) (*models.LockWaiter, error)
+	// ListLockWaiters returns the queue for project and workspace in order.
+	ListLockWaiters(project models.Project, workspace string) ([]models.LockWaiter, error)
+	// RemoveLockWaitersByPull removes the pull request from every queue.
+	RemoveLockWaitersByPull(repoFullName string, pullNum int) error
+
 	LockCommand(cmdName command.Name, lockTime time.Time) (*command.Lock, error)
 	UnlockCommand(cmdName command.Name) error
 	CheckCommandLock(cmdName command.Name) (*command.Lock, error)
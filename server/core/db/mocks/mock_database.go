@@ -85,6 +85,36 @@ func (mr *MockDatabaseMockRecorder) DeletePullStatus(pull any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePullStatus", reflect.TypeOf((*MockDatabase)(nil).DeletePullStatus), pull)
 }
 
+// DequeueLockWaiter mocks base method.
+func (m *MockDatabase) DequeueLockWaiter(project models.Project, workspace string) (*models.LockWaiter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DequeueLockWaiter", project, workspace)
+	ret0, _ := ret[0].(*models.LockWaiter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DequeueLockWaiter indicates an expected call of DequeueLockWaiter.
+func (mr *MockDatabaseMockRecorder) DequeueLockWaiter(project, workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DequeueLockWaiter", reflect.TypeOf((*MockDatabase)(nil).DequeueLockWaiter), project, workspace)
+}
+
+// EnqueueLockWaiter mocks base method.
+func (m *MockDatabase) EnqueueLockWaiter(waiter models.LockWaiter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueLockWaiter", waiter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueLockWaiter indicates an expected call of EnqueueLockWaiter.
+func (mr *MockDatabaseMockRecorder) EnqueueLockWaiter(waiter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueLockWaiter", reflect.TypeOf((*MockDatabase)(nil).EnqueueLockWaiter), waiter)
+}
+
 // GetLock mocks base method.
 func (m *MockDatabase) GetLock(project models.Project, workspace string) (*models.ProjectLock, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +160,21 @@ func (mr *MockDatabaseMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDatabase)(nil).List))
 }
 
+// ListLockWaiters mocks base method.
+func (m *MockDatabase) ListLockWaiters(project models.Project, workspace string) ([]models.LockWaiter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLockWaiters", project, workspace)
+	ret0, _ := ret[0].([]models.LockWaiter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLockWaiters indicates an expected call of ListLockWaiters.
+func (mr *MockDatabaseMockRecorder) ListLockWaiters(project, workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLockWaiters", reflect.TypeOf((*MockDatabase)(nil).ListLockWaiters), project, workspace)
+}
+
 // LockCommand mocks base method.
 func (m *MockDatabase) LockCommand(cmdName command.Name, lockTime time.Time) (*command.Lock, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +204,20 @@ func (mr *MockDatabaseMockRecorder) Ping() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockDatabase)(nil).Ping))
 }
 
+// RemoveLockWaitersByPull mocks base method.
+func (m *MockDatabase) RemoveLockWaitersByPull(repoFullName string, pullNum int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLockWaitersByPull", repoFullName, pullNum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLockWaitersByPull indicates an expected call of RemoveLockWaitersByPull.
+func (mr *MockDatabaseMockRecorder) RemoveLockWaitersByPull(repoFullName, pullNum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLockWaitersByPull", reflect.TypeOf((*MockDatabase)(nil).RemoveLockWaitersByPull), repoFullName, pullNum)
+}
+
 // TryLock mocks base method.
 func (m *MockDatabase) TryLock(lock models.ProjectLock) (bool, models.ProjectLock, error) {
 	m.ctrl.T.Helper()
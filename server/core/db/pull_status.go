@@ -0,0 +1,109 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package db
+
+import (
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// MergePullStatus returns the status of pull after newResults, for
+// implementations of Database.UpdatePullWithResults. currStatus is the
+// stored status of pull and may be nil.
+func MergePullStatus(currStatus *models.PullStatus, pull models.PullRequest, newResults []command.ProjectResult) models.PullStatus {
+	// If there is no pull OR if the pull we have is out of date, we
+	// just write a new pull.
+	if currStatus == nil || pullStatusOutdatedForPull(currStatus.Pull, pull) {
+		var statuses []models.ProjectStatus
+		for _, res := range newResults {
+			statuses = append(statuses, projectResultToProject(res))
+		}
+		// Preserve policy status from the previous commit so approvals
+		// survive between the plan DB write and the subsequent policy
+		// check DB write. doPolicyCheck applies sticky filtering and
+		// overwrites these when it writes its own results.
+		if currStatus != nil {
+			for i := range statuses {
+				for _, old := range currStatus.Projects {
+					if statuses[i].Workspace == old.Workspace &&
+						statuses[i].RepoRelDir == old.RepoRelDir &&
+						statuses[i].ProjectName == old.ProjectName &&
+						len(old.PolicyStatus) > 0 {
+						statuses[i].PolicyStatus = old.PolicyStatus
+						break
+					}
+				}
+			}
+		}
+		return models.PullStatus{
+			Pull:     pull,
+			Projects: statuses,
+		}
+	}
+
+	// If there's an existing pull at the right commit then we have to
+	// merge our project results with the existing ones. We do a merge
+	// because it's possible a user is just applying a single project
+	// in this command and so we don't want to delete our data about
+	// other projects that aren't affected by this command.
+	newStatus := *currStatus
+	for _, res := range newResults {
+		// First, check if we should update any existing projects.
+		updatedExisting := false
+		for i := range newStatus.Projects {
+			// NOTE: We're using a reference here because we are
+			// in-place updating its Status field.
+			proj := &newStatus.Projects[i]
+			if res.Workspace == proj.Workspace &&
+				res.RepoRelDir == proj.RepoRelDir &&
+				res.ProjectName == proj.ProjectName {
+
+				proj.Status = res.PlanStatus()
+
+				// Updating only policy sets which are included in results; keeping the rest.
+				if len(proj.PolicyStatus) > 0 {
+					for i, oldPolicySet := range proj.PolicyStatus {
+						for _, newPolicySet := range res.PolicyStatus() {
+							if oldPolicySet.PolicySetName == newPolicySet.PolicySetName {
+								proj.PolicyStatus[i] = newPolicySet
+							}
+						}
+					}
+				} else {
+					proj.PolicyStatus = res.PolicyStatus()
+				}
+
+				updatedExisting = true
+				break
+			}
+		}
+
+		if !updatedExisting {
+			// If we didn't update an existing project, then we need to
+			// add this because it's a new one.
+			newStatus.Projects = append(newStatus.Projects, projectResultToProject(res))
+		}
+	}
+	return newStatus
+}
+
+func pullStatusOutdatedForPull(statusPull models.PullRequest, pull models.PullRequest) bool {
+	if statusPull.HeadCommit != pull.HeadCommit {
+		return true
+	}
+	if pull.BaseBranch == "" {
+		return false
+	}
+	return statusPull.BaseBranch == "" || statusPull.BaseBranch != pull.BaseBranch
+}
+
+func projectResultToProject(p command.ProjectResult) models.ProjectStatus {
+	return models.ProjectStatus{
+		Workspace:    p.Workspace,
+		RepoRelDir:   p.RepoRelDir,
+		ProjectName:  p.ProjectName,
+		PolicyStatus: p.PolicyStatus(),
+		Status:       p.PlanStatus(),
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package db_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/db"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestMergePullStatus(t *testing.T) {
+	pull := models.PullRequest{Num: 1, HeadCommit: "sha", BaseBranch: "main"}
+	planned := []command.ProjectResult{
+		{
+			Command:    command.Plan,
+			RepoRelDir: "mergeme",
+			Workspace:  "default",
+			ProjectCommandOutput: command.ProjectCommandOutput{
+				PlanSuccess: &models.PlanSuccess{TerraformOutput: "tf out"},
+			},
+		},
+		{
+			Command:    command.Plan,
+			RepoRelDir: "staythesame",
+			Workspace:  "default",
+			ProjectCommandOutput: command.ProjectCommandOutput{
+				Failure: "failure",
+			},
+		},
+	}
+	status := db.MergePullStatus(nil, pull, planned)
+	Equals(t, pull, status.Pull)
+	Equals(t, 2, len(status.Projects))
+	Equals(t, models.PlannedPlanStatus, status.Projects[0].Status)
+	Equals(t, models.ErroredPlanStatus, status.Projects[1].Status)
+
+	t.Run("same commit merges results", func(t *testing.T) {
+		merged := db.MergePullStatus(&status, pull, []command.ProjectResult{
+			{
+				Command:    command.Apply,
+				RepoRelDir: "mergeme",
+				Workspace:  "default",
+				ProjectCommandOutput: command.ProjectCommandOutput{
+					ApplySuccess: "success!",
+				},
+			},
+			{
+				Command:    command.Plan,
+				RepoRelDir: "newproject",
+				Workspace:  "default",
+				ProjectCommandOutput: command.ProjectCommandOutput{
+					PlanSuccess: &models.PlanSuccess{TerraformOutput: "tf out"},
+				},
+			},
+		})
+		Equals(t, []models.ProjectStatus{
+			{Workspace: "default", RepoRelDir: "mergeme", Status: models.AppliedPlanStatus},
+			{Workspace: "default", RepoRelDir: "staythesame", Status: models.ErroredPlanStatus},
+			{Workspace: "default", RepoRelDir: "newproject", Status: models.PlannedPlanStatus},
+		}, merged.Projects)
+	})
+
+	t.Run("new commit replaces results", func(t *testing.T) {
+		newPull := pull
+		newPull.HeadCommit = "newsha"
+		replaced := db.MergePullStatus(&status, newPull, planned[1:])
+		Equals(t, newPull, replaced.Pull)
+		Equals(t, []models.ProjectStatus{
+			{Workspace: "default", RepoRelDir: "staythesame", Status: models.ErroredPlanStatus},
+		}, replaced.Projects)
+	})
+
+	t.Run("new base branch replaces results", func(t *testing.T) {
+		newPull := pull
+		newPull.BaseBranch = "release"
+		replaced := db.MergePullStatus(&status, newPull, planned[:1])
+		Equals(t, newPull, replaced.Pull)
+		Equals(t, 1, len(replaced.Projects))
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// ApplyIgnoreRules marks the resources matched by rules as ignored, removes
+// ignored attributes from the others and takes ignored resources out of the
+// summary's counts. An update or replacement whose changed attributes are all
+// ignored is ignored too. HasDrift is recomputed from what is left.
+// Rules can only be applied to resources, so summary is returned unchanged
+// if resources is empty.
+func ApplyIgnoreRules(summary models.DriftSummary, resources []models.ResourceDrift, rules []valid.DriftIgnoreRule) (models.DriftSummary, []models.ResourceDrift) {
+	if len(rules) == 0 || len(resources) == 0 {
+		return summary, resources
+	}
+
+	result := make([]models.ResourceDrift, 0, len(resources))
+	hasOutside, outsideRemaining := false, false
+	for _, r := range resources {
+		r = applyIgnoreRulesToResource(r, rules)
+		result = append(result, r)
+		if r.Outside {
+			hasOutside = true
+			outsideRemaining = outsideRemaining || !r.Ignored
+		}
+		if !r.Ignored {
+			continue
+		}
+		summary.Ignored++
+		if r.Outside {
+			continue
+		}
+		switch r.Action {
+		case "create":
+			summary.ToAdd--
+		case "update":
+			summary.ToChange--
+		case "delete":
+			summary.ToDestroy--
+		case "replace":
+			summary.ToAdd--
+			summary.ToDestroy--
+		case "import":
+			summary.ToImport--
+		case "forget":
+			summary.ToForget--
+		}
+	}
+	if summary.Ignored == 0 {
+		return summary, result
+	}
+
+	// The counts come from the plan's text output, so guard against it
+	// disagreeing with the plan JSON.
+	summary.ToAdd = max(summary.ToAdd, 0)
+	summary.ToChange = max(summary.ToChange, 0)
+	summary.ToDestroy = max(summary.ToDestroy, 0)
+	summary.ToImport = max(summary.ToImport, 0)
+	summary.ToForget = max(summary.ToForget, 0)
+	if hasOutside && !outsideRemaining {
+		summary.ChangesOutside = false
+	}
+	summary.HasDrift = summary.TotalChanges() > 0 || summary.ChangesOutside
+	return summary, result
+}
+
+func applyIgnoreRulesToResource(r models.ResourceDrift, rules []valid.DriftIgnoreRule) models.ResourceDrift {
+	// Fall back to the top-level attributes for resources without paths.
+	paths := r.ChangedPaths
+	if len(paths) == 0 {
+		paths = r.ChangedAttributes
+	}
+	remaining := slices.Clone(paths)
+	for _, rule := range rules {
+		if rule.Resource != "" && !matchesGlob(rule.Resource, r.Address) {
+			continue
+		}
+		if len(rule.Attributes) == 0 {
+			r.Ignored = true
+			return r
+		}
+		remaining = slices.DeleteFunc(remaining, func(path string) bool {
+			return slices.ContainsFunc(rule.Attributes, func(pattern string) bool {
+				return matchesPath(pattern, path)
+			})
+		})
+	}
+	if len(paths) > 0 && len(remaining) == 0 {
+		r.Ignored = true
+		return r
+	}
+	if len(remaining) == len(paths) {
+		return r
+	}
+	if len(r.ChangedPaths) > 0 {
+		r.ChangedPaths = remaining
+		r.ChangedAttributes = topLevelAttributes(remaining)
+	} else {
+		r.ChangedAttributes = remaining
+	}
+	return r
+}
+
+// matchesPath reports whether pattern matches path or one of its parents, so
+// that ignoring an attribute also ignores everything nested in it.
+func matchesPath(pattern, path string) bool {
+	for {
+		if matchesGlob(pattern, path) {
+			return true
+		}
+		i := strings.LastIndex(path, ".")
+		if i < 0 {
+			return false
+		}
+		path = path[:i]
+	}
+}
+
+// matchesGlob matches name against pattern. Patterns are validated when the
+// repo config is parsed, so an invalid pattern just doesn't match. Brackets
+// start a character class, so the brackets of resource addresses like
+// aws_instance.web["a"] have to be escaped with a backslash.
+func matchesGlob(pattern, name string) bool {
+	return doublestar.MatchUnvalidated(pattern, name)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestApplyIgnoreRules(t *testing.T) {
+	resources := []models.ResourceDrift{
+		{Address: "aws_autoscaling_group.web", Action: "update", ChangedAttributes: []string{"desired_capacity"}},
+		{Address: "aws_instance.web", Action: "update", ChangedAttributes: []string{"tags", "tags_all", "instance_type"}},
+		{Address: "module.legacy.aws_s3_bucket.logs", Action: "replace", ChangedAttributes: []string{"bucket"}},
+		{Address: "aws_iam_role.ci", Action: "create"},
+		{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"tags"}, Outside: true},
+	}
+	summary := models.DriftSummary{HasDrift: true, ToAdd: 2, ToChange: 2, ToDestroy: 1, ChangesOutside: true}
+	rules := []valid.DriftIgnoreRule{
+		{Resource: "aws_autoscaling_group.*", Attributes: []string{"desired_capacity"}},
+		{Attributes: []string{"tags*"}},
+		{Resource: "module.legacy.**"},
+	}
+
+	gotSummary, gotResources := drift.ApplyIgnoreRules(summary, resources, rules)
+
+	Equals(t, models.DriftSummary{HasDrift: true, ToAdd: 1, ToChange: 1, ToDestroy: 0, ChangesOutside: false, Ignored: 3}, gotSummary)
+	Equals(t, []models.ResourceDrift{
+		{Address: "aws_autoscaling_group.web", Action: "update", ChangedAttributes: []string{"desired_capacity"}, Ignored: true},
+		{Address: "aws_instance.web", Action: "update", ChangedAttributes: []string{"instance_type"}},
+		{Address: "module.legacy.aws_s3_bucket.logs", Action: "replace", ChangedAttributes: []string{"bucket"}, Ignored: true},
+		{Address: "aws_iam_role.ci", Action: "create"},
+		{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"tags"}, Outside: true, Ignored: true},
+	}, gotResources)
+	// The input isn't modified.
+	Equals(t, []string{"tags", "tags_all", "instance_type"}, resources[1].ChangedAttributes)
+}
+
+func TestApplyIgnoreRules_NestedPaths(t *testing.T) {
+	resources := []models.ResourceDrift{
+		{
+			Address:           "aws_eks_node_group.workers",
+			Action:            "update",
+			ChangedAttributes: []string{"scaling_config", "tags"},
+			ChangedPaths:      []string{"scaling_config.0.desired_size", "tags.CostCenter", "tags.Team"},
+		},
+		{
+			Address:           `aws_instance.web["a"]`,
+			Action:            "update",
+			ChangedAttributes: []string{"tags"},
+			ChangedPaths:      []string{"tags.Owner"},
+		},
+		{
+			Address:           "aws_s3_bucket.logs",
+			Action:            "update",
+			ChangedAttributes: []string{"tags"},
+			ChangedPaths:      []string{"tags.CostCenter"},
+		},
+	}
+	summary := models.DriftSummary{HasDrift: true, ToChange: 3}
+	rules := []valid.DriftIgnoreRule{
+		{Resource: "aws_eks_node_group.*", Attributes: []string{"scaling_config.*.desired_size", "tags.CostCenter"}},
+		// Brackets are escaped to match them literally.
+		{Resource: `aws_instance.web\["a"\]`, Attributes: []string{"tags"}},
+		{Resource: "aws_s3_bucket.*", Attributes: []string{"tags.Team"}},
+	}
+
+	gotSummary, gotResources := drift.ApplyIgnoreRules(summary, resources, rules)
+
+	Equals(t, models.DriftSummary{HasDrift: true, ToChange: 2, Ignored: 1}, gotSummary)
+	Equals(t, []models.ResourceDrift{
+		{
+			Address:           "aws_eks_node_group.workers",
+			Action:            "update",
+			ChangedAttributes: []string{"tags"},
+			ChangedPaths:      []string{"tags.Team"},
+		},
+		{
+			Address:           `aws_instance.web["a"]`,
+			Action:            "update",
+			ChangedAttributes: []string{"tags"},
+			ChangedPaths:      []string{"tags.Owner"},
+			Ignored:           true,
+		},
+		resources[2],
+	}, gotResources)
+}
+
+func TestApplyIgnoreRules_AllDriftIgnored(t *testing.T) {
+	resources := []models.ResourceDrift{
+		{Address: "aws_autoscaling_group.web", Action: "update", ChangedAttributes: []string{"desired_capacity"}},
+	}
+	summary := models.DriftSummary{HasDrift: true, ToChange: 1, Summary: "Plan: 0 to add, 1 to change, 0 to destroy."}
+	rules := []valid.DriftIgnoreRule{{Resource: "aws_autoscaling_group.web"}}
+
+	gotSummary, _ := drift.ApplyIgnoreRules(summary, resources, rules)
+
+	Equals(t, models.DriftSummary{HasDrift: false, Ignored: 1, Summary: "Plan: 0 to add, 1 to change, 0 to destroy."}, gotSummary)
+}
+
+func TestApplyIgnoreRules_NoMatch(t *testing.T) {
+	resources := []models.ResourceDrift{{Address: "aws_instance.web", Action: "delete"}}
+	summary := models.DriftSummary{HasDrift: true, ToDestroy: 1}
+
+	gotSummary, gotResources := drift.ApplyIgnoreRules(summary, resources, []valid.DriftIgnoreRule{{Resource: "aws_s3_bucket.*"}})
+
+	Equals(t, summary, gotSummary)
+	Equals(t, resources, gotResources)
+}
+
+func TestApplyIgnoreRules_WithoutResourcesLeavesSummary(t *testing.T) {
+	summary := models.DriftSummary{HasDrift: true, ToChange: 1}
+
+	gotSummary, gotResources := drift.ApplyIgnoreRules(summary, nil, []valid.DriftIgnoreRule{{Resource: "*"}})
+
+	Equals(t, summary, gotSummary)
+	Equals(t, 0, len(gotResources))
+}
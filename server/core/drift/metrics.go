@@ -0,0 +1,131 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	tally "github.com/uber-go/tally/v4"
+)
+
+const (
+	// DetectionRunsMetric counts drift detection runs per repo.
+	DetectionRunsMetric = "runs"
+	// DetectionErrorsMetric counts drift detection runs per repo that failed
+	// or had a project that failed.
+	DetectionErrorsMetric = "errors"
+	// DetectionDurationMetric is a histogram of drift detection durations.
+	DetectionDurationMetric = "duration"
+	// ProjectsWithDriftMetric is the number of projects with drift in the
+	// latest detection run for a repo.
+	ProjectsWithDriftMetric = "projects_with_drift"
+	// ProjectsMetric is the number of projects checked in the latest
+	// detection run for a repo.
+	ProjectsMetric = "projects"
+	// HasDriftMetric is 1 if a project had drift in its latest detection run
+	// and 0 otherwise.
+	HasDriftMetric = "has_drift"
+	// ResourcesToAddMetric is the number of resources a project's latest
+	// detection plan would add.
+	ResourcesToAddMetric = "resources_to_add"
+	// ResourcesToChangeMetric is the number of resources a project's latest
+	// detection plan would change.
+	ResourcesToChangeMetric = "resources_to_change"
+	// ResourcesToDestroyMetric is the number of resources a project's latest
+	// detection plan would destroy.
+	ResourcesToDestroyMetric = "resources_to_destroy"
+	// RemediationResultsMetric counts finished remediations per repo, action
+	// and status.
+	RemediationResultsMetric = "results"
+	// RemediationProjectResultsMetric counts finished project remediations
+	// per repo, project, workspace, action and status.
+	RemediationProjectResultsMetric = "project_results"
+)
+
+var detectionDurationBuckets = tally.DurationBuckets{
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	20 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+}
+
+// Metrics emits drift detection and remediation metrics. All metrics are
+// nested under the "drift" sub-scope and tagged with "repo"; project level
+// metrics are also tagged with "project", "path" and "workspace".
+// A nil *Metrics records nothing.
+type Metrics struct {
+	scope tally.Scope
+}
+
+// NewMetrics returns Metrics that report to scope.
+func NewMetrics(scope tally.Scope) *Metrics {
+	return &Metrics{scope: scope.SubScope("drift")}
+}
+
+// RecordDetection records a drift detection run for repo that took duration.
+// err is the error returned by the run, if any; result may be nil if err is
+// set.
+func (m *Metrics) RecordDetection(repo string, result *models.DriftDetectionResult, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	scope := m.scope.SubScope("detection").Tagged(map[string]string{"repo": repo})
+	scope.Counter(DetectionRunsMetric).Inc(1)
+	scope.Histogram(DetectionDurationMetric, detectionDurationBuckets).RecordDuration(duration)
+
+	failed := err != nil || result == nil
+	if result != nil {
+		scope.Gauge(ProjectsMetric).Update(float64(result.TotalProjects))
+		scope.Gauge(ProjectsWithDriftMetric).Update(float64(result.ProjectsWithDrift))
+		for _, p := range result.Projects {
+			if p.Error != "" {
+				failed = true
+				continue
+			}
+			projectScope := scope.Tagged(projectTags(p.ProjectName, p.Path, p.Workspace))
+			hasDrift := 0.0
+			if p.Drift.HasDrift {
+				hasDrift = 1
+			}
+			projectScope.Gauge(HasDriftMetric).Update(hasDrift)
+			projectScope.Gauge(ResourcesToAddMetric).Update(float64(p.Drift.ToAdd))
+			projectScope.Gauge(ResourcesToChangeMetric).Update(float64(p.Drift.ToChange))
+			projectScope.Gauge(ResourcesToDestroyMetric).Update(float64(p.Drift.ToDestroy))
+		}
+	}
+	if failed {
+		scope.Counter(DetectionErrorsMetric).Inc(1)
+	}
+}
+
+// RecordRemediation records the outcome of a finished remediation.
+func (m *Metrics) RecordRemediation(result *models.RemediationResult) {
+	if m == nil || result == nil {
+		return
+	}
+	scope := m.scope.SubScope("remediation").Tagged(map[string]string{
+		"repo":   result.Repository,
+		"action": string(result.Action),
+	})
+	scope.Tagged(map[string]string{"status": string(result.Status)}).Counter(RemediationResultsMetric).Inc(1)
+	for _, p := range result.Projects {
+		tags := projectTags(p.ProjectName, p.Path, p.Workspace)
+		tags["status"] = string(p.Status)
+		scope.Tagged(tags).Counter(RemediationProjectResultsMetric).Inc(1)
+	}
+}
+
+func projectTags(projectName, path, workspace string) map[string]string {
+	return map[string]string{
+		"project":   projectName,
+		"path":      path,
+		"workspace": workspace,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+	tally "github.com/uber-go/tally/v4"
+)
+
+func TestMetrics_RecordDetection(t *testing.T) {
+	scope := tally.NewTestScope("test", nil)
+	metrics := drift.NewMetrics(scope)
+
+	result := models.NewDriftDetectionResult("owner/repo")
+	result.AddProject(models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1, ToChange: 2, ToDestroy: 3},
+	})
+	result.AddProject(models.ProjectDrift{
+		ProjectName: "db",
+		Path:        "db",
+		Workspace:   "default",
+	})
+	metrics.RecordDetection("owner/repo", result, 2*time.Minute, nil)
+
+	snapshot := scope.Snapshot()
+	Equals(t, int64(1), snapshot.Counters()["test.drift.detection.runs+repo=owner/repo"].Value())
+	_, ok := snapshot.Counters()["test.drift.detection.errors+repo=owner/repo"]
+	Assert(t, !ok, "expected no errors to be recorded")
+	Equals(t, 1.0, snapshot.Gauges()["test.drift.detection.projects_with_drift+repo=owner/repo"].Value())
+	Equals(t, 2.0, snapshot.Gauges()["test.drift.detection.projects+repo=owner/repo"].Value())
+
+	appTags := "+path=app,project=app,repo=owner/repo,workspace=default"
+	Equals(t, 1.0, snapshot.Gauges()["test.drift.detection.has_drift"+appTags].Value())
+	Equals(t, 1.0, snapshot.Gauges()["test.drift.detection.resources_to_add"+appTags].Value())
+	Equals(t, 2.0, snapshot.Gauges()["test.drift.detection.resources_to_change"+appTags].Value())
+	Equals(t, 3.0, snapshot.Gauges()["test.drift.detection.resources_to_destroy"+appTags].Value())
+	Equals(t, 0.0, snapshot.Gauges()["test.drift.detection.has_drift+path=db,project=db,repo=owner/repo,workspace=default"].Value())
+
+	histogram, ok := snapshot.Histograms()["test.drift.detection.duration+repo=owner/repo"]
+	Assert(t, ok, "expected duration histogram")
+	Equals(t, int64(1), histogram.Durations()[2*time.Minute])
+}
+
+func TestMetrics_RecordDetectionErrors(t *testing.T) {
+	scope := tally.NewTestScope("test", nil)
+	metrics := drift.NewMetrics(scope)
+
+	metrics.RecordDetection("owner/repo", nil, time.Second, errors.New("setup failed"))
+
+	result := models.NewDriftDetectionResult("owner/repo")
+	result.AddProject(models.ProjectDrift{ProjectName: "app", Path: "app", Workspace: "default", Error: "plan failed"})
+	metrics.RecordDetection("owner/repo", result, time.Second, nil)
+
+	snapshot := scope.Snapshot()
+	Equals(t, int64(2), snapshot.Counters()["test.drift.detection.runs+repo=owner/repo"].Value())
+	Equals(t, int64(2), snapshot.Counters()["test.drift.detection.errors+repo=owner/repo"].Value())
+	_, ok := snapshot.Gauges()["test.drift.detection.has_drift+path=app,project=app,repo=owner/repo,workspace=default"]
+	Assert(t, !ok, "expected no drift gauge for a failed project")
+}
+
+func TestMetrics_RecordRemediation(t *testing.T) {
+	scope := tally.NewTestScope("test", nil)
+	service := drift.NewRemediationService(nil, drift.NewInMemoryRemediationStore(), drift.NewMetrics(scope), nil)
+
+	result, err := service.Remediate(models.RemediationRequest{
+		Repository: "owner/repo",
+		Ref:        "main",
+		Type:       "Github",
+		Action:     models.RemediationPlanOnly,
+		Projects:   []string{"app"},
+	}, &recordingRemediationExecutor{})
+	Ok(t, err)
+	Equals(t, 1, len(result.Projects))
+
+	snapshot := scope.Snapshot()
+	Equals(t, int64(1), snapshot.Counters()["test.drift.remediation.results+action=plan,repo=owner/repo,status=success"].Value())
+	project := result.Projects[0]
+	key := "test.drift.remediation.project_results+action=plan,path=" + project.Path + ",project=app,repo=owner/repo,status=success,workspace=" + project.Workspace
+	Equals(t, int64(1), snapshot.Counters()[key].Value())
+}
+
+func TestMetrics_NilRecordsNothing(t *testing.T) {
+	var metrics *drift.Metrics
+	metrics.RecordDetection("owner/repo", nil, time.Second, errors.New("failed"))
+	metrics.RecordRemediation(models.NewRemediationResult("id", "owner/repo", "main", models.RemediationPlanOnly))
+}
@@ -0,0 +1,111 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/core/drift (interfaces: Detector)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	models "github.com/runatlantis/atlantis/server/events/models"
+	"reflect"
+	"time"
+)
+
+type MockDetector struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockDetector(options ...pegomock.Option) *MockDetector {
+	mock := &MockDetector{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockDetector) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockDetector) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockDetector) RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockDetector().")
+	}
+	_params := []pegomock.Param{request}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("RunDriftDetection", _params, []reflect.Type{reflect.TypeOf((**models.DriftDetectionResult)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 *models.DriftDetectionResult
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(*models.DriftDetectionResult)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
+func (mock *MockDetector) VerifyWasCalledOnce() *VerifierMockDetector {
+	return &VerifierMockDetector{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockDetector) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockDetector {
+	return &VerifierMockDetector{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockDetector) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockDetector {
+	return &VerifierMockDetector{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockDetector) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockDetector {
+	return &VerifierMockDetector{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockDetector struct {
+	mock                   *MockDetector
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockDetector) RunDriftDetection(request models.DriftDetectionRequest) *MockDetector_RunDriftDetection_OngoingVerification {
+	_params := []pegomock.Param{request}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RunDriftDetection", _params, verifier.timeout)
+	return &MockDetector_RunDriftDetection_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockDetector_RunDriftDetection_OngoingVerification struct {
+	mock              *MockDetector
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockDetector_RunDriftDetection_OngoingVerification) GetCapturedArguments() models.DriftDetectionRequest {
+	request := c.GetAllCapturedArguments()
+	return request[len(request)-1]
+}
+
+func (c *MockDetector_RunDriftDetection_OngoingVerification) GetAllCapturedArguments() (_param0 []models.DriftDetectionRequest) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]models.DriftDetectionRequest, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(models.DriftDetectionRequest)
+			}
+		}
+	}
+	return
+}
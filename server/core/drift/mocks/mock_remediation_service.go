@@ -45,11 +45,30 @@ func (mock *MockRemediationService) GetResult(id string) (*models.RemediationRes
 	return _ret0, _ret1
 }
 
-func (mock *MockRemediationService) ListResults(repository string, limit int) ([]*models.RemediationResult, error) {
+func (mock *MockRemediationService) ListRepositories() ([]string, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockRemediationService().")
 	}
-	_params := []pegomock.Param{repository, limit}
+	_params := []pegomock.Param{}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ListRepositories", _params, []reflect.Type{reflect.TypeOf((*[]string)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 []string
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].([]string)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
+func (mock *MockRemediationService) ListResults(repository string, opts drift.ListResultsOptions) ([]*models.RemediationResult, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockRemediationService().")
+	}
+	_params := []pegomock.Param{repository, opts}
 	_result := pegomock.GetGenericMockFrom(mock).Invoke("ListResults", _params, []reflect.Type{reflect.TypeOf((*[]*models.RemediationResult)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
 	var _ret0 []*models.RemediationResult
 	var _ret1 error
@@ -149,8 +168,25 @@ func (c *MockRemediationService_GetResult_OngoingVerification) GetAllCapturedArg
 	return
 }
 
-func (verifier *VerifierMockRemediationService) ListResults(repository string, limit int) *MockRemediationService_ListResults_OngoingVerification {
-	_params := []pegomock.Param{repository, limit}
+func (verifier *VerifierMockRemediationService) ListRepositories() *MockRemediationService_ListRepositories_OngoingVerification {
+	_params := []pegomock.Param{}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ListRepositories", _params, verifier.timeout)
+	return &MockRemediationService_ListRepositories_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockRemediationService_ListRepositories_OngoingVerification struct {
+	mock              *MockRemediationService
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockRemediationService_ListRepositories_OngoingVerification) GetCapturedArguments() {
+}
+
+func (c *MockRemediationService_ListRepositories_OngoingVerification) GetAllCapturedArguments() {
+}
+
+func (verifier *VerifierMockRemediationService) ListResults(repository string, opts drift.ListResultsOptions) *MockRemediationService_ListResults_OngoingVerification {
+	_params := []pegomock.Param{repository, opts}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ListResults", _params, verifier.timeout)
 	return &MockRemediationService_ListResults_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
 }
@@ -160,12 +196,12 @@ type MockRemediationService_ListResults_OngoingVerification struct {
 	methodInvocations []pegomock.MethodInvocation
 }
 
-func (c *MockRemediationService_ListResults_OngoingVerification) GetCapturedArguments() (string, int) {
-	repository, limit := c.GetAllCapturedArguments()
-	return repository[len(repository)-1], limit[len(limit)-1]
+func (c *MockRemediationService_ListResults_OngoingVerification) GetCapturedArguments() (string, drift.ListResultsOptions) {
+	repository, opts := c.GetAllCapturedArguments()
+	return repository[len(repository)-1], opts[len(opts)-1]
 }
 
-func (c *MockRemediationService_ListResults_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []int) {
+func (c *MockRemediationService_ListResults_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []drift.ListResultsOptions) {
 	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
 	if len(_params) > 0 {
 		if len(_params) > 0 {
@@ -175,9 +211,9 @@ func (c *MockRemediationService_ListResults_OngoingVerification) GetAllCapturedA
 			}
 		}
 		if len(_params) > 1 {
-			_param1 = make([]int, len(c.methodInvocations))
+			_param1 = make([]drift.ListResultsOptions, len(c.methodInvocations))
 			for u, param := range _params[1] {
-				_param1[u] = param.(int)
+				_param1[u] = param.(drift.ListResultsOptions)
 			}
 		}
 	}
@@ -6,6 +6,13 @@
 package drift
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/runatlantis/atlantis/server/events/models"
 )
 
@@ -37,3 +44,189 @@ func (p *Parser) HasDrift(plan *models.PlanSuccess) bool {
 	stats := plan.Stats()
 	return stats.Add > 0 || stats.Change > 0 || stats.Destroy > 0 || stats.Import > 0 || stats.Forget > 0 || stats.ChangesOutside
 }
+
+// planJSON is the subset of the `terraform show -json` plan representation
+// needed to describe resource drift.
+type planJSON struct {
+	ResourceChanges []resourceChangeJSON `json:"resource_changes"`
+	ResourceDrift   []resourceChangeJSON `json:"resource_drift"`
+}
+
+type resourceChangeJSON struct {
+	Address string `json:"address"`
+	Change  struct {
+		Actions      []string        `json:"actions"`
+		Before       json.RawMessage `json:"before"`
+		After        json.RawMessage `json:"after"`
+		AfterUnknown json.RawMessage `json:"after_unknown"`
+		Importing    json.RawMessage `json:"importing"`
+	} `json:"change"`
+}
+
+// ParsePlanJSON extracts the resources a plan would change from the output of
+// `terraform show -json` on the plan file. Resources Terraform detected as
+// changed outside of Terraform are included even if the plan won't change
+// them, e.g. for refresh-only plans.
+func (p *Parser) ParsePlanJSON(data []byte) ([]models.ResourceDrift, error) {
+	var plan planJSON
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("parsing plan JSON: %w", err)
+	}
+
+	var resources []models.ResourceDrift
+	seen := map[string]struct{}{}
+	add := func(changes []resourceChangeJSON, outside bool) {
+		for _, rc := range changes {
+			if _, ok := seen[rc.Address]; ok {
+				continue
+			}
+			action := resourceAction(rc)
+			if action == "" {
+				continue
+			}
+			seen[rc.Address] = struct{}{}
+			resource := models.ResourceDrift{
+				Address: rc.Address,
+				Action:  action,
+				Outside: outside,
+			}
+			if action == "update" || action == "replace" {
+				resource.ChangedPaths = changedPaths(rc)
+				resource.ChangedAttributes = topLevelAttributes(resource.ChangedPaths)
+			}
+			resources = append(resources, resource)
+		}
+	}
+	add(plan.ResourceChanges, false)
+	add(plan.ResourceDrift, true)
+	return resources, nil
+}
+
+// resourceAction returns the drift action for a resource change, or "" if the
+// resource isn't changing.
+func resourceAction(rc resourceChangeJSON) string {
+	actions := rc.Change.Actions
+	switch {
+	case slices.Equal(actions, []string{"create"}):
+		return "create"
+	case slices.Equal(actions, []string{"update"}):
+		return "update"
+	case slices.Equal(actions, []string{"delete"}):
+		return "delete"
+	case slices.Equal(actions, []string{"delete", "create"}), slices.Equal(actions, []string{"create", "delete"}):
+		return "replace"
+	case slices.Equal(actions, []string{"forget"}):
+		return "forget"
+	case slices.Equal(actions, []string{"no-op"}) && isSetJSON(rc.Change.Importing):
+		return "import"
+	default:
+		return ""
+	}
+}
+
+// changedPaths returns the sorted paths of the values that differ between
+// the before and after values of a change, or that won't be known until
+// apply. Paths join attribute names, map keys and list indexes with dots,
+// e.g. tags.CostCenter or scaling_config.0.desired_size, and end at the
+// deepest value that changed.
+func changedPaths(rc resourceChangeJSON) []string {
+	var before, after, afterUnknown map[string]any
+	// Values that aren't objects have no attributes to compare.
+	_ = json.Unmarshal(rc.Change.Before, &before)
+	_ = json.Unmarshal(rc.Change.After, &after)
+	_ = json.Unmarshal(rc.Change.AfterUnknown, &afterUnknown)
+
+	changed := map[string]struct{}{}
+	for name := range before {
+		diffPaths(name, before[name], after[name], changed)
+	}
+	for name, value := range after {
+		if _, ok := before[name]; !ok {
+			diffPaths(name, nil, value, changed)
+		}
+	}
+	for name, unknown := range afterUnknown {
+		unknownPaths(name, unknown, changed)
+	}
+
+	paths := make([]string, 0, len(changed))
+	for path := range changed {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// diffPaths adds the paths below path whose values differ between before and
+// after to changed. Objects and lists are compared element by element, with a
+// missing or null one treated as empty.
+func diffPaths(path string, before, after any, changed map[string]struct{}) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if (beforeIsMap || before == nil) && (afterIsMap || after == nil) && (beforeIsMap || afterIsMap) {
+		for key, value := range beforeMap {
+			diffPaths(path+"."+key, value, afterMap[key], changed)
+		}
+		for key, value := range afterMap {
+			if _, ok := beforeMap[key]; !ok {
+				diffPaths(path+"."+key, nil, value, changed)
+			}
+		}
+		return
+	}
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if (beforeIsList || before == nil) && (afterIsList || after == nil) && (beforeIsList || afterIsList) {
+		for i := range max(len(beforeList), len(afterList)) {
+			var beforeValue, afterValue any
+			if i < len(beforeList) {
+				beforeValue = beforeList[i]
+			}
+			if i < len(afterList) {
+				afterValue = afterList[i]
+			}
+			diffPaths(path+"."+strconv.Itoa(i), beforeValue, afterValue, changed)
+		}
+		return
+	}
+	changed[path] = struct{}{}
+}
+
+// unknownPaths adds the paths below path that an after_unknown value marks as
+// unknown to changed. Nested attributes are represented as objects or lists
+// of bools.
+func unknownPaths(path string, unknown any, changed map[string]struct{}) {
+	switch v := unknown.(type) {
+	case bool:
+		if v {
+			changed[path] = struct{}{}
+		}
+	case map[string]any:
+		for key, nested := range v {
+			unknownPaths(path+"."+key, nested, changed)
+		}
+	case []any:
+		for i, nested := range v {
+			unknownPaths(path+"."+strconv.Itoa(i), nested, changed)
+		}
+	}
+}
+
+// topLevelAttributes returns the sorted, distinct top-level attribute names
+// of paths.
+func topLevelAttributes(paths []string) []string {
+	var names []string
+	for _, path := range paths {
+		name, _, _ := strings.Cut(path, ".")
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func isSetJSON(raw json.RawMessage) bool {
+	return len(raw) > 0 && string(raw) != "null"
+}
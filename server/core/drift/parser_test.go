@@ -147,3 +147,111 @@ func TestParser_ParsePlanStats(t *testing.T) {
 	Equals(t, true, result.ChangesOutside)
 	Equals(t, "Plan: 1 to import, 5 to add, 3 to change, 2 to destroy, 4 to forget", result.Summary)
 }
+
+func TestParser_ParsePlanJSON(t *testing.T) {
+	planJSON := `{
+  "format_version": "1.2",
+  "resource_drift": [
+    {
+      "address": "aws_security_group.web",
+      "change": {"actions": ["update"], "before": {"ingress": [80]}, "after": {"ingress": [80, 22]}}
+    },
+    {
+      "address": "aws_s3_bucket.logs",
+      "change": {"actions": ["update"], "before": {"tags": {}}, "after": {"tags": {"owner": "ops"}}}
+    }
+  ],
+  "resource_changes": [
+    {
+      "address": "aws_security_group.web",
+      "change": {
+        "actions": ["update"],
+        "before": {"name": "web", "ingress": [80, 22], "description": "old"},
+        "after": {"name": "web", "ingress": [80], "description": "new"},
+        "after_unknown": {"ingress": [false], "arn": true}
+      }
+    },
+    {
+      "address": "aws_instance.web",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"ami": "ami-1", "tags": {"a": "b"}},
+        "after": {"ami": "ami-2", "tags": {"a": "b"}},
+        "after_unknown": {"id": true, "tags": {}}
+      }
+    },
+    {"address": "aws_iam_role.new", "change": {"actions": ["create"], "before": null, "after": {"name": "new"}}},
+    {"address": "aws_iam_role.old", "change": {"actions": ["delete"], "before": {"name": "old"}, "after": null}},
+    {"address": "aws_iam_role.imported", "change": {"actions": ["no-op"], "importing": {"id": "role"}}},
+    {"address": "aws_iam_role.forgotten", "change": {"actions": ["forget"]}},
+    {"address": "aws_iam_role.unchanged", "change": {"actions": ["no-op"]}},
+    {"address": "data.aws_caller_identity.current", "change": {"actions": ["read"]}}
+  ]
+}`
+
+	resources, err := drift.NewParser().ParsePlanJSON([]byte(planJSON))
+	Ok(t, err)
+	Equals(t, []models.ResourceDrift{
+		{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"arn", "description", "ingress"}, ChangedPaths: []string{"arn", "description", "ingress.1"}},
+		{Address: "aws_instance.web", Action: "replace", ChangedAttributes: []string{"ami", "id"}, ChangedPaths: []string{"ami", "id"}},
+		{Address: "aws_iam_role.new", Action: "create"},
+		{Address: "aws_iam_role.old", Action: "delete"},
+		{Address: "aws_iam_role.imported", Action: "import"},
+		{Address: "aws_iam_role.forgotten", Action: "forget"},
+		{Address: "aws_s3_bucket.logs", Action: "update", ChangedAttributes: []string{"tags"}, ChangedPaths: []string{"tags.owner"}, Outside: true},
+	}, resources)
+}
+
+func TestParser_ParsePlanJSONNestedPaths(t *testing.T) {
+	planJSON := `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {
+      "address": "aws_eks_node_group.workers",
+      "change": {
+        "actions": ["update"],
+        "before": {
+          "tags": {"CostCenter": "a", "Team": "ops", "Old": "x"},
+          "scaling_config": [{"desired_size": 2, "max_size": 5}],
+          "labels": null,
+          "taints": [{"key": "a"}]
+        },
+        "after": {
+          "tags": {"CostCenter": "b", "Team": "ops"},
+          "scaling_config": [{"desired_size": 3, "max_size": 5}],
+          "labels": {"role": "worker"},
+          "taints": []
+        },
+        "after_unknown": {"tags": {}, "update_config": [{"max_unavailable": true}]}
+      }
+    }
+  ]
+}`
+
+	resources, err := drift.NewParser().ParsePlanJSON([]byte(planJSON))
+	Ok(t, err)
+	Equals(t, []models.ResourceDrift{{
+		Address:           "aws_eks_node_group.workers",
+		Action:            "update",
+		ChangedAttributes: []string{"labels", "scaling_config", "tags", "taints", "update_config"},
+		ChangedPaths: []string{
+			"labels.role",
+			"scaling_config.0.desired_size",
+			"tags.CostCenter",
+			"tags.Old",
+			"taints.0.key",
+			"update_config.0.max_unavailable",
+		},
+	}}, resources)
+}
+
+func TestParser_ParsePlanJSONNoChanges(t *testing.T) {
+	resources, err := drift.NewParser().ParsePlanJSON([]byte(`{"format_version": "1.2"}`))
+	Ok(t, err)
+	Equals(t, 0, len(resources))
+}
+
+func TestParser_ParsePlanJSONInvalid(t *testing.T) {
+	_, err := drift.NewParser().ParsePlanJSON([]byte("Version: 0.11.0 is unsupported"))
+	ErrContains(t, "parsing plan JSON", err)
+}
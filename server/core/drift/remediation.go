@@ -7,8 +7,10 @@ package drift
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"slices"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +19,23 @@ import (
 
 const defaultWorkspace = "default"
 
+const (
+	// remediationFileName is the name of the file that remediation pull
+	// requests add to every drifted project dir, so autoplan plans them.
+	remediationFileName = "atlantis_drift_remediation.tf"
+	// maxRemediationPullRequestBodyLength is the maximum length of the body of
+	// remediation pull requests. Azure DevOps rejects descriptions longer than
+	// this, the lowest limit of the supported VCS hosts.
+	maxRemediationPullRequestBodyLength = 4000
+	// minRemediationPlanOutputLength is the shortest plan output excerpt
+	// worth adding to a remediation pull request body.
+	minRemediationPlanOutputLength = 200
+)
+
+// remediationURLCredentialRE matches credentials in URLs, e.g. in module
+// sources, so they are redacted from plan output in pull request bodies.
+var remediationURLCredentialRE = regexp.MustCompile(`(?i)(https?://)([^\s/@]+:)?[^\s/@]+@`)
+
 // RemediationService handles drift remediation operations.
 type RemediationService interface {
 	// Remediate executes drift remediation for the given projects.
@@ -26,8 +45,13 @@ type RemediationService interface {
 	// GetResult retrieves a remediation result by ID.
 	GetResult(id string) (*models.RemediationResult, error)
 
-	// ListResults returns all remediation results for a repository.
-	ListResults(repository string, limit int) ([]*models.RemediationResult, error)
+	// ListResults returns remediation results for a repository, newest first,
+	// filtered and paginated according to opts.
+	ListResults(repository string, opts ListResultsOptions) ([]*models.RemediationResult, error)
+
+	// ListRepositories returns the repositories that have remediation
+	// results, whether or not they still have drift.
+	ListRepositories() ([]string, error)
 }
 
 // RemediationExecutor executes the actual plan/apply operations.
@@ -38,33 +62,52 @@ type RemediationExecutor interface {
 
 	// ExecuteApplyProjects plans and applies the given projects as one operation.
 	ExecuteApplyProjects(repository, ref, vcsType string, projects []models.ProjectDrift) ([]models.ProjectRemediationResult, error)
+
+	// OpenPullRequest opens a pull request against the repository being remediated.
+	OpenPullRequest(opts models.CreatePullRequestOptions) (models.PullRequest, error)
 }
 
-// InMemoryRemediationService implements RemediationService with in-memory storage.
-type InMemoryRemediationService struct {
-	mu           sync.RWMutex
-	results      map[string]*models.RemediationResult
-	repoResults  map[string][]string // repository -> result IDs
+// RemediationURLGenerator generates URLs to view remediation results.
+type RemediationURLGenerator interface {
+	// GenerateRemediationURL returns the full URL to the remediation with the
+	// given id.
+	GenerateRemediationURL(id string) (string, error)
+}
+
+// DefaultRemediationService implements RemediationService, recording results
+// in a RemediationStore.
+type DefaultRemediationService struct {
+	store        RemediationStore
 	driftStorage Storage
+	metrics      *Metrics
+	urlGenerator RemediationURLGenerator
 }
 
-// NewInMemoryRemediationService creates a new in-memory remediation service.
-func NewInMemoryRemediationService(driftStorage Storage) *InMemoryRemediationService {
-	return &InMemoryRemediationService{
-		results:      make(map[string]*models.RemediationResult),
-		repoResults:  make(map[string][]string),
+// NewRemediationService creates a remediation service that records results in
+// store. metrics and urlGenerator may be nil.
+func NewRemediationService(driftStorage Storage, store RemediationStore, metrics *Metrics, urlGenerator RemediationURLGenerator) *DefaultRemediationService {
+	return &DefaultRemediationService{
+		store:        store,
 		driftStorage: driftStorage,
+		metrics:      metrics,
+		urlGenerator: urlGenerator,
 	}
 }
 
+// NewInMemoryRemediationService creates a remediation service whose results
+// are kept in memory and lost on restart.
+func NewInMemoryRemediationService(driftStorage Storage) *DefaultRemediationService {
+	return NewRemediationService(driftStorage, NewInMemoryRemediationStore(), nil, nil)
+}
+
 // Remediate executes drift remediation for the given projects.
-func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, executor RemediationExecutor) (*models.RemediationResult, error) {
+func (s *DefaultRemediationService) Remediate(req models.RemediationRequest, executor RemediationExecutor) (*models.RemediationResult, error) {
 	// Validate action, default to plan-only
 	if req.Action == "" {
 		req.Action = models.RemediationPlanOnly
 	}
-	if req.Action != models.RemediationPlanOnly && req.Action != models.RemediationAutoApply {
-		return nil, fmt.Errorf("invalid action: %s, must be 'plan' or 'apply'", req.Action)
+	if !req.Action.IsValid() {
+		return nil, fmt.Errorf("invalid action: %s, must be 'plan', 'apply' or 'pr'", req.Action)
 	}
 
 	// Generate unique ID
@@ -76,7 +119,9 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 	result.Status = models.RemediationStatusRunning
 
 	// Store initial result
-	s.storeResult(result)
+	if err := s.storeResult(result); err != nil {
+		return nil, err
+	}
 
 	// Get projects to remediate
 	projects, err := s.getProjectsToRemediate(req)
@@ -85,8 +130,7 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 		result.Status = models.RemediationStatusFailed
 		completedAt := time.Now()
 		result.CompletedAt = &completedAt
-		s.storeResult(result)
-		return result, nil
+		return result, s.finishResult(result)
 	}
 	projects, err = deduplicateRemediationTargets(req, projects)
 	if err != nil {
@@ -94,8 +138,7 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 		result.Status = models.RemediationStatusFailed
 		completedAt := time.Now()
 		result.CompletedAt = &completedAt
-		s.storeResult(result)
-		return result, nil
+		return result, s.finishResult(result)
 	}
 
 	if len(projects) == 0 {
@@ -107,8 +150,7 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 		} else {
 			result.Complete()
 		}
-		s.storeResult(result)
-		return result, nil
+		return result, s.finishResult(result)
 	}
 
 	if req.Action == models.RemediationAutoApply {
@@ -117,8 +159,7 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 				result.AddProjectResult(projectResult)
 			}
 			result.Complete()
-			s.storeResult(result)
-			return result, nil
+			return result, s.finishResult(result)
 		}
 		for _, projectResult := range s.remediateProjectsWithApply(req, projects, executor) {
 			result.AddProjectResult(projectResult)
@@ -133,12 +174,183 @@ func (s *InMemoryRemediationService) Remediate(req models.RemediationRequest, ex
 
 	// Mark as complete
 	result.Complete()
-	s.storeResult(result)
+	if req.Action == models.RemediationPullRequest {
+		if err := s.openRemediationPullRequest(req, result, projects, executor); err != nil {
+			result.Error = err.Error()
+			result.Status = models.RemediationStatusFailed
+		}
+	}
+	return result, s.finishResult(result)
+}
 
-	return result, nil
+// openRemediationPullRequest opens a pull request so the drift that the plans
+// in result would revert can be codified instead. The pull request adds a
+// comment-only Terraform file to every drifted project dir so that autoplan
+// plans them. The body lists the drifted resources that detection cached for
+// projects and an excerpt of every plan, with credentials in URLs redacted;
+// the full output is linked. No pull request is opened if no plan has
+// changes.
+func (s *DefaultRemediationService) openRemediationPullRequest(req models.RemediationRequest, result *models.RemediationResult, projects []models.ProjectDrift, executor RemediationExecutor) error {
+	var drifted []models.ProjectRemediationResult
+	for _, p := range result.Projects {
+		if p.Status == models.RemediationStatusSuccess && p.DriftAfter != nil && p.DriftAfter.HasDrift {
+			drifted = append(drifted, p)
+		}
+	}
+	if len(drifted) == 0 {
+		return nil
+	}
+	resources := make(map[string][]models.ResourceDrift, len(projects))
+	for _, p := range projects {
+		resources[remediationProjectKey(p.ProjectName, p.Path, p.Workspace)] = p.Resources
+	}
+
+	// The result is still readable by ID through the API if the view isn't
+	// available.
+	resultRef := fmt.Sprintf("remediation `%s`", result.ID)
+	if s.urlGenerator != nil {
+		if resultURL, err := s.urlGenerator.GenerateRemediationURL(result.ID); err == nil {
+			resultRef = fmt.Sprintf("[remediation `%s`](%s)", result.ID, resultURL)
+		}
+	}
+
+	baseBranch := remediationBaseBranch(req)
+	pull, err := executor.OpenPullRequest(models.CreatePullRequestOptions{
+		Title:         fmt.Sprintf("Codify drift detected on %s", baseBranch),
+		Body:          remediationPullRequestBody(resultRef, baseBranch, drifted, resources),
+		BaseBranch:    baseBranch,
+		HeadBranch:    "atlantis/drift-remediation/" + result.ID,
+		CommitMessage: fmt.Sprintf("Plan projects that drifted on %s", baseBranch),
+		Files:         remediationPullRequestFiles(result.ID, baseBranch, drifted),
+	})
+	if err != nil {
+		return fmt.Errorf("opening pull request: %w", err)
+	}
+	result.PullRequestNum = pull.Num
+	result.PullRequestURL = pull.URL
+	return nil
 }
 
-func (s *InMemoryRemediationService) remediateProjectsWithApply(req models.RemediationRequest, projects []models.ProjectDrift, executor RemediationExecutor) []models.ProjectRemediationResult {
+// remediationPullRequestBody returns the drift of every project. Every
+// project gets an equal share of maxRemediationPullRequestBodyLength for its
+// plan output, and projects that don't fit are left out.
+func remediationPullRequestBody(resultRef string, baseBranch string, projects []models.ProjectRemediationResult, resources map[string][]models.ResourceDrift) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Atlantis detected drift on `%s`. Update the Terraform code on this branch until `atlantis plan` shows no changes, "+
+		"so the drift is codified instead of reverted. Then delete the `%s` files this pull request adds.\n\n", baseBranch, remediationFileName)
+	fmt.Fprintf(&body, "The full plan output is in %s.\n", resultRef)
+	share := (maxRemediationPullRequestBodyLength - body.Len()) / len(projects)
+	for i, p := range projects {
+		section := remediationProjectSection(p, resources[remediationProjectKey(p.ProjectName, p.Path, p.Workspace)], share)
+		omitted := fmt.Sprintf("\n_%d more projects are in %s._\n", len(projects)-i, resultRef)
+		// Unless this is the last project, leave room to say how many
+		// projects were left out.
+		limit := maxRemediationPullRequestBodyLength
+		if i < len(projects)-1 {
+			limit -= len(omitted)
+		}
+		if body.Len()+len(section) > limit {
+			body.WriteString(omitted)
+			break
+		}
+		body.WriteString(section)
+	}
+	return body.String()
+}
+
+// remediationProjectSection returns the drift summary and drifted resources
+// of p, followed by as much of its plan output as fits in maxLength.
+func remediationProjectSection(p models.ProjectRemediationResult, resources []models.ResourceDrift, maxLength int) string {
+	var section strings.Builder
+	fmt.Fprintf(&section, "\n### dir: `%s` workspace: `%s`", p.Path, p.Workspace)
+	if p.ProjectName != "" {
+		fmt.Fprintf(&section, " project: `%s`", p.ProjectName)
+	}
+	section.WriteString("\n")
+	if summary := remediationSummaryLine(p.DriftAfter.Summary); summary != "" {
+		fmt.Fprintf(&section, "**Drift**: %s\n", summary)
+	}
+	for _, r := range resources {
+		if r.Ignored {
+			continue
+		}
+		fmt.Fprintf(&section, "- `%s` %s", r.Address, r.Action)
+		if len(r.ChangedAttributes) > 0 {
+			fmt.Fprintf(&section, " (`%s`)", strings.Join(r.ChangedAttributes, "`, `"))
+		}
+		if r.Outside {
+			section.WriteString(", changed outside of Terraform")
+		}
+		section.WriteString("\n")
+	}
+
+	const (
+		planOutputStart = "\n<details><summary>Plan output</summary>\n\n```diff\n"
+		planOutputEnd   = "\n```\n</details>\n"
+		truncated       = "\n... (truncated)"
+	)
+	planOutput := strings.TrimSpace(remediationURLCredentialRE.ReplaceAllString(p.PlanOutput, "$1<redacted>@"))
+	available := maxLength - section.Len() - len(planOutputStart) - len(planOutputEnd)
+	if planOutput == "" || available < minRemediationPlanOutputLength {
+		return section.String()
+	}
+	if len(planOutput) > available {
+		planOutput = planOutput[:available-len(truncated)]
+		// Cut at a line break so a line isn't left half-written.
+		if i := strings.LastIndex(planOutput, "\n"); i > 0 {
+			planOutput = planOutput[:i]
+		} else {
+			planOutput = strings.ToValidUTF8(planOutput, "")
+		}
+		planOutput += truncated
+	}
+	section.WriteString(planOutputStart + planOutput + planOutputEnd)
+	return section.String()
+}
+
+// remediationPullRequestFiles returns one remediationFileName file for every
+// dir of projects, describing the drift of the projects in it.
+func remediationPullRequestFiles(id string, baseBranch string, projects []models.ProjectRemediationResult) []models.PullRequestFile {
+	var dirs []string
+	projectsByDir := make(map[string][]models.ProjectRemediationResult)
+	for _, p := range projects {
+		if _, ok := projectsByDir[p.Path]; !ok {
+			dirs = append(dirs, p.Path)
+		}
+		projectsByDir[p.Path] = append(projectsByDir[p.Path], p)
+	}
+
+	files := make([]models.PullRequestFile, 0, len(dirs))
+	for _, dir := range dirs {
+		var content strings.Builder
+		fmt.Fprintf(&content, "# Atlantis detected drift in this directory on %s (remediation %s).\n", baseBranch, id)
+		content.WriteString("# This file only makes Atlantis plan the directory. Update the Terraform code\n")
+		content.WriteString("# until the plan shows no changes, then delete this file.\n")
+		for _, p := range projectsByDir[dir] {
+			content.WriteString("#\n")
+			fmt.Fprintf(&content, "# workspace: %s", p.Workspace)
+			if p.ProjectName != "" {
+				fmt.Fprintf(&content, " project: %s", p.ProjectName)
+			}
+			content.WriteString("\n")
+			if summary := remediationSummaryLine(p.DriftAfter.Summary); summary != "" {
+				fmt.Fprintf(&content, "# %s\n", summary)
+			}
+		}
+		files = append(files, models.PullRequestFile{
+			Path:    path.Join(dir, remediationFileName),
+			Content: content.String(),
+		})
+	}
+	return files
+}
+
+// remediationSummaryLine returns summary on a single line.
+func remediationSummaryLine(summary string) string {
+	return strings.Join(strings.Fields(summary), " ")
+}
+
+func (s *DefaultRemediationService) remediateProjectsWithApply(req models.RemediationRequest, projects []models.ProjectDrift, executor RemediationExecutor) []models.ProjectRemediationResult {
 	results, err := executor.ExecuteApplyProjects(req.Repository, remediationExecutionRef(req), req.Type, projects)
 	if err != nil && len(results) == 0 {
 		return failedProjectRemediationResults(projects, err.Error())
@@ -162,7 +374,7 @@ func failedProjectRemediationResults(projects []models.ProjectDrift, errorMessag
 	return results
 }
 
-func (s *InMemoryRemediationService) completeApplyProjectResults(req models.RemediationRequest, projects []models.ProjectDrift, results []models.ProjectRemediationResult, applyErr error) []models.ProjectRemediationResult {
+func (s *DefaultRemediationService) completeApplyProjectResults(req models.RemediationRequest, projects []models.ProjectDrift, results []models.ProjectRemediationResult, applyErr error) []models.ProjectRemediationResult {
 	projectsByKey := make(map[string]models.ProjectDrift, len(projects))
 	for _, proj := range projects {
 		projectsByKey[remediationProjectKey(proj.ProjectName, proj.Path, proj.Workspace)] = proj
@@ -226,7 +438,7 @@ func (s *InMemoryRemediationService) completeApplyProjectResults(req models.Reme
 }
 
 // getProjectsToRemediate determines which projects to remediate based on the request.
-func (s *InMemoryRemediationService) getProjectsToRemediate(req models.RemediationRequest) ([]models.ProjectDrift, error) {
+func (s *DefaultRemediationService) getProjectsToRemediate(req models.RemediationRequest) ([]models.ProjectDrift, error) {
 	var err error
 	req, err = normalizeRemediationRequest(req)
 	if err != nil {
@@ -507,7 +719,7 @@ func hasRemediationPathTarget(projects []models.ProjectDrift, path string, works
 }
 
 // matchesFilters checks if a project matches the request filters.
-func (s *InMemoryRemediationService) matchesFilters(proj models.ProjectDrift, req models.RemediationRequest) bool {
+func (s *DefaultRemediationService) matchesFilters(proj models.ProjectDrift, req models.RemediationRequest) bool {
 	// Check project name filter
 	if len(req.Projects) > 0 {
 		if !slices.Contains(req.Projects, proj.ProjectName) {
@@ -545,7 +757,7 @@ func (s *InMemoryRemediationService) matchesFilters(proj models.ProjectDrift, re
 }
 
 // remediateProject executes remediation for a single project.
-func (s *InMemoryRemediationService) remediateProject(req models.RemediationRequest, proj models.ProjectDrift, executor RemediationExecutor) models.ProjectRemediationResult {
+func (s *DefaultRemediationService) remediateProject(req models.RemediationRequest, proj models.ProjectDrift, executor RemediationExecutor) models.ProjectRemediationResult {
 	result := models.ProjectRemediationResult{
 		ProjectName: proj.ProjectName,
 		Path:        proj.Path,
@@ -582,24 +794,20 @@ func (s *InMemoryRemediationService) remediateProject(req models.RemediationRequ
 	return result
 }
 
-// storeResult stores a remediation result.
-func (s *InMemoryRemediationService) storeResult(result *models.RemediationResult) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.results[result.ID] = cloneRemediationResult(result)
-
-	repositoryKey := remediationResultRepositoryKey(result)
-
-	// Track by repository
-	if _, ok := s.repoResults[repositoryKey]; !ok {
-		s.repoResults[repositoryKey] = []string{}
-	}
+// finishResult records the metrics of a finished remediation and saves its
+// final result to the store.
+func (s *DefaultRemediationService) finishResult(result *models.RemediationResult) error {
+	s.metrics.RecordRemediation(result)
+	return s.storeResult(result)
+}
 
-	// Check if ID already exists in repo results
-	if !slices.Contains(s.repoResults[repositoryKey], result.ID) {
-		s.repoResults[repositoryKey] = append(s.repoResults[repositoryKey], result.ID)
+// storeResult saves a remediation result to the store, replacing any earlier
+// version of it.
+func (s *DefaultRemediationService) storeResult(result *models.RemediationResult) error {
+	if err := s.store.Save(result); err != nil {
+		return fmt.Errorf("storing remediation result %s: %w", result.ID, err)
 	}
+	return nil
 }
 
 func remediationProjectKey(projectName, path, workspace string) string {
@@ -667,35 +875,25 @@ func remediationResultRepositoryKey(result *models.RemediationResult) string {
 }
 
 // GetResult retrieves a remediation result by ID.
-func (s *InMemoryRemediationService) GetResult(id string) (*models.RemediationResult, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	result, ok := s.results[id]
-	if !ok {
+func (s *DefaultRemediationService) GetResult(id string) (*models.RemediationResult, error) {
+	result, err := s.store.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("loading remediation result %s: %w", id, err)
+	}
+	if result == nil {
 		return nil, fmt.Errorf("remediation result not found: %s", id)
 	}
-	return cloneRemediationResult(result), nil
+	return result, nil
 }
 
-// ListResults returns all remediation results for a repository.
-func (s *InMemoryRemediationService) ListResults(repository string, limit int) ([]*models.RemediationResult, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	ids, ok := s.repoResults[repository]
-	if !ok {
-		return []*models.RemediationResult{}, nil
-	}
-
-	results := make([]*models.RemediationResult, 0, len(ids))
-	for i := len(ids) - 1; i >= 0 && (limit <= 0 || len(results) < limit); i-- {
-		if result, ok := s.results[ids[i]]; ok {
-			results = append(results, cloneRemediationResult(result))
-		}
-	}
+// ListResults returns remediation results for a repository, newest first.
+func (s *DefaultRemediationService) ListResults(repository string, opts ListResultsOptions) ([]*models.RemediationResult, error) {
+	return s.store.List(repository, opts)
+}
 
-	return results, nil
+// ListRepositories returns the repositories that have remediation results.
+func (s *DefaultRemediationService) ListRepositories() ([]string, error) {
+	return s.store.Repositories()
 }
 
 // RemediationHistory represents the history of remediations for tracking.
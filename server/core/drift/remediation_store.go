@@ -0,0 +1,179 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// RemediationStore persists remediation results so they remain queryable
+// across restarts and replicas.
+// Implementations must preserve RemediationResult.StorageRepository, which is
+// not part of the result's JSON encoding.
+type RemediationStore interface {
+	// Save creates or replaces the result with the same ID.
+	Save(result *models.RemediationResult) error
+
+	// Get returns the result with the given ID, or nil if it does not exist.
+	Get(id string) (*models.RemediationResult, error)
+
+	// List returns results for a repository, newest first, filtered and
+	// paginated according to opts.
+	List(repository string, opts ListResultsOptions) ([]*models.RemediationResult, error)
+
+	// Repositories returns the repositories that have results, in no
+	// particular order. It may include repositories whose results have all
+	// expired.
+	Repositories() ([]string, error)
+}
+
+// ListResultsOptions filters and paginates remediation history.
+type ListResultsOptions struct {
+	// Limit is the maximum number of results to return. Zero means no limit.
+	Limit int
+	// Offset is the number of matching results to skip.
+	Offset int
+	// Statuses keeps only results with one of these statuses.
+	Statuses []models.RemediationStatus
+	// Actions keeps only results with one of these actions.
+	Actions []models.RemediationAction
+	// StartedAfter keeps only results started at or after this time.
+	StartedAfter time.Time
+	// StartedBefore keeps only results started before this time.
+	StartedBefore time.Time
+}
+
+// Matches reports whether result satisfies the filters in o.
+func (o ListResultsOptions) Matches(result *models.RemediationResult) bool {
+	if len(o.Statuses) > 0 && !slices.Contains(o.Statuses, result.Status) {
+		return false
+	}
+	if len(o.Actions) > 0 && !slices.Contains(o.Actions, result.Action) {
+		return false
+	}
+	if !o.StartedAfter.IsZero() && result.StartedAt.Before(o.StartedAfter) {
+		return false
+	}
+	if !o.StartedBefore.IsZero() && !result.StartedAt.Before(o.StartedBefore) {
+		return false
+	}
+	return true
+}
+
+// ResultCollector applies ListResultsOptions to results visited newest first.
+// Store implementations use it so filtering and pagination behave the same
+// across backends.
+type ResultCollector struct {
+	opts    ListResultsOptions
+	skipped int
+	// Results holds the collected page.
+	Results []*models.RemediationResult
+}
+
+// NewResultCollector returns a collector for opts.
+func NewResultCollector(opts ListResultsOptions) *ResultCollector {
+	return &ResultCollector{
+		opts:    opts,
+		Results: []*models.RemediationResult{},
+	}
+}
+
+// Add offers the next result and reports whether the page is full, in which
+// case the caller can stop iterating.
+func (c *ResultCollector) Add(result *models.RemediationResult) bool {
+	if c.Full() {
+		return true
+	}
+	if !c.opts.Matches(result) {
+		return false
+	}
+	if c.skipped < c.opts.Offset {
+		c.skipped++
+		return false
+	}
+	c.Results = append(c.Results, result)
+	return c.Full()
+}
+
+// Full reports whether Limit results have been collected.
+func (c *ResultCollector) Full() bool {
+	return c.opts.Limit > 0 && len(c.Results) >= c.opts.Limit
+}
+
+// InMemoryRemediationStore keeps remediation results in memory.
+// Results are lost on server restart.
+type InMemoryRemediationStore struct {
+	mu          sync.RWMutex
+	results     map[string]*models.RemediationResult
+	repoResults map[string][]string // repository -> result IDs, oldest first
+}
+
+// NewInMemoryRemediationStore creates a new in-memory remediation store.
+func NewInMemoryRemediationStore() *InMemoryRemediationStore {
+	return &InMemoryRemediationStore{
+		results:     make(map[string]*models.RemediationResult),
+		repoResults: make(map[string][]string),
+	}
+}
+
+// Save stores a copy of result.
+func (s *InMemoryRemediationStore) Save(result *models.RemediationResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.results[result.ID] = cloneRemediationResult(result)
+
+	repositoryKey := remediationResultRepositoryKey(result)
+	if !slices.Contains(s.repoResults[repositoryKey], result.ID) {
+		s.repoResults[repositoryKey] = append(s.repoResults[repositoryKey], result.ID)
+	}
+	return nil
+}
+
+// Get returns a copy of the result with the given ID, or nil.
+func (s *InMemoryRemediationStore) Get(id string) (*models.RemediationResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result, ok := s.results[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneRemediationResult(result), nil
+}
+
+// List returns copies of the results for a repository, newest first.
+func (s *InMemoryRemediationStore) List(repository string, opts ListResultsOptions) ([]*models.RemediationResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	collector := NewResultCollector(opts)
+	ids := s.repoResults[repository]
+	for i := len(ids) - 1; i >= 0; i-- {
+		result, ok := s.results[ids[i]]
+		if !ok {
+			continue
+		}
+		if collector.Add(cloneRemediationResult(result)) {
+			break
+		}
+	}
+	return collector.Results, nil
+}
+
+// Repositories returns the repositories that have results.
+func (s *InMemoryRemediationStore) Repositories() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	repositories := make([]string, 0, len(s.repoResults))
+	for repository := range s.repoResults {
+		repositories = append(repositories, repository)
+	}
+	return repositories, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newStoredRemediation(id string, action models.RemediationAction, status models.RemediationStatus, startedAt time.Time) *models.RemediationResult {
+	result := models.NewRemediationResult(id, "acme/infra", "main", action)
+	result.StorageRepository = "github.com/acme/infra"
+	result.Status = status
+	result.StartedAt = startedAt
+	return result
+}
+
+func TestInMemoryRemediationStore_List(t *testing.T) {
+	store := drift.NewInMemoryRemediationStore()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	Ok(t, store.Save(newStoredRemediation("1", models.RemediationPlanOnly, models.RemediationStatusSuccess, base)))
+	Ok(t, store.Save(newStoredRemediation("2", models.RemediationAutoApply, models.RemediationStatusFailed, base.Add(time.Hour))))
+	Ok(t, store.Save(newStoredRemediation("3", models.RemediationAutoApply, models.RemediationStatusSuccess, base.Add(2*time.Hour))))
+	Ok(t, store.Save(newStoredRemediation("4", models.RemediationAutoApply, models.RemediationStatusPartial, base.Add(3*time.Hour))))
+
+	ids := func(results []*models.RemediationResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	cases := []struct {
+		name string
+		opts drift.ListResultsOptions
+		exp  []string
+	}{
+		{"all newest first", drift.ListResultsOptions{}, []string{"4", "3", "2", "1"}},
+		{"limit", drift.ListResultsOptions{Limit: 2}, []string{"4", "3"}},
+		{"offset", drift.ListResultsOptions{Limit: 2, Offset: 2}, []string{"2", "1"}},
+		{"status", drift.ListResultsOptions{Statuses: []models.RemediationStatus{models.RemediationStatusSuccess}}, []string{"3", "1"}},
+		{"action with offset", drift.ListResultsOptions{Actions: []models.RemediationAction{models.RemediationAutoApply}, Offset: 1}, []string{"3", "2"}},
+		{"time range", drift.ListResultsOptions{StartedAfter: base.Add(time.Hour), StartedBefore: base.Add(3 * time.Hour)}, []string{"3", "2"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			results, err := store.List("github.com/acme/infra", c.opts)
+			Ok(t, err)
+			Equals(t, c.exp, ids(results))
+		})
+	}
+}
+
+func TestInMemoryRemediationStore_Repositories(t *testing.T) {
+	store := drift.NewInMemoryRemediationStore()
+	repositories, err := store.Repositories()
+	Ok(t, err)
+	Equals(t, []string{}, repositories)
+
+	Ok(t, store.Save(newStoredRemediation("1", models.RemediationPlanOnly, models.RemediationStatusSuccess, time.Now())))
+	Ok(t, store.Save(newStoredRemediation("2", models.RemediationPlanOnly, models.RemediationStatusSuccess, time.Now())))
+	repositories, err = store.Repositories()
+	Ok(t, err)
+	Equals(t, []string{"github.com/acme/infra"}, repositories)
+}
+
+func TestInMemoryRemediationStore_GetMissing(t *testing.T) {
+	store := drift.NewInMemoryRemediationStore()
+	result, err := store.Get("missing")
+	Ok(t, err)
+	Assert(t, result == nil, "expected nil result")
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/scheduled"
+)
+
+// SchedulerPeriod is how often the Scheduler job should be run. Cron
+// schedules have minute granularity so a shorter period only reduces how late
+// a detection can start.
+const SchedulerPeriod = 30 * time.Second
+
+// SchedulerJobName names the Scheduler's job so only one replica runs it with
+// distributed execution.
+const SchedulerJobName = "drift-scheduler"
+
+// Detector runs a single drift detection. It is implemented by the API
+// controller so scheduled detections behave exactly like POST
+// /api/drift/detect requests.
+//
+//go:generate go tool pegomock generate --package mocks -o mocks/mock_detector.go Detector
+type Detector interface {
+	RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error)
+}
+
+// Scheduler runs drift detection for the schedules configured in the
+// server-side repo config. It is meant to be run by the scheduled executor
+// service every SchedulerPeriod.
+//
+// With distributed execution only the replica holding the scheduler's lease
+// runs detections; the others call Skip.
+type Scheduler struct {
+	detector Detector
+	logger   logging.SimpleLogging
+	jitter   time.Duration
+	// slots limits how many detections run at once across all schedules.
+	slots   chan struct{}
+	entries []*schedulerEntry
+	wg      sync.WaitGroup
+}
+
+var _ scheduled.Skipper = (*Scheduler)(nil)
+
+type schedulerEntry struct {
+	request models.DriftDetectionRequest
+	cron    *scheduled.CronSchedule
+	next    time.Time
+	// running is set while a detection for this entry is waiting or running
+	// so a slow detection is not queued again on the next tick.
+	running atomic.Bool
+}
+
+// NewScheduler returns a scheduler for every drift_detection schedule in
+// globalCfg, starting from now.
+func NewScheduler(globalCfg valid.GlobalCfg, detector Detector, logger logging.SimpleLogging, now time.Time) (*Scheduler, error) {
+	maxConcurrency := globalCfg.DriftDetection.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = valid.DefaultDriftDetectionMaxConcurrency
+	}
+	s := &Scheduler{
+		detector: detector,
+		logger:   logger,
+		jitter:   globalCfg.DriftDetection.Jitter,
+		slots:    make(chan struct{}, maxConcurrency),
+	}
+
+	for _, repo := range globalCfg.Repos {
+		if repo.DriftDetection == nil {
+			continue
+		}
+		for _, schedule := range repo.DriftDetection.Schedules {
+			cron, err := scheduled.ParseCron(schedule.Cron)
+			if err != nil {
+				return nil, fmt.Errorf("drift detection schedule for %s: %w", repo.ID, err)
+			}
+			request := models.DriftDetectionRequest{
+				Repository: repo.DriftDetection.Repository,
+				Ref:        "refs/heads/" + schedule.Branch,
+				Type:       repo.DriftDetection.VCSType,
+				Projects:   schedule.Projects,
+			}
+			for _, p := range schedule.Paths {
+				request.Paths = append(request.Paths, models.DriftDetectionPath{Directory: p.Dir, Workspace: p.Workspace})
+			}
+			if errs := request.Validate(); len(errs) > 0 {
+				return nil, fmt.Errorf("drift detection schedule for %s: %s: %s", repo.ID, errs[0].Field, errs[0].Message)
+			}
+			s.entries = append(s.entries, &schedulerEntry{
+				request: request,
+				cron:    cron,
+				next:    cron.Next(now),
+			})
+		}
+	}
+	return s, nil
+}
+
+// Len returns the number of configured schedules.
+func (s *Scheduler) Len() int {
+	return len(s.entries)
+}
+
+// Run starts every detection that is due.
+func (s *Scheduler) Run() {
+	s.RunAt(time.Now())
+}
+
+// RunAt starts every detection due at now. A schedule that fired several
+// times since the last call, for example because the previous detection was
+// still running, is only started once.
+func (s *Scheduler) RunAt(now time.Time) {
+	for _, e := range s.entries {
+		if now.Before(e.next) {
+			continue
+		}
+		e.next = e.cron.Next(now)
+		if !e.running.CompareAndSwap(false, true) {
+			s.logger.Warn("skipping scheduled drift detection for %s@%s: previous run is still in progress",
+				e.request.Repository, models.NormalizeAPIRef(e.request.Ref))
+			continue
+		}
+		s.wg.Go(func() { s.detect(e) })
+	}
+}
+
+// Skip moves every due schedule on without running it. The scheduled
+// executor service calls it when another replica holds the scheduler's lease,
+// so detections that replica already ran aren't repeated if this one takes
+// over.
+func (s *Scheduler) Skip() {
+	s.SkipAt(time.Now())
+}
+
+// SkipAt moves every schedule due at now on to its next run.
+func (s *Scheduler) SkipAt(now time.Time) {
+	for _, e := range s.entries {
+		if !now.Before(e.next) {
+			e.next = e.cron.Next(now)
+		}
+	}
+}
+
+// Wait blocks until all started detections have finished.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) detect(e *schedulerEntry) {
+	defer e.running.Store(false)
+
+	if s.jitter > 0 {
+		time.Sleep(rand.N(s.jitter))
+	}
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	repository, ref := e.request.Repository, models.NormalizeAPIRef(e.request.Ref)
+	s.logger.Info("running scheduled drift detection for %s@%s", repository, ref)
+	result, err := s.detector.RunDriftDetection(e.request)
+	if err != nil {
+		s.logger.Err("scheduled drift detection for %s@%s failed: %s", repository, ref, err)
+		return
+	}
+	s.logger.Info("scheduled drift detection for %s@%s found drift in %d of %d projects",
+		repository, ref, result.ProjectsWithDrift, result.TotalProjects)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drift_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+type recordingDetector struct {
+	mu            sync.Mutex
+	requests      []models.DriftDetectionRequest
+	running       int
+	maxRunning    int
+	release       chan struct{}
+	started       chan struct{}
+	detectionTime time.Duration
+}
+
+func (d *recordingDetector) RunDriftDetection(request models.DriftDetectionRequest) (*models.DriftDetectionResult, error) {
+	d.mu.Lock()
+	d.requests = append(d.requests, request)
+	d.running++
+	d.maxRunning = max(d.maxRunning, d.running)
+	d.mu.Unlock()
+
+	if d.started != nil {
+		d.started <- struct{}{}
+	}
+	if d.release != nil {
+		<-d.release
+	}
+	time.Sleep(d.detectionTime)
+
+	d.mu.Lock()
+	d.running--
+	d.mu.Unlock()
+	return models.NewDriftDetectionResult(request.Repository), nil
+}
+
+func scheduledRepo(id string, schedules ...valid.DriftSchedule) valid.Repo {
+	return valid.Repo{
+		ID: "github.com/" + id,
+		DriftDetection: &valid.RepoDriftDetection{
+			Repository: id,
+			VCSType:    "Github",
+			Schedules:  schedules,
+		},
+	}
+}
+
+func TestScheduler_RunsDueSchedulesOnce(t *testing.T) {
+	start := time.Date(2025, 1, 15, 1, 0, 0, 0, time.UTC)
+	detector := &recordingDetector{}
+	globalCfg := valid.GlobalCfg{
+		Repos: []valid.Repo{
+			{ID: "github.com/owner/unscheduled"},
+			scheduledRepo("owner/repo", valid.DriftSchedule{
+				Cron:     "0 2 * * *",
+				Branch:   "main",
+				Projects: []string{"app"},
+			}),
+		},
+	}
+	s, err := drift.NewScheduler(globalCfg, detector, logging.NewNoopLogger(t), start)
+	Ok(t, err)
+	Equals(t, 1, s.Len())
+
+	s.RunAt(start.Add(30 * time.Minute))
+	s.Wait()
+	Equals(t, 0, len(detector.requests))
+
+	s.RunAt(start.Add(time.Hour + 10*time.Second))
+	s.Wait()
+	s.RunAt(start.Add(time.Hour + 40*time.Second))
+	s.Wait()
+	Equals(t, []models.DriftDetectionRequest{{
+		Repository: "owner/repo",
+		Ref:        "refs/heads/main",
+		Type:       "Github",
+		Projects:   []string{"app"},
+	}}, detector.requests)
+
+	// The next day's run fires even if the tick is late.
+	s.RunAt(start.Add(25*time.Hour + 5*time.Minute))
+	s.Wait()
+	Equals(t, 2, len(detector.requests))
+}
+
+func TestScheduler_SkipMovesDueSchedulesOn(t *testing.T) {
+	start := time.Date(2025, 1, 15, 1, 0, 0, 0, time.UTC)
+	detector := &recordingDetector{}
+	globalCfg := valid.GlobalCfg{
+		Repos: []valid.Repo{
+			scheduledRepo("owner/repo", valid.DriftSchedule{Cron: "0 2 * * *", Branch: "main"}),
+		},
+	}
+	s, err := drift.NewScheduler(globalCfg, detector, logging.NewNoopLogger(t), start)
+	Ok(t, err)
+
+	// Another replica ran the 02:00 detection, so taking over later that day
+	// must not run it again.
+	s.SkipAt(start.Add(time.Hour + 10*time.Second))
+	s.RunAt(start.Add(5 * time.Hour))
+	s.Wait()
+	Equals(t, 0, len(detector.requests))
+
+	s.RunAt(start.Add(25 * time.Hour))
+	s.Wait()
+	Equals(t, 1, len(detector.requests))
+}
+
+func TestScheduler_SkipsScheduleStillRunning(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	detector := &recordingDetector{release: make(chan struct{}), started: make(chan struct{})}
+	globalCfg := valid.GlobalCfg{
+		Repos: []valid.Repo{
+			scheduledRepo("owner/repo", valid.DriftSchedule{Cron: "* * * * *", Branch: "main"}),
+		},
+	}
+	s, err := drift.NewScheduler(globalCfg, detector, logging.NewNoopLogger(t), start)
+	Ok(t, err)
+
+	s.RunAt(start.Add(time.Minute))
+	<-detector.started
+	s.RunAt(start.Add(2 * time.Minute))
+	close(detector.release)
+	s.Wait()
+	Equals(t, 1, len(detector.requests))
+}
+
+func TestScheduler_MaxConcurrency(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	detector := &recordingDetector{detectionTime: 10 * time.Millisecond}
+	globalCfg := valid.GlobalCfg{
+		DriftDetection: valid.DriftDetection{MaxConcurrency: 2},
+	}
+	for _, repo := range []string{"owner/a", "owner/b", "owner/c", "owner/d", "owner/e"} {
+		globalCfg.Repos = append(globalCfg.Repos, scheduledRepo(repo, valid.DriftSchedule{Cron: "@hourly", Branch: "main"}))
+	}
+	s, err := drift.NewScheduler(globalCfg, detector, logging.NewNoopLogger(t), start)
+	Ok(t, err)
+
+	s.RunAt(start.Add(time.Hour))
+	s.Wait()
+	Equals(t, 5, len(detector.requests))
+	Equals(t, 2, detector.maxRunning)
+}
+
+func TestNewScheduler_InvalidSchedule(t *testing.T) {
+	globalCfg := valid.GlobalCfg{
+		Repos: []valid.Repo{
+			scheduledRepo("owner/repo", valid.DriftSchedule{
+				Cron:   "@daily",
+				Branch: "main",
+				Paths:  []valid.DriftScheduledPath{{Dir: "../outside"}},
+			}),
+		},
+	}
+	_, err := drift.NewScheduler(globalCfg, &recordingDetector{}, logging.NewNoopLogger(t), time.Now())
+	ErrContains(t, "drift detection schedule for github.com/owner/repo: paths:", err)
+}
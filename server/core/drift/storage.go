@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
 )
 
 // Storage defines the interface for drift status persistence.
@@ -61,19 +62,28 @@ type GetOptions struct {
 	Exact bool
 }
 
-type driftCacheKey struct {
-	ProjectName string
-	Path        string
-	Workspace   string
-	Ref         string
-	BaseBranch  string
+// Compactor is implemented by persistent Storage backends that enforce a
+// retention period. Compact removes drift records whose LastChecked is older
+// than the retention period and returns how many records were removed.
+type Compactor interface {
+	Compact() (int, error)
+}
+
+// RecordKey identifies a single drift record within a repository. Persistent
+// backends serialize it to build stable per-record keys.
+type RecordKey struct {
+	ProjectName string `json:"project_name"`
+	Path        string `json:"path"`
+	Workspace   string `json:"workspace"`
+	Ref         string `json:"ref"`
+	BaseBranch  string `json:"base_branch"`
 }
 
-// driftKey creates a unique key for a project drift entry.
+// KeyFor returns the RecordKey for a drift record.
 // Includes the git ref so the same project on different branches
 // does not overwrite each other's drift data.
-func driftKey(drift models.ProjectDrift) driftCacheKey {
-	return driftCacheKey{
+func KeyFor(drift models.ProjectDrift) RecordKey {
+	return RecordKey{
 		ProjectName: drift.ProjectName,
 		Path:        drift.Path,
 		Workspace:   drift.Workspace,
@@ -87,13 +97,13 @@ func driftKey(drift models.ProjectDrift) driftCacheKey {
 type InMemoryStorage struct {
 	mu sync.RWMutex
 	// data maps repository -> (project identity -> ProjectDrift)
-	data map[string]map[driftCacheKey]models.ProjectDrift
+	data map[string]map[RecordKey]models.ProjectDrift
 }
 
 // NewInMemoryStorage creates a new in-memory drift storage.
 func NewInMemoryStorage() *InMemoryStorage {
 	return &InMemoryStorage{
-		data: make(map[string]map[driftCacheKey]models.ProjectDrift),
+		data: make(map[string]map[RecordKey]models.ProjectDrift),
 	}
 }
 
@@ -106,10 +116,10 @@ func (s *InMemoryStorage) Store(repository string, drift models.ProjectDrift) er
 	drift.PlanOutput = ""
 
 	if s.data[repository] == nil {
-		s.data[repository] = make(map[driftCacheKey]models.ProjectDrift)
+		s.data[repository] = make(map[RecordKey]models.ProjectDrift)
 	}
 
-	key := driftKey(drift)
+	key := KeyFor(drift)
 	s.data[repository][key] = drift
 	return nil
 }
@@ -128,7 +138,7 @@ func (s *InMemoryStorage) Get(repository string, opts GetOptions) ([]models.Proj
 	result := make([]models.ProjectDrift, 0)
 
 	for _, drift := range repoData {
-		if !matchesGetOptions(drift, opts, now) {
+		if !MatchesGetOptions(drift, opts, now) {
 			continue
 		}
 
@@ -138,7 +148,10 @@ func (s *InMemoryStorage) Get(repository string, opts GetOptions) ([]models.Proj
 	return result, nil
 }
 
-func matchesGetOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
+// MatchesGetOptions reports whether a drift record satisfies the Get filters.
+// Empty filter fields act as wildcards. Storage backends share it so they all
+// filter identically.
+func MatchesGetOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
 	if opts.ProjectName != "" && drift.ProjectName != opts.ProjectName {
 		return false
 	}
@@ -201,7 +214,7 @@ func (s *InMemoryStorage) DeleteMatching(repository string, opts GetOptions) err
 	}
 
 	for key, drift := range repoData {
-		if matchesDeleteOptions(drift, opts, time.Now()) {
+		if MatchesDeleteOptions(drift, opts, time.Now()) {
 			delete(repoData, key)
 		}
 	}
@@ -209,9 +222,12 @@ func (s *InMemoryStorage) DeleteMatching(repository string, opts GetOptions) err
 	return nil
 }
 
-func matchesDeleteOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
+// MatchesDeleteOptions reports whether a drift record satisfies the
+// DeleteMatching filters. When opts.Exact is set, empty fields must match
+// empty values rather than acting as wildcards.
+func MatchesDeleteOptions(drift models.ProjectDrift, opts GetOptions, now time.Time) bool {
 	if !opts.Exact {
-		return matchesGetOptions(drift, opts, now)
+		return MatchesGetOptions(drift, opts, now)
 	}
 	if drift.ProjectName != opts.ProjectName {
 		return false
@@ -250,3 +266,22 @@ func (s *InMemoryStorage) GetAll() (map[string][]models.ProjectDrift, error) {
 
 	return result, nil
 }
+
+// CompactionJob periodically removes expired drift records from a Compactor.
+// It is registered with the scheduled executor service.
+type CompactionJob struct {
+	Compactor Compactor
+	Logger    logging.SimpleLogging
+}
+
+// Run compacts the underlying storage once.
+func (j *CompactionJob) Run() {
+	removed, err := j.Compactor.Compact()
+	if err != nil {
+		j.Logger.Err("compacting drift storage: %s", err)
+		return
+	}
+	if removed > 0 {
+		j.Logger.Info("removed %d expired drift records", removed)
+	}
+}
@@ -7,11 +7,13 @@ package locking
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"time"
 
 	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
 )
 
 // TryLockResponse results from an attempted lock.
@@ -22,11 +24,25 @@ type TryLockResponse struct {
 	CurrLock models.ProjectLock
 	// LockKey is an identified by which to lookup and delete this lock.
 	LockKey string
+	// QueuePosition is the 1-based position of the pull request in the queue
+	// for the lock. It is only set if the lock wasn't acquired and the client
+	// queues waiting pull requests.
+	QueuePosition int
+}
+
+// LockQueueHandler is notified when a released lock is handed to the next
+// pull request in its queue.
+type LockQueueHandler interface {
+	// LockReleased is called after lock was released and next was removed
+	// from the front of its queue.
+	LockReleased(lock models.ProjectLock, next models.LockWaiter)
 }
 
 // Client is used to perform locking actions.
 type Client struct {
-	database db.Database
+	database     db.Database
+	queueHandler LockQueueHandler
+	logger       logging.SimpleLogging
 }
 
 //go:generate go tool mockgen -package mocks -destination mocks/mock_locker.go . Locker
@@ -47,6 +63,17 @@ func NewClient(database db.Database) *Client {
 	}
 }
 
+// NewQueueingClient returns a locking client that queues pull requests that
+// fail to acquire a lock, in order, and passes the next pull request in the
+// queue to handler whenever a lock is released.
+func NewQueueingClient(database db.Database, handler LockQueueHandler, logger logging.SimpleLogging) *Client {
+	return &Client{
+		database:     database,
+		queueHandler: handler,
+		logger:       logger,
+	}
+}
+
 // keyRegex matches and captures {repoFullName}/{path}/{workspace}/{projectName} where path can have multiple /'s in it.
 var keyRegex = regexp.MustCompile(`^(.*?\/.*?)\/(.*)\/(.*)\/(.*)$`)
 
@@ -63,7 +90,23 @@ func (c *Client) TryLock(p models.Project, workspace string, pull models.PullReq
 	if err != nil {
 		return TryLockResponse{}, err
 	}
-	return TryLockResponse{lockAcquired, currLock, c.key(p, workspace)}, nil
+	resp := TryLockResponse{LockAcquired: lockAcquired, CurrLock: currLock, LockKey: c.key(p, workspace)}
+	// A pull request never waits for its own lock. API and merge group runs
+	// use synthetic pull numbers that can't be planned again, so they don't
+	// queue either.
+	if !lockAcquired && c.queueHandler != nil && pull.Num > 0 && currLock.Pull.Num != pull.Num {
+		resp.QueuePosition, err = c.database.EnqueueLockWaiter(models.LockWaiter{
+			Project:   p,
+			Workspace: workspace,
+			Pull:      pull,
+			User:      user,
+			Time:      lock.Time,
+		})
+		if err != nil {
+			return TryLockResponse{}, fmt.Errorf("queueing for lock: %w", err)
+		}
+	}
+	return resp, nil
 }
 
 // Unlock attempts to unlock a project and workspace. If successful,
@@ -75,12 +118,22 @@ func (c *Client) Unlock(key string) (*models.ProjectLock, error) {
 	if err != nil {
 		return nil, err
 	}
-	return c.database.Unlock(project, workspace)
+	lock, err := c.database.Unlock(project, workspace)
+	if err != nil || lock == nil {
+		return lock, err
+	}
+	c.handOff(*lock)
+	return lock, nil
 }
 
 // UnlockIfOwnedByPull unlocks project and workspace only when it is still owned by pullNum.
 func (c *Client) UnlockIfOwnedByPull(project models.Project, workspace string, pullNum int) (*models.ProjectLock, error) {
-	return c.database.UnlockIfOwnedByPull(project, workspace, pullNum)
+	lock, err := c.database.UnlockIfOwnedByPull(project, workspace, pullNum)
+	if err != nil || lock == nil {
+		return lock, err
+	}
+	c.handOff(*lock)
+	return lock, nil
 }
 
 // List returns a map of all locks with their lock key as the map key.
@@ -97,9 +150,19 @@ func (c *Client) List() (map[string]models.ProjectLock, error) {
 	return m, nil
 }
 
-// UnlockByPull deletes all locks associated with that pull request.
+// UnlockByPull deletes all locks associated with that pull request. If the
+// client queues pull requests, the pull request also leaves every queue.
 func (c *Client) UnlockByPull(repoFullName string, pullNum int) ([]models.ProjectLock, error) {
-	return c.database.UnlockByPull(repoFullName, pullNum)
+	if c.queueHandler != nil {
+		if err := c.database.RemoveLockWaitersByPull(repoFullName, pullNum); err != nil {
+			c.logger.Warn("removing %s#%d from lock queues: %s", repoFullName, pullNum, err)
+		}
+	}
+	locks, err := c.database.UnlockByPull(repoFullName, pullNum)
+	for _, lock := range locks {
+		c.handOff(lock)
+	}
+	return locks, err
 }
 
 // GetLock attempts to get the lock stored at key. If successful,
@@ -120,6 +183,25 @@ func (c *Client) GetLock(key string) (*models.ProjectLock, error) {
 	return projectLock, nil
 }
 
+// handOff passes the next pull request in the queue for a released lock to
+// the queue handler. The pull request still has to acquire the lock itself,
+// so if another pull request takes it first, it is queued again at the back.
+func (c *Client) handOff(lock models.ProjectLock) {
+	if c.queueHandler == nil {
+		return
+	}
+	next, err := c.database.DequeueLockWaiter(lock.Project, lock.Workspace)
+	if err != nil {
+		c.logger.Warn("getting next pull request in queue for lock %q: %s", c.key(lock.Project, lock.Workspace), err)
+		return
+	}
+	if next == nil {
+		return
+	}
+	c.logger.Info("lock %q was released, handing it to %s#%d", c.key(lock.Project, lock.Workspace), next.Pull.BaseRepo.FullName, next.Pull.Num)
+	c.queueHandler.LockReleased(lock, *next)
+}
+
 func (c *Client) key(p models.Project, workspace string) string {
 	return models.GenerateLockKey(p, workspace)
 }
@@ -140,6 +222,66 @@ func (c *Client) lockKeyToProjectWorkspace(key string) (models.Project, string,
 	return models.Project{RepoFullName: matches[1], Path: matches[2], ProjectName: matches[4]}, matches[3], nil
 }
 
+// LockListener is notified about the locks a NotifyingLocker acquires and
+// releases.
+type LockListener interface {
+	LockAcquired(lock models.ProjectLock)
+	LockReleased(lock models.ProjectLock)
+}
+
+// NotifyingLocker is a Locker that tells its listener whenever the Locker it
+// wraps acquires or releases a lock.
+type NotifyingLocker struct {
+	Locker
+	listener LockListener
+}
+
+// NewNotifyingLocker returns a locker that notifies listener about locker's
+// locks.
+func NewNotifyingLocker(locker Locker, listener LockListener) *NotifyingLocker {
+	return &NotifyingLocker{
+		Locker:   locker,
+		listener: listener,
+	}
+}
+
+// TryLock attempts to acquire a lock to a project and workspace. The listener
+// is only notified if the lock wasn't already held.
+func (n *NotifyingLocker) TryLock(p models.Project, workspace string, pull models.PullRequest, user models.User) (TryLockResponse, error) {
+	resp, err := n.Locker.TryLock(p, workspace, pull, user)
+	if err == nil && resp.LockAcquired {
+		n.listener.LockAcquired(resp.CurrLock)
+	}
+	return resp, err
+}
+
+// Unlock attempts to unlock a project and workspace.
+func (n *NotifyingLocker) Unlock(key string) (*models.ProjectLock, error) {
+	lock, err := n.Locker.Unlock(key)
+	if err == nil && lock != nil {
+		n.listener.LockReleased(*lock)
+	}
+	return lock, err
+}
+
+// UnlockIfOwnedByPull unlocks project and workspace only when it is still owned by pullNum.
+func (n *NotifyingLocker) UnlockIfOwnedByPull(project models.Project, workspace string, pullNum int) (*models.ProjectLock, error) {
+	lock, err := n.Locker.UnlockIfOwnedByPull(project, workspace, pullNum)
+	if err == nil && lock != nil {
+		n.listener.LockReleased(*lock)
+	}
+	return lock, err
+}
+
+// UnlockByPull deletes all locks associated with that pull request.
+func (n *NotifyingLocker) UnlockByPull(repoFullName string, pullNum int) ([]models.ProjectLock, error) {
+	locks, err := n.Locker.UnlockByPull(repoFullName, pullNum)
+	for _, lock := range locks {
+		n.listener.LockReleased(lock)
+	}
+	return locks, err
+}
+
 type NoOpLocker struct{}
 
 // NewNoOpLocker returns a new lno operation lockingclient.
@@ -149,7 +291,7 @@ func NewNoOpLocker() *NoOpLocker {
 
 // TryLock attempts to acquire a lock to a project and workspace.
 func (c *NoOpLocker) TryLock(p models.Project, workspace string, _ models.PullRequest, _ models.User) (TryLockResponse, error) {
-	return TryLockResponse{true, models.ProjectLock{}, c.key(p, workspace)}, nil
+	return TryLockResponse{LockAcquired: true, LockKey: c.key(p, workspace)}, nil
 }
 
 // Unlock attempts to unlock a project and workspace. If successful,
@@ -15,6 +15,7 @@ import (
 	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
 	. "github.com/runatlantis/atlantis/testing"
 	"go.uber.org/mock/gomock"
 )
@@ -141,6 +142,96 @@ func TestUnlockByPull(t *testing.T) {
 	Equals(t, errExpected, err)
 }
 
+type fakeLockQueueHandler struct {
+	released []models.LockWaiter
+}
+
+func (f *fakeLockQueueHandler) LockReleased(_ models.ProjectLock, next models.LockWaiter) {
+	f.released = append(f.released, next)
+}
+
+func TestTryLock_QueuesWaitingPull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	waitingPull := models.PullRequest{Num: 2}
+	currLock := models.ProjectLock{Pull: models.PullRequest{Num: 1}}
+	database.EXPECT().TryLock(gomock.Any()).Return(false, currLock, nil)
+	database.EXPECT().EnqueueLockWaiter(gomock.Any()).DoAndReturn(func(w models.LockWaiter) (int, error) {
+		Equals(t, project, w.Project)
+		Equals(t, workspace, w.Workspace)
+		Equals(t, waitingPull, w.Pull)
+		return 3, nil
+	})
+	l := locking.NewQueueingClient(database, &fakeLockQueueHandler{}, logging.NewNoopLogger(t))
+	r, err := l.TryLock(project, workspace, waitingPull, user)
+	Ok(t, err)
+	Equals(t, locking.TryLockResponse{LockAcquired: false, CurrLock: currLock, LockKey: "owner/repo/path/workspace/projectName", QueuePosition: 3}, r)
+}
+
+func TestTryLock_DoesNotQueueLockOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	currLock := models.ProjectLock{Pull: models.PullRequest{Num: 1}}
+	database.EXPECT().TryLock(gomock.Any()).Return(false, currLock, nil)
+	l := locking.NewQueueingClient(database, &fakeLockQueueHandler{}, logging.NewNoopLogger(t))
+	r, err := l.TryLock(project, workspace, models.PullRequest{Num: 1}, user)
+	Ok(t, err)
+	Equals(t, 0, r.QueuePosition)
+}
+
+func TestTryLock_DoesNotQueueNonPullRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	currLock := models.ProjectLock{Pull: models.PullRequest{Num: 1}}
+	database.EXPECT().TryLock(gomock.Any()).Return(false, currLock, nil)
+	l := locking.NewQueueingClient(database, &fakeLockQueueHandler{}, logging.NewNoopLogger(t))
+	r, err := l.TryLock(project, workspace, models.PullRequest{Num: -1}, user)
+	Ok(t, err)
+	Equals(t, 0, r.QueuePosition)
+}
+
+func TestUnlock_HandsOffToNextWaiter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	next := models.LockWaiter{Project: project, Workspace: workspace, Pull: models.PullRequest{Num: 2}}
+	database.EXPECT().Unlock(project, workspace).Return(&pl, nil)
+	database.EXPECT().DequeueLockWaiter(project, workspace).Return(&next, nil)
+	handler := &fakeLockQueueHandler{}
+	l := locking.NewQueueingClient(database, handler, logging.NewNoopLogger(t))
+	_, err := l.Unlock("owner/repo/path/workspace/projectName")
+	Ok(t, err)
+	Equals(t, []models.LockWaiter{next}, handler.released)
+}
+
+func TestUnlock_EmptyQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	database.EXPECT().UnlockIfOwnedByPull(project, workspace, 1).Return(&pl, nil)
+	database.EXPECT().DequeueLockWaiter(project, workspace).Return(nil, nil)
+	handler := &fakeLockQueueHandler{}
+	l := locking.NewQueueingClient(database, handler, logging.NewNoopLogger(t))
+	_, err := l.UnlockIfOwnedByPull(project, workspace, 1)
+	Ok(t, err)
+	Equals(t, 0, len(handler.released))
+}
+
+func TestUnlockByPull_LeavesQueuesAndHandsOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	next := models.LockWaiter{Project: project, Workspace: workspace, Pull: models.PullRequest{Num: 2}}
+	gomock.InOrder(
+		database.EXPECT().RemoveLockWaitersByPull("owner/repo", 1).Return(nil),
+		database.EXPECT().UnlockByPull("owner/repo", 1).Return([]models.ProjectLock{pl}, nil),
+		database.EXPECT().DequeueLockWaiter(project, workspace).Return(&next, nil),
+	)
+	handler := &fakeLockQueueHandler{}
+	l := locking.NewQueueingClient(database, handler, logging.NewNoopLogger(t))
+	locks, err := l.UnlockByPull("owner/repo", 1)
+	Ok(t, err)
+	Equals(t, []models.ProjectLock{pl}, locks)
+	Equals(t, []models.LockWaiter{next}, handler.released)
+}
+
 func TestGetLock_BadKey(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	database := mocks.NewMockDatabase(ctrl)
@@ -213,6 +304,60 @@ func TestGetLock_NoOpLocker(t *testing.T) {
 	Equals(t, expected, lock)
 }
 
+// recordingLockListener records the locks it's notified about.
+type recordingLockListener struct {
+	acquired []models.ProjectLock
+	released []models.ProjectLock
+}
+
+func (r *recordingLockListener) LockAcquired(lock models.ProjectLock) {
+	r.acquired = append(r.acquired, lock)
+}
+
+func (r *recordingLockListener) LockReleased(lock models.ProjectLock) {
+	r.released = append(r.released, lock)
+}
+
+func TestNotifyingLocker_TryLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	database.EXPECT().TryLock(gomock.Any()).Return(true, pl, nil)
+	database.EXPECT().TryLock(gomock.Any()).Return(false, pl, nil)
+	database.EXPECT().TryLock(gomock.Any()).Return(false, models.ProjectLock{}, errExpected)
+	listener := &recordingLockListener{}
+	l := locking.NewNotifyingLocker(locking.NewClient(database), listener)
+
+	r, err := l.TryLock(project, workspace, pull, user)
+	Ok(t, err)
+	Equals(t, true, r.LockAcquired)
+	// The listener isn't notified when another pull holds the lock or locking fails.
+	_, err = l.TryLock(project, workspace, pull, user)
+	Ok(t, err)
+	_, err = l.TryLock(project, workspace, pull, user)
+	Equals(t, errExpected, err)
+	Equals(t, []models.ProjectLock{pl}, listener.acquired)
+	Equals(t, 0, len(listener.released))
+}
+
+func TestNotifyingLocker_Unlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	database := mocks.NewMockDatabase(ctrl)
+	database.EXPECT().Unlock(project, workspace).Return(&pl, nil)
+	database.EXPECT().UnlockIfOwnedByPull(project, workspace, 1).Return(nil, nil)
+	database.EXPECT().UnlockByPull("owner/repo", 1).Return([]models.ProjectLock{pl, pl}, nil)
+	listener := &recordingLockListener{}
+	l := locking.NewNotifyingLocker(locking.NewClient(database), listener)
+
+	_, err := l.Unlock("owner/repo/path/workspace/projectName")
+	Ok(t, err)
+	_, err = l.UnlockIfOwnedByPull(project, workspace, 1)
+	Ok(t, err)
+	_, err = l.UnlockByPull("owner/repo", 1)
+	Ok(t, err)
+	Equals(t, []models.ProjectLock{pl, pl, pl}, listener.released)
+	Equals(t, 0, len(listener.acquired))
+}
+
 func TestApplyLocker(t *testing.T) {
 	applyLock := &command.Lock{
 		CommandName: command.Apply,
@@ -0,0 +1,164 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// AzureStorageKeyEnvVar is the environment variable holding a storage
+// account key. When it is unset the Azure plan store authenticates with
+// DefaultAzureCredential instead.
+const AzureStorageKeyEnvVar = "AZURE_STORAGE_KEY"
+
+// AzurePlanStoreConfig holds configuration for connecting to Azure Blob
+// Storage.
+type AzurePlanStoreConfig struct {
+	Account   string
+	Container string
+	Prefix    string
+	// Endpoint overrides the blob service URL, which defaults to
+	// https://<account>.blob.core.windows.net. Set it to use an emulator
+	// such as Azurite, e.g. http://127.0.0.1:10000/devstoreaccount1.
+	Endpoint string
+}
+
+// AzurePlanStore implements PlanStore by persisting plan files to an Azure
+// Blob Storage container.
+type AzurePlanStore struct {
+	blobPlanStore
+}
+
+// NewAzurePlanStore creates an AzurePlanStore. It authenticates with the
+// account key in AZURE_STORAGE_KEY if set, and DefaultAzureCredential
+// otherwise.
+func NewAzurePlanStore(cfg AzurePlanStoreConfig, logger logging.SimpleLogging) (*AzurePlanStore, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", cfg.Account)
+	}
+	containerURL := strings.TrimSuffix(endpoint, "/") + "/" + cfg.Container
+
+	var client *container.Client
+	if key := os.Getenv(AzureStorageKeyEnvVar); key != "" {
+		cred, err := container.NewSharedKeyCredential(cfg.Account, key)
+		if err != nil {
+			return nil, fmt.Errorf("loading Azure storage account key: %w", err)
+		}
+		client, err = container.NewClientWithSharedKeyCredential(containerURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating Azure Blob client: %w", err)
+		}
+	} else {
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("loading Azure credentials: %w", err)
+		}
+		client, err = container.NewClient(containerURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating Azure Blob client: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.GetProperties(ctx, nil); err != nil {
+		return nil, fmt.Errorf("validating Azure plan store container %q: %w", cfg.Container, err)
+	}
+
+	return NewAzurePlanStoreWithClient(&azureContainerClient{client: client}, containerURL, cfg.Prefix, logger), nil
+}
+
+// NewAzurePlanStoreWithClient creates an AzurePlanStore with an injected
+// BlobClient (for testing). containerURL is only used in logs.
+func NewAzurePlanStoreWithClient(client BlobClient, containerURL, prefix string, logger logging.SimpleLogging) *AzurePlanStore {
+	return &AzurePlanStore{
+		blobPlanStore: newBlobPlanStore(client, prefix, "Azure Blob Storage", containerURL, logger),
+	}
+}
+
+// azureContainerClient implements BlobClient for an Azure Blob Storage
+// container.
+type azureContainerClient struct {
+	client *container.Client
+}
+
+func (c *azureContainerClient) Upload(ctx context.Context, key string, body io.Reader, metadata map[string]string) error {
+	// Metadata names must be valid C# identifiers, so hyphens can't be used.
+	azMetadata := make(map[string]*string, len(metadata))
+	for k, v := range metadata {
+		azMetadata[strings.ReplaceAll(k, "-", "_")] = &v
+	}
+	_, err := c.client.NewBlockBlobClient(key).UploadStream(ctx, body, &blockblob.UploadStreamOptions{
+		Metadata: azMetadata,
+	})
+	return err
+}
+
+func (c *azureContainerClient) Download(ctx context.Context, key string) (io.ReadCloser, map[string]string, error) {
+	resp, err := c.client.NewBlobClient(key).DownloadStream(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := make(map[string]string, len(resp.Metadata))
+	for k, v := range resp.Metadata {
+		if v != nil {
+			metadata[strings.ReplaceAll(k, "_", "-")] = *v
+		}
+	}
+	return resp.Body, metadata, nil
+}
+
+func (c *azureContainerClient) Delete(ctx context.Context, key string) error {
+	_, err := c.client.NewBlobClient(key).Delete(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *azureContainerClient) List(ctx context.Context, prefix string) ([]BlobObject, error) {
+	pager := c.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &prefix})
+	var objects []BlobObject
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			obj := BlobObject{Key: *item.Name}
+			if props := item.Properties; props != nil {
+				if props.ContentLength != nil {
+					obj.Size = *props.ContentLength
+				}
+				if props.LastModified != nil {
+					obj.Updated = *props.LastModified
+				}
+			}
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+// Ensure AzurePlanStore satisfies PlanStore and PlanLister at compile time.
+var (
+	_ PlanStore  = (*AzurePlanStore)(nil)
+	_ PlanLister = (*AzurePlanStore)(nil)
+)
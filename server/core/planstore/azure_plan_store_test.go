@@ -0,0 +1,71 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// azuriteAccount and azuriteKey are Azurite's well-known development
+// credentials.
+const (
+	azuriteAccount = "devstoreaccount1"
+	azuriteKey     = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+// TestAzurePlanStore_Emulator runs against Azurite when
+// ATLANTIS_TEST_AZURITE_ENDPOINT is set, e.g.:
+//
+//	docker run -d -p 10000:10000 mcr.microsoft.com/azure-storage/azurite azurite-blob --blobHost 0.0.0.0
+//	ATLANTIS_TEST_AZURITE_ENDPOINT=http://127.0.0.1:10000/devstoreaccount1 go test ./server/core/planstore/...
+func TestAzurePlanStore_Emulator(t *testing.T) {
+	endpoint := os.Getenv("ATLANTIS_TEST_AZURITE_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("ATLANTIS_TEST_AZURITE_ENDPOINT is not set")
+	}
+	t.Setenv(planstore.AzureStorageKeyEnvVar, azuriteKey)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	containerName := fmt.Sprintf("atlantis-test-%d", time.Now().UnixNano())
+	cred, err := container.NewSharedKeyCredential(azuriteAccount, azuriteKey)
+	require.NoError(t, err)
+	client, err := container.NewClientWithSharedKeyCredential(endpoint+"/"+containerName, cred, nil)
+	require.NoError(t, err)
+	_, err = client.Create(ctx, nil)
+	require.NoError(t, err)
+
+	store, err := planstore.NewAzurePlanStore(planstore.AzurePlanStoreConfig{
+		Account:   azuriteAccount,
+		Container: containerName,
+		Prefix:    "plans",
+		Endpoint:  endpoint,
+	}, logging.NewNoopLogger(t))
+	require.NoError(t, err)
+	testPlanStoreRoundTrip(t, store)
+}
+
+func TestNewAzurePlanStore_MissingContainer(t *testing.T) {
+	endpoint := os.Getenv("ATLANTIS_TEST_AZURITE_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("ATLANTIS_TEST_AZURITE_ENDPOINT is not set")
+	}
+	t.Setenv(planstore.AzureStorageKeyEnvVar, azuriteKey)
+
+	_, err := planstore.NewAzurePlanStore(planstore.AzurePlanStoreConfig{
+		Account:   azuriteAccount,
+		Container: "atlantis-test-missing",
+		Endpoint:  endpoint,
+	}, logging.NewNoopLogger(t))
+	require.ErrorContains(t, err, "validating Azure plan store container")
+}
@@ -0,0 +1,373 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/utils"
+)
+
+// BlobClient is the subset of an object storage API used by the GCS and Azure
+// Blob plan stores, extracted for testability. A BlobClient is bound to a
+// single bucket or container; keys are object names within it.
+type BlobClient interface {
+	// Upload writes body to key, replacing any existing object, and attaches
+	// metadata to it.
+	Upload(ctx context.Context, key string, body io.Reader, metadata map[string]string) error
+	// Download returns the contents and metadata of the object at key.
+	Download(ctx context.Context, key string) (io.ReadCloser, map[string]string, error)
+	// Delete removes the object at key. Deleting a missing object is not an
+	// error.
+	Delete(ctx context.Context, key string) error
+	// List returns all objects whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]BlobObject, error)
+}
+
+// BlobObject describes an object returned by BlobClient.List.
+type BlobObject struct {
+	Key     string
+	Size    int64
+	Updated time.Time
+}
+
+// blobOpTimeout is the per-operation timeout for object storage calls.
+const blobOpTimeout = 30 * time.Second
+
+// blobCtx returns a context with the standard object storage operation timeout.
+func blobCtx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), blobOpTimeout)
+}
+
+// blobPlanStore implements PlanStore on top of a BlobClient. It uses the same
+// key layout and head-commit staleness check as S3PlanStore, so the backends
+// only differ in how they talk to their storage service.
+type blobPlanStore struct {
+	client BlobClient
+	prefix string
+	// name is the storage service name used in errors, e.g. "GCS".
+	name string
+	// location is the URL of the bucket or container used in logs, e.g.
+	// "gs://my-bucket".
+	location string
+	logger   logging.SimpleLogging
+}
+
+func newBlobPlanStore(client BlobClient, prefix, name, location string, logger logging.SimpleLogging) blobPlanStore {
+	return blobPlanStore{
+		client:   client,
+		prefix:   strings.TrimSuffix(prefix, "/"),
+		name:     name,
+		location: location,
+		logger:   logger,
+	}
+}
+
+// Save uploads the plan file at planPath.
+func (s *blobPlanStore) Save(ctx command.ProjectContext, planPath string) error {
+	key := s.planKey(ctx, planPath)
+
+	f, err := os.Open(planPath)
+	if err != nil {
+		return fmt.Errorf("opening plan file for %s upload: %w", s.name, err)
+	}
+	defer f.Close()
+
+	metadata := map[string]string{}
+	if ctx.Pull.HeadCommit != "" {
+		metadata["head-commit"] = ctx.Pull.HeadCommit
+	}
+	if ctx.User.Username != "" {
+		metadata["planned-by"] = ctx.User.Username
+	}
+
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	if err := s.client.Upload(opCtx, key, f, metadata); err != nil {
+		return fmt.Errorf("uploading plan to %s (key=%s): %w", s.name, key, err)
+	}
+
+	s.logger.Info("uploaded plan to %s/%s", s.location, key)
+	return nil
+}
+
+// Load downloads the plan file and writes it to planPath. Plans created at a
+// different commit than the one the pull request now points to are rejected.
+func (s *blobPlanStore) Load(ctx command.ProjectContext, planPath string) error {
+	key := s.planKey(ctx, planPath)
+
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	body, metadata, err := s.client.Download(opCtx, key)
+	if err != nil {
+		return fmt.Errorf("downloading plan from %s (key=%s): %w", s.name, key, err)
+	}
+	defer body.Close()
+
+	// Services differ in how they case metadata keys, so look up
+	// "head-commit" case-insensitively.
+	var planCommit string
+	for k, v := range metadata {
+		if strings.EqualFold(k, "head-commit") {
+			planCommit = v
+			break
+		}
+	}
+	if planCommit == "" {
+		return fmt.Errorf("plan in %s has no head-commit metadata (key=%s) — run plan again", s.name, key)
+	}
+	if ctx.Pull.HeadCommit != "" && planCommit != ctx.Pull.HeadCommit {
+		return fmt.Errorf("plan was created at commit %.8s but PR is now at %.8s — run plan again", planCommit, ctx.Pull.HeadCommit)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(planPath), 0o700); err != nil {
+		return fmt.Errorf("creating parent directories for plan file: %w", err)
+	}
+	if err := writeFile(planPath, body); err != nil {
+		return fmt.Errorf("writing plan file from %s: %w", s.name, err)
+	}
+
+	s.logger.Debug("downloaded plan from %s/%s", s.location, key)
+	return nil
+}
+
+// Remove deletes the plan file from the store and locally.
+func (s *blobPlanStore) Remove(ctx command.ProjectContext, planPath string) error {
+	s.deleteObject(s.planKey(ctx, planPath))
+	return utils.RemoveIgnoreNonExistent(planPath)
+}
+
+// ListWorkspaces returns the unique workspace names (first path segment after
+// owner/repo/pullNum/) that have at least one .tfplan stored.
+func (s *blobPlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string, error) {
+	listPrefix := s.pullPrefix(owner, repo, pullNum)
+	plans, err := s.listPlans(listPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspaces from %s (prefix=%s): %w", s.name, listPrefix, err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, plan := range plans {
+		workspace, _, ok := strings.Cut(strings.TrimPrefix(plan.Key, listPrefix), "/")
+		if !ok || workspace == "" {
+			continue
+		}
+		seen[workspace] = struct{}{}
+	}
+
+	workspaces := make([]string, 0, len(seen))
+	for w := range seen {
+		workspaces = append(workspaces, w)
+	}
+	sort.Strings(workspaces)
+	return workspaces, nil
+}
+
+// RestorePlans downloads every plan stored for a pull request into pullDir so
+// PendingPlanFinder can discover them.
+func (s *blobPlanStore) RestorePlans(pullDir, owner, repo string, pullNum int) error {
+	if pullDir == "" {
+		return nil // capability probe: external store supports restore
+	}
+	listPrefix := s.pullPrefix(owner, repo, pullNum)
+	plans, err := s.listPlans(listPrefix)
+	if err != nil {
+		return fmt.Errorf("listing plans from %s (prefix=%s): %w", s.name, listPrefix, err)
+	}
+
+	for _, plan := range plans {
+		key := plan.Key
+		// SecureJoin guarantees the result stays within pullDir,
+		// preventing path traversal from untrusted object keys.
+		localPath, err := securejoin.SecureJoin(pullDir, strings.TrimPrefix(key, listPrefix))
+		if err != nil {
+			return fmt.Errorf("resolving safe path for %s key %s: %w", s.name, key, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o700); err != nil {
+			return fmt.Errorf("creating directory for restored plan: %w", err)
+		}
+		if err := s.downloadObjectTo(key, localPath); err != nil {
+			return err
+		}
+		s.logger.Info("restored plan from %s/%s to %s", s.location, key, localPath)
+	}
+
+	s.logger.Info("restored %d plan(s) from %s for %s/%s#%d", len(plans), s.name, owner, repo, pullNum)
+	return nil
+}
+
+// downloadObjectTo fetches the object at key and writes it to localPath.
+// Each call uses its own bounded context so a slow object doesn't starve the
+// rest of a restore.
+func (s *blobPlanStore) downloadObjectTo(key, localPath string) error {
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	body, _, err := s.client.Download(opCtx, key)
+	if err != nil {
+		return fmt.Errorf("downloading plan from %s (key=%s): %w", s.name, key, err)
+	}
+	defer body.Close()
+
+	if err := writeFile(localPath, body); err != nil {
+		return fmt.Errorf("writing restored plan file %s: %w", localPath, err)
+	}
+	return nil
+}
+
+// DeleteForPull removes all objects stored under the pull request prefix.
+func (s *blobPlanStore) DeleteForPull(owner, repo string, pullNum int) error {
+	listPrefix := s.pullPrefix(owner, repo, pullNum)
+	opCtx, opCancel := blobCtx()
+	objects, err := s.client.List(opCtx, listPrefix)
+	opCancel()
+	if err != nil {
+		return fmt.Errorf("listing plans for deletion (prefix=%s): %w", listPrefix, err)
+	}
+
+	var deleted int
+	for _, obj := range objects {
+		if s.deleteObject(obj.Key) {
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		s.logger.Info("deleted %d plan(s) from %s for %s/%s#%d", deleted, s.name, owner, repo, pullNum)
+	}
+	return nil
+}
+
+// DeletePlanForProject removes a single project's plan from the store.
+func (s *blobPlanStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, repoRelDir, projectName string) error {
+	s.deleteObject(s.key(owner, repo, strconv.Itoa(pullNum), workspace, repoRelDir, planFilename(workspace, projectName)))
+	return nil
+}
+
+// ListAllPlans lists every plan object under the store's prefix.
+func (s *blobPlanStore) ListAllPlans() ([]StoredPlan, error) {
+	rootPrefix := ""
+	if s.prefix != "" {
+		rootPrefix = s.prefix + "/"
+	}
+	objects, err := s.listPlans(rootPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing plans from %s (prefix=%s): %w", s.name, rootPrefix, err)
+	}
+
+	var plans []StoredPlan
+	for _, obj := range objects {
+		owner, repo, pullNum, ok := parsePlanKey(strings.TrimPrefix(obj.Key, rootPrefix))
+		if !ok {
+			continue
+		}
+		plans = append(plans, StoredPlan{
+			Owner:    owner,
+			Repo:     repo,
+			PullNum:  pullNum,
+			Key:      obj.Key,
+			Size:     obj.Size,
+			Modified: obj.Updated,
+		})
+	}
+	return plans, nil
+}
+
+// DeleteStoredPlan deletes a plan object returned by ListAllPlans.
+func (s *blobPlanStore) DeleteStoredPlan(plan StoredPlan) error {
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	if err := s.client.Delete(opCtx, plan.Key); err != nil {
+		return fmt.Errorf("deleting plan from %s (key=%s): %w", s.name, plan.Key, err)
+	}
+	return nil
+}
+
+// deleteObject deletes key, logging rather than returning failures since a
+// leftover plan is rejected by Load's staleness check anyway. It reports
+// whether the delete succeeded.
+func (s *blobPlanStore) deleteObject(key string) bool {
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	if err := s.client.Delete(opCtx, key); err != nil {
+		s.logger.Warn("failed to delete plan from %s (key=%s): %v", s.name, key, err)
+		return false
+	}
+	s.logger.Debug("deleted plan from %s/%s", s.location, key)
+	return true
+}
+
+// listPlans returns the .tfplan objects under listPrefix.
+func (s *blobPlanStore) listPlans(listPrefix string) ([]BlobObject, error) {
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	objects, err := s.client.List(opCtx, listPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var plans []BlobObject
+	for _, obj := range objects {
+		if strings.HasSuffix(obj.Key, ".tfplan") {
+			plans = append(plans, obj)
+		}
+	}
+	return plans, nil
+}
+
+// planKey builds the object key for a plan file.
+// Format: <prefix>/<owner>/<repo>/<pullNum>/<workspace>/<repoRelDir>/<planfilename>
+func (s *blobPlanStore) planKey(ctx command.ProjectContext, planPath string) string {
+	return s.key(
+		ctx.BaseRepo.Owner,
+		ctx.BaseRepo.Name,
+		strconv.Itoa(ctx.Pull.Num),
+		ctx.Workspace,
+		ctx.RepoRelDir,
+		filepath.Base(planPath),
+	)
+}
+
+// pullPrefix returns the key prefix under which all plans for a pull request
+// are stored, including the trailing slash.
+func (s *blobPlanStore) pullPrefix(owner, repo string, pullNum int) string {
+	return s.key(owner, repo, strconv.Itoa(pullNum)) + "/"
+}
+
+func (s *blobPlanStore) key(parts ...string) string {
+	if s.prefix != "" {
+		parts = append([]string{s.prefix}, parts...)
+	}
+	return strings.Join(parts, "/")
+}
+
+// planFilename mirrors runtime.GetPlanFilename, which can't be imported here
+// without a cycle.
+func planFilename(workspace, projectName string) string {
+	if projectName == "" {
+		return workspace + ".tfplan"
+	}
+	return strings.ReplaceAll(projectName, "/", "::") + "-" + workspace + ".tfplan"
+}
+
+func writeFile(path string, body io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
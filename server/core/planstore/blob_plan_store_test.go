@@ -0,0 +1,274 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlob struct {
+	body     []byte
+	metadata map[string]string
+	updated  time.Time
+}
+
+// fakeBlobClient is an in-memory BlobClient.
+type fakeBlobClient struct {
+	mu        sync.Mutex
+	blobs     map[string]fakeBlob
+	uploadErr error
+	deleteErr error
+	listErr   error
+}
+
+func newFakeBlobClient() *fakeBlobClient {
+	return &fakeBlobClient{blobs: map[string]fakeBlob{}}
+}
+
+func (f *fakeBlobClient) Upload(_ context.Context, key string, body io.Reader, metadata map[string]string) error {
+	if f.uploadErr != nil {
+		return f.uploadErr
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blobs[key] = fakeBlob{body: b, metadata: metadata, updated: time.Now()}
+	return nil
+}
+
+func (f *fakeBlobClient) Download(_ context.Context, key string) (io.ReadCloser, map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	blob, ok := f.blobs[key]
+	if !ok {
+		return nil, nil, errors.New("no such key: " + key)
+	}
+	return io.NopCloser(bytes.NewReader(blob.body)), blob.metadata, nil
+}
+
+func (f *fakeBlobClient) Delete(_ context.Context, key string) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.blobs, key)
+	return nil
+}
+
+func (f *fakeBlobClient) List(_ context.Context, prefix string) ([]planstore.BlobObject, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var objects []planstore.BlobObject
+	for key, blob := range f.blobs {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, planstore.BlobObject{Key: key, Size: int64(len(blob.body)), Updated: blob.updated})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (f *fakeBlobClient) keys() []string {
+	objects, _ := f.List(context.Background(), "")
+	var keys []string
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+func writePlan(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	planPath := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(planPath), 0o700))
+	require.NoError(t, os.WriteFile(planPath, []byte(content), 0o600))
+	return planPath
+}
+
+// testPlanStoreRoundTrip exercises the full PlanStore interface against store.
+// It is shared by the fake client tests and the emulator tests.
+func testPlanStoreRoundTrip(t *testing.T, store planstore.PlanStore) {
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+	ctx.ProjectName = "vpc"
+	planPath := writePlan(t, t.TempDir(), "vpc-default.tfplan", "plan-content")
+
+	require.NoError(t, store.Save(ctx, planPath))
+
+	stagingCtx := ctx
+	stagingCtx.Workspace = "staging"
+	stagingPlan := writePlan(t, t.TempDir(), "vpc-staging.tfplan", "staging-plan")
+	require.NoError(t, store.Save(stagingCtx, stagingPlan))
+
+	// Load restores a plan lost with the working dir.
+	require.NoError(t, os.Remove(planPath))
+	require.NoError(t, store.Load(ctx, planPath))
+	got, err := os.ReadFile(planPath)
+	require.NoError(t, err)
+	assert.Equal(t, "plan-content", string(got))
+
+	// A new commit makes the stored plan stale.
+	staleCtx := ctx
+	staleCtx.Pull.HeadCommit = "def456"
+	assert.ErrorContains(t, store.Load(staleCtx, planPath), "run plan again")
+
+	workspaces, err := store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "staging"}, workspaces)
+
+	pullDir := t.TempDir()
+	require.NoError(t, store.RestorePlans(pullDir, "acme", "infra", 42))
+	got, err = os.ReadFile(filepath.Join(pullDir, "default", "modules", "vpc", "vpc-default.tfplan"))
+	require.NoError(t, err)
+	assert.Equal(t, "plan-content", string(got))
+	got, err = os.ReadFile(filepath.Join(pullDir, "staging", "modules", "vpc", "vpc-staging.tfplan"))
+	require.NoError(t, err)
+	assert.Equal(t, "staging-plan", string(got))
+
+	require.NoError(t, store.DeletePlanForProject("acme", "infra", 42, "staging", "modules/vpc", "vpc"))
+	workspaces, err = store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, workspaces)
+
+	require.NoError(t, store.Remove(ctx, planPath))
+	_, err = os.Stat(planPath)
+	assert.True(t, os.IsNotExist(err))
+	assert.Error(t, store.Load(ctx, planPath))
+
+	require.NoError(t, store.Save(ctx, writePlan(t, t.TempDir(), "vpc-default.tfplan", "plan-content")))
+	require.NoError(t, store.DeleteForPull("acme", "infra", 42))
+	workspaces, err = store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Empty(t, workspaces)
+}
+
+func TestGCSPlanStore_RoundTrip(t *testing.T) {
+	testPlanStoreRoundTrip(t, planstore.NewGCSPlanStoreWithClient(newFakeBlobClient(), "bucket", "pfx", logging.NewNoopLogger(t)))
+}
+
+func TestAzurePlanStore_RoundTrip(t *testing.T) {
+	testPlanStoreRoundTrip(t, planstore.NewAzurePlanStoreWithClient(newFakeBlobClient(), "https://account.blob.core.windows.net/plans", "", logging.NewNoopLogger(t)))
+}
+
+func TestBlobPlanStore_SaveKeyAndMetadata(t *testing.T) {
+	client := newFakeBlobClient()
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "atlantis/plans/", logging.NewNoopLogger(t))
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+	ctx.User.Username = "alice"
+
+	require.NoError(t, store.Save(ctx, writePlan(t, t.TempDir(), "default.tfplan", "data")))
+
+	key := "atlantis/plans/acme/infra/42/default/modules/vpc/default.tfplan"
+	assert.Equal(t, []string{key}, client.keys())
+	assert.Equal(t, map[string]string{"head-commit": "abc123", "planned-by": "alice"}, client.blobs[key].metadata)
+}
+
+func TestBlobPlanStore_LoadMetadataCaseInsensitive(t *testing.T) {
+	client := newFakeBlobClient()
+	client.blobs["acme/infra/42/default/modules/vpc/default.tfplan"] = fakeBlob{
+		body:     []byte("data"),
+		metadata: map[string]string{"Head-Commit": "abc123"},
+	}
+	store := planstore.NewAzurePlanStoreWithClient(client, "container", "", logging.NewNoopLogger(t))
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+
+	require.NoError(t, store.Load(ctx, filepath.Join(t.TempDir(), "default.tfplan")))
+}
+
+func TestBlobPlanStore_LoadMissingMetadataRejected(t *testing.T) {
+	client := newFakeBlobClient()
+	client.blobs["acme/infra/42/default/modules/vpc/default.tfplan"] = fakeBlob{body: []byte("data")}
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+
+	err := store.Load(testProjectContext(), filepath.Join(t.TempDir(), "default.tfplan"))
+	assert.ErrorContains(t, err, "no head-commit metadata")
+}
+
+func TestBlobPlanStore_SaveError(t *testing.T) {
+	client := newFakeBlobClient()
+	client.uploadErr = errors.New("access denied")
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+
+	err := store.Save(testProjectContext(), writePlan(t, t.TempDir(), "default.tfplan", "data"))
+	assert.ErrorContains(t, err, "uploading plan to GCS")
+	assert.ErrorContains(t, err, "access denied")
+}
+
+func TestBlobPlanStore_RemoveIgnoresDeleteError(t *testing.T) {
+	client := newFakeBlobClient()
+	client.deleteErr = errors.New("access denied")
+	store := planstore.NewAzurePlanStoreWithClient(client, "container", "", logging.NewNoopLogger(t))
+	planPath := writePlan(t, t.TempDir(), "default.tfplan", "data")
+
+	require.NoError(t, store.Remove(testProjectContext(), planPath))
+	_, err := os.Stat(planPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBlobPlanStore_ListError(t *testing.T) {
+	client := newFakeBlobClient()
+	client.listErr = errors.New("throttled")
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+
+	_, err := store.ListWorkspaces("acme", "infra", 42)
+	assert.ErrorContains(t, err, "throttled")
+	assert.ErrorContains(t, store.RestorePlans(t.TempDir(), "acme", "infra", 42), "throttled")
+	assert.ErrorContains(t, store.DeleteForPull("acme", "infra", 42), "throttled")
+}
+
+func TestBlobPlanStore_RestoreProbe(t *testing.T) {
+	client := newFakeBlobClient()
+	client.listErr = errors.New("should not be called")
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+
+	require.NoError(t, store.RestorePlans("", "", "", 0))
+}
+
+func TestBlobPlanStore_RestoreRejectsTraversal(t *testing.T) {
+	client := newFakeBlobClient()
+	client.blobs["acme/infra/42/../../../escape.tfplan"] = fakeBlob{body: []byte("data")}
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+	root := t.TempDir()
+	pullDir := filepath.Join(root, "pull")
+
+	require.NoError(t, store.RestorePlans(pullDir, "acme", "infra", 42))
+	_, err := os.Stat(filepath.Join(root, "escape.tfplan"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBlobPlanStore_ListWorkspacesIgnoresNonPlans(t *testing.T) {
+	client := newFakeBlobClient()
+	client.blobs["acme/infra/42/default/modules/vpc/default.tfplan"] = fakeBlob{}
+	client.blobs["acme/infra/42/other/modules/vpc/notes.txt"] = fakeBlob{}
+	client.blobs["acme/infra/420/third/third.tfplan"] = fakeBlob{}
+	store := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+
+	workspaces, err := store.ListWorkspaces("acme", "infra", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, workspaces)
+}
@@ -0,0 +1,283 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// encryptedPlanMagic starts every plan file written by EncryptedPlanStore and
+// versions the format that follows it:
+//
+//	magic | uint16 key ID length | key ID | uint16 wrapped key length |
+//	wrapped data key | nonce | AES-GCM ciphertext
+//
+// The header and the plan's location are authenticated as additional data,
+// so a plan can't be moved to another project or pull request, or have its
+// key swapped, without failing to decrypt.
+var encryptedPlanMagic = []byte("ATLPENC1")
+
+// EncryptedPlanStore is a PlanStore decorator that envelope-encrypts plan
+// files before the wrapped store persists them, and decrypts them after they
+// are loaded or restored. Each plan is encrypted with its own AES-256-GCM
+// data key, which is stored alongside it wrapped by a KeyProvider.
+// Local plan files stay in plaintext because terraform reads them directly.
+type EncryptedPlanStore struct {
+	store  PlanStore
+	keys   KeyProvider
+	logger logging.SimpleLogging
+}
+
+// NewEncryptedPlanStore returns an EncryptedPlanStore that wraps store and
+// protects data keys with keys.
+func NewEncryptedPlanStore(store PlanStore, keys KeyProvider, logger logging.SimpleLogging) *EncryptedPlanStore {
+	return &EncryptedPlanStore{store: store, keys: keys, logger: logger}
+}
+
+// Save encrypts the plan file at planPath into a temporary file with the same
+// name and has the wrapped store save that instead.
+func (s *EncryptedPlanStore) Save(ctx command.ProjectContext, planPath string) error {
+	plaintext, err := os.ReadFile(planPath)
+	if err != nil {
+		return fmt.Errorf("reading plan file for encryption: %w", err)
+	}
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	ciphertext, err := s.encrypt(opCtx, plaintext, projectPlanLocation(ctx, planPath))
+	if err != nil {
+		return fmt.Errorf("encrypting plan: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "atlantis-encrypted-plan")
+	if err != nil {
+		return fmt.Errorf("creating temporary directory for encrypted plan: %w", err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint: errcheck
+	// Stores derive the object key from the plan's file name, so the
+	// encrypted copy has to keep it.
+	encryptedPath := filepath.Join(tmpDir, filepath.Base(planPath))
+	if err := os.WriteFile(encryptedPath, ciphertext, 0o600); err != nil {
+		return fmt.Errorf("writing encrypted plan: %w", err)
+	}
+	return s.store.Save(ctx, encryptedPath)
+}
+
+// Load has the wrapped store download the plan to planPath and then decrypts
+// it in place. If decryption fails the downloaded file is removed so
+// terraform never sees it.
+func (s *EncryptedPlanStore) Load(ctx command.ProjectContext, planPath string) error {
+	if err := s.store.Load(ctx, planPath); err != nil {
+		return err
+	}
+	if err := s.decryptFile(planPath, planPath, projectPlanLocation(ctx, planPath)); err != nil {
+		os.Remove(planPath) // nolint: errcheck
+		return err
+	}
+	return nil
+}
+
+func (s *EncryptedPlanStore) Remove(ctx command.ProjectContext, planPath string) error {
+	return s.store.Remove(ctx, planPath)
+}
+
+func (s *EncryptedPlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string, error) {
+	return s.store.ListWorkspaces(owner, repo, pullNum)
+}
+
+// RestorePlans has the wrapped store restore the pull request's plans into a
+// temporary directory and decrypts each of them into pullDir.
+func (s *EncryptedPlanStore) RestorePlans(pullDir, owner, repo string, pullNum int) error {
+	if pullDir == "" {
+		return s.store.RestorePlans(pullDir, owner, repo, pullNum)
+	}
+	tmpDir, err := os.MkdirTemp("", "atlantis-encrypted-plans")
+	if err != nil {
+		return fmt.Errorf("creating temporary directory for encrypted plans: %w", err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint: errcheck
+	if err := s.store.RestorePlans(tmpDir, owner, repo, pullNum); err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(tmpDir, func(encryptedPath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(tmpDir, encryptedPath)
+		if err != nil {
+			return err
+		}
+		localPath, err := securejoin.SecureJoin(pullDir, relPath)
+		if err != nil {
+			return fmt.Errorf("resolving safe path for restored plan %s: %w", relPath, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o700); err != nil {
+			return fmt.Errorf("creating directory for restored plan: %w", err)
+		}
+		location := planLocation(owner, repo, pullNum, filepath.ToSlash(relPath))
+		return s.decryptFile(encryptedPath, localPath, location)
+	})
+}
+
+func (s *EncryptedPlanStore) DeleteForPull(owner, repo string, pullNum int) error {
+	return s.store.DeleteForPull(owner, repo, pullNum)
+}
+
+func (s *EncryptedPlanStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, repoRelDir, projectName string) error {
+	return s.store.DeletePlanForProject(owner, repo, pullNum, workspace, repoRelDir, projectName)
+}
+
+func (s *EncryptedPlanStore) ListAllPlans() ([]StoredPlan, error) {
+	lister, ok := s.store.(PlanLister)
+	if !ok {
+		return nil, ErrListNotSupported
+	}
+	return lister.ListAllPlans()
+}
+
+func (s *EncryptedPlanStore) DeleteStoredPlan(plan StoredPlan) error {
+	lister, ok := s.store.(PlanLister)
+	if !ok {
+		return ErrListNotSupported
+	}
+	return lister.DeleteStoredPlan(plan)
+}
+
+// decryptFile decrypts the plan at src and writes it to dst, which may be the
+// same file.
+func (s *EncryptedPlanStore) decryptFile(src, dst, location string) error {
+	ciphertext, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("reading encrypted plan: %w", err)
+	}
+	opCtx, opCancel := blobCtx()
+	defer opCancel()
+	plaintext, err := s.decrypt(opCtx, ciphertext, location)
+	if err != nil {
+		return fmt.Errorf("decrypting plan %s: %w", location, err)
+	}
+	if err := os.WriteFile(dst, plaintext, 0o600); err != nil {
+		return fmt.Errorf("writing decrypted plan: %w", err)
+	}
+	s.logger.Debug("decrypted plan %s", location)
+	return nil
+}
+
+func (s *EncryptedPlanStore) encrypt(ctx context.Context, plaintext []byte, location string) ([]byte, error) {
+	dataKey, wrapped, keyID, err := s.keys.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyID) > 0xffff || len(wrapped) > 0xffff {
+		return nil, errors.New("key ID or wrapped data key is too long")
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var header bytes.Buffer
+	header.Write(encryptedPlanMagic)
+	binary.Write(&header, binary.BigEndian, uint16(len(keyID))) // nolint: errcheck
+	header.WriteString(keyID)
+	binary.Write(&header, binary.BigEndian, uint16(len(wrapped))) // nolint: errcheck
+	header.Write(wrapped)
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	aad := additionalData(header.Bytes(), location)
+	out := append(header.Bytes(), nonce...)
+	return gcm.Seal(out, nonce, plaintext, aad), nil
+}
+
+func (s *EncryptedPlanStore) decrypt(ctx context.Context, data []byte, location string) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptedPlanMagic) {
+		// Plans saved before encryption was enabled end up here. Rejecting
+		// them rather than passing them through means a plaintext plan
+		// planted in the bucket can't be applied.
+		return nil, errors.New("plan is not encrypted — run plan again")
+	}
+	r := bytes.NewReader(data[len(encryptedPlanMagic):])
+	keyID, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	header := data[:len(data)-r.Len()]
+
+	dataKey, err := s.keys.Decrypt(ctx, string(keyID), wrapped)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	rest := data[len(header):]
+	if len(rest) < gcm.NonceSize() {
+		return nil, errors.New("encrypted plan is truncated")
+	}
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], additionalData(header, location))
+	if err != nil {
+		return nil, fmt.Errorf("plan failed authentication: %w", err)
+	}
+	return plaintext, nil
+}
+
+// readField reads a uint16 length-prefixed field.
+func readField(r *bytes.Reader) ([]byte, error) {
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, errors.New("encrypted plan header is truncated")
+	}
+	field := make([]byte, n)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, errors.New("encrypted plan header is truncated")
+	}
+	return field, nil
+}
+
+func additionalData(header []byte, location string) []byte {
+	return append(append([]byte{}, header...), location...)
+}
+
+// projectPlanLocation identifies where the plan at planPath belongs, in the
+// same form planLocation produces for restored plans.
+func projectPlanLocation(ctx command.ProjectContext, planPath string) string {
+	return planLocation(ctx.BaseRepo.Owner, ctx.BaseRepo.Name, ctx.Pull.Num,
+		path.Join(ctx.Workspace, filepath.ToSlash(ctx.RepoRelDir), filepath.Base(planPath)))
+}
+
+// planLocation joins a pull request and a plan's path within the pull
+// request's directory into a single cleaned path.
+func planLocation(owner, repo string, pullNum int, relPath string) string {
+	return path.Join(owner, repo, strconv.Itoa(pullNum), relPath)
+}
+
+// Ensure EncryptedPlanStore satisfies PlanStore and PlanLister at compile time.
+var (
+	_ PlanStore  = (*EncryptedPlanStore)(nil)
+	_ PlanLister = (*EncryptedPlanStore)(nil)
+)
@@ -0,0 +1,136 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyProvider(t *testing.T, seed byte) *planstore.LocalKeyProvider {
+	t.Helper()
+	keys, err := planstore.NewLocalKeyProviderWithKey(bytes.Repeat([]byte{seed}, 32))
+	require.NoError(t, err)
+	return keys
+}
+
+func newEncryptedTestStore(t *testing.T) (*planstore.EncryptedPlanStore, *fakeBlobClient) {
+	t.Helper()
+	client := newFakeBlobClient()
+	inner := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+	return planstore.NewEncryptedPlanStore(inner, newTestKeyProvider(t, 1), logging.NewNoopLogger(t)), client
+}
+
+// committedProjectContext returns a project context with a head commit, which
+// the wrapped store needs to load a plan.
+func committedProjectContext() command.ProjectContext {
+	ctx := testProjectContext()
+	ctx.Pull.HeadCommit = "abc123"
+	return ctx
+}
+
+func TestEncryptedPlanStore_RoundTrip(t *testing.T) {
+	store, _ := newEncryptedTestStore(t)
+	testPlanStoreRoundTrip(t, store)
+}
+
+func TestEncryptedPlanStore_SaveEncrypts(t *testing.T) {
+	store, client := newEncryptedTestStore(t)
+	ctx := committedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "default.tfplan", "secret-password")
+
+	require.NoError(t, store.Save(ctx, planPath))
+
+	blob := client.blobs["acme/infra/42/default/modules/vpc/default.tfplan"]
+	assert.True(t, bytes.HasPrefix(blob.body, []byte("ATLPENC1")))
+	assert.NotContains(t, string(blob.body), "secret-password")
+	assert.Equal(t, "abc123", blob.metadata["head-commit"])
+
+	// The local plan is left alone for terraform to read.
+	got, err := os.ReadFile(planPath)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-password", string(got))
+}
+
+func TestEncryptedPlanStore_LoadRejectsTampering(t *testing.T) {
+	store, client := newEncryptedTestStore(t)
+	ctx := committedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "default.tfplan", "plan")
+	require.NoError(t, store.Save(ctx, planPath))
+
+	key := "acme/infra/42/default/modules/vpc/default.tfplan"
+	blob := client.blobs[key]
+	blob.body[len(blob.body)-1] ^= 0xff
+	client.blobs[key] = blob
+
+	err := store.Load(ctx, planPath)
+	assert.ErrorContains(t, err, "plan failed authentication")
+	_, statErr := os.Stat(planPath)
+	assert.True(t, os.IsNotExist(statErr), "expected undecryptable plan to be removed")
+}
+
+func TestEncryptedPlanStore_LoadRejectsMovedPlan(t *testing.T) {
+	store, client := newEncryptedTestStore(t)
+	ctx := committedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "default.tfplan", "plan")
+	require.NoError(t, store.Save(ctx, planPath))
+
+	// Copy the plan to another pull request's location.
+	client.blobs["acme/infra/43/default/modules/vpc/default.tfplan"] = client.blobs["acme/infra/42/default/modules/vpc/default.tfplan"]
+	otherCtx := ctx
+	otherCtx.Pull.Num = 43
+
+	assert.ErrorContains(t, store.Load(otherCtx, planPath), "plan failed authentication")
+}
+
+func TestEncryptedPlanStore_LoadRejectsPlaintext(t *testing.T) {
+	client := newFakeBlobClient()
+	inner := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+	ctx := committedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "default.tfplan", "plan")
+	require.NoError(t, inner.Save(ctx, planPath))
+
+	store := planstore.NewEncryptedPlanStore(inner, newTestKeyProvider(t, 1), logging.NewNoopLogger(t))
+	assert.ErrorContains(t, store.Load(ctx, planPath), "plan is not encrypted")
+}
+
+func TestEncryptedPlanStore_LoadRejectsOtherKey(t *testing.T) {
+	store, client := newEncryptedTestStore(t)
+	ctx := committedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "default.tfplan", "plan")
+	require.NoError(t, store.Save(ctx, planPath))
+
+	inner := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+	rotated := planstore.NewEncryptedPlanStore(inner, newTestKeyProvider(t, 2), logging.NewNoopLogger(t))
+	assert.ErrorContains(t, rotated.Load(ctx, planPath), "but the configured key is")
+}
+
+func TestEncryptedPlanStore_RestorePlansRejectsTampering(t *testing.T) {
+	store, client := newEncryptedTestStore(t)
+	ctx := committedProjectContext()
+	require.NoError(t, store.Save(ctx, writePlan(t, t.TempDir(), "default.tfplan", "plan")))
+
+	key := "acme/infra/42/default/modules/vpc/default.tfplan"
+	blob := client.blobs[key]
+	blob.body[len(blob.body)-1] ^= 0xff
+	client.blobs[key] = blob
+
+	pullDir := t.TempDir()
+	assert.ErrorContains(t, store.RestorePlans(pullDir, "acme", "infra", 42), "plan failed authentication")
+	_, err := os.Stat(filepath.Join(pullDir, "default", "modules", "vpc", "default.tfplan"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestEncryptedPlanStore_RestoreProbeDelegates(t *testing.T) {
+	store := planstore.NewEncryptedPlanStore(&planstore.LocalPlanStore{}, newTestKeyProvider(t, 1), logging.NewNoopLogger(t))
+	assert.ErrorIs(t, store.RestorePlans("", "", "", 0), planstore.ErrRestoreNotSupported)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs"
+	"github.com/runatlantis/atlantis/server/logging"
+	tally "github.com/uber-go/tally/v4"
+)
+
+const (
+	// GCPlansRemovedMetric counts plans removed by the garbage collector,
+	// tagged with the "reason" they were removed for and "dry_run".
+	GCPlansRemovedMetric = "plans_removed"
+	// GCBytesReclaimedMetric counts the bytes of plans removed by the garbage
+	// collector, tagged with "dry_run".
+	GCBytesReclaimedMetric = "bytes_reclaimed"
+	// GCErrorsMetric counts failures to list or remove plans.
+	GCErrorsMetric = "errors"
+	// GCStoredPlansMetric is the number of plans in the store at the start of
+	// the latest garbage collection run.
+	GCStoredPlansMetric = "stored_plans"
+)
+
+const (
+	// GCReasonClosedPull is the reason tag of plans removed because their pull
+	// request is closed or merged.
+	GCReasonClosedPull = "closed_pull"
+	// GCReasonExpired is the reason tag of plans removed because they are
+	// older than the retention period.
+	GCReasonExpired = "expired"
+)
+
+// GCJobName names the garbage collector's job so only one replica runs it
+// with distributed execution.
+const GCJobName = "plan-gc"
+
+// GarbageCollector removes plans that are left in a plan store after their
+// pull request is closed, e.g. because the close webhook was missed or the
+// repo was deleted. It is registered with the scheduled executor service.
+type GarbageCollector struct {
+	Store     PlanLister
+	VCSClient vcs.Client
+	// VCSHost is the VCS host the stored plans' pull requests belong to.
+	// Pull requests are only looked up if it is set; Bitbucket Server pull
+	// requests can't be looked up from a stored plan, so only Retention
+	// applies to them.
+	VCSHost *models.VCSHost
+	// Retention is the age after which a plan is removed even if its pull
+	// request is still open. Zero disables it.
+	Retention time.Duration
+	// DryRun only logs and counts the plans that would be removed.
+	DryRun bool
+	Scope  tally.Scope
+	Logger logging.SimpleLogging
+}
+
+// GCResult summarizes a garbage collection run.
+type GCResult struct {
+	Scanned        int
+	Removed        int
+	BytesReclaimed int64
+	Errors         int
+}
+
+// Run collects garbage once.
+func (g *GarbageCollector) Run() {
+	result, err := g.Collect()
+	if err != nil {
+		g.Logger.Err("collecting plan garbage: %s", err)
+		return
+	}
+	verb := "removed"
+	if g.DryRun {
+		verb = "would remove"
+	}
+	if result.Removed > 0 || result.Errors > 0 {
+		g.Logger.Info("plan garbage collection scanned %d plans and %s %d plans (%d bytes) with %d errors",
+			result.Scanned, verb, result.Removed, result.BytesReclaimed, result.Errors)
+	}
+}
+
+// Collect removes every plan in the store that is older than Retention or
+// whose pull request is closed. Pull request states are looked up once per
+// pull request; if a lookup fails the pull request is treated as open.
+func (g *GarbageCollector) Collect() (GCResult, error) {
+	var result GCResult
+	scope := g.scope()
+	plans, err := g.Store.ListAllPlans()
+	if err != nil {
+		scope.Counter(GCErrorsMetric).Inc(1)
+		return result, fmt.Errorf("listing stored plans: %w", err)
+	}
+	result.Scanned = len(plans)
+	scope.Gauge(GCStoredPlansMetric).Update(float64(len(plans)))
+
+	dryRun := strconv.FormatBool(g.DryRun)
+	pullStates := make(map[string]models.PullRequestState)
+	for _, plan := range plans {
+		reason := ""
+		switch {
+		case g.Retention > 0 && !plan.Modified.IsZero() && time.Since(plan.Modified) > g.Retention:
+			reason = GCReasonExpired
+		case g.pullClosed(plan, pullStates):
+			reason = GCReasonClosedPull
+		default:
+			continue
+		}
+
+		if g.DryRun {
+			g.Logger.Info("would remove plan %s of %s#%d: %s", plan.Key, plan.RepoFullName(), plan.PullNum, reason)
+		} else {
+			if err := g.Store.DeleteStoredPlan(plan); err != nil {
+				g.Logger.Warn("removing plan %s of %s#%d: %s", plan.Key, plan.RepoFullName(), plan.PullNum, err)
+				scope.Counter(GCErrorsMetric).Inc(1)
+				result.Errors++
+				continue
+			}
+			g.Logger.Info("removed plan %s of %s#%d: %s", plan.Key, plan.RepoFullName(), plan.PullNum, reason)
+		}
+		result.Removed++
+		result.BytesReclaimed += plan.Size
+		tagged := scope.Tagged(map[string]string{"dry_run": dryRun})
+		tagged.Tagged(map[string]string{"reason": reason}).Counter(GCPlansRemovedMetric).Inc(1)
+		tagged.Counter(GCBytesReclaimedMetric).Inc(plan.Size)
+	}
+	return result, nil
+}
+
+// pullClosed returns whether the plan's pull request is closed or merged,
+// caching pull request states in pullStates.
+func (g *GarbageCollector) pullClosed(plan StoredPlan, pullStates map[string]models.PullRequestState) bool {
+	if g.VCSClient == nil || g.VCSHost == nil || g.VCSHost.Type == models.BitbucketServer {
+		return false
+	}
+	pullKey := fmt.Sprintf("%s#%d", plan.RepoFullName(), plan.PullNum)
+	state, ok := pullStates[pullKey]
+	if !ok {
+		repo := models.Repo{
+			FullName: plan.RepoFullName(),
+			Owner:    plan.Owner,
+			Name:     plan.Repo,
+			VCSHost:  *g.VCSHost,
+		}
+		pull := models.PullRequest{Num: plan.PullNum, BaseRepo: repo}
+		var err error
+		state, err = g.VCSClient.GetPullState(g.Logger, repo, pull)
+		if err != nil {
+			g.Logger.Debug("getting state of %s, keeping its plans: %s", pullKey, err)
+			state = models.OpenPullState
+		}
+		pullStates[pullKey] = state
+	}
+	return state == models.ClosedPullState
+}
+
+func (g *GarbageCollector) scope() tally.Scope {
+	if g.Scope == nil {
+		return tally.NoopScope.SubScope("plan_gc")
+	}
+	return g.Scope.SubScope("plan_gc")
+}
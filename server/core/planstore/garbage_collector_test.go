@@ -0,0 +1,165 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+)
+
+type fakePlanLister struct {
+	plans     []planstore.StoredPlan
+	deleted   []string
+	deleteErr error
+}
+
+func (f *fakePlanLister) ListAllPlans() ([]planstore.StoredPlan, error) {
+	return f.plans, nil
+}
+
+func (f *fakePlanLister) DeleteStoredPlan(plan planstore.StoredPlan) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, plan.Key)
+	return nil
+}
+
+// fakePullStateClient reports the pulls in closed as closed and all others as
+// open, and counts the lookups.
+type fakePullStateClient struct {
+	vcs.NotConfiguredVCSClient
+	closed  map[int]bool
+	lookups int
+}
+
+func (f *fakePullStateClient) GetPullState(_ logging.SimpleLogging, _ models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	f.lookups++
+	if pull.Num == 404 {
+		return models.OpenPullState, errors.New("not found")
+	}
+	if f.closed[pull.Num] {
+		return models.ClosedPullState, nil
+	}
+	return models.OpenPullState, nil
+}
+
+func gcTestPlans() []planstore.StoredPlan {
+	now := time.Now()
+	return []planstore.StoredPlan{
+		{Owner: "acme", Repo: "infra", PullNum: 1, Key: "acme/infra/1/default/a.tfplan", Size: 100, Modified: now},
+		{Owner: "acme", Repo: "infra", PullNum: 1, Key: "acme/infra/1/default/b.tfplan", Size: 200, Modified: now},
+		{Owner: "acme", Repo: "infra", PullNum: 2, Key: "acme/infra/2/default/a.tfplan", Size: 300, Modified: now},
+		{Owner: "acme", Repo: "infra", PullNum: 3, Key: "acme/infra/3/default/a.tfplan", Size: 400, Modified: now.Add(-40 * 24 * time.Hour)},
+		{Owner: "acme", Repo: "infra", PullNum: 404, Key: "acme/infra/404/default/a.tfplan", Size: 500, Modified: now},
+	}
+}
+
+func TestGarbageCollector_Collect(t *testing.T) {
+	store := &fakePlanLister{plans: gcTestPlans()}
+	client := &fakePullStateClient{closed: map[int]bool{1: true}}
+	scope := tally.NewTestScope("atlantis", nil)
+	gc := &planstore.GarbageCollector{
+		Store:     store,
+		VCSClient: client,
+		VCSHost:   &models.VCSHost{Type: models.Github, Hostname: "github.com"},
+		Retention: 30 * 24 * time.Hour,
+		Scope:     scope,
+		Logger:    logging.NewNoopLogger(t),
+	}
+
+	result, err := gc.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, planstore.GCResult{Scanned: 5, Removed: 3, BytesReclaimed: 700}, result)
+	assert.Equal(t, []string{
+		"acme/infra/1/default/a.tfplan",
+		"acme/infra/1/default/b.tfplan",
+		"acme/infra/3/default/a.tfplan",
+	}, store.deleted)
+	// The expired plan's pull isn't looked up, and pull 1 is looked up once.
+	assert.Equal(t, 3, client.lookups)
+
+	counters := scope.Snapshot().Counters()
+	assert.EqualValues(t, 2, counters["atlantis.plan_gc.plans_removed+dry_run=false,reason=closed_pull"].Value())
+	assert.EqualValues(t, 1, counters["atlantis.plan_gc.plans_removed+dry_run=false,reason=expired"].Value())
+	assert.EqualValues(t, 700, counters["atlantis.plan_gc.bytes_reclaimed+dry_run=false"].Value())
+	assert.EqualValues(t, 5, scope.Snapshot().Gauges()["atlantis.plan_gc.stored_plans+"].Value())
+}
+
+func TestGarbageCollector_DryRun(t *testing.T) {
+	store := &fakePlanLister{plans: gcTestPlans()}
+	scope := tally.NewTestScope("atlantis", nil)
+	gc := &planstore.GarbageCollector{
+		Store:     store,
+		VCSClient: &fakePullStateClient{closed: map[int]bool{1: true}},
+		VCSHost:   &models.VCSHost{Type: models.Github, Hostname: "github.com"},
+		Retention: 30 * 24 * time.Hour,
+		DryRun:    true,
+		Scope:     scope,
+		Logger:    logging.NewNoopLogger(t),
+	}
+
+	result, err := gc.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Removed)
+	assert.Empty(t, store.deleted)
+	assert.EqualValues(t, 700, scope.Snapshot().Counters()["atlantis.plan_gc.bytes_reclaimed+dry_run=true"].Value())
+}
+
+func TestGarbageCollector_RetentionOnlyWithoutVCSHost(t *testing.T) {
+	store := &fakePlanLister{plans: gcTestPlans()}
+	client := &fakePullStateClient{closed: map[int]bool{1: true}}
+	gc := &planstore.GarbageCollector{
+		Store:     store,
+		VCSClient: client,
+		Retention: 30 * 24 * time.Hour,
+		Logger:    logging.NewNoopLogger(t),
+	}
+
+	_, err := gc.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/infra/3/default/a.tfplan"}, store.deleted)
+	assert.Zero(t, client.lookups)
+}
+
+func TestGarbageCollector_DeleteErrors(t *testing.T) {
+	store := &fakePlanLister{plans: gcTestPlans(), deleteErr: errors.New("access denied")}
+	gc := &planstore.GarbageCollector{
+		Store:     store,
+		Retention: 30 * 24 * time.Hour,
+		Logger:    logging.NewNoopLogger(t),
+	}
+
+	result, err := gc.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, planstore.GCResult{Scanned: 5, Errors: 1}, result)
+}
+
+func TestGarbageCollector_LocalStore(t *testing.T) {
+	planDir := t.TempDir()
+	writePlan(t, planDir, "repos/acme/infra/7/default/default.tfplan", "plan")
+	store := &planstore.LocalPlanStore{SeparatePlanDir: planDir}
+	gc := &planstore.GarbageCollector{
+		Store:     store,
+		VCSClient: &fakePullStateClient{closed: map[int]bool{7: true}},
+		VCSHost:   &models.VCSHost{Type: models.Gitlab, Hostname: "gitlab.com"},
+		Logger:    logging.NewNoopLogger(t),
+	}
+
+	result, err := gc.Collect()
+	require.NoError(t, err)
+	assert.Equal(t, planstore.GCResult{Scanned: 1, Removed: 1, BytesReclaimed: 4}, result)
+	plans, err := store.ListAllPlans()
+	require.NoError(t, err)
+	assert.Empty(t, plans)
+}
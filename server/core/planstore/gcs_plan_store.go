@@ -0,0 +1,127 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// GCSPlanStoreConfig holds configuration for connecting to Google Cloud
+// Storage.
+type GCSPlanStoreConfig struct {
+	Bucket string
+	Prefix string
+	// Endpoint overrides the GCS JSON API endpoint, e.g. for a private
+	// service connect endpoint. To use an emulator such as fake-gcs-server,
+	// set STORAGE_EMULATOR_HOST instead, which also disables authentication.
+	Endpoint string
+}
+
+// GCSPlanStore implements PlanStore by persisting plan files to a Google Cloud
+// Storage bucket.
+type GCSPlanStore struct {
+	blobPlanStore
+}
+
+// NewGCSPlanStore creates a GCSPlanStore using Application Default Credentials.
+func NewGCSPlanStore(cfg GCSPlanStoreConfig, logger logging.SimpleLogging) (*GCSPlanStore, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var opts []option.ClientOption
+	if cfg.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(cfg.Endpoint))
+	}
+	// The client is used for the lifetime of the server, so it must not be
+	// bound to the setup context.
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCS client: %w", err)
+	}
+
+	bucket := client.Bucket(cfg.Bucket)
+	if _, err := bucket.Attrs(ctx); err != nil {
+		client.Close() // nolint: errcheck
+		return nil, fmt.Errorf("validating GCS plan store bucket %q: %w", cfg.Bucket, err)
+	}
+
+	return NewGCSPlanStoreWithClient(&gcsBucketClient{bucket: bucket}, cfg.Bucket, cfg.Prefix, logger), nil
+}
+
+// NewGCSPlanStoreWithClient creates a GCSPlanStore with an injected BlobClient
+// (for testing).
+func NewGCSPlanStoreWithClient(client BlobClient, bucket, prefix string, logger logging.SimpleLogging) *GCSPlanStore {
+	return &GCSPlanStore{
+		blobPlanStore: newBlobPlanStore(client, prefix, "GCS", "gs://"+bucket, logger),
+	}
+}
+
+// gcsBucketClient implements BlobClient for a GCS bucket.
+type gcsBucketClient struct {
+	bucket *storage.BucketHandle
+}
+
+func (c *gcsBucketClient) Upload(ctx context.Context, key string, body io.Reader, metadata map[string]string) error {
+	w := c.bucket.Object(key).NewWriter(ctx)
+	w.Metadata = metadata
+	if _, err := io.Copy(w, body); err != nil {
+		w.Close() // nolint: errcheck
+		return err
+	}
+	return w.Close()
+}
+
+func (c *gcsBucketClient) Download(ctx context.Context, key string) (io.ReadCloser, map[string]string, error) {
+	obj := c.bucket.Object(key)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Pin the generation so the contents match the metadata just read even
+	// if the plan is overwritten in between.
+	r, err := obj.Generation(attrs.Generation).NewReader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, attrs.Metadata, nil
+}
+
+func (c *gcsBucketClient) Delete(ctx context.Context, key string) error {
+	err := c.bucket.Object(key).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (c *gcsBucketClient) List(ctx context.Context, prefix string) ([]BlobObject, error) {
+	it := c.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	var objects []BlobObject
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, BlobObject{Key: attrs.Name, Size: attrs.Size, Updated: attrs.Updated})
+	}
+}
+
+// Ensure GCSPlanStore satisfies PlanStore and PlanLister at compile time.
+var (
+	_ PlanStore  = (*GCSPlanStore)(nil)
+	_ PlanLister = (*GCSPlanStore)(nil)
+)
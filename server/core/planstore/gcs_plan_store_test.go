@@ -0,0 +1,53 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGCSPlanStore_Emulator runs against fake-gcs-server when
+// STORAGE_EMULATOR_HOST is set, e.g.:
+//
+//	docker run -d -p 4443:4443 fsouza/fake-gcs-server -scheme http -public-host localhost:4443
+//	STORAGE_EMULATOR_HOST=localhost:4443 go test ./server/core/planstore/...
+func TestGCSPlanStore_Emulator(t *testing.T) {
+	if os.Getenv("STORAGE_EMULATOR_HOST") == "" {
+		t.Skip("STORAGE_EMULATOR_HOST is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, err := storage.NewClient(ctx)
+	require.NoError(t, err)
+	defer client.Close()
+	bucket := fmt.Sprintf("atlantis-test-%d", time.Now().UnixNano())
+	require.NoError(t, client.Bucket(bucket).Create(ctx, "test-project", nil))
+
+	store, err := planstore.NewGCSPlanStore(planstore.GCSPlanStoreConfig{
+		Bucket: bucket,
+		Prefix: "plans",
+	}, logging.NewNoopLogger(t))
+	require.NoError(t, err)
+	testPlanStoreRoundTrip(t, store)
+}
+
+func TestNewGCSPlanStore_MissingBucket(t *testing.T) {
+	if os.Getenv("STORAGE_EMULATOR_HOST") == "" {
+		t.Skip("STORAGE_EMULATOR_HOST is not set")
+	}
+	_, err := planstore.NewGCSPlanStore(planstore.GCSPlanStoreConfig{
+		Bucket: "atlantis-test-missing",
+	}, logging.NewNoopLogger(t))
+	require.ErrorContains(t, err, "validating GCS plan store bucket")
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// dataKeySize is the size of the AES-256 keys used to encrypt plan files.
+const dataKeySize = 32
+
+// KeyProvider generates and unwraps the data keys used by EncryptedPlanStore.
+// It mirrors the GenerateDataKey/Decrypt API of cloud key management
+// services, so the key that wraps data keys never has to leave the provider.
+type KeyProvider interface {
+	// GenerateDataKey returns a new 32 byte data key, the data key wrapped
+	// by the provider's key, and the ID of that key.
+	GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, keyID string, err error)
+	// Decrypt unwraps a data key that was wrapped by the key with keyID.
+	Decrypt(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyProvider wraps data keys with AES-GCM using a master key read from
+// a local file. It needs no cloud service, so it also stands in for a KMS in
+// development and tests.
+type LocalKeyProvider struct {
+	gcm   cipher.AEAD
+	keyID string
+}
+
+// NewLocalKeyProvider reads a 32 byte master key from keyFile. The file may
+// hold the raw key, or the key encoded as hex or base64.
+func NewLocalKeyProvider(keyFile string) (*LocalKeyProvider, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan encryption key file: %w", err)
+	}
+	key, err := decodeMasterKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan encryption key file %s: %w", keyFile, err)
+	}
+	return NewLocalKeyProviderWithKey(key)
+}
+
+// NewLocalKeyProviderWithKey creates a LocalKeyProvider from a 32 byte master
+// key.
+func NewLocalKeyProviderWithKey(key []byte) (*LocalKeyProvider, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", dataKeySize, len(key))
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	// The key ID identifies which master key wrapped a data key without
+	// revealing it, so a plan encrypted under a rotated key fails with a
+	// clear error.
+	sum := sha256.Sum256(key)
+	return &LocalKeyProvider{gcm: gcm, keyID: "local:" + hex.EncodeToString(sum[:8])}, nil
+}
+
+func decodeMasterKey(data []byte) ([]byte, error) {
+	if len(data) == dataKeySize {
+		return data, nil
+	}
+	text := strings.TrimSpace(string(data))
+	if key, err := hex.DecodeString(text); err == nil && len(key) == dataKeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && len(key) == dataKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("expected a %d byte key, raw or encoded as hex or base64", dataKeySize)
+}
+
+func (p *LocalKeyProvider) GenerateDataKey(_ context.Context) ([]byte, []byte, string, error) {
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, "", fmt.Errorf("generating data key: %w", err)
+	}
+	nonce := make([]byte, p.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, "", fmt.Errorf("generating nonce: %w", err)
+	}
+	wrapped := p.gcm.Seal(nonce, nonce, plaintext, []byte(p.keyID))
+	return plaintext, wrapped, p.keyID, nil
+}
+
+func (p *LocalKeyProvider) Decrypt(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, fmt.Errorf("data key was wrapped by key %q but the configured key is %q", keyID, p.keyID)
+	}
+	nonceSize := p.gcm.NonceSize()
+	if len(wrapped) < nonceSize {
+		return nil, errors.New("wrapped data key is truncated")
+	}
+	plaintext, err := p.gcm.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+	return plaintext, nil
+}
+
+// KMSClient is the subset of the AWS KMS API used by AWSKMSKeyProvider,
+// extracted for testability.
+type KMSClient interface {
+	GenerateDataKey(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// AWSKMSKeyProvider generates and unwraps data keys with an AWS KMS key.
+type AWSKMSKeyProvider struct {
+	client KMSClient
+	keyID  string
+}
+
+// NewAWSKMSKeyProvider creates an AWSKMSKeyProvider for keyID, which may be a
+// key ID, key ARN or alias, using the AWS SDK default credential chain.
+func NewAWSKMSKeyProvider(keyID, region string) (*AWSKMSKeyProvider, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return NewAWSKMSKeyProviderWithClient(kms.NewFromConfig(awsCfg), keyID), nil
+}
+
+// NewAWSKMSKeyProviderWithClient creates an AWSKMSKeyProvider with an
+// injected KMSClient (for testing).
+func NewAWSKMSKeyProviderWithClient(client KMSClient, keyID string) *AWSKMSKeyProvider {
+	return &AWSKMSKeyProvider{client: client, keyID: keyID}
+}
+
+func (p *AWSKMSKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, string, error) {
+	out, err := p.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(p.keyID),
+		KeySpec: kmstypes.DataKeySpecAes256,
+	})
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("generating data key with KMS key %s: %w", p.keyID, err)
+	}
+	// KMS reports the ARN of the key even when an alias was configured, so
+	// decryption keeps working if the alias is later pointed elsewhere.
+	return out.Plaintext, out.CiphertextBlob, aws.ToString(out.KeyId), nil
+}
+
+func (p *AWSKMSKeyProvider) Decrypt(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key with KMS key %s: %w", keyID, err)
+	}
+	return out.Plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Ensure the key providers satisfy KeyProvider at compile time.
+var (
+	_ KeyProvider = (*LocalKeyProvider)(nil)
+	_ KeyProvider = (*AWSKMSKeyProvider)(nil)
+)
+
+// Ensure the real KMS client satisfies our interface at compile time.
+var _ KMSClient = (*kms.Client)(nil)
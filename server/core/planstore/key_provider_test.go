@@ -0,0 +1,116 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalKeyProvider_KeyFileEncodings(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	cases := map[string][]byte{
+		"raw":    key,
+		"hex":    []byte(hex.EncodeToString(key) + "\n"),
+		"base64": []byte(base64.StdEncoding.EncodeToString(key) + "\n"),
+	}
+	want, err := planstore.NewLocalKeyProviderWithKey(key)
+	require.NoError(t, err)
+	_, wrapped, keyID, err := want.GenerateDataKey(context.Background())
+	require.NoError(t, err)
+
+	for name, contents := range cases {
+		t.Run(name, func(t *testing.T) {
+			keyFile := filepath.Join(t.TempDir(), "plan.key")
+			require.NoError(t, os.WriteFile(keyFile, contents, 0o600))
+
+			keys, err := planstore.NewLocalKeyProvider(keyFile)
+			require.NoError(t, err)
+			_, err = keys.Decrypt(context.Background(), keyID, wrapped)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewLocalKeyProvider_InvalidKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "plan.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("too-short"), 0o600))
+
+	_, err := planstore.NewLocalKeyProvider(keyFile)
+	assert.ErrorContains(t, err, "expected a 32 byte key")
+
+	_, err = planstore.NewLocalKeyProvider(filepath.Join(t.TempDir(), "missing.key"))
+	assert.ErrorContains(t, err, "reading plan encryption key file")
+}
+
+func TestLocalKeyProvider_RoundTrip(t *testing.T) {
+	keys, err := planstore.NewLocalKeyProviderWithKey(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	plaintext, wrapped, keyID, err := keys.GenerateDataKey(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, plaintext, 32)
+	assert.NotContains(t, string(wrapped), string(plaintext))
+
+	got, err := keys.Decrypt(context.Background(), keyID, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, got)
+
+	wrapped[len(wrapped)-1] ^= 0xff
+	_, err = keys.Decrypt(context.Background(), keyID, wrapped)
+	assert.ErrorContains(t, err, "unwrapping data key")
+}
+
+// fakeKMSClient stands in for AWS KMS by wrapping data keys with a local key.
+type fakeKMSClient struct {
+	keys        *planstore.LocalKeyProvider
+	decryptedBy string
+}
+
+func (f *fakeKMSClient) GenerateDataKey(ctx context.Context, _ *kms.GenerateDataKeyInput, _ ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+	plaintext, wrapped, _, err := f.keys.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GenerateDataKeyOutput{
+		Plaintext:      plaintext,
+		CiphertextBlob: wrapped,
+		KeyId:          aws.String("arn:aws:kms:us-east-1:123456789012:key/abc"),
+	}, nil
+}
+
+func (f *fakeKMSClient) Decrypt(ctx context.Context, params *kms.DecryptInput, _ ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	f.decryptedBy = aws.ToString(params.KeyId)
+	_, _, keyID, _ := f.keys.GenerateDataKey(ctx)
+	plaintext, err := f.keys.Decrypt(ctx, keyID, params.CiphertextBlob)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.DecryptOutput{Plaintext: plaintext}, nil
+}
+
+func TestAWSKMSKeyProvider_RoundTrip(t *testing.T) {
+	client := &fakeKMSClient{keys: newTestKeyProvider(t, 3)}
+	keys := planstore.NewAWSKMSKeyProviderWithClient(client, "alias/atlantis")
+
+	plaintext, wrapped, keyID, err := keys.GenerateDataKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/abc", keyID)
+
+	got, err := keys.Decrypt(context.Background(), keyID, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, got)
+	assert.Equal(t, keyID, client.decryptedBy)
+}
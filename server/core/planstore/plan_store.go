@@ -5,10 +5,13 @@ package planstore
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/utils"
@@ -29,6 +32,66 @@ func PullDir(root, repoFullName string, pullNum int) string {
 // distinguish this from actual restore failures.
 var ErrRestoreNotSupported = errors.New("plan store does not support restore")
 
+// ErrListNotSupported is returned by PlanLister implementations that have no
+// inventory of plans to list, e.g. a LocalPlanStore without a separate plan
+// dir.
+var ErrListNotSupported = errors.New("plan store does not support listing plans")
+
+// StoredPlan is a plan file held by a plan store.
+type StoredPlan struct {
+	Owner   string
+	Repo    string
+	PullNum int
+	// Key identifies the plan within its store: an object key for external
+	// stores, or a file path for a local plan dir.
+	Key      string
+	Size     int64
+	Modified time.Time
+}
+
+// RepoFullName returns the owner/repo name of the plan's repository.
+func (p StoredPlan) RepoFullName() string {
+	return p.Owner + "/" + p.Repo
+}
+
+// PlanLister is implemented by plan stores that can list every plan they
+// hold, across all pull requests, so plans whose pull request was never
+// cleaned up can be garbage collected.
+type PlanLister interface {
+	// ListAllPlans returns every .tfplan in the store.
+	ListAllPlans() ([]StoredPlan, error)
+	// DeleteStoredPlan removes a plan returned by ListAllPlans.
+	DeleteStoredPlan(plan StoredPlan) error
+}
+
+// parsePlanKey splits the path of a plan relative to the root of a store,
+// <owner>/<repo>/<pullNum>/<workspace>/<repoRelDir>/<planfile>, into the pull
+// request it belongs to. Owners (GitLab subgroups) and repo rel dirs may both
+// span several segments, so the path is anchored on the plan file name, which
+// always ends with the workspace: the pull number is the segment right before
+// a workspace segment the file name ends with. Paths that can be split more
+// than one way are rejected rather than guessed at, since a wrong guess could
+// collect a plan of an open pull request.
+func parsePlanKey(rel string) (owner, repo string, pullNum int, ok bool) {
+	parts := strings.Split(rel, "/")
+	filename := parts[len(parts)-1]
+	for i := 3; i < len(parts)-1; i++ {
+		workspace := parts[i]
+		if filename != workspace+".tfplan" && !strings.HasSuffix(filename, "-"+workspace+".tfplan") {
+			continue
+		}
+		n, err := strconv.Atoi(parts[i-1])
+		if err != nil || strconv.Itoa(n) != parts[i-1] {
+			continue
+		}
+		if ok {
+			return "", "", 0, false
+		}
+		owner, repo, pullNum, ok = strings.Join(parts[:i-2], "/"), parts[i-2], n, true
+	}
+	return owner, repo, pullNum, ok
+}
+
 // PlanStore abstracts plan file persistence.
 // LocalPlanStore wraps current filesystem behavior (Save/Load are no-ops).
 // S3PlanStore uploads after plan and downloads before apply.
@@ -159,6 +222,60 @@ func containsPlanFile(dir string) (bool, error) {
 	return found, nil
 }
 
+// ListAllPlans lists the plans in the separate plan store dir. Without one,
+// plans live in the checkouts and are removed with them.
+func (s *LocalPlanStore) ListAllPlans() ([]StoredPlan, error) {
+	if s.SeparatePlanDir == "" {
+		return nil, ErrListNotSupported
+	}
+	root := filepath.Join(s.SeparatePlanDir, ReposDir)
+	var plans []StoredPlan
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && path == root {
+			return fs.SkipAll
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".tfplan" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		owner, repo, pullNum, ok := parsePlanKey(filepath.ToSlash(rel))
+		if !ok {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		plans = append(plans, StoredPlan{
+			Owner:    owner,
+			Repo:     repo,
+			PullNum:  pullNum,
+			Key:      path,
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		})
+		return nil
+	})
+	return plans, err
+}
+
+// DeleteStoredPlan removes a plan file from the separate plan store dir.
+func (s *LocalPlanStore) DeleteStoredPlan(plan StoredPlan) error {
+	if s.SeparatePlanDir == "" {
+		return ErrListNotSupported
+	}
+	if err := utils.EnsureSubPath(s.SeparatePlanDir, plan.Key); err != nil {
+		return fmt.Errorf("refusing to delete plan outside the plan dir: %w", err)
+	}
+	return utils.RemoveIgnoreNonExistent(plan.Key)
+}
+
 func (s *LocalPlanStore) DeleteForPull(_, _ string, _ int) error {
 	return nil // no-op: working dir deletion handles local files
 }
@@ -166,3 +283,6 @@ func (s *LocalPlanStore) DeleteForPull(_, _ string, _ int) error {
 func (s *LocalPlanStore) DeletePlanForProject(_, _ string, _ int, _, _, _ string) error {
 	return nil // no-op: local plan deleted by WorkingDir.DeletePlan
 }
+
+// Ensure LocalPlanStore satisfies PlanLister at compile time.
+var _ PlanLister = (*LocalPlanStore)(nil)
@@ -100,6 +100,71 @@ func TestLocalPlanStore_ListWorkspaces(t *testing.T) {
 	Equals(t, []string{"default", "staging"}, workspaces)
 }
 
+func TestParsePlanKey(t *testing.T) {
+	cases := []struct {
+		description string
+		key         string
+		owner       string
+		repo        string
+		pullNum     int
+		ok          bool
+	}{
+		{
+			description: "simple owner",
+			key:         "acme/infra/7/default/./default.tfplan",
+			owner:       "acme",
+			repo:        "infra",
+			pullNum:     7,
+			ok:          true,
+		},
+		{
+			description: "project plan in a nested dir",
+			key:         "acme/infra/7/staging/envs/12/app-staging.tfplan",
+			owner:       "acme",
+			repo:        "infra",
+			pullNum:     7,
+			ok:          true,
+		},
+		{
+			description: "numeric subgroup",
+			key:         "group/2024/infra/7/default/./default.tfplan",
+			owner:       "group/2024",
+			repo:        "infra",
+			pullNum:     7,
+			ok:          true,
+		},
+		{
+			description: "numeric subgroup and numeric repo",
+			key:         "group/2024/42/7/default/modules/3/default.tfplan",
+			owner:       "group/2024",
+			repo:        "42",
+			pullNum:     7,
+			ok:          true,
+		},
+		{
+			description: "ambiguous",
+			key:         "acme/infra/7/default/1/default/default.tfplan",
+		},
+		{
+			description: "no pull number",
+			key:         "acme/infra/default/./default.tfplan",
+		},
+		{
+			description: "not a plan",
+			key:         "acme/infra/7/default/./plan.json",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			owner, repo, pullNum, ok := parsePlanKey(c.key)
+			Equals(t, c.ok, ok)
+			Equals(t, c.owner, owner)
+			Equals(t, c.repo, repo)
+			Equals(t, c.pullNum, pullNum)
+		})
+	}
+}
+
 // An unknown pull request is not an error, it just has nothing stored.
 func TestLocalPlanStore_ListWorkspacesMissingPullDir(t *testing.T) {
 	store := &LocalPlanStore{SeparatePlanDir: t.TempDir()}
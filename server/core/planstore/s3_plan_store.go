@@ -418,6 +418,65 @@ func (s *S3PlanStore) DeleteForPull(owner, repo string, pullNum int) error {
 	return nil
 }
 
+// ListAllPlans lists every plan object under the store's prefix.
+func (s *S3PlanStore) ListAllPlans() ([]StoredPlan, error) {
+	rootPrefix := ""
+	if s.prefix != "" {
+		rootPrefix = s.prefix + "/"
+	}
+
+	var plans []StoredPlan
+	var continuationToken *string
+	for {
+		opCtx, opCancel := s3Ctx()
+		resp, err := s.client.ListObjectsV2(opCtx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(s.bucket),
+			Prefix:            aws.String(rootPrefix),
+			ContinuationToken: continuationToken,
+		})
+		opCancel()
+		if err != nil {
+			return nil, fmt.Errorf("listing plans from S3 (prefix=%s): %w", rootPrefix, err)
+		}
+		for _, obj := range resp.Contents {
+			key := aws.ToString(obj.Key)
+			if !strings.HasSuffix(key, ".tfplan") {
+				continue
+			}
+			owner, repo, pullNum, ok := parsePlanKey(strings.TrimPrefix(key, rootPrefix))
+			if !ok {
+				continue
+			}
+			plans = append(plans, StoredPlan{
+				Owner:    owner,
+				Repo:     repo,
+				PullNum:  pullNum,
+				Key:      key,
+				Size:     aws.ToInt64(obj.Size),
+				Modified: aws.ToTime(obj.LastModified),
+			})
+		}
+		if !aws.ToBool(resp.IsTruncated) {
+			break
+		}
+		continuationToken = resp.NextContinuationToken
+	}
+	return plans, nil
+}
+
+// DeleteStoredPlan deletes a plan object returned by ListAllPlans.
+func (s *S3PlanStore) DeleteStoredPlan(plan StoredPlan) error {
+	opCtx, opCancel := s3Ctx()
+	defer opCancel()
+	if _, err := s.client.DeleteObject(opCtx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(plan.Key),
+	}); err != nil {
+		return fmt.Errorf("deleting plan from S3 (key=%s): %w", plan.Key, err)
+	}
+	return nil
+}
+
 // s3Key builds a deterministic S3 object key from the ProjectContext and plan filename.
 // Format: <prefix>/<owner>/<repo>/<pullNum>/<workspace>/<repoRelDir>/<planfilename>
 func (s *S3PlanStore) s3Key(ctx command.ProjectContext, planPath string) string {
@@ -468,8 +527,11 @@ func (s *S3PlanStore) DeletePlanForProject(owner, repo string, pullNum int, work
 	return nil
 }
 
-// Ensure S3PlanStore satisfies PlanStore at compile time.
-var _ PlanStore = (*S3PlanStore)(nil)
+// Ensure S3PlanStore satisfies PlanStore and PlanLister at compile time.
+var (
+	_ PlanStore  = (*S3PlanStore)(nil)
+	_ PlanLister = (*S3PlanStore)(nil)
+)
 
 // Ensure the real S3 client satisfies our interface at compile time.
 var _ S3Client = (*s3.Client)(nil)
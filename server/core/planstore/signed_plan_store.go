@@ -0,0 +1,276 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// ManifestSuffix is appended to a plan file's path to get the path of its
+// manifest. It doesn't end in .tfplan, so manifests are never mistaken for
+// plans.
+const ManifestSuffix = ".manifest.json"
+
+// minSigningKeySize is the minimum size of a plan signing key.
+const minSigningKeySize = 32
+
+// PlanManifest records what a plan file was created for. It is signed with
+// an HMAC so a plan can't be swapped, or moved to another project or commit,
+// between plan and apply without the signature failing to match.
+type PlanManifest struct {
+	Version          int    `json:"version"`
+	PlanSHA256       string `json:"plan_sha256"`
+	Repo             string `json:"repo"`
+	PullNum          int    `json:"pull_num"`
+	HeadCommit       string `json:"head_commit"`
+	Project          string `json:"project"`
+	Workspace        string `json:"workspace"`
+	RepoRelDir       string `json:"repo_rel_dir"`
+	TerraformVersion string `json:"terraform_version"`
+	Signature        string `json:"signature"`
+}
+
+// PlanVerificationError is returned by SignedPlanStore.Load when a plan's
+// manifest is missing or doesn't match the plan or the project it is being
+// applied to.
+type PlanVerificationError struct {
+	// Reason completes the sentence "the plan ...".
+	Reason string
+}
+
+func (e *PlanVerificationError) Error() string {
+	return "plan " + e.Reason
+}
+
+// SignedPlanStore is a PlanStore decorator that records a signed manifest for
+// every plan it saves and verifies it when the plan is loaded. Manifests are
+// saved next to their plan, through the wrapped store, so they work the same
+// in the local plan dir and in external stores.
+type SignedPlanStore struct {
+	store  PlanStore
+	key    []byte
+	logger logging.SimpleLogging
+}
+
+// LoadPlanSigningKey reads a plan signing key of at least 32 bytes from
+// keyFile. Surrounding whitespace is ignored.
+func LoadPlanSigningKey(keyFile string) ([]byte, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan signing key file: %w", err)
+	}
+	key := []byte(strings.TrimSpace(string(data)))
+	if len(key) < minSigningKeySize {
+		return nil, fmt.Errorf("plan signing key in %s must be at least %d bytes, got %d", keyFile, minSigningKeySize, len(key))
+	}
+	return key, nil
+}
+
+// NewSignedPlanStore returns a SignedPlanStore that wraps store and signs
+// manifests with key.
+func NewSignedPlanStore(store PlanStore, key []byte, logger logging.SimpleLogging) *SignedPlanStore {
+	return &SignedPlanStore{store: store, key: key, logger: logger}
+}
+
+// Save saves the plan, then writes and saves its signed manifest.
+func (s *SignedPlanStore) Save(ctx command.ProjectContext, planPath string) error {
+	manifest, err := s.newManifest(ctx, planPath)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("encoding plan manifest: %w", err)
+	}
+	if err := s.store.Save(ctx, planPath); err != nil {
+		return err
+	}
+	manifestPath := planPath + ManifestSuffix
+	if err := os.WriteFile(manifestPath, data, 0o600); err != nil {
+		return fmt.Errorf("writing plan manifest: %w", err)
+	}
+	if err := s.store.Save(ctx, manifestPath); err != nil {
+		return fmt.Errorf("saving plan manifest: %w", err)
+	}
+	return nil
+}
+
+// Load loads the plan and its manifest, then checks that the manifest's
+// signature is valid and that it matches the plan file and ctx. Workflows
+// made only of custom run steps manage their own plan files, which Save never
+// sees, so their plans aren't checked.
+func (s *SignedPlanStore) Load(ctx command.ProjectContext, planPath string) error {
+	if err := s.store.Load(ctx, planPath); err != nil {
+		return err
+	}
+	if !ctx.RequiresAtlantisManagedPlanFile {
+		return nil
+	}
+	if _, err := os.Stat(planPath); os.IsNotExist(err) {
+		// Nothing to apply; the caller reports the missing plan.
+		return nil
+	}
+	manifestPath := planPath + ManifestSuffix
+	if err := s.store.Load(ctx, manifestPath); err != nil {
+		return fmt.Errorf("loading plan manifest: %w", err)
+	}
+	return s.verify(ctx, planPath, manifestPath)
+}
+
+// Remove removes the plan and its manifest.
+func (s *SignedPlanStore) Remove(ctx command.ProjectContext, planPath string) error {
+	if err := s.store.Remove(ctx, planPath); err != nil {
+		return err
+	}
+	return s.store.Remove(ctx, planPath+ManifestSuffix)
+}
+
+func (s *SignedPlanStore) ListWorkspaces(owner, repo string, pullNum int) ([]string, error) {
+	return s.store.ListWorkspaces(owner, repo, pullNum)
+}
+
+// RestorePlans only restores plans; Load fetches each manifest before the
+// plan is applied.
+func (s *SignedPlanStore) RestorePlans(pullDir, owner, repo string, pullNum int) error {
+	return s.store.RestorePlans(pullDir, owner, repo, pullNum)
+}
+
+func (s *SignedPlanStore) DeleteForPull(owner, repo string, pullNum int) error {
+	return s.store.DeleteForPull(owner, repo, pullNum)
+}
+
+// DeletePlanForProject deletes the project's plan. An external copy of its
+// manifest is left until the pull request is closed; without the plan it is
+// never read.
+func (s *SignedPlanStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, repoRelDir, projectName string) error {
+	return s.store.DeletePlanForProject(owner, repo, pullNum, workspace, repoRelDir, projectName)
+}
+
+func (s *SignedPlanStore) ListAllPlans() ([]StoredPlan, error) {
+	lister, ok := s.store.(PlanLister)
+	if !ok {
+		return nil, ErrListNotSupported
+	}
+	return lister.ListAllPlans()
+}
+
+// DeleteStoredPlan deletes the plan and its manifest, whose key is the plan's
+// with ManifestSuffix appended in every store.
+func (s *SignedPlanStore) DeleteStoredPlan(plan StoredPlan) error {
+	lister, ok := s.store.(PlanLister)
+	if !ok {
+		return ErrListNotSupported
+	}
+	if err := lister.DeleteStoredPlan(plan); err != nil {
+		return err
+	}
+	manifest := plan
+	manifest.Key += ManifestSuffix
+	manifest.Size = 0
+	return lister.DeleteStoredPlan(manifest)
+}
+
+func (s *SignedPlanStore) newManifest(ctx command.ProjectContext, planPath string) (PlanManifest, error) {
+	planHash, err := fileSHA256(planPath)
+	if err != nil {
+		return PlanManifest{}, fmt.Errorf("hashing plan for manifest: %w", err)
+	}
+	manifest := expectedManifest(ctx)
+	manifest.PlanSHA256 = planHash
+	manifest.Signature = s.sign(manifest)
+	return manifest, nil
+}
+
+func (s *SignedPlanStore) verify(ctx command.ProjectContext, planPath, manifestPath string) error {
+	data, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return &PlanVerificationError{Reason: "has no signed manifest"}
+	}
+	if err != nil {
+		return fmt.Errorf("reading plan manifest: %w", err)
+	}
+	var manifest PlanManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return &PlanVerificationError{Reason: "has a manifest that can't be read"}
+	}
+	if !hmac.Equal([]byte(manifest.Signature), []byte(s.sign(manifest))) {
+		return &PlanVerificationError{Reason: "has a manifest with an invalid signature"}
+	}
+
+	planHash, err := fileSHA256(planPath)
+	if err != nil {
+		return fmt.Errorf("hashing plan: %w", err)
+	}
+	if planHash != manifest.PlanSHA256 {
+		return &PlanVerificationError{Reason: "file was modified after it was created"}
+	}
+
+	exp := expectedManifest(ctx)
+	switch {
+	case manifest.HeadCommit != exp.HeadCommit:
+		return &PlanVerificationError{Reason: fmt.Sprintf("was created at commit %.8s but the pull request is now at %.8s", manifest.HeadCommit, exp.HeadCommit)}
+	case manifest.Repo != exp.Repo || manifest.PullNum != exp.PullNum:
+		return &PlanVerificationError{Reason: fmt.Sprintf("was created for %s#%d but is being applied to %s#%d", manifest.Repo, manifest.PullNum, exp.Repo, exp.PullNum)}
+	case manifest.Project != exp.Project || manifest.Workspace != exp.Workspace || manifest.RepoRelDir != exp.RepoRelDir:
+		return &PlanVerificationError{Reason: fmt.Sprintf("was created for dir %q workspace %q project %q but is being applied to dir %q workspace %q project %q",
+			manifest.RepoRelDir, manifest.Workspace, manifest.Project, exp.RepoRelDir, exp.Workspace, exp.Project)}
+	case manifest.TerraformVersion != exp.TerraformVersion:
+		return &PlanVerificationError{Reason: fmt.Sprintf("was created with Terraform version %q but is being applied with %q", manifest.TerraformVersion, exp.TerraformVersion)}
+	}
+	s.logger.Debug("verified plan manifest for %s", planPath)
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of manifest without its signature.
+func (s *SignedPlanStore) sign(manifest PlanManifest) string {
+	manifest.Signature = ""
+	// Marshalling a struct is deterministic, so this is stable across
+	// Atlantis restarts and versions that share the manifest format.
+	data, _ := json.Marshal(manifest) // nolint: errchkjson
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// expectedManifest returns the unsigned manifest fields that describe ctx.
+func expectedManifest(ctx command.ProjectContext) PlanManifest {
+	var tfVersion string
+	if ctx.TerraformVersion != nil {
+		tfVersion = ctx.TerraformVersion.String()
+	}
+	return PlanManifest{
+		Version:          1,
+		Repo:             ctx.Pull.BaseRepo.FullName,
+		PullNum:          ctx.Pull.Num,
+		HeadCommit:       ctx.Pull.HeadCommit,
+		Project:          ctx.ProjectName,
+		Workspace:        ctx.Workspace,
+		RepoRelDir:       ctx.RepoRelDir,
+		TerraformVersion: tfVersion,
+	}
+}
+
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Ensure SignedPlanStore satisfies PlanStore and PlanLister at compile time.
+var (
+	_ PlanStore  = (*SignedPlanStore)(nil)
+	_ PlanLister = (*SignedPlanStore)(nil)
+)
@@ -0,0 +1,174 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planstore_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigningKey = bytes.Repeat([]byte("k"), 32)
+
+// signedProjectContext returns a project context for a plan managed by the
+// built-in plan step, which is the only kind SignedPlanStore verifies.
+func signedProjectContext() command.ProjectContext {
+	ctx := committedProjectContext()
+	ctx.Pull.BaseRepo.FullName = "acme/infra"
+	ctx.ProjectName = "vpc"
+	ctx.RequiresAtlantisManagedPlanFile = true
+	return ctx
+}
+
+// newLocalSignedTestStore returns a SignedPlanStore over the local plan store,
+// so plans and manifests are only ever on disk, and a saved plan.
+func newLocalSignedTestStore(t *testing.T) (*planstore.SignedPlanStore, command.ProjectContext, string) {
+	t.Helper()
+	store := planstore.NewSignedPlanStore(&planstore.LocalPlanStore{}, testSigningKey, logging.NewNoopLogger(t))
+	ctx := signedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "vpc-default.tfplan", "plan-content")
+	require.NoError(t, store.Save(ctx, planPath))
+	return store, ctx, planPath
+}
+
+func requireVerificationError(t *testing.T, err error, reason string) {
+	t.Helper()
+	var verifyErr *planstore.PlanVerificationError
+	require.True(t, errors.As(err, &verifyErr), "expected a PlanVerificationError, got %v", err)
+	assert.Contains(t, verifyErr.Reason, reason)
+}
+
+func TestSignedPlanStore_RoundTrip(t *testing.T) {
+	inner := planstore.NewGCSPlanStoreWithClient(newFakeBlobClient(), "bucket", "", logging.NewNoopLogger(t))
+	testPlanStoreRoundTrip(t, planstore.NewSignedPlanStore(inner, testSigningKey, logging.NewNoopLogger(t)))
+}
+
+func TestSignedPlanStore_ExternalStore(t *testing.T) {
+	client := newFakeBlobClient()
+	inner := planstore.NewGCSPlanStoreWithClient(client, "bucket", "", logging.NewNoopLogger(t))
+	store := planstore.NewSignedPlanStore(inner, testSigningKey, logging.NewNoopLogger(t))
+	ctx := signedProjectContext()
+	planPath := writePlan(t, t.TempDir(), "vpc-default.tfplan", "plan-content")
+	require.NoError(t, store.Save(ctx, planPath))
+
+	assert.Equal(t, []string{
+		"acme/infra/42/default/modules/vpc/vpc-default.tfplan",
+		"acme/infra/42/default/modules/vpc/vpc-default.tfplan.manifest.json",
+	}, client.keys())
+
+	// Both the plan and its manifest are downloaded into a fresh checkout.
+	freshPath := filepath.Join(t.TempDir(), "vpc-default.tfplan")
+	require.NoError(t, store.Load(ctx, freshPath))
+
+	// A plan replaced in the bucket is rejected.
+	key := "acme/infra/42/default/modules/vpc/vpc-default.tfplan"
+	blob := client.blobs[key]
+	blob.body = []byte("malicious-plan")
+	client.blobs[key] = blob
+	requireVerificationError(t, store.Load(ctx, freshPath), "modified after it was created")
+}
+
+func TestSignedPlanStore_LoadRejectsModifiedPlan(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	require.NoError(t, os.WriteFile(planPath, []byte("malicious-plan"), 0o600))
+
+	err := store.Load(ctx, planPath)
+	requireVerificationError(t, err, "modified after it was created")
+	assert.EqualError(t, err, "plan file was modified after it was created")
+}
+
+func TestSignedPlanStore_LoadRejectsNewCommit(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	ctx.Pull.HeadCommit = "def4567890"
+
+	requireVerificationError(t, store.Load(ctx, planPath), "was created at commit abc123 but the pull request is now at def45678")
+}
+
+func TestSignedPlanStore_LoadRejectsOtherProject(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	ctx.ProjectName = "dns"
+
+	requireVerificationError(t, store.Load(ctx, planPath), `project "vpc" but is being applied to dir "modules/vpc" workspace "default" project "dns"`)
+}
+
+func TestSignedPlanStore_LoadRejectsOtherTerraformVersion(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	ctx.TerraformVersion = version.Must(version.NewVersion("1.9.0"))
+
+	requireVerificationError(t, store.Load(ctx, planPath), `created with Terraform version "" but is being applied with "1.9.0"`)
+}
+
+func TestSignedPlanStore_LoadRejectsMissingManifest(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	require.NoError(t, os.Remove(planPath+planstore.ManifestSuffix))
+
+	requireVerificationError(t, store.Load(ctx, planPath), "has no signed manifest")
+}
+
+func TestSignedPlanStore_LoadRejectsForgedManifest(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	require.NoError(t, os.WriteFile(planPath, []byte("malicious-plan"), 0o600))
+
+	// Update the manifest to match the new plan without re-signing it.
+	manifestPath := planPath + planstore.ManifestSuffix
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	var manifest planstore.PlanManifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	manifest.PlanSHA256 = "7a0a4bfa2f7a9fb1e8e1b2a0d5ff42e35d1e0df1a0c3b1e8c9e5cf1b7e3a6a1d"
+	data, err = json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestPath, data, 0o600))
+
+	requireVerificationError(t, store.Load(ctx, planPath), "invalid signature")
+}
+
+func TestSignedPlanStore_LoadRejectsOtherKey(t *testing.T) {
+	_, ctx, planPath := newLocalSignedTestStore(t)
+	rotated := planstore.NewSignedPlanStore(&planstore.LocalPlanStore{}, bytes.Repeat([]byte("r"), 32), logging.NewNoopLogger(t))
+
+	requireVerificationError(t, rotated.Load(ctx, planPath), "invalid signature")
+}
+
+func TestSignedPlanStore_LoadSkipsCustomPlanFiles(t *testing.T) {
+	store := planstore.NewSignedPlanStore(&planstore.LocalPlanStore{}, testSigningKey, logging.NewNoopLogger(t))
+	ctx := signedProjectContext()
+	ctx.RequiresAtlantisManagedPlanFile = false
+	planPath := writePlan(t, t.TempDir(), "vpc-default.tfplan", "custom-plan")
+
+	assert.NoError(t, store.Load(ctx, planPath))
+}
+
+func TestSignedPlanStore_RemoveRemovesManifest(t *testing.T) {
+	store, ctx, planPath := newLocalSignedTestStore(t)
+	require.NoError(t, store.Remove(ctx, planPath))
+
+	_, err := os.Stat(planPath + planstore.ManifestSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLoadPlanSigningKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "plan-signing.key")
+	require.NoError(t, os.WriteFile(keyFile, append(testSigningKey, '\n'), 0o600))
+	key, err := planstore.LoadPlanSigningKey(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, testSigningKey, key)
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("too-short"), 0o600))
+	_, err = planstore.LoadPlanSigningKey(keyFile)
+	assert.ErrorContains(t, err, "must be at least 32 bytes, got 9")
+
+	_, err = planstore.LoadPlanSigningKey(filepath.Join(t.TempDir(), "missing.key"))
+	assert.ErrorContains(t, err, "reading plan signing key file")
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// DriftStorage is a drift.Storage backed by the same PostgreSQL database used
+// for locks, so drift results survive restarts and are shared by all servers.
+// Each record is a row keyed by its repository and serialized
+// drift.RecordKey.
+type DriftStorage struct {
+	db *PostgresDB
+	// retention is how long a record is kept after LastChecked. Zero keeps
+	// records forever.
+	retention time.Duration
+}
+
+var _ drift.Storage = (*DriftStorage)(nil)
+var _ drift.Compactor = (*DriftStorage)(nil)
+
+// NewDriftStorage returns a drift storage that shares p's connection pool.
+func NewDriftStorage(p *PostgresDB, retention time.Duration) *DriftStorage {
+	return &DriftStorage{db: p, retention: retention}
+}
+
+// Store saves a drift result for a project. PlanOutput is stripped before
+// persisting.
+func (d *DriftStorage) Store(repository string, projectDrift models.ProjectDrift) error {
+	projectDrift.PlanOutput = ""
+	key, err := json.Marshal(drift.KeyFor(projectDrift))
+	if err != nil {
+		return fmt.Errorf("serializing drift key: %w", err)
+	}
+	serialized, err := json.Marshal(projectDrift)
+	if err != nil {
+		return fmt.Errorf("serializing drift: %w", err)
+	}
+	_, err = d.db.pool.Exec(ctx, `INSERT INTO atlantis_drift (repo_full_name, drift_key, last_checked, drift) VALUES ($1, $2, $3, $4)
+ON CONFLICT (repo_full_name, drift_key) DO UPDATE SET last_checked = EXCLUDED.last_checked, drift = EXCLUDED.drift`,
+		repository, string(key), projectDrift.LastChecked, serialized)
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves drift results for a repository with optional filtering.
+// Records older than the retention period are never returned.
+func (d *DriftStorage) Get(repository string, opts drift.GetOptions) ([]models.ProjectDrift, error) {
+	rows, err := d.db.pool.Query(ctx, "SELECT drift_key, drift FROM atlantis_drift WHERE repo_full_name = $1 ORDER BY drift_key", repository)
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByPos[driftRow])
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	result := make([]models.ProjectDrift, 0, len(records))
+	now := time.Now()
+	for _, record := range records {
+		projectDrift, err := record.decode()
+		if err != nil {
+			return nil, err
+		}
+		if d.expired(projectDrift, now) || !drift.MatchesGetOptions(projectDrift, opts, now) {
+			continue
+		}
+		result = append(result, projectDrift)
+	}
+	return result, nil
+}
+
+// Delete removes drift results for a repository.
+// If projectName is empty, all drift results for the repository are removed.
+func (d *DriftStorage) Delete(repository string, projectName string) error {
+	if projectName == "" {
+		if _, err := d.db.pool.Exec(ctx, "DELETE FROM atlantis_drift WHERE repo_full_name = $1", repository); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		return nil
+	}
+	return d.deleteWhere(repository, func(projectDrift models.ProjectDrift) bool {
+		return projectDrift.ProjectName == projectName
+	})
+}
+
+// DeleteMatching removes drift results for a repository that match the given filters.
+func (d *DriftStorage) DeleteMatching(repository string, opts drift.GetOptions) error {
+	if opts == (drift.GetOptions{}) {
+		return errors.New("at least one drift delete filter is required")
+	}
+	now := time.Now()
+	return d.deleteWhere(repository, func(projectDrift models.ProjectDrift) bool {
+		return drift.MatchesDeleteOptions(projectDrift, opts, now)
+	})
+}
+
+// GetAll retrieves all unexpired drift results across all repositories.
+func (d *DriftStorage) GetAll() (map[string][]models.ProjectDrift, error) {
+	rows, err := d.db.pool.Query(ctx, "SELECT repo_full_name, drift_key, drift FROM atlantis_drift ORDER BY repo_full_name, drift_key")
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByPos[repoDriftRow])
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	result := make(map[string][]models.ProjectDrift)
+	now := time.Now()
+	for _, record := range records {
+		projectDrift, err := record.decode()
+		if err != nil {
+			return nil, err
+		}
+		if !d.expired(projectDrift, now) {
+			result[record.Repository] = append(result[record.Repository], projectDrift)
+		}
+	}
+	return result, nil
+}
+
+// Compact removes all records older than the retention period.
+func (d *DriftStorage) Compact() (int, error) {
+	if d.retention <= 0 {
+		return 0, nil
+	}
+	tag, err := d.db.pool.Exec(ctx, "DELETE FROM atlantis_drift WHERE last_checked < $1", time.Now().Add(-d.retention))
+	if err != nil {
+		return 0, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (d *DriftStorage) expired(projectDrift models.ProjectDrift, now time.Time) bool {
+	return d.retention > 0 && now.Sub(projectDrift.LastChecked) > d.retention
+}
+
+// deleteWhere deletes every record of the repository matching match. The
+// rows are locked while they are matched so concurrent stores wait.
+func (d *DriftStorage) deleteWhere(repository string, match func(models.ProjectDrift) bool) error {
+	err := pgx.BeginFunc(ctx, d.db.pool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT drift_key, drift FROM atlantis_drift WHERE repo_full_name = $1 FOR UPDATE", repository)
+		if err != nil {
+			return err
+		}
+		records, err := pgx.CollectRows(rows, pgx.RowToStructByPos[driftRow])
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			projectDrift, err := record.decode()
+			if err != nil {
+				return err
+			}
+			if !match(projectDrift) {
+				continue
+			}
+			if _, err := tx.Exec(ctx, "DELETE FROM atlantis_drift WHERE repo_full_name = $1 AND drift_key = $2", repository, record.Key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// driftRow is a row of atlantis_drift.
+type driftRow struct {
+	Key   string
+	Drift []byte
+}
+
+func (r driftRow) decode() (models.ProjectDrift, error) {
+	var projectDrift models.ProjectDrift
+	if err := json.Unmarshal(r.Drift, &projectDrift); err != nil {
+		return models.ProjectDrift{}, fmt.Errorf("failed to deserialize drift at key '%s': %w", r.Key, err)
+	}
+	return projectDrift, nil
+}
+
+// repoDriftRow is a row of atlantis_drift with its repository.
+type repoDriftRow struct {
+	Repository string
+	driftRow
+}
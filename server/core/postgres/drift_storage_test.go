@@ -0,0 +1,100 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/postgres"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestDriftStorage_StoreAndGet(t *testing.T) {
+	storage := postgres.NewDriftStorage(newTestDB(t), 0)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "main",
+		PlanOutput:  "secret plan output",
+		Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1},
+		LastChecked: time.Now(),
+	}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "feature",
+		LastChecked: time.Now(),
+	}))
+	// Storing the same identity again replaces the record.
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "feature",
+		Drift:       models.DriftSummary{HasDrift: true, ToChange: 2},
+		LastChecked: time.Now(),
+	}))
+	Ok(t, storage.Store("owner/repo-two", models.ProjectDrift{ProjectName: "other", LastChecked: time.Now()}))
+
+	results, err := storage.Get("owner/repo", drift.GetOptions{Ref: "main"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, 1, results[0].Drift.ToAdd)
+	Equals(t, "", results[0].PlanOutput)
+
+	results, err = storage.Get("owner/repo", drift.GetOptions{Ref: "feature"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, 2, results[0].Drift.ToChange)
+
+	all, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 2, len(all))
+}
+
+func TestDriftStorage_RetentionAndCompact(t *testing.T) {
+	storage := postgres.NewDriftStorage(newTestDB(t), time.Hour)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "old", LastChecked: time.Now().Add(-2 * time.Hour)}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "new", LastChecked: time.Now()}))
+
+	results, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, "new", results[0].ProjectName)
+
+	removed, err := storage.Compact()
+	Ok(t, err)
+	Equals(t, 1, removed)
+}
+
+func TestDriftStorage_DeleteAndGetAll(t *testing.T) {
+	storage := postgres.NewDriftStorage(newTestDB(t), 0)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "a", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "b", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "c", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/other", models.ProjectDrift{ProjectName: "d", Ref: "main", LastChecked: time.Now()}))
+
+	ErrContains(t, "at least one drift delete filter is required", storage.DeleteMatching("owner/repo", drift.GetOptions{}))
+	Ok(t, storage.DeleteMatching("owner/repo", drift.GetOptions{ProjectName: "a"}))
+	Ok(t, storage.Delete("owner/repo", "b"))
+
+	all, err := storage.GetAll()
+	Ok(t, err)
+	Equals(t, 1, len(all["owner/repo"]))
+	Equals(t, "c", all["owner/repo"][0].ProjectName)
+	Equals(t, 1, len(all["owner/other"]))
+
+	Ok(t, storage.Delete("owner/repo", ""))
+	results, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockID is the key of the advisory lock held while migrating, so
+// that Atlantis servers starting at the same time don't migrate concurrently.
+// It's an arbitrary constant ("atlantis" in hex).
+const migrationLockID = 0x61746c616e746973
+
+// migrations are applied in order and recorded in atlantis_schema_migrations.
+// Migration n (1-based) is applied once, so existing migrations must never be
+// changed; add a new one instead.
+var migrations = []string{
+	// 1: locks, lock queues, pull statuses and command locks.
+	`
+CREATE TABLE atlantis_locks (
+	lock_key       TEXT PRIMARY KEY,
+	repo_full_name TEXT NOT NULL,
+	pull_num       INTEGER NOT NULL,
+	lock           JSONB NOT NULL
+);
+CREATE INDEX atlantis_locks_pull_idx ON atlantis_locks (repo_full_name, pull_num);
+
+CREATE TABLE atlantis_lock_waiters (
+	id             BIGSERIAL PRIMARY KEY,
+	lock_key       TEXT NOT NULL,
+	repo_full_name TEXT NOT NULL,
+	pull_num       INTEGER NOT NULL,
+	waiter         JSONB NOT NULL,
+	UNIQUE (lock_key, pull_num)
+);
+CREATE INDEX atlantis_lock_waiters_pull_idx ON atlantis_lock_waiters (repo_full_name, pull_num);
+
+CREATE TABLE atlantis_pull_statuses (
+	pull_key TEXT PRIMARY KEY,
+	status   JSONB NOT NULL
+);
+
+CREATE TABLE atlantis_command_locks (
+	command_name TEXT PRIMARY KEY,
+	lock         JSONB NOT NULL
+);
+`,
+	// 2: webhook dead letters.
+	`
+CREATE TABLE atlantis_webhook_dead_letters (
+	id     BIGSERIAL PRIMARY KEY,
+	letter JSONB NOT NULL
+);
+`,
+	// 3: drift results and remediation history.
+	`
+CREATE TABLE atlantis_drift (
+	repo_full_name TEXT NOT NULL,
+	drift_key      TEXT NOT NULL,
+	last_checked   TIMESTAMPTZ NOT NULL,
+	drift          JSONB NOT NULL,
+	PRIMARY KEY (repo_full_name, drift_key)
+);
+CREATE INDEX atlantis_drift_last_checked_idx ON atlantis_drift (last_checked);
+
+CREATE TABLE atlantis_remediations (
+	id             TEXT PRIMARY KEY,
+	repo_full_name TEXT NOT NULL,
+	started_at     TIMESTAMPTZ NOT NULL,
+	result         JSONB NOT NULL
+);
+CREATE INDEX atlantis_remediations_repo_idx ON atlantis_remediations (repo_full_name, started_at DESC);
+CREATE INDEX atlantis_remediations_started_at_idx ON atlantis_remediations (started_at);
+`,
+}
+
+// migrate applies the migrations that haven't been applied yet in a single
+// transaction.
+func migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", int64(migrationLockID)); err != nil {
+			return fmt.Errorf("locking schema migrations: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS atlantis_schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`); err != nil {
+			return fmt.Errorf("creating schema migrations table: %w", err)
+		}
+		var current int
+		if err := tx.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM atlantis_schema_migrations").Scan(&current); err != nil {
+			return fmt.Errorf("reading schema version: %w", err)
+		}
+		if current > len(migrations) {
+			return fmt.Errorf("database schema version %d is newer than the latest version %d supported by this version of Atlantis", current, len(migrations))
+		}
+		for version := current + 1; version <= len(migrations); version++ {
+			if _, err := tx.Exec(ctx, migrations[version-1]); err != nil {
+				return fmt.Errorf("applying schema migration %d: %w", version, err)
+			}
+			if _, err := tx.Exec(ctx, "INSERT INTO atlantis_schema_migrations (version) VALUES ($1)", version); err != nil {
+				return fmt.Errorf("recording schema migration %d: %w", version, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestPullKey(t *testing.T) {
+	pull := models.PullRequest{
+		Num: 7,
+		BaseRepo: models.Repo{
+			FullName: "runatlantis/atlantis",
+			VCSHost:  models.VCSHost{Hostname: "github.com"},
+		},
+	}
+	key, err := pullKey(pull)
+	Ok(t, err)
+	Equals(t, "github.com::runatlantis/atlantis::7", key)
+
+	pull.BaseRepo.FullName = "runatlantis::atlantis"
+	_, err = pullKey(pull)
+	ErrContains(t, "contains illegal string", err)
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// RemediationStore is a drift.RemediationStore backed by the same PostgreSQL
+// database used for locks, so every server sees the same remediation
+// history. Each result is a row keyed by its ID and indexed by repository and
+// start time.
+type RemediationStore struct {
+	db *PostgresDB
+	// retention is how long a result is kept after it started. Zero keeps
+	// results forever.
+	retention time.Duration
+}
+
+var _ drift.RemediationStore = (*RemediationStore)(nil)
+var _ drift.Compactor = (*RemediationStore)(nil)
+
+// NewRemediationStore returns a remediation store that shares p's connection
+// pool.
+func NewRemediationStore(p *PostgresDB, retention time.Duration) *RemediationStore {
+	return &RemediationStore{db: p, retention: retention}
+}
+
+// Save creates or replaces the result with the same ID.
+func (s *RemediationStore) Save(result *models.RemediationResult) error {
+	repository := result.StorageRepository
+	if repository == "" {
+		repository = result.Repository
+	}
+	serialized, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("serializing remediation result: %w", err)
+	}
+	_, err = s.db.pool.Exec(ctx, `INSERT INTO atlantis_remediations (id, repo_full_name, started_at, result) VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO UPDATE SET repo_full_name = EXCLUDED.repo_full_name, started_at = EXCLUDED.started_at, result = EXCLUDED.result`,
+		result.ID, repository, result.StartedAt, serialized)
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get returns the result with the given ID, or nil if it does not exist.
+func (s *RemediationStore) Get(id string) (*models.RemediationResult, error) {
+	rows, err := s.db.pool.Query(ctx, "SELECT id, repo_full_name, result FROM atlantis_remediations WHERE id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	record, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByPos[remediationRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return record.decode()
+}
+
+// List returns results for a repository, newest first.
+func (s *RemediationStore) List(repository string, opts drift.ListResultsOptions) ([]*models.RemediationResult, error) {
+	rows, err := s.db.pool.Query(ctx, "SELECT id, repo_full_name, result FROM atlantis_remediations WHERE repo_full_name = $1 ORDER BY started_at DESC, id DESC", repository)
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	defer rows.Close()
+	collector := drift.NewResultCollector(opts)
+	for rows.Next() {
+		record, err := pgx.RowToStructByPos[remediationRow](rows)
+		if err != nil {
+			return nil, fmt.Errorf("db transaction failed: %w", err)
+		}
+		result, err := record.decode()
+		if err != nil {
+			return nil, err
+		}
+		if collector.Add(result) {
+			return collector.Results, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return collector.Results, nil
+}
+
+// Repositories returns the repositories that have results.
+func (s *RemediationStore) Repositories() ([]string, error) {
+	rows, err := s.db.pool.Query(ctx, "SELECT DISTINCT repo_full_name FROM atlantis_remediations")
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	repositories, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return repositories, nil
+}
+
+// Compact removes results that started before the retention period.
+func (s *RemediationStore) Compact() (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	tag, err := s.db.pool.Exec(ctx, "DELETE FROM atlantis_remediations WHERE started_at < $1", time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// remediationRow is a row of atlantis_remediations.
+type remediationRow struct {
+	ID         string
+	Repository string
+	Result     []byte
+}
+
+func (r remediationRow) decode() (*models.RemediationResult, error) {
+	var result models.RemediationResult
+	if err := json.Unmarshal(r.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to deserialize remediation result '%s': %w", r.ID, err)
+	}
+	result.StorageRepository = r.Repository
+	return &result, nil
+}
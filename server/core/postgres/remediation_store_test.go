@@ -0,0 +1,82 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/postgres"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newTestRemediation(id string, status models.RemediationStatus, startedAt time.Time) *models.RemediationResult {
+	result := models.NewRemediationResult(id, "acme/infra", "main", models.RemediationAutoApply)
+	result.StorageRepository = "github.com/acme/infra"
+	result.Status = status
+	result.StartedAt = startedAt
+	return result
+}
+
+func TestRemediationStore_SaveGetList(t *testing.T) {
+	store := postgres.NewRemediationStore(newTestDB(t), 0)
+
+	now := time.Now()
+	for i, status := range []models.RemediationStatus{
+		models.RemediationStatusSuccess,
+		models.RemediationStatusFailed,
+		models.RemediationStatusSuccess,
+	} {
+		Ok(t, store.Save(newTestRemediation(string(rune('a'+i)), status, now.Add(time.Duration(i)*time.Minute))))
+	}
+
+	got, err := store.Get("b")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusFailed, got.Status)
+	Equals(t, "github.com/acme/infra", got.StorageRepository)
+
+	missing, err := store.Get("z")
+	Ok(t, err)
+	Assert(t, missing == nil, "expected no result")
+
+	results, err := store.List("github.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 3, len(results))
+	Equals(t, "c", results[0].ID)
+
+	successes, err := store.List("github.com/acme/infra", drift.ListResultsOptions{
+		Statuses: []models.RemediationStatus{models.RemediationStatusSuccess},
+		Offset:   1,
+	})
+	Ok(t, err)
+	Equals(t, 1, len(successes))
+	Equals(t, "a", successes[0].ID)
+
+	other, err := store.List("gitlab.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(other))
+
+	repositories, err := store.Repositories()
+	Ok(t, err)
+	Equals(t, []string{"github.com/acme/infra"}, repositories)
+}
+
+func TestRemediationStore_Compact(t *testing.T) {
+	store := postgres.NewRemediationStore(newTestDB(t), time.Hour)
+
+	Ok(t, store.Save(newTestRemediation("old", models.RemediationStatusSuccess, time.Now().Add(-2*time.Hour))))
+	Ok(t, store.Save(newTestRemediation("new", models.RemediationStatusSuccess, time.Now())))
+
+	removed, err := store.Compact()
+	Ok(t, err)
+	Equals(t, 1, removed)
+	got, err := store.Get("old")
+	Ok(t, err)
+	Assert(t, got == nil, "expected compacted result")
+	results, err := store.List("github.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+}
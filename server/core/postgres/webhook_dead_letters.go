@@ -0,0 +1,70 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+)
+
+// WebhookDeadLetterStore is a webhooks.DeadLetterStore backed by the same
+// PostgreSQL database used for locks, so dead letters from every server are
+// listed together.
+type WebhookDeadLetterStore struct {
+	db *PostgresDB
+}
+
+var _ webhooks.DeadLetterStore = (*WebhookDeadLetterStore)(nil)
+
+// NewWebhookDeadLetterStore returns a dead letter store that shares p's
+// connection pool.
+func NewWebhookDeadLetterStore(p *PostgresDB) *WebhookDeadLetterStore {
+	return &WebhookDeadLetterStore{db: p}
+}
+
+// Add records a dead letter and drops the oldest ones beyond
+// webhooks.MaxDeadLetters.
+func (s *WebhookDeadLetterStore) Add(letter webhooks.DeadLetter) error {
+	serialized, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("serializing webhook dead letter: %w", err)
+	}
+	err = pgx.BeginFunc(ctx, s.db.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "INSERT INTO atlantis_webhook_dead_letters (letter) VALUES ($1)", serialized); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx,
+			"DELETE FROM atlantis_webhook_dead_letters WHERE id <= (SELECT id FROM atlantis_webhook_dead_letters ORDER BY id DESC OFFSET $1 LIMIT 1)",
+			webhooks.MaxDeadLetters)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// List returns the dead letters, newest first.
+func (s *WebhookDeadLetterStore) List() ([]webhooks.DeadLetter, error) {
+	rows, err := s.db.pool.Query(ctx, "SELECT letter FROM atlantis_webhook_dead_letters ORDER BY id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	vals, err := pgx.CollectRows(rows, pgx.RowTo[[]byte])
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	letters := make([]webhooks.DeadLetter, 0, len(vals))
+	for _, val := range vals {
+		var letter webhooks.DeadLetter
+		if err := json.Unmarshal(val, &letter); err != nil {
+			return nil, fmt.Errorf("failed to deserialize webhook dead letter: %w", err)
+		}
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
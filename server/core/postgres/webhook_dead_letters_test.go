@@ -0,0 +1,45 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/postgres"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestWebhookDeadLetterStore_AddAndList(t *testing.T) {
+	store := postgres.NewWebhookDeadLetterStore(newTestDB(t))
+
+	letters, err := store.List()
+	Ok(t, err)
+	Equals(t, []webhooks.DeadLetter{}, letters)
+
+	now := time.Now().UTC()
+	first := webhooks.DeadLetter{ID: "first", Event: "apply", URL: "https://example.com", Payload: "{}", Attempts: 4, LastError: "status 500", FailedAt: now.Add(-time.Minute)}
+	second := webhooks.DeadLetter{ID: "second", Event: "drift", URL: "https://example.com", Payload: "{}", Attempts: 1, LastError: "status 400", FailedAt: now}
+	Ok(t, store.Add(first))
+	Ok(t, store.Add(second))
+
+	letters, err = store.List()
+	Ok(t, err)
+	Equals(t, []webhooks.DeadLetter{second, first}, letters)
+}
+
+func TestWebhookDeadLetterStore_DropsOldest(t *testing.T) {
+	store := postgres.NewWebhookDeadLetterStore(newTestDB(t))
+
+	for i := range webhooks.MaxDeadLetters + 2 {
+		Ok(t, store.Add(webhooks.DeadLetter{ID: fmt.Sprint(i)}))
+	}
+	letters, err := store.List()
+	Ok(t, err)
+	Equals(t, webhooks.MaxDeadLetters, len(letters))
+	Equals(t, fmt.Sprint(webhooks.MaxDeadLetters+1), letters[0].ID)
+	Equals(t, "2", letters[len(letters)-1].ID)
+}
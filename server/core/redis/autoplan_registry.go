@@ -0,0 +1,95 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const autoplanKeyPrefix = "autoplan/"
+
+// autoplanTTL bounds how long an autoplan is remembered if it never
+// finishes, e.g. because its replica died.
+const autoplanTTL = 24 * time.Hour
+
+// finishAutoplanScript deletes the latest autoplan of a pull request only if
+// it's still the one for the head commit in ARGV[1].
+const finishAutoplanScript = "" +
+	"if redis.call(\"GET\", KEYS[1]) == ARGV[1] then\n" +
+	"  return redis.call(\"DEL\", KEYS[1])\n" +
+	"end\n" +
+	"return 0\n"
+
+// AutoplanRegistry is an events.AutoplanRegistry backed by Redis, so an
+// autoplan started on one replica supersedes the autoplans running on the
+// others.
+type AutoplanRegistry struct {
+	client redis.Cmdable
+	logger logging.SimpleLogging
+}
+
+var _ events.AutoplanRegistry = (*AutoplanRegistry)(nil)
+
+// NewAutoplanRegistry returns an autoplan registry that shares r's Redis
+// client.
+func NewAutoplanRegistry(r *RedisDB, logger logging.SimpleLogging) *AutoplanRegistry {
+	return &AutoplanRegistry{
+		client: r.client,
+		logger: logger,
+	}
+}
+
+// Start records pull.HeadCommit as the latest autoplan for pull.
+func (a *AutoplanRegistry) Start(pull models.PullRequest) {
+	key, err := a.key(pull)
+	if err != nil {
+		a.logger.Err("recording autoplan: %s", err)
+		return
+	}
+	if err := a.client.Set(ctx, key, pull.HeadCommit, autoplanTTL).Err(); err != nil {
+		a.logger.Err("recording autoplan: db transaction failed: %s", err)
+	}
+}
+
+// Latest returns the head commit of the latest autoplan for pull. Errors are
+// logged and treated as no autoplan so a Redis outage doesn't drop autoplans.
+func (a *AutoplanRegistry) Latest(pull models.PullRequest) string {
+	key, err := a.key(pull)
+	if err != nil {
+		return ""
+	}
+	headCommit, err := a.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return ""
+	} else if err != nil {
+		a.logger.Err("getting latest autoplan: db transaction failed: %s", err)
+		return ""
+	}
+	return headCommit
+}
+
+// Finish forgets the latest autoplan for pull if it's still the one for
+// pull.HeadCommit.
+func (a *AutoplanRegistry) Finish(pull models.PullRequest) {
+	key, err := a.key(pull)
+	if err != nil {
+		return
+	}
+	if err := a.client.Eval(ctx, finishAutoplanScript, []string{key}, pull.HeadCommit).Err(); err != nil {
+		a.logger.Err("finishing autoplan: db transaction failed: %s", err)
+	}
+}
+
+func (a *AutoplanRegistry) key(pull models.PullRequest) (string, error) {
+	key, err := pullKey(pull)
+	if err != nil {
+		return "", err
+	}
+	return autoplanKeyPrefix + key, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestAutoplanRegistry_SharedBetweenReplicas(t *testing.T) {
+	s := miniredis.RunT(t)
+	logger := logging.NewNoopLogger(t)
+	first := redis.NewAutoplanRegistry(newTestRedis(s), logger)
+	second := redis.NewAutoplanRegistry(newTestRedis(s), logger)
+
+	oldPull := models.PullRequest{
+		Num:        1,
+		HeadCommit: "abc123",
+		BaseRepo:   models.Repo{FullName: "owner/repo", VCSHost: models.VCSHost{Hostname: "github.com"}},
+	}
+	newPull := oldPull
+	newPull.HeadCommit = "def456"
+	otherPull := oldPull
+	otherPull.Num = 2
+
+	Equals(t, "", second.Latest(oldPull))
+	first.Start(oldPull)
+	Equals(t, "abc123", second.Latest(oldPull))
+	Equals(t, "", second.Latest(otherPull))
+	Assert(t, s.TTL("autoplan/github.com::owner/repo::1") > 0, "expected autoplan to expire")
+
+	second.Start(newPull)
+	Equals(t, "def456", first.Latest(oldPull))
+
+	// The superseded autoplan finishing must not forget the newer one.
+	first.Finish(oldPull)
+	Equals(t, "def456", first.Latest(oldPull))
+	second.Finish(newPull)
+	Equals(t, "", first.Latest(oldPull))
+}
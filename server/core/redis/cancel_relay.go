@@ -0,0 +1,95 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const cancelChannel = "cancel"
+
+// CancelRelay is an events.CancelRelay that publishes cancel commands on a
+// Redis channel every replica subscribes to, so `atlantis cancel` interrupts
+// operations running on any replica. The replica that published a cancel
+// command receives it too, which is harmless since its operations are
+// already interrupted.
+type CancelRelay struct {
+	client redis.Cmdable
+	// handle cancels the operations matching a relayed cancel command on
+	// this replica.
+	handle func(logging.SimpleLogging, events.CancelRequest)
+	logger logging.SimpleLogging
+
+	runCtx context.Context
+	cancel context.CancelFunc
+}
+
+var _ events.CancelRelay = (*CancelRelay)(nil)
+
+// NewCancelRelay returns a cancel relay that shares r's Redis client and
+// passes the cancel commands it receives to handle.
+func NewCancelRelay(r *RedisDB, handle func(logging.SimpleLogging, events.CancelRequest), logger logging.SimpleLogging) *CancelRelay {
+	runCtx, cancel := context.WithCancel(ctx)
+	return &CancelRelay{
+		client: r.client,
+		handle: handle,
+		logger: logger,
+		runCtx: runCtx,
+		cancel: cancel,
+	}
+}
+
+// Relay publishes req to the other replicas.
+func (c *CancelRelay) Relay(req events.CancelRequest) error {
+	serialized, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("serializing cancel request: %w", err)
+	}
+	if err := c.client.Publish(ctx, cancelChannel, serialized).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Start handles the cancel commands published by any replica until Stop is
+// called.
+func (c *CancelRelay) Start() {
+	subscriber, ok := c.client.(redis.UniversalClient)
+	if !ok {
+		c.logger.Err("relaying cancel commands: %T doesn't support subscribing to channels", c.client)
+		return
+	}
+	pubsub := subscriber.Subscribe(c.runCtx, cancelChannel)
+	defer pubsub.Close() // nolint: errcheck
+
+	// The channel reconnects by itself if the connection is lost.
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-c.runCtx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var req events.CancelRequest
+			if err := json.Unmarshal([]byte(msg.Payload), &req); err != nil {
+				c.logger.Err("deserializing relayed cancel request: %s", err)
+				continue
+			}
+			c.handle(c.logger, req)
+		}
+	}
+}
+
+// Stop stops handling cancel commands.
+func (c *CancelRelay) Stop() {
+	c.cancel()
+}
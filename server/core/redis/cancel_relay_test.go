@@ -0,0 +1,41 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelRelay_SharedBetweenReplicas(t *testing.T) {
+	s := miniredis.RunT(t)
+	logger := logging.NewNoopLogger(t)
+	received := make(chan events.CancelRequest, 1)
+	receiving := redis.NewCancelRelay(newTestRedis(s), func(_ logging.SimpleLogging, req events.CancelRequest) {
+		received <- req
+	}, logger)
+	go receiving.Start()
+	defer receiving.Stop()
+	require.Eventually(t, func() bool {
+		return s.PubSubNumSub("cancel")["cancel"] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	sending := redis.NewCancelRelay(newTestRedis(s), nil, logger)
+	req := events.CancelRequest{RepoFullName: "owner/repo", PullNum: 1, ProjectName: "project1"}
+	Ok(t, sending.Relay(req))
+
+	select {
+	case got := <-received:
+		Equals(t, req, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the cancel request to be relayed")
+	}
+}
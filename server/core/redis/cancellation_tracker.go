@@ -0,0 +1,83 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const cancelledPullKeyPrefix = "cancelled/"
+
+// cancelledPullTTL bounds how long a cancellation is remembered if the
+// command that should clear it never finishes, e.g. because its replica died.
+const cancelledPullTTL = 24 * time.Hour
+
+// CancellationTracker is an events.CancellationTracker backed by Redis, so
+// `atlantis cancel` stops execution order groups running on any replica.
+type CancellationTracker struct {
+	client redis.Cmdable
+	logger logging.SimpleLogging
+}
+
+var _ events.CancellationTracker = (*CancellationTracker)(nil)
+
+// NewCancellationTracker returns a cancellation tracker that shares r's Redis
+// client.
+func NewCancellationTracker(r *RedisDB, logger logging.SimpleLogging) *CancellationTracker {
+	return &CancellationTracker{
+		client: r.client,
+		logger: logger,
+	}
+}
+
+// Cancel marks an entire pull request as cancelled.
+func (c *CancellationTracker) Cancel(pull models.PullRequest) {
+	key, err := c.key(pull)
+	if err != nil {
+		c.logger.Err("cancelling pull request: %s", err)
+		return
+	}
+	if err := c.client.Set(ctx, key, time.Now().Unix(), cancelledPullTTL).Err(); err != nil {
+		c.logger.Err("cancelling pull request: db transaction failed: %s", err)
+	}
+}
+
+// IsCancelled checks if the pull request has been cancelled. Errors are
+// logged and treated as not cancelled so a Redis outage doesn't skip work.
+func (c *CancellationTracker) IsCancelled(pull models.PullRequest) bool {
+	key, err := c.key(pull)
+	if err != nil {
+		return false
+	}
+	n, err := c.client.Exists(ctx, key).Result()
+	if err != nil {
+		c.logger.Err("checking pull request cancellation: db transaction failed: %s", err)
+		return false
+	}
+	return n > 0
+}
+
+// Clear removes the cancellation for a pull request.
+func (c *CancellationTracker) Clear(pull models.PullRequest) {
+	key, err := c.key(pull)
+	if err != nil {
+		return
+	}
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		c.logger.Err("clearing pull request cancellation: db transaction failed: %s", err)
+	}
+}
+
+func (c *CancellationTracker) key(pull models.PullRequest) (string, error) {
+	key, err := pullKey(pull)
+	if err != nil {
+		return "", err
+	}
+	return cancelledPullKeyPrefix + key, nil
+}
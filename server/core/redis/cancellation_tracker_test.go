@@ -0,0 +1,37 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestCancellationTracker_SharedBetweenReplicas(t *testing.T) {
+	s := miniredis.RunT(t)
+	logger := logging.NewNoopLogger(t)
+	cancelling := redis.NewCancellationTracker(newTestRedis(s), logger)
+	running := redis.NewCancellationTracker(newTestRedis(s), logger)
+
+	pull := models.PullRequest{
+		Num:      1,
+		BaseRepo: models.Repo{FullName: "owner/repo", VCSHost: models.VCSHost{Hostname: "github.com"}},
+	}
+	otherPull := pull
+	otherPull.Num = 2
+
+	Equals(t, false, running.IsCancelled(pull))
+	cancelling.Cancel(pull)
+	Equals(t, true, running.IsCancelled(pull))
+	Equals(t, false, running.IsCancelled(otherPull))
+	Assert(t, s.TTL("cancelled/github.com::owner/repo::1") > 0, "expected cancellation to expire")
+
+	running.Clear(pull)
+	Equals(t, false, cancelling.IsCancelled(pull))
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+const driftKeyPrefix = "drift/"
+
+// DriftStorage is a drift.Storage backed by the same Redis used for locks,
+// so drift results survive restarts and are shared by all replicas.
+// Each record is stored under its own key so Redis can expire it after the
+// retention period.
+type DriftStorage struct {
+	client redis.Cmdable
+	// retention is used as the key TTL. Zero keeps records forever.
+	retention time.Duration
+}
+
+var _ drift.Storage = (*DriftStorage)(nil)
+
+// driftRecord is the value stored under each drift key. The repository is
+// kept alongside the drift so GetAll does not need to parse keys.
+type driftRecord struct {
+	Repository string              `json:"repository"`
+	Drift      models.ProjectDrift `json:"drift"`
+}
+
+// NewDriftStorage returns a drift storage that shares r's Redis client.
+func NewDriftStorage(r *RedisDB, retention time.Duration) *DriftStorage {
+	return &DriftStorage{
+		client:    r.client,
+		retention: retention,
+	}
+}
+
+// Store saves a drift result for a project. PlanOutput is stripped before
+// persisting.
+func (d *DriftStorage) Store(repository string, projectDrift models.ProjectDrift) error {
+	projectDrift.PlanOutput = ""
+	key, err := d.recordKey(repository, projectDrift)
+	if err != nil {
+		return err
+	}
+	serialized, err := json.Marshal(driftRecord{Repository: repository, Drift: projectDrift})
+	if err != nil {
+		return fmt.Errorf("serializing drift: %w", err)
+	}
+	if err := d.client.Set(ctx, key, serialized, d.retention).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves drift results for a repository with optional filtering.
+func (d *DriftStorage) Get(repository string, opts drift.GetOptions) ([]models.ProjectDrift, error) {
+	result := make([]models.ProjectDrift, 0)
+	now := time.Now()
+	err := d.scan(d.repoPattern(repository), func(_ string, record driftRecord) error {
+		if record.Repository == repository && drift.MatchesGetOptions(record.Drift, opts, now) {
+			result = append(result, record.Drift)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete removes drift results for a repository.
+// If projectName is empty, all drift results for the repository are removed.
+func (d *DriftStorage) Delete(repository string, projectName string) error {
+	return d.deleteWhere(repository, func(projectDrift models.ProjectDrift) bool {
+		return projectName == "" || projectDrift.ProjectName == projectName
+	})
+}
+
+// DeleteMatching removes drift results for a repository that match the given filters.
+func (d *DriftStorage) DeleteMatching(repository string, opts drift.GetOptions) error {
+	if opts == (drift.GetOptions{}) {
+		return errors.New("at least one drift delete filter is required")
+	}
+	now := time.Now()
+	return d.deleteWhere(repository, func(projectDrift models.ProjectDrift) bool {
+		return drift.MatchesDeleteOptions(projectDrift, opts, now)
+	})
+}
+
+// GetAll retrieves all stored drift results across all repositories.
+func (d *DriftStorage) GetAll() (map[string][]models.ProjectDrift, error) {
+	result := make(map[string][]models.ProjectDrift)
+	err := d.scan(driftKeyPrefix+"*", func(_ string, record driftRecord) error {
+		result[record.Repository] = append(result[record.Repository], record.Drift)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *DriftStorage) deleteWhere(repository string, match func(models.ProjectDrift) bool) error {
+	var keys []string
+	err := d.scan(d.repoPattern(repository), func(key string, record driftRecord) error {
+		if record.Repository == repository && match(record.Drift) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Delete keys one at a time since they may live on different cluster slots.
+	for _, key := range keys {
+		if err := d.client.Del(ctx, key).Err(); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// scan calls fn for every drift record whose key matches pattern. Keys that
+// expire between SCAN and GET are skipped.
+func (d *DriftStorage) scan(pattern string, fn func(key string, record driftRecord) error) error {
+	iter := d.client.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		val, err := d.client.Get(ctx, iter.Val()).Result()
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		var record driftRecord
+		if err := json.Unmarshal([]byte(val), &record); err != nil {
+			return fmt.Errorf("failed to deserialize drift at key '%s': %w", iter.Val(), err)
+		}
+		if err := fn(iter.Val(), record); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// recordKey returns drift/{repository}::{hash of record identity}. The
+// identity is hashed because ref and path values may contain characters that
+// are awkward in keys and SCAN patterns.
+func (d *DriftStorage) recordKey(repository string, projectDrift models.ProjectDrift) (string, error) {
+	if strings.Contains(repository, pullKeySeparator) {
+		return "", fmt.Errorf("repo name %q contains illegal string %q", repository, pullKeySeparator)
+	}
+	identity, err := json.Marshal(drift.KeyFor(projectDrift))
+	if err != nil {
+		return "", fmt.Errorf("serializing drift key: %w", err)
+	}
+	sum := sha256.Sum256(identity)
+	return fmt.Sprintf("%s%s%s%s", driftKeyPrefix, repository, pullKeySeparator, hex.EncodeToString(sum[:])), nil
+}
+
+func (d *DriftStorage) repoPattern(repository string) string {
+	return driftKeyPrefix + escapeScanPattern(repository) + pullKeySeparator + "*"
+}
+
+// escapeScanPattern escapes glob metacharacters so a repository name is
+// matched literally by SCAN MATCH.
+func escapeScanPattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestDriftStorage_StoreAndGet(t *testing.T) {
+	s := miniredis.RunT(t)
+	r := newTestRedis(s)
+	storage := redis.NewDriftStorage(r, 0)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "main",
+		PlanOutput:  "secret plan output",
+		Drift:       models.DriftSummary{HasDrift: true, ToAdd: 1},
+		LastChecked: time.Now(),
+	}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{
+		ProjectName: "app",
+		Path:        "app",
+		Workspace:   "default",
+		Ref:         "feature",
+		LastChecked: time.Now(),
+	}))
+	// Repositories sharing a prefix must not leak into each other's results.
+	Ok(t, storage.Store("owner/repo-two", models.ProjectDrift{ProjectName: "other", LastChecked: time.Now()}))
+
+	results, err := storage.Get("owner/repo", drift.GetOptions{Ref: "main"})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+	Equals(t, 1, results[0].Drift.ToAdd)
+	Equals(t, "", results[0].PlanOutput)
+
+	all, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 2, len(all))
+}
+
+func TestDriftStorage_Retention(t *testing.T) {
+	s := miniredis.RunT(t)
+	r := newTestRedis(s)
+	storage := redis.NewDriftStorage(r, time.Hour)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "app", LastChecked: time.Now()}))
+	results, err := storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 1, len(results))
+
+	s.FastForward(2 * time.Hour)
+	results, err = storage.Get("owner/repo", drift.GetOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+}
+
+func TestDriftStorage_DeleteAndGetAll(t *testing.T) {
+	s := miniredis.RunT(t)
+	r := newTestRedis(s)
+	storage := redis.NewDriftStorage(r, 0)
+
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "a", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/repo", models.ProjectDrift{ProjectName: "b", Ref: "main", LastChecked: time.Now()}))
+	Ok(t, storage.Store("owner/other", models.ProjectDrift{ProjectName: "c", Ref: "main", LastChecked: time.Now()}))
+
+	ErrContains(t, "at least one drift delete filter is required", storage.DeleteMatching("owner/repo", drift.GetOptions{}))
+	Ok(t, storage.DeleteMatching("owner/repo", drift.GetOptions{ProjectName: "a"}))
+
+	all, err := storage.GetAll()
+	Ok(t, err)
+	Equals(t, 2, len(all))
+	Equals(t, 1, len(all["owner/repo"]))
+	Equals(t, "b", all["owner/repo"][0].ProjectName)
+
+	Ok(t, storage.Delete("owner/repo", ""))
+	all, err = storage.GetAll()
+	Ok(t, err)
+	Equals(t, 1, len(all))
+	Equals(t, "c", all["owner/other"][0].ProjectName)
+}
+
+func TestDriftStorage_RejectsSeparatorInRepository(t *testing.T) {
+	s := miniredis.RunT(t)
+	storage := redis.NewDriftStorage(newTestRedis(s), 0)
+	ErrContains(t, "contains illegal string", storage.Store("owner::repo", models.ProjectDrift{}))
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/scheduled"
+)
+
+const jobLeaseKeyPrefix = "jobs/lease/"
+
+// holdLeaseScript renews the lease in KEYS[1] for ARGV[2] milliseconds if
+// ARGV[1] holds it, or takes it if nobody does.
+const holdLeaseScript = "" +
+	"if redis.call(\"GET\", KEYS[1]) == ARGV[1] then\n" +
+	"  return redis.call(\"PEXPIRE\", KEYS[1], ARGV[2])\n" +
+	"end\n" +
+	"if redis.call(\"SET\", KEYS[1], ARGV[1], \"NX\", \"PX\", ARGV[2]) then\n" +
+	"  return 1\n" +
+	"end\n" +
+	"return 0\n"
+
+// JobLease is a scheduled.Lease backed by Redis, so scheduled jobs like drift
+// detection and the lock reaper run on one replica at a time. A replica keeps
+// a lease for as long as it renews it in time.
+type JobLease struct {
+	client redis.Cmdable
+	owner  string
+}
+
+var _ scheduled.Lease = (*JobLease)(nil)
+
+// NewJobLease returns a lease that shares r's Redis client. owner must be
+// unique to this replica.
+func NewJobLease(r *RedisDB, owner string) *JobLease {
+	return &JobLease{
+		client: r.client,
+		owner:  owner,
+	}
+}
+
+// Hold takes or renews the lease on name for ttl and reports whether this
+// replica holds it.
+func (l *JobLease) Hold(name string, ttl time.Duration) (bool, error) {
+	held, err := l.client.Eval(ctx, holdLeaseScript, []string{jobLeaseKeyPrefix + name}, l.owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return held == 1, nil
+}
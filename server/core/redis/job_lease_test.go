@@ -0,0 +1,47 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestJobLease_OneHolderAtATime(t *testing.T) {
+	s := miniredis.RunT(t)
+	first := redis.NewJobLease(newTestRedis(s), "first")
+	second := redis.NewJobLease(newTestRedis(s), "second")
+
+	held, err := first.Hold("drift-scheduler", time.Minute)
+	Ok(t, err)
+	Assert(t, held, "expected the first replica to take the lease")
+	held, err = second.Hold("drift-scheduler", time.Minute)
+	Ok(t, err)
+	Assert(t, !held, "expected the second replica not to take a held lease")
+
+	// Other jobs have their own lease.
+	held, err = second.Hold("lock-reaper", time.Minute)
+	Ok(t, err)
+	Assert(t, held, "expected the second replica to take another job's lease")
+
+	// The holder renews its lease.
+	s.FastForward(50 * time.Second)
+	held, err = first.Hold("drift-scheduler", time.Minute)
+	Ok(t, err)
+	Assert(t, held, "expected the first replica to renew its lease")
+	Equals(t, time.Minute, s.TTL("jobs/lease/drift-scheduler"))
+
+	// Another replica takes over once the holder stops renewing.
+	s.FastForward(time.Minute)
+	held, err = second.Hold("drift-scheduler", time.Minute)
+	Ok(t, err)
+	Assert(t, held, "expected the second replica to take an expired lease")
+	held, err = first.Hold("drift-scheduler", time.Minute)
+	Ok(t, err)
+	Assert(t, !held, "expected the first replica to have lost the lease")
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/jobs"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	jobOutputKeyPrefix = "jobs/output/"
+	jobIndexKey        = "jobs/index"
+	// jobOutputTTL is how long job output is kept after its last line, and
+	// how long a job is listed on the index page if its pull request is
+	// never cleaned up.
+	jobOutputTTL = 24 * time.Hour
+	// jobOutputBlock is how long a tail waits for new lines before checking
+	// whether the job's output still exists.
+	jobOutputBlock    = 5 * time.Second
+	jobOutputBatch    = 100
+	jobLineField      = "line"
+	jobCompleteField  = "complete"
+	jobTailRetryDelay = time.Second
+)
+
+// jobIndexEntry is the value stored for each job in the job index.
+type jobIndexEntry struct {
+	Pull jobs.PullInfo
+	Job  jobs.JobIDInfo
+}
+
+// JobOutputRelay is a jobs.ProjectCommandOutputHandler that copies job output
+// to Redis streams, so a job's output can be watched from any replica and not
+// just the one running it. Jobs running on this replica are still served
+// from the local handler's buffers.
+type JobOutputRelay struct {
+	local  jobs.ProjectCommandOutputHandler
+	client redis.Cmdable
+	logger logging.SimpleLogging
+
+	// indexed holds the IDs of jobs this replica has added to the index.
+	indexed sync.Map
+
+	tailsLock sync.Mutex
+	tails     map[chan string]context.CancelFunc
+}
+
+var _ jobs.ProjectCommandOutputHandler = (*JobOutputRelay)(nil)
+
+// NewJobOutputRelay returns a handler that relays local's output through r's
+// Redis client.
+func NewJobOutputRelay(r *RedisDB, local jobs.ProjectCommandOutputHandler, logger logging.SimpleLogging) *JobOutputRelay {
+	return &JobOutputRelay{
+		local:  local,
+		client: r.client,
+		logger: logger,
+		tails:  map[chan string]context.CancelFunc{},
+	}
+}
+
+func (j *JobOutputRelay) Send(ctx command.ProjectContext, msg string, operationComplete bool) {
+	j.local.Send(ctx, msg, operationComplete)
+	j.publish(ctx.JobID, jobs.PullInfo{
+		PullNum:      ctx.Pull.Num,
+		Repo:         ctx.BaseRepo.Name,
+		RepoFullName: ctx.BaseRepo.FullName,
+		ProjectName:  ctx.ProjectName,
+		Path:         ctx.RepoRelDir,
+		Workspace:    ctx.Workspace,
+	}, "", ctx.CommandName.String(), msg, operationComplete)
+}
+
+func (j *JobOutputRelay) SendWorkflowHook(ctx models.WorkflowHookCommandContext, msg string, operationComplete bool) {
+	j.local.SendWorkflowHook(ctx, msg, operationComplete)
+	j.publish(ctx.HookID, jobs.PullInfo{
+		PullNum:      ctx.Pull.Num,
+		Repo:         ctx.BaseRepo.Name,
+		RepoFullName: ctx.BaseRepo.FullName,
+	}, ctx.HookDescription, ctx.HookStepName, msg, operationComplete)
+}
+
+// publish appends a line, or the completion marker, to the job's stream and
+// adds the job to the index the first time it's seen.
+func (j *JobOutputRelay) publish(jobID string, pull jobs.PullInfo, description string, step string, line string, operationComplete bool) {
+	if !operationComplete {
+		if _, seen := j.indexed.LoadOrStore(jobID, struct{}{}); !seen {
+			entry, err := json.Marshal(jobIndexEntry{
+				Pull: pull,
+				Job: jobs.JobIDInfo{
+					JobID:          jobID,
+					JobDescription: description,
+					Time:           time.Now(),
+					JobStep:        step,
+				},
+			})
+			if err == nil {
+				err = j.client.HSet(ctx, jobIndexKey, jobID, entry).Err()
+			}
+			if err != nil {
+				j.logger.Err("indexing job %s: %s", jobID, err)
+			}
+		}
+	}
+
+	values := map[string]any{jobLineField: line}
+	if operationComplete {
+		values = map[string]any{jobCompleteField: "1"}
+		j.indexed.Delete(jobID)
+	}
+	key := jobOutputKeyPrefix + jobID
+	_, err := j.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{Stream: key, Values: values})
+		pipe.Expire(ctx, key, jobOutputTTL)
+		return nil
+	})
+	if err != nil {
+		j.logger.Err("relaying output for job %s: %s", jobID, err)
+	}
+}
+
+// Register streams the job's output to receiver, from the local handler if
+// the job runs on this replica and from Redis otherwise.
+func (j *JobOutputRelay) Register(jobID string, receiver chan string) {
+	if j.local.IsKeyExists(jobID) {
+		j.local.Register(jobID, receiver)
+		return
+	}
+
+	tailCtx, cancel := context.WithCancel(ctx)
+	j.tailsLock.Lock()
+	j.tails[receiver] = cancel
+	j.tailsLock.Unlock()
+	defer func() {
+		j.tailsLock.Lock()
+		delete(j.tails, receiver)
+		j.tailsLock.Unlock()
+		cancel()
+	}()
+
+	if j.tail(tailCtx, jobOutputKeyPrefix+jobID, receiver) {
+		close(receiver)
+	}
+}
+
+// tail sends lines from the stream at key to receiver until the job
+// completes, its output expires or ctx is cancelled. It returns true if the
+// output ended, in which case receiver should be closed.
+func (j *JobOutputRelay) tail(tailCtx context.Context, key string, receiver chan string) bool {
+	lastID := "0"
+	for {
+		streams, err := j.client.XRead(tailCtx, &redis.XReadArgs{
+			Streams: []string{key, lastID},
+			Count:   jobOutputBatch,
+			Block:   jobOutputBlock,
+		}).Result()
+		if tailCtx.Err() != nil {
+			return false
+		}
+		if errors.Is(err, redis.Nil) {
+			// Nothing new. Stop if the output expired or was cleaned up,
+			// which is all that happens to jobs whose replica went away.
+			n, err := j.client.Exists(tailCtx, key).Result()
+			if err == nil && n == 0 {
+				return true
+			}
+			continue
+		}
+		if err != nil {
+			j.logger.Err("reading output from %s: %s", key, err)
+			select {
+			case <-time.After(jobTailRetryDelay):
+				continue
+			case <-tailCtx.Done():
+				return false
+			}
+		}
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+				if _, ok := msg.Values[jobCompleteField]; ok {
+					return true
+				}
+				line, _ := msg.Values[jobLineField].(string)
+				select {
+				case receiver <- line:
+				case <-tailCtx.Done():
+					return false
+				}
+			}
+		}
+	}
+}
+
+func (j *JobOutputRelay) Deregister(jobID string, receiver chan string) {
+	j.tailsLock.Lock()
+	if cancel, ok := j.tails[receiver]; ok {
+		cancel()
+		delete(j.tails, receiver)
+	}
+	j.tailsLock.Unlock()
+	j.local.Deregister(jobID, receiver)
+}
+
+func (j *JobOutputRelay) IsKeyExists(key string) bool {
+	if j.local.IsKeyExists(key) {
+		return true
+	}
+	n, err := j.client.Exists(ctx, jobOutputKeyPrefix+key).Result()
+	if err != nil {
+		j.logger.Err("checking output for job %s: %s", key, err)
+		return false
+	}
+	return n > 0
+}
+
+func (j *JobOutputRelay) Handle() {
+	j.local.Handle()
+}
+
+// CleanUp removes the pull request's jobs locally and from Redis, wherever
+// they ran.
+func (j *JobOutputRelay) CleanUp(pullInfo jobs.PullInfo) {
+	j.local.CleanUp(pullInfo)
+	entries, err := j.index()
+	if err != nil {
+		j.logger.Err("cleaning up jobs: %s", err)
+		return
+	}
+	for jobID, entry := range entries {
+		if entry.Pull != pullInfo {
+			continue
+		}
+		if err := j.deleteJob(jobID); err != nil {
+			j.logger.Err("cleaning up job %s: %s", jobID, err)
+		}
+	}
+}
+
+// GetPullToJobMapping returns the jobs of all replicas. It falls back to
+// this replica's jobs if Redis can't be read.
+func (j *JobOutputRelay) GetPullToJobMapping() []jobs.PullInfoWithJobIDs {
+	entries, err := j.index()
+	if err != nil {
+		j.logger.Err("listing jobs: %s", err)
+		return j.local.GetPullToJobMapping()
+	}
+
+	var pullToJobMappings []jobs.PullInfoWithJobIDs
+	positions := map[jobs.PullInfo]int{}
+	for jobID, entry := range entries {
+		if time.Since(entry.Job.Time) > jobOutputTTL {
+			if err := j.deleteJob(jobID); err != nil {
+				j.logger.Err("removing expired job %s: %s", jobID, err)
+			}
+			continue
+		}
+		i, ok := positions[entry.Pull]
+		if !ok {
+			i = len(pullToJobMappings)
+			positions[entry.Pull] = i
+			pullToJobMappings = append(pullToJobMappings, jobs.PullInfoWithJobIDs{Pull: entry.Pull})
+		}
+		pullToJobMappings[i].JobIDInfos = append(pullToJobMappings[i].JobIDInfos, entry.Job)
+	}
+	return pullToJobMappings
+}
+
+func (j *JobOutputRelay) index() (map[string]jobIndexEntry, error) {
+	raw, err := j.client.HGetAll(ctx, jobIndexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]jobIndexEntry, len(raw))
+	for jobID, value := range raw {
+		var entry jobIndexEntry
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			j.logger.Warn("skipping malformed index entry for job %s: %s", jobID, err)
+			continue
+		}
+		entries[jobID] = entry
+	}
+	return entries, nil
+}
+
+func (j *JobOutputRelay) deleteJob(jobID string) error {
+	_, err := j.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, jobOutputKeyPrefix+jobID)
+		pipe.HDel(ctx, jobIndexKey, jobID)
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/jobs"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newTestJobOutputRelay(t *testing.T, s *miniredis.Miniredis) *redis.JobOutputRelay {
+	logger := logging.NewNoopLogger(t)
+	local := jobs.NewAsyncProjectCommandOutputHandler(make(chan *jobs.ProjectCmdOutputLine), logger)
+	relay := redis.NewJobOutputRelay(newTestRedis(s), local, logger)
+	go relay.Handle()
+	return relay
+}
+
+// readAll reads from receiver until it is closed.
+func readAll(t *testing.T, receiver chan string) []string {
+	t.Helper()
+	var lines []string
+	for {
+		select {
+		case line, ok := <-receiver:
+			if !ok {
+				return lines
+			}
+			lines = append(lines, line)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for output, got %v", lines)
+		}
+	}
+}
+
+func TestJobOutputRelay_OutputFromOtherReplica(t *testing.T) {
+	s := miniredis.RunT(t)
+	running := newTestJobOutputRelay(t, s)
+	watching := newTestJobOutputRelay(t, s)
+
+	ctx := command.ProjectContext{
+		BaseRepo:    models.Repo{Name: "repo", FullName: "owner/repo"},
+		Pull:        models.PullRequest{Num: 1},
+		ProjectName: "project",
+		RepoRelDir:  "dir",
+		Workspace:   "default",
+		CommandName: command.Plan,
+		JobID:       "job-1",
+	}
+	Equals(t, false, watching.IsKeyExists("job-1"))
+	running.Send(ctx, "line 1", false)
+	running.Send(ctx, "line 2", false)
+	Equals(t, true, watching.IsKeyExists("job-1"))
+
+	receiver := make(chan string, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watching.Register("job-1", receiver)
+	}()
+	running.Send(ctx, "line 3", false)
+	running.Send(ctx, "", true)
+	Equals(t, []string{"line 1", "line 2", "line 3"}, readAll(t, receiver))
+	<-done
+
+	pullInfo := jobs.PullInfo{
+		PullNum:      1,
+		Repo:         "repo",
+		RepoFullName: "owner/repo",
+		ProjectName:  "project",
+		Path:         "dir",
+		Workspace:    "default",
+	}
+	mappings := watching.GetPullToJobMapping()
+	Equals(t, 1, len(mappings))
+	Equals(t, pullInfo, mappings[0].Pull)
+	Equals(t, 1, len(mappings[0].JobIDInfos))
+	Equals(t, "job-1", mappings[0].JobIDInfos[0].JobID)
+	Equals(t, "plan", mappings[0].JobIDInfos[0].JobStep)
+
+	watching.CleanUp(pullInfo)
+	Equals(t, 0, len(running.GetPullToJobMapping()))
+	Assert(t, !s.Exists("jobs/output/job-1"), "expected job output to be removed")
+}
+
+func TestJobOutputRelay_DeregisterStopsTail(t *testing.T) {
+	s := miniredis.RunT(t)
+	running := newTestJobOutputRelay(t, s)
+	watching := newTestJobOutputRelay(t, s)
+
+	ctx := command.ProjectContext{JobID: "job-1", CommandName: command.Apply}
+	running.Send(ctx, "line 1", false)
+
+	receiver := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watching.Register("job-1", receiver)
+	}()
+	Equals(t, "line 1", <-receiver)
+	// The tail is blocked waiting for the job to finish.
+	time.Sleep(100 * time.Millisecond)
+	watching.Deregister("job-1", receiver)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Register did not return after Deregister")
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
@@ -49,6 +50,19 @@ const unlockIfOwnedByPullScript = "" +
 	"redis.call(\"DEL\", KEYS[1])\n" +
 	"return value\n"
 
+// enqueueLockWaiterScript appends ARGV[1] to the lock queue at KEYS[1] unless
+// a waiter for pull ARGV[2] is already queued, and returns the waiter's
+// 1-based position.
+const enqueueLockWaiterScript = "" +
+	"local queue = redis.call(\"LRANGE\", KEYS[1], 0, -1)\n" +
+	"for i, value in ipairs(queue) do\n" +
+	"  local ok, waiter = pcall(cjson.decode, value)\n" +
+	"  if ok and waiter[\"Pull\"] and tonumber(waiter[\"Pull\"][\"Num\"]) == tonumber(ARGV[2]) then\n" +
+	"    return i\n" +
+	"  end\n" +
+	"end\n" +
+	"return redis.call(\"RPUSH\", KEYS[1], ARGV[1])\n"
+
 // Config holds configuration for Redis connections.
 type Config struct {
 	Hostname           string
@@ -343,6 +357,85 @@ func (r *RedisDB) UnlockByPull(repoFullName string, pullNum int) ([]models.Proje
 	return locks, nil
 }
 
+// EnqueueLockWaiter appends waiter to the queue for its project and
+// workspace, unless its pull request is already queued, and returns its
+// 1-based position in the queue.
+func (r *RedisDB) EnqueueLockWaiter(waiter models.LockWaiter) (int, error) {
+	serialized, err := json.Marshal(waiter)
+	if err != nil {
+		return 0, fmt.Errorf("serializing lock waiter: %w", err)
+	}
+	key := r.lockQueueKey(waiter.Project, waiter.Workspace)
+	position, err := r.client.Eval(ctx, enqueueLockWaiterScript, []string{key}, serialized, waiter.Pull.Num).Int()
+	if err != nil {
+		return 0, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return position, nil
+}
+
+// DequeueLockWaiter removes and returns the first waiter in the queue for
+// project and workspace. If the queue is empty, it returns a nil pointer.
+func (r *RedisDB) DequeueLockWaiter(project models.Project, workspace string) (*models.LockWaiter, error) {
+	val, err := r.client.LPop(ctx, r.lockQueueKey(project, workspace)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	var waiter models.LockWaiter
+	if err := json.Unmarshal([]byte(val), &waiter); err != nil {
+		return nil, fmt.Errorf("failed to deserialize lock waiter: %w", err)
+	}
+	return &waiter, nil
+}
+
+// ListLockWaiters returns the queue for project and workspace in order.
+func (r *RedisDB) ListLockWaiters(project models.Project, workspace string) ([]models.LockWaiter, error) {
+	key := r.lockQueueKey(project, workspace)
+	vals, err := r.client.LRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	var queue []models.LockWaiter
+	for _, val := range vals {
+		var waiter models.LockWaiter
+		if err := json.Unmarshal([]byte(val), &waiter); err != nil {
+			return queue, fmt.Errorf("failed to deserialize lock waiter in %q: %w", key, err)
+		}
+		queue = append(queue, waiter)
+	}
+	return queue, nil
+}
+
+// RemoveLockWaitersByPull removes the pull request from every queue in its
+// repo.
+func (r *RedisDB) RemoveLockWaitersByPull(repoFullName string, pullNum int) error {
+	iter := r.client.Scan(ctx, 0, fmt.Sprintf("lockqueue/%s/*", repoFullName), 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		vals, err := r.client.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+		for _, val := range vals {
+			var waiter models.LockWaiter
+			if err := json.Unmarshal([]byte(val), &waiter); err != nil {
+				return fmt.Errorf("failed to deserialize lock waiter in %q: %w", key, err)
+			}
+			if waiter.Project.RepoFullName != repoFullName || waiter.Pull.Num != pullNum {
+				continue
+			}
+			if err := r.client.LRem(ctx, key, 0, val).Err(); err != nil {
+				return fmt.Errorf("db transaction failed: %w", err)
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
 func (r *RedisDB) LockCommand(cmdName command.Name, lockTime time.Time) (*command.Lock, error) {
 
 	lock := command.Lock{
@@ -403,7 +496,7 @@ func (r *RedisDB) CheckCommandLock(cmdName command.Name) (*command.Lock, error)
 // UpdateProjectStatus updates pull's status with the latest project results.
 // It returns the new PullStatus object.
 func (r *RedisDB) UpdateProjectStatus(pull models.PullRequest, workspace string, repoRelDir string, newStatus models.ProjectPlanStatus) error {
-	key, err := r.pullKey(pull)
+	key, err := pullKey(pull)
 	if err != nil {
 		return err
 	}
@@ -436,7 +529,7 @@ func (r *RedisDB) UpdateProjectStatus(pull models.PullRequest, workspace string,
 }
 
 func (r *RedisDB) GetPullStatus(pull models.PullRequest) (*models.PullStatus, error) {
-	key, err := r.pullKey(pull)
+	key, err := pullKey(pull)
 	if err != nil {
 		return nil, err
 	}
@@ -449,7 +542,7 @@ func (r *RedisDB) GetPullStatus(pull models.PullRequest) (*models.PullStatus, er
 }
 
 func (r *RedisDB) DeletePullStatus(pull models.PullRequest) error {
-	key, err := r.pullKey(pull)
+	key, err := pullKey(pull)
 	if err != nil {
 		return err
 	}
@@ -461,7 +554,7 @@ func (r *RedisDB) DeletePullStatus(pull models.PullRequest) error {
 }
 
 func (r *RedisDB) UpdatePullWithResults(pull models.PullRequest, newResults []command.ProjectResult) (models.PullStatus, error) {
-	key, err := r.pullKey(pull)
+	key, err := pullKey(pull)
 	if err != nil {
 		return models.PullStatus{}, err
 	}
@@ -476,79 +569,7 @@ func (r *RedisDB) UpdatePullWithResults(pull models.PullRequest, newResults []co
 		currStatus = nil
 	}
 
-	// If there is no pull OR if the pull we have is out of date, we
-	// just write a new pull.
-	if currStatus == nil || pullStatusOutdatedForPull(currStatus.Pull, pull) {
-		var statuses []models.ProjectStatus
-		for _, res := range newResults {
-			statuses = append(statuses, r.projectResultToProject(res))
-		}
-		// Preserve policy status from the previous commit so approvals
-		// survive between the plan DB write and the subsequent policy
-		// check DB write. doPolicyCheck applies sticky filtering and
-		// overwrites these when it writes its own results.
-		if currStatus != nil {
-			for i := range statuses {
-				for _, old := range currStatus.Projects {
-					if statuses[i].Workspace == old.Workspace &&
-						statuses[i].RepoRelDir == old.RepoRelDir &&
-						statuses[i].ProjectName == old.ProjectName &&
-						len(old.PolicyStatus) > 0 {
-						statuses[i].PolicyStatus = old.PolicyStatus
-						break
-					}
-				}
-			}
-		}
-		newStatus = models.PullStatus{
-			Pull:     pull,
-			Projects: statuses,
-		}
-	} else {
-		// If there's an existing pull at the right commit then we have to
-		// merge our project results with the existing ones. We do a merge
-		// because it's possible a user is just applying a single project
-		// in this command and so we don't want to delete our data about
-		// other projects that aren't affected by this command.
-		newStatus = *currStatus
-		for _, res := range newResults {
-			// First, check if we should update any existing projects.
-			updatedExisting := false
-			for i := range newStatus.Projects {
-				// NOTE: We're using a reference here because we are
-				// in-place updating its Status field.
-				proj := &newStatus.Projects[i]
-				if res.Workspace == proj.Workspace &&
-					res.RepoRelDir == proj.RepoRelDir &&
-					res.ProjectName == proj.ProjectName {
-
-					proj.Status = res.PlanStatus()
-
-					// Updating only policy sets which are included in results; keeping the rest.
-					if len(proj.PolicyStatus) > 0 {
-						for i, oldPolicySet := range proj.PolicyStatus {
-							for _, newPolicySet := range res.PolicyStatus() {
-								if oldPolicySet.PolicySetName == newPolicySet.PolicySetName {
-									proj.PolicyStatus[i] = newPolicySet
-								}
-							}
-						}
-					} else {
-						proj.PolicyStatus = res.PolicyStatus()
-					}
-
-					updatedExisting = true
-					break
-				}
-			}
-
-			if !updatedExisting {
-				// If we didn't update an existing project, then we need to
-				// add this because it's a new one.
-				newStatus.Projects = append(newStatus.Projects, r.projectResultToProject(res))
-			}
-		}
-	}
+	newStatus = db.MergePullStatus(currStatus, pull, newResults)
 
 	// Now, we overwrite the key with our new status.
 	err = r.writePull(key, newStatus)
@@ -558,16 +579,6 @@ func (r *RedisDB) UpdatePullWithResults(pull models.PullRequest, newResults []co
 	return newStatus, nil
 }
 
-func pullStatusOutdatedForPull(statusPull models.PullRequest, pull models.PullRequest) bool {
-	if statusPull.HeadCommit != pull.HeadCommit {
-		return true
-	}
-	if pull.BaseBranch == "" {
-		return false
-	}
-	return statusPull.BaseBranch == "" || statusPull.BaseBranch != pull.BaseBranch
-}
-
 func (r *RedisDB) getPull(key string) (*models.PullStatus, error) {
 	val, err := r.client.Get(ctx, key).Result()
 	if err == redis.Nil {
@@ -607,11 +618,16 @@ func (r *RedisDB) lockKey(p models.Project, workspace string) string {
 	return fmt.Sprintf("pr/%s", models.GenerateLockKey(p, workspace))
 }
 
+// lockQueueKey doesn't start with "pr" so lock queues aren't listed as locks.
+func (r *RedisDB) lockQueueKey(p models.Project, workspace string) string {
+	return fmt.Sprintf("lockqueue/%s", models.GenerateLockKey(p, workspace))
+}
+
 func (r *RedisDB) commandLockKey(cmdName command.Name) string {
 	return fmt.Sprintf("global/%s/lock", cmdName)
 }
 
-func (r *RedisDB) pullKey(pull models.PullRequest) (string, error) {
+func pullKey(pull models.PullRequest) (string, error) {
 	hostname := pull.BaseRepo.VCSHost.Hostname
 	if strings.Contains(hostname, pullKeySeparator) {
 		return "", fmt.Errorf("vcs hostname %q contains illegal string %q", hostname, pullKeySeparator)
@@ -624,16 +640,6 @@ func (r *RedisDB) pullKey(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("%s::%s::%d", hostname, repo, pull.Num), nil
 }
 
-func (r *RedisDB) projectResultToProject(p command.ProjectResult) models.ProjectStatus {
-	return models.ProjectStatus{
-		Workspace:    p.Workspace,
-		RepoRelDir:   p.RepoRelDir,
-		ProjectName:  p.ProjectName,
-		PolicyStatus: p.PolicyStatus(),
-		Status:       p.PlanStatus(),
-	}
-}
-
 // Ping checks the Redis connection health.
 func (r *RedisDB) Ping() error {
 	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
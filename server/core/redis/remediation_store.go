@@ -0,0 +1,157 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+const (
+	remediationKeyPrefix      = "remediation/"
+	remediationIndexKeyPrefix = "remediations/"
+	// remediationListBatchSize is how many IDs List reads from the index per
+	// round trip while filling a page.
+	remediationListBatchSize = 50
+)
+
+// RemediationStore is a drift.RemediationStore backed by the same Redis used
+// for locks, so every replica sees the same remediation history.
+// Each result is stored under remediation/{id} and indexed per repository in
+// a sorted set scored by start time.
+type RemediationStore struct {
+	client redis.Cmdable
+	// retention is used as the result key TTL and to trim the per-repository
+	// index. Zero keeps results forever.
+	retention time.Duration
+}
+
+var _ drift.RemediationStore = (*RemediationStore)(nil)
+
+// remediationRecord is the value stored for each result. The repository is
+// stored explicitly because RemediationResult.StorageRepository is not
+// serialized.
+type remediationRecord struct {
+	Repository string                    `json:"repository"`
+	Result     *models.RemediationResult `json:"result"`
+}
+
+// NewRemediationStore returns a remediation store that shares r's Redis client.
+func NewRemediationStore(r *RedisDB, retention time.Duration) *RemediationStore {
+	return &RemediationStore{
+		client:    r.client,
+		retention: retention,
+	}
+}
+
+// Save creates or replaces the result with the same ID.
+func (s *RemediationStore) Save(result *models.RemediationResult) error {
+	repository := result.StorageRepository
+	if repository == "" {
+		repository = result.Repository
+	}
+	serialized, err := json.Marshal(remediationRecord{Repository: repository, Result: result})
+	if err != nil {
+		return fmt.Errorf("serializing remediation result: %w", err)
+	}
+	if err := s.client.Set(ctx, s.resultKey(result.ID), serialized, s.retention).Err(); err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+
+	indexKey := s.indexKey(repository)
+	err = s.client.ZAdd(ctx, indexKey, redis.Z{
+		Score:  float64(result.StartedAt.UnixNano()),
+		Member: result.ID,
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	if s.retention > 0 {
+		cutoff := time.Now().Add(-s.retention).UnixNano()
+		if err := s.client.ZRemRangeByScore(ctx, indexKey, "-inf", "("+strconv.FormatInt(cutoff, 10)).Err(); err != nil {
+			return fmt.Errorf("db transaction failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Get returns the result with the given ID, or nil if it does not exist.
+func (s *RemediationStore) Get(id string) (*models.RemediationResult, error) {
+	val, err := s.client.Get(ctx, s.resultKey(id)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return decodeRemediationRecord(s.resultKey(id), val)
+}
+
+// List returns results for a repository, newest first. Index entries whose
+// result has already expired are skipped.
+func (s *RemediationStore) List(repository string, opts drift.ListResultsOptions) ([]*models.RemediationResult, error) {
+	collector := drift.NewResultCollector(opts)
+	indexKey := s.indexKey(repository)
+	for start := int64(0); ; start += remediationListBatchSize {
+		ids, err := s.client.ZRevRange(ctx, indexKey, start, start+remediationListBatchSize-1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("db transaction failed: %w", err)
+		}
+		for _, id := range ids {
+			result, err := s.Get(id)
+			if err != nil {
+				return nil, err
+			}
+			if result == nil {
+				continue
+			}
+			if collector.Add(result) {
+				return collector.Results, nil
+			}
+		}
+		if len(ids) < remediationListBatchSize {
+			return collector.Results, nil
+		}
+	}
+}
+
+// Repositories returns the repositories with a result index. The index of a
+// repository outlives its results until the next Save trims it.
+func (s *RemediationStore) Repositories() ([]string, error) {
+	var repositories []string
+	iter := s.client.Scan(ctx, 0, remediationIndexKeyPrefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		repositories = append(repositories, strings.TrimPrefix(iter.Val(), remediationIndexKeyPrefix))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	return repositories, nil
+}
+
+func (s *RemediationStore) resultKey(id string) string {
+	return remediationKeyPrefix + id
+}
+
+func (s *RemediationStore) indexKey(repository string) string {
+	return remediationIndexKeyPrefix + repository
+}
+
+func decodeRemediationRecord(key, val string) (*models.RemediationResult, error) {
+	var record remediationRecord
+	if err := json.Unmarshal([]byte(val), &record); err != nil {
+		return nil, fmt.Errorf("failed to deserialize remediation result at key '%s': %w", key, err)
+	}
+	if record.Result == nil {
+		return nil, fmt.Errorf("remediation result at key '%s' is empty", key)
+	}
+	record.Result.StorageRepository = record.Repository
+	return record.Result, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newTestRemediation(id string, status models.RemediationStatus, startedAt time.Time) *models.RemediationResult {
+	result := models.NewRemediationResult(id, "acme/infra", "main", models.RemediationAutoApply)
+	result.StorageRepository = "github.com/acme/infra"
+	result.Status = status
+	result.StartedAt = startedAt
+	return result
+}
+
+func TestRemediationStore_SaveGetList(t *testing.T) {
+	s := miniredis.RunT(t)
+	store := redis.NewRemediationStore(newTestRedis(s), 0)
+
+	now := time.Now()
+	for i, status := range []models.RemediationStatus{
+		models.RemediationStatusSuccess,
+		models.RemediationStatusFailed,
+		models.RemediationStatusSuccess,
+	} {
+		Ok(t, store.Save(newTestRemediation(string(rune('a'+i)), status, now.Add(time.Duration(i)*time.Minute))))
+	}
+
+	got, err := store.Get("b")
+	Ok(t, err)
+	Equals(t, models.RemediationStatusFailed, got.Status)
+	Equals(t, "github.com/acme/infra", got.StorageRepository)
+
+	results, err := store.List("github.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 3, len(results))
+	Equals(t, "c", results[0].ID)
+
+	successes, err := store.List("github.com/acme/infra", drift.ListResultsOptions{
+		Statuses: []models.RemediationStatus{models.RemediationStatusSuccess},
+		Offset:   1,
+	})
+	Ok(t, err)
+	Equals(t, 1, len(successes))
+	Equals(t, "a", successes[0].ID)
+
+	other, err := store.List("gitlab.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(other))
+
+	repositories, err := store.Repositories()
+	Ok(t, err)
+	Equals(t, []string{"github.com/acme/infra"}, repositories)
+}
+
+func TestRemediationStore_Retention(t *testing.T) {
+	s := miniredis.RunT(t)
+	store := redis.NewRemediationStore(newTestRedis(s), time.Hour)
+
+	Ok(t, store.Save(newTestRemediation("a", models.RemediationStatusSuccess, time.Now())))
+	s.FastForward(2 * time.Hour)
+
+	got, err := store.Get("a")
+	Ok(t, err)
+	Assert(t, got == nil, "expected expired result")
+	results, err := store.List("github.com/acme/infra", drift.ListResultsOptions{})
+	Ok(t, err)
+	Equals(t, 0, len(results))
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+)
+
+const webhookDeadLettersKey = "webhooks/dead-letters"
+
+// WebhookDeadLetterStore is a webhooks.DeadLetterStore backed by the same
+// Redis used for locks, so dead letters from every replica are listed
+// together. Dead letters are kept in a list, newest first.
+type WebhookDeadLetterStore struct {
+	client redis.Cmdable
+}
+
+var _ webhooks.DeadLetterStore = (*WebhookDeadLetterStore)(nil)
+
+// NewWebhookDeadLetterStore returns a dead letter store that shares r's
+// Redis client.
+func NewWebhookDeadLetterStore(r *RedisDB) *WebhookDeadLetterStore {
+	return &WebhookDeadLetterStore{client: r.client}
+}
+
+// Add records a dead letter and drops the oldest ones beyond
+// webhooks.MaxDeadLetters.
+func (s *WebhookDeadLetterStore) Add(letter webhooks.DeadLetter) error {
+	serialized, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("serializing webhook dead letter: %w", err)
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, webhookDeadLettersKey, serialized)
+		pipe.LTrim(ctx, webhookDeadLettersKey, 0, webhooks.MaxDeadLetters-1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// List returns the dead letters, newest first.
+func (s *WebhookDeadLetterStore) List() ([]webhooks.DeadLetter, error) {
+	vals, err := s.client.LRange(ctx, webhookDeadLettersKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("db transaction failed: %w", err)
+	}
+	letters := make([]webhooks.DeadLetter, 0, len(vals))
+	for _, val := range vals {
+		var letter webhooks.DeadLetter
+		if err := json.Unmarshal([]byte(val), &letter); err != nil {
+			return nil, fmt.Errorf("failed to deserialize webhook dead letter: %w", err)
+		}
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestWebhookDeadLetterStore_SharedBetweenReplicas(t *testing.T) {
+	s := miniredis.RunT(t)
+	failing := redis.NewWebhookDeadLetterStore(newTestRedis(s))
+	listing := redis.NewWebhookDeadLetterStore(newTestRedis(s))
+
+	letters, err := listing.List()
+	Ok(t, err)
+	Equals(t, []webhooks.DeadLetter{}, letters)
+
+	now := time.Now().UTC()
+	first := webhooks.DeadLetter{ID: "first", Event: "apply", URL: "https://example.com", Payload: "{}", Attempts: 4, LastError: "status 500", FailedAt: now.Add(-time.Minute)}
+	second := webhooks.DeadLetter{ID: "second", Event: "drift", URL: "https://example.com", Payload: "{}", Attempts: 1, LastError: "status 400", FailedAt: now}
+	Ok(t, failing.Add(first))
+	Ok(t, failing.Add(second))
+
+	letters, err = listing.List()
+	Ok(t, err)
+	Equals(t, []webhooks.DeadLetter{second, first}, letters)
+}
+
+func TestWebhookDeadLetterStore_DropsOldest(t *testing.T) {
+	s := miniredis.RunT(t)
+	store := redis.NewWebhookDeadLetterStore(newTestRedis(s))
+
+	for i := range webhooks.MaxDeadLetters + 2 {
+		Ok(t, store.Add(webhooks.DeadLetter{ID: fmt.Sprint(i)}))
+	}
+	letters, err := store.List()
+	Ok(t, err)
+	Equals(t, webhooks.MaxDeadLetters, len(letters))
+	Equals(t, fmt.Sprint(webhooks.MaxDeadLetters+1), letters[0].ID)
+	Equals(t, "2", letters[len(letters)-1].ID)
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	workQueueStream = "workqueue/commands"
+	workQueueGroup  = "atlantis"
+	// workQueueMaxLen caps the stream length. Claimed entries are never read
+	// again, so only a backlog this long would lose events to trimming.
+	workQueueMaxLen = 10000
+	// workQueueBlock is how long a worker waits for new entries before
+	// checking whether it has been stopped.
+	workQueueBlock = 5 * time.Second
+	// workQueueRetryDelay is how long a worker backs off after a Redis error.
+	workQueueRetryDelay = time.Second
+	workItemField       = "item"
+)
+
+const (
+	autoplanWorkItem = "autoplan"
+	commentWorkItem  = "comment"
+)
+
+// workItem is a command event as it is stored in the work queue.
+type workItem struct {
+	Kind       string
+	BaseRepo   models.Repo
+	HeadRepo   *models.Repo
+	Pull       *models.PullRequest
+	User       models.User
+	PullNum    int
+	Command    *events.CommentCommand
+	EnqueuedAt time.Time
+}
+
+// WorkQueue is an events.CommandRunner that puts command events on a Redis
+// stream instead of running them, so whichever replica has a free worker
+// picks them up rather than the replica that received the webhook.
+//
+// Entries are acknowledged when they are claimed, so each event runs at most
+// once: if a replica dies while running a command, the command is not
+// retried, just as it wouldn't be without the queue. Applying twice is worse
+// than asking the user to comment again.
+type WorkQueue struct {
+	client redis.Cmdable
+	// runner runs the commands this replica claims. It's also used directly
+	// if an event can't be enqueued.
+	runner      events.CommandRunner
+	consumer    string
+	concurrency int
+	logger      logging.SimpleLogging
+
+	runCtx context.Context
+	cancel context.CancelFunc
+}
+
+var _ events.CommandRunner = (*WorkQueue)(nil)
+
+// NewWorkQueue returns a work queue that shares r's Redis client. consumer
+// must be unique per replica and concurrency is the number of commands this
+// replica runs at once.
+func NewWorkQueue(r *RedisDB, runner events.CommandRunner, consumer string, concurrency int, logger logging.SimpleLogging) *WorkQueue {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	return &WorkQueue{
+		client:      r.client,
+		runner:      runner,
+		consumer:    consumer,
+		concurrency: concurrency,
+		logger:      logger,
+		runCtx:      runCtx,
+		cancel:      cancel,
+	}
+}
+
+// RunCommentCommand enqueues a comment command. Cancel commands run
+// immediately on this replica so they aren't stuck behind the commands they
+// are meant to cancel.
+func (q *WorkQueue) RunCommentCommand(baseRepo models.Repo, maybeHeadRepo *models.Repo, maybePull *models.PullRequest, user models.User, pullNum int, cmd *events.CommentCommand) {
+	if cmd != nil && cmd.Name == command.Cancel {
+		q.runner.RunCommentCommand(baseRepo, maybeHeadRepo, maybePull, user, pullNum, cmd)
+		return
+	}
+	item := workItem{
+		Kind:     commentWorkItem,
+		BaseRepo: baseRepo,
+		HeadRepo: maybeHeadRepo,
+		Pull:     maybePull,
+		User:     user,
+		PullNum:  pullNum,
+		Command:  cmd,
+	}
+	if err := q.enqueue(item); err != nil {
+		q.logger.Err("%s, running command on this replica instead", err)
+		q.runner.RunCommentCommand(baseRepo, maybeHeadRepo, maybePull, user, pullNum, cmd)
+	}
+}
+
+// RunAutoplanCommand enqueues an autoplan.
+func (q *WorkQueue) RunAutoplanCommand(baseRepo models.Repo, headRepo models.Repo, pull models.PullRequest, user models.User) {
+	item := workItem{
+		Kind:     autoplanWorkItem,
+		BaseRepo: baseRepo,
+		HeadRepo: &headRepo,
+		Pull:     &pull,
+		User:     user,
+		PullNum:  pull.Num,
+	}
+	if err := q.enqueue(item); err != nil {
+		q.logger.Err("%s, running autoplan on this replica instead", err)
+		q.runner.RunAutoplanCommand(baseRepo, headRepo, pull, user)
+	}
+}
+
+func (q *WorkQueue) enqueue(item workItem) error {
+	item.EnqueuedAt = time.Now()
+	serialized, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("serializing work item: %w", err)
+	}
+	err = q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: workQueueStream,
+		MaxLen: workQueueMaxLen,
+		Approx: true,
+		Values: map[string]any{workItemField: serialized},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("enqueueing work item: db transaction failed: %w", err)
+	}
+	return nil
+}
+
+// Start claims and runs queued commands until Stop is called. It blocks, so
+// callers should run it in a goroutine.
+func (q *WorkQueue) Start() {
+	runCtx := q.runCtx
+
+	// Reading from ID 0 means a group created after events were enqueued
+	// still sees them.
+	err := q.client.XGroupCreateMkStream(runCtx, workQueueStream, workQueueGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		q.logger.Err("creating work queue consumer group: %s", err)
+	}
+
+	// A slot is taken before claiming an entry so a busy replica leaves
+	// entries for idle ones.
+	slots := make(chan struct{}, q.concurrency)
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-runCtx.Done():
+			return
+		}
+		claimed, err := q.claim(runCtx)
+		if err != nil {
+			<-slots
+			if runCtx.Err() != nil {
+				return
+			}
+			q.logger.Err("%s", err)
+			select {
+			case <-time.After(workQueueRetryDelay):
+			case <-runCtx.Done():
+				return
+			}
+			continue
+		}
+		if claimed == nil {
+			<-slots
+			continue
+		}
+		go func() {
+			defer func() { <-slots }()
+			q.run(*claimed)
+		}()
+	}
+}
+
+// Stop stops claiming new commands. Commands that are already running are
+// left to finish and are tracked by the server's drainer.
+func (q *WorkQueue) Stop() {
+	q.cancel()
+}
+
+// claim reads and acknowledges the next entry. It returns nil if there was
+// nothing to claim before the read timed out.
+func (q *WorkQueue) claim(runCtx context.Context) (*workItem, error) {
+	streams, err := q.client.XReadGroup(runCtx, &redis.XReadGroupArgs{
+		Group:    workQueueGroup,
+		Consumer: q.consumer,
+		Streams:  []string{workQueueStream, ">"},
+		Count:    1,
+		Block:    workQueueBlock,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "NOGROUP") {
+			// The stream was deleted from under us, e.g. by FLUSHDB.
+			q.client.XGroupCreateMkStream(runCtx, workQueueStream, workQueueGroup, "0") // nolint: errcheck
+		}
+		return nil, fmt.Errorf("claiming work item: db transaction failed: %w", err)
+	}
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			if err := q.client.XAck(runCtx, workQueueStream, workQueueGroup, msg.ID).Err(); err != nil {
+				return nil, fmt.Errorf("acknowledging work item %s: db transaction failed: %w", msg.ID, err)
+			}
+			raw, ok := msg.Values[workItemField].(string)
+			if !ok {
+				q.logger.Err("discarding malformed work item %s", msg.ID)
+				return nil, nil
+			}
+			var item workItem
+			if err := json.Unmarshal([]byte(raw), &item); err != nil {
+				q.logger.Err("discarding malformed work item %s: %s", msg.ID, err)
+				return nil, nil
+			}
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+func (q *WorkQueue) run(item workItem) {
+	q.logger.Debug("running %s work item for %s#%d enqueued %s ago", item.Kind, item.BaseRepo.FullName, item.PullNum, time.Since(item.EnqueuedAt).Round(time.Millisecond))
+	switch item.Kind {
+	case autoplanWorkItem:
+		if item.HeadRepo == nil || item.Pull == nil {
+			q.logger.Err("discarding autoplan work item for %s#%d without a pull request", item.BaseRepo.FullName, item.PullNum)
+			return
+		}
+		q.runner.RunAutoplanCommand(item.BaseRepo, *item.HeadRepo, *item.Pull, item.User)
+	case commentWorkItem:
+		q.runner.RunCommentCommand(item.BaseRepo, item.HeadRepo, item.Pull, item.User, item.PullNum, item.Command)
+	default:
+		q.logger.Err("discarding work item with unknown kind %q", item.Kind)
+	}
+}
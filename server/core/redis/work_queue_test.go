@@ -0,0 +1,111 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/runatlantis/atlantis/server/core/redis"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+// ranCommand records a call to recordingCommandRunner.
+type ranCommand struct {
+	autoplan bool
+	pullNum  int
+	cmd      *events.CommentCommand
+}
+
+type recordingCommandRunner struct {
+	ran chan ranCommand
+}
+
+func newRecordingCommandRunner() *recordingCommandRunner {
+	return &recordingCommandRunner{ran: make(chan ranCommand, 10)}
+}
+
+func (r *recordingCommandRunner) RunCommentCommand(_ models.Repo, _ *models.Repo, _ *models.PullRequest, _ models.User, pullNum int, cmd *events.CommentCommand) {
+	r.ran <- ranCommand{pullNum: pullNum, cmd: cmd}
+}
+
+func (r *recordingCommandRunner) RunAutoplanCommand(_ models.Repo, _ models.Repo, pull models.PullRequest, _ models.User) {
+	r.ran <- ranCommand{autoplan: true, pullNum: pull.Num}
+}
+
+func (r *recordingCommandRunner) next(t *testing.T) ranCommand {
+	t.Helper()
+	select {
+	case ran := <-r.ran:
+		return ran
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for command to run")
+		return ranCommand{}
+	}
+}
+
+func (r *recordingCommandRunner) assertNothingRan(t *testing.T) {
+	t.Helper()
+	select {
+	case ran := <-r.ran:
+		t.Fatalf("expected no command to run, got %+v", ran)
+	default:
+	}
+}
+
+func TestWorkQueue_CommandsRunOnWorkers(t *testing.T) {
+	s := miniredis.RunT(t)
+	logger := logging.NewNoopLogger(t)
+
+	// The replica receiving webhooks doesn't run a worker.
+	receiverRunner := newRecordingCommandRunner()
+	receiver := redis.NewWorkQueue(newTestRedis(s), receiverRunner, "receiver", 1, logger)
+	workerRunner := newRecordingCommandRunner()
+	worker := redis.NewWorkQueue(newTestRedis(s), workerRunner, "worker", 1, logger)
+	go worker.Start()
+	t.Cleanup(worker.Stop)
+
+	pull := models.PullRequest{Num: 1, HeadCommit: "sha"}
+	receiver.RunAutoplanCommand(models.Repo{FullName: "owner/repo"}, models.Repo{FullName: "owner/repo"}, pull, models.User{Username: "user"})
+	ran := workerRunner.next(t)
+	Equals(t, true, ran.autoplan)
+	Equals(t, 1, ran.pullNum)
+
+	receiver.RunCommentCommand(models.Repo{FullName: "owner/repo"}, nil, nil, models.User{Username: "user"}, 2, &events.CommentCommand{
+		Name:      command.Plan,
+		Workspace: "staging",
+		Flags:     []string{"-target=foo"},
+	})
+	ran = workerRunner.next(t)
+	Equals(t, false, ran.autoplan)
+	Equals(t, 2, ran.pullNum)
+	Equals(t, &events.CommentCommand{Name: command.Plan, Workspace: "staging", Flags: []string{"-target=foo"}}, ran.cmd)
+
+	receiverRunner.assertNothingRan(t)
+}
+
+func TestWorkQueue_CancelSkipsQueue(t *testing.T) {
+	s := miniredis.RunT(t)
+	runner := newRecordingCommandRunner()
+	queue := redis.NewWorkQueue(newTestRedis(s), runner, "replica", 1, logging.NewNoopLogger(t))
+
+	queue.RunCommentCommand(models.Repo{}, nil, nil, models.User{}, 1, &events.CommentCommand{Name: command.Cancel})
+	Equals(t, command.Cancel, runner.next(t).cmd.Name)
+	Assert(t, !s.Exists("workqueue/commands"), "expected cancel not to be enqueued")
+}
+
+func TestWorkQueue_RunsLocallyWhenEnqueueFails(t *testing.T) {
+	s := miniredis.RunT(t)
+	runner := newRecordingCommandRunner()
+	queue := redis.NewWorkQueue(newTestRedis(s), runner, "replica", 1, logging.NewNoopLogger(t))
+	s.Close()
+
+	queue.RunAutoplanCommand(models.Repo{}, models.Repo{}, models.PullRequest{Num: 3}, models.User{})
+	Equals(t, 3, runner.next(t).pullNum)
+}
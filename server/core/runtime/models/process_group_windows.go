@@ -0,0 +1,21 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, which has no process groups.
+func setProcessGroup(_ *exec.Cmd) {}
+
+// interruptProcessGroup kills cmd's process since Windows doesn't support
+// sending it an interrupt.
+func interruptProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
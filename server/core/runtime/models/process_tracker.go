@@ -0,0 +1,148 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+)
+
+// ProcessKey identifies the project a process was started for.
+type ProcessKey struct {
+	RepoFullName string
+	PullNum      int
+	ProjectName  string
+	RepoRelDir   string
+	Workspace    string
+}
+
+// NewProcessKey returns the key of the project described by ctx.
+func NewProcessKey(ctx command.ProjectContext) ProcessKey {
+	return ProcessKey{
+		RepoFullName: ctx.Pull.BaseRepo.FullName,
+		PullNum:      ctx.Pull.Num,
+		ProjectName:  ctx.ProjectName,
+		RepoRelDir:   ctx.RepoRelDir,
+		Workspace:    ctx.Workspace,
+	}
+}
+
+// InterruptedProcess describes a process stopped by ProcessTracker.Interrupt.
+type InterruptedProcess struct {
+	ProcessKey
+	// CommandName is the Atlantis command the process was run for.
+	CommandName command.Name
+	// Command is the command line of the process, as shown in logs.
+	Command string
+}
+
+type trackedProcess struct {
+	key         ProcessKey
+	commandName command.Name
+	command     string
+	interrupted bool
+}
+
+// ProcessTracker records the processes started for project commands so that
+// `atlantis cancel` can stop them. A nil *ProcessTracker tracks nothing.
+type ProcessTracker struct {
+	mutex   sync.Mutex
+	running map[*exec.Cmd]*trackedProcess
+	// interrupted holds the projects whose processes were interrupted and
+	// have since exited, until TakeInterrupted is called for them.
+	interrupted map[ProcessKey]struct{}
+}
+
+// NewProcessTracker returns an empty ProcessTracker.
+func NewProcessTracker() *ProcessTracker {
+	return &ProcessTracker{
+		running:     make(map[*exec.Cmd]*trackedProcess),
+		interrupted: make(map[ProcessKey]struct{}),
+	}
+}
+
+// prepare configures cmd, before it's started, so the whole process tree can
+// be signalled.
+func (p *ProcessTracker) prepare(cmd *exec.Cmd) {
+	if p == nil {
+		return
+	}
+	setProcessGroup(cmd)
+}
+
+// track records the started cmd as running for the project in ctx.
+func (p *ProcessTracker) track(ctx command.ProjectContext, cmd *exec.Cmd, display string) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.running[cmd] = &trackedProcess{
+		key:         NewProcessKey(ctx),
+		commandName: ctx.CommandName,
+		command:     display,
+	}
+}
+
+// untrack records that cmd has exited.
+func (p *ProcessTracker) untrack(cmd *exec.Cmd) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if proc, ok := p.running[cmd]; ok && proc.interrupted {
+		p.interrupted[proc.key] = struct{}{}
+	}
+	delete(p.running, cmd)
+}
+
+// Interrupt sends SIGINT to the process trees of the running processes whose
+// key matches, and SIGKILL to those still running after grace. It returns
+// the processes that were signalled.
+func (p *ProcessTracker) Interrupt(match func(ProcessKey) bool, grace time.Duration) []InterruptedProcess {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var interrupted []InterruptedProcess
+	for cmd, proc := range p.running {
+		if proc.interrupted || !match(proc.key) {
+			continue
+		}
+		proc.interrupted = true
+		interruptProcessGroup(cmd) // nolint: errcheck
+		interrupted = append(interrupted, InterruptedProcess{
+			ProcessKey:  proc.key,
+			CommandName: proc.commandName,
+			Command:     proc.command,
+		})
+		time.AfterFunc(grace, func() {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+			if _, ok := p.running[cmd]; ok {
+				killProcessGroup(cmd) // nolint: errcheck
+			}
+		})
+	}
+	return interrupted
+}
+
+// TakeInterrupted reports whether a process run for key was interrupted, and
+// forgets about it.
+func (p *ProcessTracker) TakeInterrupted(key ProcessKey) bool {
+	if p == nil {
+		return false
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, ok := p.interrupted[key]
+	delete(p.interrupted, key)
+	return ok
+}
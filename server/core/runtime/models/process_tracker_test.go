@@ -0,0 +1,108 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package models_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/runtime/models"
+	"github.com/runatlantis/atlantis/server/events/command"
+	eventsmodels "github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/jobs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func processTrackerCtx(t *testing.T) command.ProjectContext {
+	return command.ProjectContext{
+		CommandName: command.Plan,
+		Log:         logging.NewNoopLogger(t),
+		Pull: eventsmodels.PullRequest{
+			Num:      1,
+			BaseRepo: eventsmodels.Repo{FullName: "owner/repo"},
+		},
+		ProjectName: "project1",
+		RepoRelDir:  ".",
+		Workspace:   "default",
+	}
+}
+
+// interruptWhenRunning interrupts the processes of ctx as soon as one has
+// started.
+func interruptWhenRunning(t *testing.T, tracker *models.ProcessTracker, ctx command.ProjectContext, grace time.Duration) []models.InterruptedProcess {
+	key := models.NewProcessKey(ctx)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		interrupted := tracker.Interrupt(func(k models.ProcessKey) bool { return k == key }, grace)
+		if len(interrupted) > 0 {
+			return interrupted
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("process was never tracked")
+	return nil
+}
+
+func TestProcessTracker_Interrupt(t *testing.T) {
+	ctx := processTrackerCtx(t)
+	tracker := models.NewProcessTracker()
+	cwd, err := os.Getwd()
+	Ok(t, err)
+
+	// The shell runs sleep as a child process, so this also checks that the
+	// interrupt reaches the whole process tree.
+	runner := models.NewShellCommandRunner(nil, "sleep 30; echo done", nil, cwd, false, mocks.NewMockProjectCommandOutputHandler(), tracker)
+	errCh := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		_, err := runner.Run(ctx)
+		errCh <- err
+	}()
+
+	interrupted := interruptWhenRunning(t, tracker, ctx, time.Minute)
+	Equals(t, 1, len(interrupted))
+	Equals(t, models.NewProcessKey(ctx), interrupted[0].ProcessKey)
+	Equals(t, command.Plan, interrupted[0].CommandName)
+
+	Assert(t, <-errCh != nil, "expected interrupted command to fail")
+	Assert(t, time.Since(start) < 10*time.Second, "expected command to stop when interrupted")
+	Assert(t, tracker.TakeInterrupted(models.NewProcessKey(ctx)), "expected project to be marked interrupted")
+	Assert(t, !tracker.TakeInterrupted(models.NewProcessKey(ctx)), "expected interrupted mark to be cleared")
+}
+
+func TestProcessTracker_KillsAfterGracePeriod(t *testing.T) {
+	ctx := processTrackerCtx(t)
+	tracker := models.NewProcessTracker()
+	cwd, err := os.Getwd()
+	Ok(t, err)
+
+	// Ignored signals stay ignored in child processes, so neither the shell
+	// nor sleep stops on SIGINT.
+	runner := models.NewShellCommandRunner(nil, "trap '' INT; sleep 30", nil, cwd, false, mocks.NewMockProjectCommandOutputHandler(), tracker)
+	errCh := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		_, err := runner.Run(ctx)
+		errCh <- err
+	}()
+
+	interruptWhenRunning(t, tracker, ctx, 100*time.Millisecond)
+	Assert(t, <-errCh != nil, "expected killed command to fail")
+	Assert(t, time.Since(start) < 10*time.Second, "expected command to be killed after the grace period")
+}
+
+func TestProcessTracker_NoMatch(t *testing.T) {
+	tracker := models.NewProcessTracker()
+	interrupted := tracker.Interrupt(func(models.ProcessKey) bool { return true }, time.Second)
+	Equals(t, 0, len(interrupted))
+	Assert(t, !tracker.TakeInterrupted(models.NewProcessKey(processTrackerCtx(t))), "expected nothing to be interrupted")
+}
+
+func TestProcessTracker_Nil(t *testing.T) {
+	var tracker *models.ProcessTracker
+	Equals(t, 0, len(tracker.Interrupt(func(models.ProcessKey) bool { return true }, time.Second)))
+	Assert(t, !tracker.TakeInterrupted(models.ProcessKey{}), "expected nil tracker to track nothing")
+}
@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -22,6 +23,10 @@ import (
 // Setting the buffer size to 10mb
 const BufioScannerBufferSize = 10 * 1024 * 1024
 
+// timeoutGracePeriod is how long a process that timed out has to exit after
+// being interrupted before it's killed.
+const timeoutGracePeriod = 30 * time.Second
+
 // Line represents a line that was output from a shell command.
 type Line struct {
 	// Line is the contents of the line (without the newline).
@@ -39,6 +44,9 @@ type ShellCommandRunner struct {
 	streamOutput  bool
 	cmd           *exec.Cmd
 	shell         *valid.CommandShell
+	// processTracker, if set, lets the process be interrupted by `atlantis
+	// cancel`.
+	processTracker *ProcessTracker
 }
 
 func NewShellCommandRunner(
@@ -48,6 +56,7 @@ func NewShellCommandRunner(
 	workingDir string,
 	streamOutput bool,
 	outputHandler jobs.ProjectCommandOutputHandler,
+	processTracker *ProcessTracker,
 ) *ShellCommandRunner {
 	if shell == nil {
 		shell = &valid.CommandShell{
@@ -63,12 +72,13 @@ func NewShellCommandRunner(
 	cmd.Dir = workingDir
 
 	return &ShellCommandRunner{
-		command:       command,
-		workingDir:    workingDir,
-		outputHandler: outputHandler,
-		streamOutput:  streamOutput,
-		cmd:           cmd,
-		shell:         shell,
+		command:        command,
+		workingDir:     workingDir,
+		outputHandler:  outputHandler,
+		streamOutput:   streamOutput,
+		cmd:            cmd,
+		shell:          shell,
+		processTracker: processTracker,
 	}
 }
 
@@ -84,17 +94,19 @@ func NewArgvCommandRunner(
 	workingDir string,
 	streamOutput bool,
 	outputHandler jobs.ProjectCommandOutputHandler,
+	processTracker *ProcessTracker,
 ) *ShellCommandRunner {
 	cmd := exec.Command(argv[0], argv[1:]...) // #nosec G204 -- argv[0] is a resolved executable path, and the arguments are passed as a vector rather than as shell source
 	cmd.Env = environ
 	cmd.Dir = workingDir
 
 	return &ShellCommandRunner{
-		command:       display,
-		workingDir:    workingDir,
-		outputHandler: outputHandler,
-		streamOutput:  streamOutput,
-		cmd:           cmd,
+		command:        display,
+		workingDir:     workingDir,
+		outputHandler:  outputHandler,
+		streamOutput:   streamOutput,
+		cmd:            cmd,
+		processTracker: processTracker,
 	}
 }
 
@@ -162,6 +174,11 @@ func (s *ShellCommandRunner) RunCommandAsync(ctx command.ProjectContext) (chan<-
 		stdin, _ := s.cmd.StdinPipe()
 
 		ctx.Log.Debug("starting '%s' in '%s'", s.describe(), s.workingDir)
+		s.processTracker.prepare(s.cmd)
+		timeout, reportedTimeout := ctx.ProcessTimeout()
+		if timeout > 0 {
+			setProcessGroup(s.cmd)
+		}
 		err := s.cmd.Start()
 		if err != nil {
 			err = fmt.Errorf("running '%s' in '%s': %w", s.describe(), s.workingDir, err)
@@ -169,6 +186,18 @@ func (s *ShellCommandRunner) RunCommandAsync(ctx command.ProjectContext) (chan<-
 			outCh <- Line{Err: err}
 			return
 		}
+		s.processTracker.track(ctx, s.cmd, s.command)
+
+		exited := make(chan struct{})
+		var timedOut atomic.Bool
+		var timeoutTimer *time.Timer
+		if timeout > 0 {
+			timeoutTimer = time.AfterFunc(timeout, func() {
+				timedOut.Store(true)
+				ctx.Log.Warn("'%s' timed out after %s, terminating it", s.describe(), reportedTimeout)
+				s.terminate(exited)
+			})
+		}
 
 		// If we get anything on inCh, write it to stdin.
 		// This function will exit when inCh is closed which we do in our defer.
@@ -233,6 +262,14 @@ func (s *ShellCommandRunner) RunCommandAsync(ctx command.ProjectContext) (chan<-
 
 		// Wait for the command to complete.
 		err = s.cmd.Wait()
+		if timeoutTimer != nil {
+			timeoutTimer.Stop()
+		}
+		close(exited)
+		s.processTracker.untrack(s.cmd)
+		if timedOut.Load() {
+			err = &command.TimeoutError{Timeout: reportedTimeout}
+		}
 
 		dur := time.Since(start)
 		log := ctx.Log.With("duration", dur)
@@ -250,3 +287,14 @@ func (s *ShellCommandRunner) RunCommandAsync(ctx command.ProjectContext) (chan<-
 
 	return inCh, outCh
 }
+
+// terminate interrupts the process tree of the command, and kills it if it
+// hasn't exited within timeoutGracePeriod.
+func (s *ShellCommandRunner) terminate(exited <-chan struct{}) {
+	interruptProcessGroup(s.cmd) // nolint: errcheck
+	select {
+	case <-exited:
+	case <-time.After(timeoutGracePeriod):
+		killProcessGroup(s.cmd) // nolint: errcheck
+	}
+}
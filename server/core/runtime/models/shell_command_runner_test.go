@@ -4,15 +4,18 @@
 package models_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/petergtz/pegomock/v4"
 	"github.com/runatlantis/atlantis/server/core/runtime/models"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/jobs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
 	logmocks "github.com/runatlantis/atlantis/server/logging/mocks"
 	. "github.com/runatlantis/atlantis/testing"
 )
@@ -57,7 +60,7 @@ func TestShellCommandRunner_Run(t *testing.T) {
 			expectedOutput := fmt.Sprintf("%s\n", strings.Join(c.ExpLines, "\n"))
 
 			// Run once with streaming enabled
-			runner := models.NewShellCommandRunner(nil, c.Command, environ, cwd, true, projectCmdOutputHandler)
+			runner := models.NewShellCommandRunner(nil, c.Command, environ, cwd, true, projectCmdOutputHandler, nil)
 			output, err := runner.Run(ctx)
 			Ok(t, err)
 			Equals(t, expectedOutput, output)
@@ -71,7 +74,7 @@ func TestShellCommandRunner_Run(t *testing.T) {
 			// command output handler should not have received anything
 
 			projectCmdOutputHandler = mocks.NewMockProjectCommandOutputHandler()
-			runner = models.NewShellCommandRunner(nil, c.Command, environ, cwd, false, projectCmdOutputHandler)
+			runner = models.NewShellCommandRunner(nil, c.Command, environ, cwd, false, projectCmdOutputHandler, nil)
 			output, err = runner.Run(ctx)
 			Ok(t, err)
 			Equals(t, expectedOutput, output)
@@ -122,10 +125,55 @@ func TestShellCommandRunner_RunLongOutputLines(t *testing.T) {
 			cwd, err := os.Getwd()
 			Ok(t, err)
 
-			runner := models.NewShellCommandRunner(nil, c.command, []string{}, cwd, false, projectCmdOutputHandler)
+			runner := models.NewShellCommandRunner(nil, c.command, []string{}, cwd, false, projectCmdOutputHandler, nil)
 			output, err := runner.Run(ctx)
 			Ok(t, err)
 			Equals(t, strings.Repeat("x", longLen)+"\n", output)
 		})
 	}
 }
+
+func TestShellCommandRunner_RunTimeout(t *testing.T) {
+	RegisterMockTestingT(t)
+	ctx := command.ProjectContext{
+		Log:         logging.NewNoopLogger(t),
+		Workspace:   "default",
+		RepoRelDir:  ".",
+		StepTimeout: 200 * time.Millisecond,
+	}
+	cwd, err := os.Getwd()
+	Ok(t, err)
+
+	start := time.Now()
+	runner := models.NewShellCommandRunner(nil, "echo started; sleep 30; echo done", os.Environ(), cwd, false, mocks.NewMockProjectCommandOutputHandler(), nil)
+	output, err := runner.Run(ctx)
+
+	var timeoutErr *command.TimeoutError
+	Assert(t, errors.As(err, &timeoutErr), "expected a timeout error, got %v", err)
+	Equals(t, 200*time.Millisecond, timeoutErr.Timeout)
+	ErrContains(t, "timed out after 200ms", err)
+	Equals(t, "started\n", output)
+	Assert(t, time.Since(start) < 10*time.Second, "expected the command to be terminated")
+}
+
+func TestShellCommandRunner_RunStageDeadline(t *testing.T) {
+	RegisterMockTestingT(t)
+	ctx := command.ProjectContext{
+		Log:           logging.NewNoopLogger(t),
+		Workspace:     "default",
+		RepoRelDir:    ".",
+		StepTimeout:   time.Hour,
+		StageTimeout:  30 * time.Minute,
+		StageDeadline: time.Now().Add(200 * time.Millisecond),
+	}
+	cwd, err := os.Getwd()
+	Ok(t, err)
+
+	start := time.Now()
+	runner := models.NewShellCommandRunner(nil, "echo started; sleep 30; echo done", os.Environ(), cwd, false, mocks.NewMockProjectCommandOutputHandler(), nil)
+	output, err := runner.Run(ctx)
+
+	ErrContains(t, "timed out after 30m0s", err)
+	Equals(t, "started\n", output)
+	Assert(t, time.Since(start) < 10*time.Second, "expected the command to be terminated")
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	. "github.com/petergtz/pegomock/v4"
@@ -99,6 +100,30 @@ func TestRun_AddsEnvVarFile(t *testing.T) {
 	Equals(t, "output", output)
 }
 
+func TestRun_InheritsStageDeadline(t *testing.T) {
+	// Test that the plan step doesn't change the stage deadline its
+	// processes inherit.
+	RegisterMockTestingT(t)
+	terraform := tfclientmocks.NewMockClient()
+	tfDistribution := tf.NewDistributionTerraformWithDownloader(mocks.NewMockDownloader())
+	tfVersion, _ := version.NewVersion("0.10.0")
+	s := runtime.NewPlanStepRunner(terraform, tfDistribution, tfVersion, runtimemocks.NewMockStatusUpdater(), runtimemocks.NewMockAsyncTFExec(), &runtime.LocalPlanStore{})
+	tmpDir := t.TempDir()
+	ctx := command.ProjectContext{
+		Log:           logging.NewNoopLogger(t),
+		Workspace:     "default",
+		RepoRelDir:    ".",
+		PlanTimeout:   30 * time.Minute,
+		StageTimeout:  30 * time.Minute,
+		StageDeadline: time.Now().Add(10 * time.Minute),
+	}
+	When(terraform.RunCommandWithVersion(Eq(ctx), Eq(tmpDir), Any[[]string](), Any[map[string]string](), Eq(tfDistribution), Eq(tfVersion), Eq("default"))).ThenReturn("output", nil)
+
+	output, err := s.Run(ctx, nil, tmpDir, map[string]string(nil))
+	Ok(t, err)
+	Equals(t, "output", output)
+}
+
 func TestRun_UsesDiffPathForProject(t *testing.T) {
 	// Test that if running for a project, uses a different path for the plan
 	// file.
@@ -10,9 +10,20 @@ import "github.com/runatlantis/atlantis/server/core/planstore"
 type PlanStore = planstore.PlanStore
 type LocalPlanStore = planstore.LocalPlanStore
 type S3PlanStoreConfig = planstore.S3PlanStoreConfig
+type GCSPlanStoreConfig = planstore.GCSPlanStoreConfig
+type AzurePlanStoreConfig = planstore.AzurePlanStoreConfig
+type KeyProvider = planstore.KeyProvider
+type PlanVerificationError = planstore.PlanVerificationError
 
 var (
 	NewS3PlanStore           = planstore.NewS3PlanStore
 	NewS3PlanStoreWithClient = planstore.NewS3PlanStoreWithClient
+	NewGCSPlanStore          = planstore.NewGCSPlanStore
+	NewAzurePlanStore        = planstore.NewAzurePlanStore
+	NewEncryptedPlanStore    = planstore.NewEncryptedPlanStore
+	NewLocalKeyProvider      = planstore.NewLocalKeyProvider
+	NewAWSKMSKeyProvider     = planstore.NewAWSKMSKeyProvider
+	NewSignedPlanStore       = planstore.NewSignedPlanStore
+	LoadPlanSigningKey       = planstore.LoadPlanSigningKey
 	ErrRestoreNotSupported   = planstore.ErrRestoreNotSupported
 )
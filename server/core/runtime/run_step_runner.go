@@ -27,6 +27,8 @@ type RunStepRunner struct {
 	// TerraformBinDir is the directory where Atlantis downloads Terraform binaries.
 	TerraformBinDir         string
 	ProjectCmdOutputHandler jobs.ProjectCommandOutputHandler
+	// ProcessTracker lets `atlantis cancel` interrupt running commands.
+	ProcessTracker *models.ProcessTracker
 }
 
 func (r *RunStepRunner) Run(
@@ -99,7 +101,7 @@ func (r *RunStepRunner) Run(
 		finalEnvVars = append(finalEnvVars, fmt.Sprintf("%s=%s", key, val))
 	}
 
-	runner := models.NewShellCommandRunner(shell, command, finalEnvVars, path, streamOutput, r.ProjectCmdOutputHandler)
+	runner := models.NewShellCommandRunner(shell, command, finalEnvVars, path, streamOutput, r.ProjectCmdOutputHandler, r.ProcessTracker)
 	output, err := runner.Run(ctx)
 
 	// These need to run before the error check to filter output
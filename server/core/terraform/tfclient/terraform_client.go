@@ -33,6 +33,10 @@ var LogStreamingValidCmds = [...]string{"init", "plan", "apply"}
 
 const versionCommandTimeout = 10 * time.Second
 
+// killedCommandWaitDelay is how long a killed command's output is still read
+// for, in case a process it started keeps the output open.
+const killedCommandWaitDelay = 10 * time.Second
+
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_terraform_client.go Client
 
 type Client interface {
@@ -84,6 +88,8 @@ type DefaultClient struct {
 	usePluginCache bool
 
 	projectCmdOutputHandler jobs.ProjectCommandOutputHandler
+	// processTracker lets `atlantis cancel` interrupt running commands.
+	processTracker *models.ProcessTracker
 }
 
 // versionRegex extracts the version from `terraform version` output.
@@ -113,6 +119,7 @@ func NewClientWithDefaultVersion(
 	usePluginCache bool,
 	fetchAsync bool,
 	projectCmdOutputHandler jobs.ProjectCommandOutputHandler,
+	processTracker *models.ProcessTracker,
 ) (*DefaultClient, error) {
 	var finalDefaultVersion *version.Version
 	var localVersion *version.Version
@@ -184,6 +191,7 @@ func NewClientWithDefaultVersion(
 		downloadLock:            &downloadLock,
 		usePluginCache:          usePluginCache,
 		projectCmdOutputHandler: projectCmdOutputHandler,
+		processTracker:          processTracker,
 	}, nil
 
 }
@@ -216,6 +224,7 @@ func NewTestClient(
 		usePluginCache,
 		false,
 		projectCmdOutputHandler,
+		nil,
 	)
 }
 
@@ -239,6 +248,7 @@ func NewClient(
 	tfDownloadAllowed bool,
 	usePluginCache bool,
 	projectCmdOutputHandler jobs.ProjectCommandOutputHandler,
+	processTracker *models.ProcessTracker,
 ) (*DefaultClient, error) {
 	return NewClientWithDefaultVersion(
 		log,
@@ -254,6 +264,7 @@ func NewClient(
 		usePluginCache,
 		true,
 		projectCmdOutputHandler,
+		processTracker,
 	)
 }
 
@@ -390,7 +401,15 @@ func (c *DefaultClient) RunCommandWithVersion(ctx command.ProjectContext, path s
 		output = ansi.Strip(output)
 		return fmt.Sprintf("%s\n", output), err
 	}
-	tfCmd, cmd, err := c.prepExecCmd(ctx, d, v, workspace, path, args, customEnvVars)
+	// Commands that don't stream their output are bound by the same stage
+	// and step timeouts as those that do.
+	cmdCtx, cancel := context.WithCancel(context.Background())
+	timeout, reportedTimeout := ctx.ProcessTimeout()
+	if timeout > 0 {
+		cmdCtx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	defer cancel()
+	tfCmd, cmd, err := c.prepExecCmd(cmdCtx, ctx, d, v, workspace, path, args, customEnvVars)
 	if err != nil {
 		return "", err
 	}
@@ -398,6 +417,9 @@ func (c *DefaultClient) RunCommandWithVersion(ctx command.ProjectContext, path s
 	out, err := cmd.CombinedOutput()
 	dur := time.Since(start)
 	log := ctx.Log.With("duration", dur)
+	if err != nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded) {
+		err = &command.TimeoutError{Timeout: reportedTimeout}
+	}
 	if err != nil {
 		err = fmt.Errorf("running '%s' in '%s': %w", tfCmd, path, err)
 		log.Err("%s", err.Error())
@@ -410,13 +432,14 @@ func (c *DefaultClient) RunCommandWithVersion(ctx command.ProjectContext, path s
 
 // prepExecCmd builds a ready to execute command based on the version of terraform
 // v, and args. It returns a printable representation of the command that will
-// be run and the actual command.
-func (c *DefaultClient) prepExecCmd(ctx command.ProjectContext, d terraform.Distribution, v *version.Version, workspace string, path string, args []string, customEnvVars map[string]string) (string, *exec.Cmd, error) {
+// be run and the actual command, which is killed once cmdCtx is done.
+func (c *DefaultClient) prepExecCmd(cmdCtx context.Context, ctx command.ProjectContext, d terraform.Distribution, v *version.Version, workspace string, path string, args []string, customEnvVars map[string]string) (string, *exec.Cmd, error) {
 	argv, display, envVars, err := c.prepCmd(ctx.Log, d, v, workspace, path, args, customEnvVars, ctx.ExpandableArgs, ctx.CommentArgs)
 	if err != nil {
 		return "", nil, err
 	}
-	cmd := exec.Command(argv[0], argv[1:]...) // #nosec G204 -- argv[0] is a resolved Terraform binary path, and the arguments are passed as a vector rather than as shell source
+	cmd := exec.CommandContext(cmdCtx, argv[0], argv[1:]...) // #nosec G204 -- argv[0] is a resolved Terraform binary path, and the arguments are passed as a vector rather than as shell source
+	cmd.WaitDelay = killedCommandWaitDelay
 	cmd.Dir = path
 	cmd.Env = envVars
 	return display, cmd, nil
@@ -562,7 +585,7 @@ func (c *DefaultClient) RunCommandAsync(ctx command.ProjectContext, path string,
 		return inCh, outCh
 	}
 
-	runner := models.NewArgvCommandRunner(argv, display, envVars, path, !ctx.SuppressJobOutput, c.projectCmdOutputHandler)
+	runner := models.NewArgvCommandRunner(argv, display, envVars, path, !ctx.SuppressJobOutput, c.projectCmdOutputHandler, c.processTracker)
 	inCh, outCh := runner.RunCommandAsync(ctx)
 	return inCh, outCh
 }
@@ -4,11 +4,13 @@
 package tfclient
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	version "github.com/hashicorp/go-version"
 	. "github.com/petergtz/pegomock/v4"
@@ -181,6 +183,36 @@ func TestDefaultClient_RunCommandWithVersion_Error(t *testing.T) {
 	Equals(t, "dying\n", out)
 }
 
+func TestDefaultClient_RunCommandWithVersion_StageDeadline(t *testing.T) {
+	v, err := version.NewVersion("0.11.11")
+	Ok(t, err)
+	tmp := t.TempDir()
+
+	ctx := command.ProjectContext{
+		Log:           logging.NewNoopLogger(t),
+		Workspace:     "default",
+		RepoRelDir:    ".",
+		StageTimeout:  30 * time.Minute,
+		StageDeadline: time.Now().Add(200 * time.Millisecond),
+	}
+	client := &DefaultClient{
+		defaultVersion:          v,
+		terraformPluginCacheDir: tmp,
+		overrideTF:              "sh",
+		projectCmdOutputHandler: jobmocks.NewMockProjectCommandOutputHandler(),
+	}
+
+	// Commands that don't stream their output are terminated once the stage
+	// runs out too.
+	start := time.Now()
+	distribution := terraform.NewDistributionTerraformWithDownloader(terraform_mocks.NewMockDownloader())
+	_, err = client.RunCommandWithVersion(ctx, tmp, []string{"-c", "exec sleep 30"}, map[string]string{}, distribution, nil, "workspace")
+	var timeoutErr *command.TimeoutError
+	Assert(t, errors.As(err, &timeoutErr), "expected a timeout error, got %v", err)
+	Equals(t, 30*time.Minute, timeoutErr.Timeout)
+	Assert(t, time.Since(start) < 10*time.Second, "expected the command to be terminated")
+}
+
 func TestDefaultClient_RunCommandAsync_Success(t *testing.T) {
 	RegisterMockTestingT(t)
 	v, err := version.NewVersion("0.11.11")
@@ -366,7 +366,7 @@ func applyResultStatusUpdateError(result command.Result, pullStatus models.PullS
 	if err := pullStatusApplyEligibilityError(currentPull, pullStatus.Pull, "recorded apply status"); err != nil {
 		return err
 	}
-	if result.HasErrors() && pullStatus.StatusCount(models.ErroredApplyStatus) == 0 {
+	if result.HasErrors() && pullStatus.StatusCount(models.ErroredApplyStatus)+pullStatus.StatusCount(models.CancelledApplyStatus) == 0 {
 		return errors.New("apply result has errors but no errored apply status was recorded")
 	}
 	return nil
@@ -470,7 +470,7 @@ func (a *ApplyCommandRunner) updateCommitStatus(ctx *command.Context, pullStatus
 
 	numNoChanges = pullStatus.StatusCount(models.PlannedNoChangesPlanStatus)
 	numSuccess = pullStatus.StatusCount(models.AppliedPlanStatus) + numNoChanges
-	numErrored = pullStatus.StatusCount(models.ErroredApplyStatus)
+	numErrored = pullStatus.StatusCount(models.ErroredApplyStatus) + pullStatus.StatusCount(models.CancelledApplyStatus)
 
 	if numErrored > 0 {
 		status = models.FailedCommitStatus
@@ -230,7 +230,7 @@ func (v *DefaultApplyPlanValidator) getLivePullIdentity(ctx command.ProjectConte
 func statusAllowedForApplyExecution(status models.ProjectPlanStatus) bool {
 	switch status {
 	case models.PlannedPlanStatus, models.PassedPolicyCheckStatus, models.ErroredApplyStatus,
-		models.PlannedNoChangesPlanStatus:
+		models.CancelledApplyStatus, models.PlannedNoChangesPlanStatus:
 		return true
 	default:
 		return false
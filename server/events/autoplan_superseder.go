@@ -0,0 +1,202 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+//go:generate go tool pegomock generate github.com/runatlantis/atlantis/server/events --package events -o mock_autoplan_registry_test.go AutoplanRegistry
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	runtimemodels "github.com/runatlantis/atlantis/server/core/runtime/models"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// AutoplanSuperseder keeps track of the autoplans running for each pull
+// request so that a newer autoplan for the same pull can supersede them.
+// A superseded autoplan that is still waiting for the pull is dropped, one
+// that is running has its remaining execution order groups cancelled and its
+// running plans interrupted. Only the superseded autoplan is cancelled, other
+// commands running for the pull request are left alone.
+type AutoplanSuperseder struct {
+	mutex sync.Mutex
+	// latest is the most recent autoplan for each pull request.
+	latest map[string]*autoplanRun
+	// processTracker is used to interrupt the running plans of a superseded
+	// autoplan. If nil, running plans are left to finish.
+	processTracker *runtimemodels.ProcessTracker
+	// gracePeriod is how long interrupted plans have to exit before they're
+	// killed.
+	gracePeriod time.Duration
+	// registry shares the latest autoplan for each pull request with the
+	// other replicas. If nil, only autoplans started on this replica
+	// supersede each other.
+	registry AutoplanRegistry
+}
+
+// AutoplanRegistry records the head commit of the latest autoplan for each
+// pull request where every replica can see it, so an autoplan started on one
+// replica supersedes the autoplans running on the others.
+type AutoplanRegistry interface {
+	// Start records pull.HeadCommit as the latest autoplan for pull.
+	Start(pull models.PullRequest)
+	// Latest returns the head commit of the latest autoplan for pull, or an
+	// empty string if none is running.
+	Latest(pull models.PullRequest) string
+	// Finish forgets the latest autoplan for pull if it's still the one for
+	// pull.HeadCommit.
+	Finish(pull models.PullRequest)
+}
+
+// autoplanRun is a single autoplan for a pull request.
+type autoplanRun struct {
+	pull       models.PullRequest
+	headCommit string
+	// supersededBy is the head commit of the autoplan that superseded this
+	// one, or empty if it hasn't been superseded.
+	supersededBy string
+	// projects are the projects this autoplan is planning, once known.
+	projects []command.ProjectContext
+	// previous is the autoplan this one superseded, if any.
+	previous *autoplanRun
+	done     chan struct{}
+}
+
+// NewAutoplanSuperseder returns an AutoplanSuperseder. registry may be nil if
+// autoplans only run on this replica.
+func NewAutoplanSuperseder(processTracker *runtimemodels.ProcessTracker, gracePeriod time.Duration, registry AutoplanRegistry) *AutoplanSuperseder {
+	return &AutoplanSuperseder{
+		latest:         make(map[string]*autoplanRun),
+		processTracker: processTracker,
+		gracePeriod:    gracePeriod,
+		registry:       registry,
+	}
+}
+
+// start registers a new autoplan for pull and supersedes the previous one. It
+// returns the new autoplan and whether a previous autoplan was superseded.
+func (s *AutoplanSuperseder) start(pull models.PullRequest) (*autoplanRun, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	run := &autoplanRun{
+		pull:       pull,
+		headCommit: pull.HeadCommit,
+		done:       make(chan struct{}),
+	}
+	key := pullKey(pull)
+	if previous, ok := s.latest[key]; ok {
+		previous.supersededBy = pull.HeadCommit
+		run.previous = previous
+		s.interrupt(pull, previous)
+	}
+	s.latest[key] = run
+	if s.registry != nil {
+		s.registry.Start(pull)
+	}
+	return run, run.previous != nil
+}
+
+// interrupt interrupts the running plans of a superseded autoplan. It must be
+// called with the mutex held.
+func (s *AutoplanSuperseder) interrupt(pull models.PullRequest, run *autoplanRun) {
+	if len(run.projects) == 0 {
+		return
+	}
+	s.processTracker.Interrupt(func(key runtimemodels.ProcessKey) bool {
+		if key.RepoFullName != pull.BaseRepo.FullName || key.PullNum != pull.Num {
+			return false
+		}
+		for _, project := range run.projects {
+			if key.ProjectName == project.ProjectName && key.RepoRelDir == project.RepoRelDir && key.Workspace == project.Workspace {
+				return true
+			}
+		}
+		return false
+	}, s.gracePeriod)
+}
+
+// waitForPrevious blocks until the autoplan superseded by run has exited.
+func (s *AutoplanSuperseder) waitForPrevious(run *autoplanRun) {
+	if run.previous != nil {
+		<-run.previous.done
+		run.previous = nil
+	}
+}
+
+// setProjects records the projects run is planning, so they can be
+// interrupted if it's superseded.
+func (s *AutoplanSuperseder) setProjects(run *autoplanRun, projects []command.ProjectContext) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	run.projects = projects
+}
+
+// supersededBy returns the head commit of the autoplan that superseded run,
+// or an empty string if it hasn't been superseded.
+func (s *AutoplanSuperseder) supersededBy(run *autoplanRun) string {
+	s.mutex.Lock()
+	supersededBy := run.supersededBy
+	s.mutex.Unlock()
+	if supersededBy == "" && s.registry != nil {
+		// An autoplan started on another replica supersedes run too.
+		if latest := s.registry.Latest(run.pull); latest != "" && latest != run.headCommit {
+			supersededBy = latest
+		}
+	}
+	return supersededBy
+}
+
+// finish marks run as exited, letting the autoplan that superseded it start.
+func (s *AutoplanSuperseder) finish(pull models.PullRequest, run *autoplanRun) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := pullKey(pull)
+	if s.latest[key] == run {
+		delete(s.latest, key)
+		if s.registry != nil {
+			s.registry.Finish(run.pull)
+		}
+	}
+	close(run.done)
+}
+
+// supersededRunTracker is the CancellationTracker of a single autoplan. It
+// reports the autoplan as cancelled once it's superseded, without cancelling
+// the other commands running for the pull request, and otherwise defers to
+// the pull request's tracker so `atlantis cancel` still applies.
+type supersededRunTracker struct {
+	CancellationTracker
+	superseder *AutoplanSuperseder
+	run        *autoplanRun
+}
+
+func (t supersededRunTracker) Cancel(pull models.PullRequest) {
+	if t.CancellationTracker != nil {
+		t.CancellationTracker.Cancel(pull)
+	}
+}
+
+func (t supersededRunTracker) IsCancelled(pull models.PullRequest) bool {
+	if t.superseder.supersededBy(t.run) != "" {
+		return true
+	}
+	return t.CancellationTracker != nil && t.CancellationTracker.IsCancelled(pull)
+}
+
+func (t supersededRunTracker) Clear(pull models.PullRequest) {
+	if t.CancellationTracker != nil {
+		t.CancellationTracker.Clear(pull)
+	}
+}
+
+// supersededComment is the comment left in place of the results of a
+// superseded autoplan. It mentions the plan command in its first line so it's
+// hidden along with the other previous plan comments.
+func supersededComment(run *autoplanRun, supersededBy string) string {
+	return fmt.Sprintf("Autoplan for commit `%s` was superseded by a newer autoplan for commit `%s`. "+
+		"Its results have been discarded.", run.headCommit, supersededBy)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/events/models"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestAutoplanSuperseder_SupersedesQueuedAndRunningAutoplans(t *testing.T) {
+	superseder := NewAutoplanSuperseder(nil, 0, nil)
+	pull := models.PullRequest{BaseRepo: models.Repo{FullName: "owner/repo"}, Num: 1}
+	otherPull := models.PullRequest{BaseRepo: models.Repo{FullName: "owner/repo"}, Num: 2}
+
+	pull.HeadCommit = "sha1"
+	first, superseded := superseder.start(pull)
+	Assert(t, !superseded, "expected the first autoplan not to supersede anything")
+	pull.HeadCommit = "sha2"
+	second, superseded := superseder.start(pull)
+	Assert(t, superseded, "expected the second autoplan to supersede the first")
+	pull.HeadCommit = "sha3"
+	third, superseded := superseder.start(pull)
+	Assert(t, superseded, "expected the third autoplan to supersede the second")
+	other, superseded := superseder.start(otherPull)
+	Assert(t, !superseded, "expected autoplans of other pulls not to be superseded")
+
+	Equals(t, "sha2", superseder.supersededBy(first))
+	Equals(t, "sha3", superseder.supersededBy(second))
+	Equals(t, "", superseder.supersededBy(third))
+	Equals(t, "", superseder.supersededBy(other))
+
+	// The second autoplan is queued behind the first, the third behind the
+	// second.
+	secondStarted := make(chan struct{})
+	go func() {
+		superseder.waitForPrevious(second)
+		close(secondStarted)
+	}()
+	select {
+	case <-secondStarted:
+		t.Fatal("expected the second autoplan to wait for the first to exit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	superseder.finish(pull, first)
+	<-secondStarted
+	superseder.finish(pull, second)
+	superseder.waitForPrevious(third)
+
+	superseder.finish(pull, third)
+	superseder.finish(otherPull, other)
+	Equals(t, 0, len(superseder.latest))
+}
+
+func TestAutoplanSuperseder_SupersededOnAnotherReplica(t *testing.T) {
+	RegisterMockTestingT(t)
+	registry := NewMockAutoplanRegistry()
+	superseder := NewAutoplanSuperseder(nil, 0, registry)
+	pull := models.PullRequest{BaseRepo: models.Repo{FullName: "owner/repo"}, Num: 1, HeadCommit: "sha1"}
+
+	run, _ := superseder.start(pull)
+	registry.VerifyWasCalledOnce().Start(pull)
+	cancellationTracker := NewCancellationTracker()
+	tracker := supersededRunTracker{CancellationTracker: cancellationTracker, superseder: superseder, run: run}
+	When(registry.Latest(pull)).ThenReturn("sha1")
+	Equals(t, "", superseder.supersededBy(run))
+	Equals(t, false, tracker.IsCancelled(pull))
+
+	// Another replica starts an autoplan for a newer commit.
+	When(registry.Latest(pull)).ThenReturn("sha2")
+	Equals(t, "sha2", superseder.supersededBy(run))
+	Equals(t, true, tracker.IsCancelled(pull))
+	Equals(t, false, cancellationTracker.IsCancelled(pull))
+
+	superseder.finish(pull, run)
+	registry.VerifyWasCalledOnce().Finish(pull)
+}
@@ -4,12 +4,19 @@
 package events
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	runtimemodels "github.com/runatlantis/atlantis/server/core/runtime/models"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/vcs"
+	"github.com/runatlantis/atlantis/server/logging"
 )
 
 const cancelComment = "Cancelled all queued operations and released working directory locks for this pull request.\n" +
-	"New operations can now be started. Currently running operations will continue to completion."
+	"New operations can now be started."
 
 func NewCancelCommandRunner(
 	vcsClient vcs.Client,
@@ -17,6 +24,7 @@ func NewCancelCommandRunner(
 	pullUpdater *PullUpdater,
 	workingDirLocker WorkingDirLocker,
 	silenceNoProjects bool,
+	gracePeriod time.Duration,
 ) *CancelCommandRunner {
 	return &CancelCommandRunner{
 		VCSClient:         vcsClient,
@@ -24,6 +32,7 @@ func NewCancelCommandRunner(
 		PullUpdater:       pullUpdater,
 		WorkingDirLocker:  workingDirLocker,
 		SilenceNoProjects: silenceNoProjects,
+		GracePeriod:       gracePeriod,
 	}
 }
 
@@ -33,6 +42,44 @@ type CancelCommandRunner struct {
 	PullUpdater       *PullUpdater
 	WorkingDirLocker  WorkingDirLocker
 	SilenceNoProjects bool
+	// GracePeriod is how long interrupted processes have to exit before
+	// they're killed.
+	GracePeriod time.Duration
+	// Relay passes cancel commands on to the other replicas so they
+	// interrupt their running operations too. If nil, only operations
+	// running on this replica are interrupted.
+	Relay CancelRelay
+}
+
+// CancelRelay passes cancel commands on to the other replicas.
+type CancelRelay interface {
+	// Relay asks the other replicas to cancel the running operations
+	// matching req.
+	Relay(req CancelRequest) error
+}
+
+// CancelRequest describes the running operations a cancel command stops.
+// Empty fields match everything.
+type CancelRequest struct {
+	RepoFullName string
+	PullNum      int
+	ProjectName  string
+	RepoRelDir   string
+	Workspace    string
+}
+
+// isForSpecificProject returns true if req only cancels some projects of the
+// pull request.
+func (r CancelRequest) isForSpecificProject() bool {
+	return r.ProjectName != "" || r.RepoRelDir != "" || r.Workspace != ""
+}
+
+func (r CancelRequest) matches(key runtimemodels.ProcessKey) bool {
+	return key.RepoFullName == r.RepoFullName &&
+		key.PullNum == r.PullNum &&
+		(r.ProjectName == "" || key.ProjectName == r.ProjectName) &&
+		(r.RepoRelDir == "" || key.RepoRelDir == r.RepoRelDir) &&
+		(r.Workspace == "" || key.Workspace == r.Workspace)
 }
 
 func (c *CancelCommandRunner) Run(ctx *command.Context, cmd *CommentCommand) {
@@ -53,17 +100,103 @@ func (c *CancelCommandRunner) Run(ctx *command.Context, cmd *CommentCommand) {
 		return
 	}
 
-	// Cancel the entire pull request to prevent future execution order groups from running
-	defaultRunner.CancellationTracker.Cancel(ctx.Pull)
-
-	// Clean up working directory locks for this pull request
-	if defaultRunner.WorkingDirLocker != nil {
-		defaultRunner.WorkingDirLocker.UnlockByPull(ctx.Pull.BaseRepo.FullName, ctx.Pull.Num)
-		ctx.Log.Debug("Released working directory locks for pull request")
+	req := CancelRequest{
+		RepoFullName: ctx.Pull.BaseRepo.FullName,
+		PullNum:      ctx.Pull.Num,
+		ProjectName:  cmd.ProjectName,
+		RepoRelDir:   cmd.RepoRelDir,
+		Workspace:    cmd.Workspace,
+	}
+	var comment string
+	if req.isForSpecificProject() {
+		// Only the matching running operations are interrupted. Queued
+		// operations are tracked per pull request, so they are left alone.
+		comment = "Cancelled running operations matching " + cancelFilterText(cmd) + "."
+	} else {
+		// Cancel the entire pull request to prevent future execution order groups from running
+		defaultRunner.CancellationTracker.Cancel(ctx.Pull)
+		comment = cancelComment
 	}
 
-	ctx.Log.Info("Cancelled all queued operations and future execution groups for pull request; currently running operations will continue to completion")
-	if err := c.VCSClient.CreateComment(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull.Num, cancelComment, ""); err != nil {
+	interrupted := c.cancel(ctx.Log, defaultRunner, req)
+	comment += "\n\n" + c.interruptedText(interrupted)
+	if c.Relay != nil {
+		if err := c.Relay.Relay(req); err != nil {
+			ctx.Log.Err("unable to relay cancel to the other replicas: %s", err)
+			comment += "\n\nUnable to reach the other Atlantis replicas, operations running on them may not have been interrupted."
+		} else {
+			comment += "\n\nThe other Atlantis replicas were asked to interrupt their matching running operations."
+		}
+	}
+	if err := c.VCSClient.CreateComment(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull.Num, comment, ""); err != nil {
 		ctx.Log.Err("unable to comment: %s", err)
 	}
 }
+
+// CancelRelayed cancels the operations running on this replica that match a
+// cancel command relayed from another replica. Queued execution order groups
+// are already cancelled through the shared CancellationTracker.
+func (c *CancelCommandRunner) CancelRelayed(logger logging.SimpleLogging, req CancelRequest) {
+	defaultRunner, ok := c.ProjectCmdRunner.(*DefaultProjectCommandRunner)
+	if !ok {
+		logger.Err("ProjectCmdRunner is not a DefaultProjectCommandRunner")
+		return
+	}
+	c.cancel(logger, defaultRunner, req)
+}
+
+// cancel interrupts the running operations on this replica matching req and,
+// if req cancels the whole pull request, releases its working directory
+// locks.
+func (c *CancelCommandRunner) cancel(logger logging.SimpleLogging, defaultRunner *DefaultProjectCommandRunner, req CancelRequest) []runtimemodels.InterruptedProcess {
+	if !req.isForSpecificProject() && defaultRunner.WorkingDirLocker != nil {
+		defaultRunner.WorkingDirLocker.UnlockByPull(req.RepoFullName, req.PullNum)
+		logger.Debug("Released working directory locks for pull request")
+	}
+
+	interrupted := defaultRunner.ProcessTracker.Interrupt(req.matches, c.GracePeriod)
+	for _, proc := range interrupted {
+		logger.Info("interrupted '%s' in dir %q, workspace %q", proc.Command, proc.RepoRelDir, proc.Workspace)
+	}
+	return interrupted
+}
+
+// interruptedText lists the interrupted processes for the cancel comment.
+func (c *CancelCommandRunner) interruptedText(interrupted []runtimemodels.InterruptedProcess) string {
+	if len(interrupted) == 0 {
+		if c.Relay != nil {
+			return "No running operations were interrupted on this replica."
+		}
+		return "No running operations were interrupted."
+	}
+	var lines []string
+	for _, proc := range interrupted {
+		project := fmt.Sprintf("dir: `%s` workspace: `%s`", proc.RepoRelDir, proc.Workspace)
+		if proc.ProjectName != "" {
+			project = fmt.Sprintf("project: `%s` %s", proc.ProjectName, project)
+		}
+		lines = append(lines, fmt.Sprintf("* `%s` for %s", proc.CommandName.String(), project))
+	}
+	sort.Strings(lines)
+	where := ""
+	if c.Relay != nil {
+		where = " on this replica"
+	}
+	return fmt.Sprintf("Interrupted running operations%s, which will be killed if they haven't stopped within %s:\n%s",
+		where, c.GracePeriod, strings.Join(lines, "\n"))
+}
+
+// cancelFilterText renders the -p/-d/-w flags of a cancel command.
+func cancelFilterText(cmd *CommentCommand) string {
+	var flags []string
+	if cmd.ProjectName != "" {
+		flags = append(flags, fmt.Sprintf("`-p %s`", cmd.ProjectName))
+	}
+	if cmd.RepoRelDir != "" {
+		flags = append(flags, fmt.Sprintf("`-d %s`", cmd.RepoRelDir))
+	}
+	if cmd.Workspace != "" {
+		flags = append(flags, fmt.Sprintf("`-w %s`", cmd.Workspace))
+	}
+	return strings.Join(flags, " ")
+}
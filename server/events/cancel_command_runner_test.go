@@ -0,0 +1,141 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	runtimemodels "github.com/runatlantis/atlantis/server/core/runtime/models"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	jobmocks "github.com/runatlantis/atlantis/server/jobs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func setupCancelCommandRunner(t *testing.T) (*events.CancelCommandRunner, *vcsmocks.MockClient, *events.DefaultProjectCommandRunner) {
+	RegisterMockTestingT(t)
+	vcsClient := vcsmocks.NewMockClient()
+	projectCmdRunner := &events.DefaultProjectCommandRunner{
+		CancellationTracker: events.NewCancellationTracker(),
+		ProcessTracker:      runtimemodels.NewProcessTracker(),
+		WorkingDirLocker:    events.NewDefaultWorkingDirLocker(),
+	}
+	runner := events.NewCancelCommandRunner(vcsClient, projectCmdRunner, nil, projectCmdRunner.WorkingDirLocker, false, time.Minute)
+	return runner, vcsClient, projectCmdRunner
+}
+
+func cancelCommandContext(t *testing.T) *command.Context {
+	return &command.Context{
+		Log: logging.NewNoopLogger(t),
+		Pull: models.PullRequest{
+			Num:      1,
+			BaseRepo: models.Repo{FullName: "owner/repo"},
+		},
+	}
+}
+
+func cancelComment(vcsClient *vcsmocks.MockClient) string {
+	_, _, _, comment, _ := vcsClient.VerifyWasCalledOnce().CreateComment(
+		Any[logging.SimpleLogging](), Any[models.Repo](), Eq(1), Any[string](), Eq("")).GetCapturedArguments()
+	return comment
+}
+
+func TestCancelCommandRunner_CancelsQueuedOperations(t *testing.T) {
+	runner, vcsClient, projectCmdRunner := setupCancelCommandRunner(t)
+	ctx := cancelCommandContext(t)
+
+	unlock, err := projectCmdRunner.WorkingDirLocker.TryLock("owner/repo", 1, "default", ".", "", command.Plan, events.WorkingDirLockMetadata{})
+	Ok(t, err)
+	defer unlock()
+
+	runner.Run(ctx, &events.CommentCommand{Name: command.Cancel})
+
+	Assert(t, projectCmdRunner.CancellationTracker.IsCancelled(ctx.Pull), "expected pull request to be cancelled")
+	_, err = projectCmdRunner.WorkingDirLocker.TryLock("owner/repo", 1, "default", ".", "", command.Plan, events.WorkingDirLockMetadata{})
+	Ok(t, err)
+	comment := cancelComment(vcsClient)
+	Assert(t, strings.HasPrefix(comment, "Cancelled all queued operations"), "unexpected comment %q", comment)
+	Assert(t, strings.HasSuffix(comment, "No running operations were interrupted."), "unexpected comment %q", comment)
+}
+
+func TestCancelCommandRunner_InterruptsRunningOperations(t *testing.T) {
+	runner, vcsClient, projectCmdRunner := setupCancelCommandRunner(t)
+	ctx := cancelCommandContext(t)
+
+	projectCtx := command.ProjectContext{
+		CommandName: command.Apply,
+		Log:         ctx.Log,
+		Pull:        ctx.Pull,
+		ProjectName: "project1",
+		RepoRelDir:  "infra",
+		Workspace:   "default",
+	}
+	cwd, err := os.Getwd()
+	Ok(t, err)
+	shell := runtimemodels.NewShellCommandRunner(nil, "echo started; sleep 30", nil, cwd, false, jobmocks.NewMockProjectCommandOutputHandler(), projectCmdRunner.ProcessTracker)
+	_, outCh := shell.RunCommandAsync(projectCtx)
+	// The process is tracked before its output is read.
+	Equals(t, "started", (<-outCh).Line)
+
+	runner.Run(ctx, &events.CommentCommand{Name: command.Cancel, ProjectName: "project1"})
+	for range outCh {
+		// Wait for the process to exit.
+	}
+
+	Assert(t, !projectCmdRunner.CancellationTracker.IsCancelled(ctx.Pull), "expected queued operations to be left alone")
+	Assert(t, projectCmdRunner.ProcessTracker.TakeInterrupted(runtimemodels.NewProcessKey(projectCtx)), "expected process to be interrupted")
+	Equals(t, "Cancelled running operations matching `-p project1`.\n\n"+
+		"Interrupted running operations, which will be killed if they haven't stopped within 1m0s:\n"+
+		"* `apply` for project: `project1` dir: `infra` workspace: `default`", cancelComment(vcsClient))
+}
+
+// fakeCancelRelay records the cancel requests relayed to other replicas.
+type fakeCancelRelay struct {
+	relayed []events.CancelRequest
+}
+
+func (f *fakeCancelRelay) Relay(req events.CancelRequest) error {
+	f.relayed = append(f.relayed, req)
+	return nil
+}
+
+func TestCancelCommandRunner_RelaysToOtherReplicas(t *testing.T) {
+	runner, vcsClient, _ := setupCancelCommandRunner(t)
+	relay := &fakeCancelRelay{}
+	runner.Relay = relay
+	ctx := cancelCommandContext(t)
+
+	runner.Run(ctx, &events.CommentCommand{Name: command.Cancel, RepoRelDir: "infra"})
+
+	Equals(t, []events.CancelRequest{{RepoFullName: "owner/repo", PullNum: 1, RepoRelDir: "infra"}}, relay.relayed)
+	Equals(t, "Cancelled running operations matching `-d infra`.\n\n"+
+		"No running operations were interrupted on this replica.\n\n"+
+		"The other Atlantis replicas were asked to interrupt their matching running operations.", cancelComment(vcsClient))
+}
+
+func TestCancelCommandRunner_CancelRelayed(t *testing.T) {
+	runner, vcsClient, projectCmdRunner := setupCancelCommandRunner(t)
+
+	_, err := projectCmdRunner.WorkingDirLocker.TryLock("owner/repo", 1, "default", ".", "", command.Plan, events.WorkingDirLockMetadata{})
+	Ok(t, err)
+
+	runner.CancelRelayed(logging.NewNoopLogger(t), events.CancelRequest{RepoFullName: "owner/repo", PullNum: 1})
+
+	unlock, err := projectCmdRunner.WorkingDirLocker.TryLock("owner/repo", 1, "default", ".", "", command.Plan, events.WorkingDirLockMetadata{})
+	Ok(t, err)
+	unlock()
+	// The replica the cancel command came from already cancelled the queued
+	// operations and commented.
+	Assert(t, !projectCmdRunner.CancellationTracker.IsCancelled(models.PullRequest{Num: 1, BaseRepo: models.Repo{FullName: "owner/repo"}}),
+		"expected the cancellation not to be recorded again")
+	vcsClient.VerifyWasCalled(Never()).CreateComment(
+		Any[logging.SimpleLogging](), Any[models.Repo](), Any[int](), Any[string](), Any[string]())
+}
@@ -66,8 +66,13 @@ type Context struct {
 	// publishing raw command output to the public job stream.
 	SuppressJobOutput bool
 
+	// CapturePlanJSON makes plans also run `terraform show -json` on the plan
+	// file so drift detection can report which resources changed.
+	CapturePlanJSON bool
+
 	// SuppressApplyWebhooks prevents synthetic API workflows such as drift
-	// remediation from sending legacy event: apply webhooks.
+	// remediation from sending legacy event: apply webhooks and plan and
+	// policy check lifecycle webhooks.
 	SuppressApplyWebhooks bool
 
 	// RunPolicyChecks allows API workflows that model the full plan lifecycle
@@ -91,6 +96,12 @@ type Context struct {
 	// execution order.
 	SortByExecutionOrder bool
 
+	// MergeGroup is set when the command runs against the temporary branch of
+	// a GitHub merge queue group. That branch is created by GitHub from the
+	// base branch, so its head commit is not yet reachable from the base
+	// branch.
+	MergeGroup bool
+
 	// PreWorkflowHooksAlreadyRun is set when an API workflow has already run
 	// pre-workflow hooks before project discovery.
 	PreWorkflowHooksAlreadyRun bool
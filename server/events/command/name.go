@@ -35,6 +35,8 @@ const (
 	State
 	// Cancel is a command to cancel running plan or apply operations
 	Cancel
+	// Drift is a command to run drift detection against the base branch
+	Drift
 	// Adding more? Don't forget to update String() below
 )
 
@@ -53,6 +55,7 @@ var AllCommentCommands = []Name{
 	ApprovePolicies,
 	Import,
 	State,
+	Drift,
 }
 
 // TitleString returns the string representation in title form.
@@ -82,6 +85,8 @@ func (c Name) String() string {
 		return "state"
 	case Cancel:
 		return "cancel"
+	case Drift:
+		return "drift"
 	}
 	return ""
 }
@@ -159,6 +164,8 @@ func ParseCommandName(name string) (Name, error) {
 		return State, nil
 	case "cancel":
 		return Cancel, nil
+	case "drift":
+		return Drift, nil
 	}
 	return -1, fmt.Errorf("unknown command name: %s", name)
 }
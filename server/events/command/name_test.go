@@ -44,6 +44,7 @@ func TestName_String(t *testing.T) {
 		{command.Version, "version"},
 		{command.Import, "import"},
 		{command.State, "state"},
+		{command.Drift, "drift"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -182,6 +183,7 @@ func TestParseCommandName(t *testing.T) {
 		{command.Version, "version"},
 		{command.Import, "import"},
 		{command.State, "state"},
+		{command.Drift, "drift"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -130,6 +131,20 @@ type ProjectContext struct {
 	// Steps are the sequence of commands we need to run for this project and this
 	// stage.
 	Steps []valid.Step
+	// PlanTimeout and ApplyTimeout are how long the whole plan and apply
+	// stages may take before they're terminated. Zero means no limit.
+	PlanTimeout  time.Duration
+	ApplyTimeout time.Duration
+	// StageTimeout is the timeout of the stage being run and StageDeadline
+	// is when it runs out. Every process started by the stage's steps is
+	// terminated once the deadline passes. They're set while running steps;
+	// zero means no limit.
+	StageTimeout  time.Duration
+	StageDeadline time.Time
+	// StepTimeout is how long the processes started by the step currently
+	// being run may take before they're terminated. It's set while running
+	// steps; zero means no limit.
+	StepTimeout time.Duration
 	// TerraformDistribution is the distribution of terraform we should use when
 	// executing commands for this project. This can be set to nil in which case
 	// we will use the default Atlantis terraform distribution.
@@ -167,6 +182,9 @@ type ProjectContext struct {
 	// Allows custom policy check tools outside of Conftest to run in checks
 	CustomPolicyCheck bool
 	SilencePRComments []string
+	// DriftIgnore are the rules for resources and attributes to leave out of
+	// drift detection results.
+	DriftIgnore []valid.DriftIgnoreRule
 
 	// TeamAllowlistChecker is used to check authorization on a project-level
 	TeamAllowlistChecker TeamAllowlistChecker
@@ -175,6 +193,11 @@ type ProjectContext struct {
 	// a PR comment.
 	API bool
 
+	// MergeGroup is set when the command runs for a GitHub merge queue group.
+	// Merge groups don't take project locks since their projects are still
+	// locked by the queued pull requests.
+	MergeGroup bool
+
 	// SkipPRRequirements allows explicitly opted-in non-PR API workflows to skip
 	// PR-only requirements like approved and mergeable.
 	SkipPRRequirements bool
@@ -187,8 +210,13 @@ type ProjectContext struct {
 	// publishing raw command output to the public job stream.
 	SuppressJobOutput bool
 
+	// CapturePlanJSON makes plans also run `terraform show -json` on the plan
+	// file so drift detection can report which resources changed.
+	CapturePlanJSON bool
+
 	// SuppressApplyWebhooks prevents synthetic API workflows such as drift
-	// remediation from sending legacy event: apply webhooks.
+	// remediation from sending legacy event: apply webhooks and plan and
+	// policy check lifecycle webhooks.
 	SuppressApplyWebhooks bool
 
 	// RemoteApplyRunURL receives the Terraform Cloud/Enterprise run URL found by
@@ -223,6 +251,21 @@ func (p ProjectContext) SetProjectScopeTags(scope tally.Scope) tally.Scope {
 // status names (e.g. "<vcs-status-name>/plan: <ProjectID>"). It must stay in
 // sync with DefaultCommitStatusUpdater.UpdateProject, which builds the status
 // name, so that consumers can match a commit status back to its project.
+// ProcessTimeout returns how long a process started now may run, and the
+// timeout to report if it's terminated: the step timeout, or what's left of
+// the stage timeout if that runs out first. Zero means no limit.
+func (p ProjectContext) ProcessTimeout() (time.Duration, time.Duration) {
+	timeout, reported := p.StepTimeout, p.StepTimeout
+	if !p.StageDeadline.IsZero() {
+		// A process started after the deadline is terminated right away.
+		remaining := max(time.Until(p.StageDeadline), time.Millisecond)
+		if timeout == 0 || remaining < timeout {
+			timeout, reported = remaining, p.StageTimeout
+		}
+	}
+	return timeout, reported
+}
+
 func (p ProjectContext) ProjectID() string {
 	if p.ProjectName != "" {
 		return p.ProjectName
@@ -5,6 +5,7 @@ package command_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 	"github.com/runatlantis/atlantis/server/events/command"
@@ -162,3 +163,36 @@ func TestPolicyCheckResults_PolicyFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectContext_ProcessTimeout(t *testing.T) {
+	timeout, reported := command.ProjectContext{}.ProcessTimeout()
+	Equals(t, time.Duration(0), timeout)
+	Equals(t, time.Duration(0), reported)
+
+	timeout, reported = command.ProjectContext{StepTimeout: 5 * time.Minute}.ProcessTimeout()
+	Equals(t, 5*time.Minute, timeout)
+	Equals(t, 5*time.Minute, reported)
+
+	// Less of the stage is left than the step may take.
+	ctx := command.ProjectContext{
+		StepTimeout:   5 * time.Minute,
+		StageTimeout:  30 * time.Minute,
+		StageDeadline: time.Now().Add(time.Minute),
+	}
+	timeout, reported = ctx.ProcessTimeout()
+	Assert(t, timeout > 0 && timeout <= time.Minute, "expected the rest of the stage, got %s", timeout)
+	Equals(t, 30*time.Minute, reported)
+
+	// More of the stage is left than the step may take.
+	ctx.StageDeadline = time.Now().Add(20 * time.Minute)
+	timeout, reported = ctx.ProcessTimeout()
+	Equals(t, 5*time.Minute, timeout)
+	Equals(t, 5*time.Minute, reported)
+
+	// The stage has already run out.
+	ctx.StepTimeout = 0
+	ctx.StageDeadline = time.Now().Add(-time.Minute)
+	timeout, reported = ctx.ProcessTimeout()
+	Equals(t, time.Millisecond, timeout)
+	Equals(t, 30*time.Minute, reported)
+}
@@ -3,7 +3,28 @@
 
 package command
 
-import "github.com/runatlantis/atlantis/server/events/models"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/events/models"
+)
+
+// ErrCancelled is the error of project commands that were stopped, or never
+// started, because of `atlantis cancel`.
+var ErrCancelled = errors.New("operation cancelled via `atlantis cancel` command")
+
+// TimeoutError is the error of processes that were terminated because they
+// ran longer than the timeout of their step.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s", e.Timeout)
+}
 
 // ProjectResult is the result of executing a plan/policy_check/apply for a specific project.
 type ProjectResult struct {
@@ -14,6 +35,7 @@ type ProjectResult struct {
 	Workspace         string
 	ProjectName       string
 	SilencePRComments []string
+	DriftIgnore       []valid.DriftIgnoreRule
 }
 
 // ProjectCommandOutput is the output of a plan/policy_check/apply for a specific project.
@@ -60,6 +82,14 @@ func (p ProjectResult) PolicyStatus() []models.PolicySetStatus {
 
 // PlanStatus returns the plan status.
 func (p ProjectResult) PlanStatus() models.ProjectPlanStatus {
+	if errors.Is(p.Error, ErrCancelled) {
+		switch p.Command {
+		case Plan:
+			return models.CancelledPlanStatus
+		case Apply:
+			return models.CancelledApplyStatus
+		}
+	}
 	switch p.Command {
 
 	case Plan:
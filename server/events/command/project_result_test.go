@@ -6,6 +6,7 @@ package command_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/runatlantis/atlantis/server/events/command"
@@ -81,6 +82,33 @@ func TestProjectResult_PlanStatus(t *testing.T) {
 			},
 			expStatus: models.ErroredPlanStatus,
 		},
+		{
+			p: command.ProjectResult{
+				Command: command.Plan,
+				ProjectCommandOutput: command.ProjectCommandOutput{
+					Error: fmt.Errorf("%w: interrupt", command.ErrCancelled),
+				},
+			},
+			expStatus: models.CancelledPlanStatus,
+		},
+		{
+			p: command.ProjectResult{
+				Command: command.Apply,
+				ProjectCommandOutput: command.ProjectCommandOutput{
+					Error: command.ErrCancelled,
+				},
+			},
+			expStatus: models.CancelledApplyStatus,
+		},
+		{
+			p: command.ProjectResult{
+				Command: command.Import,
+				ProjectCommandOutput: command.ProjectCommandOutput{
+					Error: command.ErrCancelled,
+				},
+			},
+			expStatus: models.ErroredPlanStatus,
+		},
 		{
 			p: command.ProjectResult{
 				Command: command.Plan,
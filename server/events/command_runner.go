@@ -18,6 +18,7 @@ import (
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/vcs"
 	"github.com/runatlantis/atlantis/server/events/vcs/gitea"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/metrics"
 	"github.com/runatlantis/atlantis/server/recovery"
@@ -139,6 +140,21 @@ type DefaultCommandRunner struct {
 	TeamAllowlistChecker           command.TeamAllowlistChecker          `validate:"required"`
 	VarFileAllowlistChecker        *VarFileAllowlistChecker              `validate:"required"`
 	CommitStatusUpdater            CommitStatusUpdater                   `validate:"required"`
+	// LifecycleWebhooks sends autoplan_skipped webhooks. It may be nil.
+	LifecycleWebhooks LifecycleWebhooksSender
+}
+
+// sendAutoplanSkipped sends an autoplan_skipped webhook explaining why
+// autoplan didn't run.
+func (c *DefaultCommandRunner) sendAutoplanSkipped(log logging.SimpleLogging, pull models.PullRequest, user models.User, reason string) {
+	if c.LifecycleWebhooks == nil {
+		return
+	}
+	event := newPullLifecycleEvent(webhooks.AutoplanSkippedEvent, pull, user)
+	event.Reason = reason
+	if err := c.LifecycleWebhooks.SendLifecycle(log, event); err != nil {
+		log.Warn("%s", err)
+	}
 }
 
 // RunAutoplanCommand runs plan and policy_checks when a pull request is opened or updated.
@@ -178,6 +194,7 @@ func (c *DefaultCommandRunner) RunAutoplanCommand(baseRepo models.Repo, headRepo
 			return
 		}
 		if !ok {
+			c.sendAutoplanSkipped(log, pull, user, autoplanSkippedUserNotAllowed)
 			return
 		}
 		c.addPolicyCheckHierarchyTeamsForPlan(baseRepo, &user, command.Plan, directUserTeams)
@@ -193,9 +210,11 @@ func (c *DefaultCommandRunner) RunAutoplanCommand(baseRepo models.Repo, headRepo
 		Trigger:    command.AutoTrigger,
 	}
 	if !c.validateCtxAndComment(ctx, command.Autoplan, true) {
+		c.sendAutoplanSkipped(log, pull, user, autoplanSkippedPullNotEligible)
 		return
 	}
 	if c.DisableAutoplan {
+		c.sendAutoplanSkipped(log, pull, user, autoplanSkippedDisabled)
 		return
 	}
 	if len(c.DisableAutoplanLabel) > 0 {
@@ -204,6 +223,7 @@ func (c *DefaultCommandRunner) RunAutoplanCommand(baseRepo models.Repo, headRepo
 			ctx.Log.Err("Unable to get VCS pull/merge request labels: %s. Proceeding with autoplan.", err)
 		} else if slices.Contains(labels, c.DisableAutoplanLabel) {
 			ctx.Log.Info("Pull/merge request has disable auto plan label '%s' so not running autoplan.", c.DisableAutoplanLabel)
+			c.sendAutoplanSkipped(log, pull, user, autoplanSkippedLabel)
 			return
 		}
 	}
@@ -234,6 +254,7 @@ func (c *DefaultCommandRunner) RunAutoplanCommand(baseRepo models.Repo, headRepo
 				ctx.Log.Warn("Unable to update plan commit status: %s", err)
 			}
 
+			c.sendAutoplanSkipped(log, pull, user, autoplanSkippedPreWorkflowHook)
 			return
 		}
 
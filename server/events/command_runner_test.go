@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/core/boltdb"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
@@ -30,6 +31,7 @@ import (
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/models/testdata"
 	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
 	. "github.com/runatlantis/atlantis/testing"
 	"go.uber.org/mock/gomock"
 )
@@ -1941,6 +1943,26 @@ func TestRunCommentCommand_DisableAutoplan(t *testing.T) {
 	projectCommandBuilder.VerifyWasCalled(Never()).BuildAutoplanCommands(Any[*command.Context]())
 }
 
+func TestRunAutoplanCommand_DisableAutoplanSendsLifecycleWebhook(t *testing.T) {
+	setup(t)
+	modelPull := models.PullRequest{BaseRepo: testdata.GithubRepo, BaseBranch: "main", Num: 1}
+	lifecycleWebhooks := mocks.NewMockLifecycleWebhooksSender()
+
+	ch.DisableAutoplan = true
+	ch.LifecycleWebhooks = lifecycleWebhooks
+	defer func() {
+		ch.DisableAutoplan = false
+		ch.LifecycleWebhooks = nil
+	}()
+
+	ch.RunAutoplanCommand(testdata.GithubRepo, testdata.GithubRepo, modelPull, testdata.User)
+	_, event := lifecycleWebhooks.VerifyWasCalledOnce().SendLifecycle(Any[logging.SimpleLogging](), Any[webhooks.LifecycleEvent]()).GetCapturedArguments()
+	Equals(t, webhooks.AutoplanSkippedEvent, event.Event)
+	Equals(t, "autoplan is disabled", event.Reason)
+	Equals(t, testdata.GithubRepo.FullName, event.Repository)
+	Equals(t, 1, event.PullNum)
+}
+
 func TestRunCommentCommand_DisableAutoplanLabel(t *testing.T) {
 	t.Log("if \"DisableAutoplanLabel\" is present and pull request has that label, auto plans are disabled and we are silencing return and do not comment with error")
 	vcsClient := setup(t)
@@ -2212,6 +2234,60 @@ func TestRunAutoplanCommand_DeletePlans(t *testing.T) {
 	pendingPlanFinder.VerifyWasCalledOnce().Find(tmp)
 }
 
+func TestRunAutoplanCommand_SupersedesRunningAutoplan(t *testing.T) {
+	vcsClient := setup(t)
+	autoplanRegistry := events.NewMockAutoplanRegistry()
+	planCommandRunner.AutoplanSuperseder = events.NewAutoplanSuperseder(nil, 0, autoplanRegistry)
+	oldPull := models.PullRequest{BaseRepo: testdata.GithubRepo, State: models.OpenPullState, Num: testdata.Pull.Num, HeadCommit: "abc123"}
+	newPull := oldPull
+	newPull.HeadCommit = "def456"
+	oldCtx := command.ProjectContext{
+		CommandName: command.Plan,
+		RepoRelDir:  ".",
+		Workspace:   events.DefaultWorkspace,
+		BaseRepo:    testdata.GithubRepo,
+		Pull:        oldPull,
+	}
+	newCtx := oldCtx
+	newCtx.Pull = newPull
+	tmp := t.TempDir()
+
+	When(projectCommandBuilder.BuildAutoplanCommands(Any[*command.Context]())).Then(func(params []Param) ReturnValues {
+		if params[0].(*command.Context).Pull.HeadCommit == oldPull.HeadCommit {
+			return ReturnValues{[]command.ProjectContext{oldCtx}, nil}
+		}
+		return ReturnValues{[]command.ProjectContext{newCtx}, nil}
+	})
+	When(workingDir.GetPullDir(Any[models.Repo](), Any[models.PullRequest]())).ThenReturn(tmp, nil)
+	When(pendingPlanFinder.Find(tmp)).ThenReturn([]events.PendingPlan{}, nil)
+	newAutoplanDone := make(chan struct{})
+	When(projectCommandRunner.Plan(oldCtx)).Then(func([]Param) ReturnValues {
+		// Push a new commit while the first autoplan is planning.
+		go func() {
+			defer close(newAutoplanDone)
+			ch.RunAutoplanCommand(testdata.GithubRepo, testdata.GithubRepo, newPull, testdata.User)
+		}()
+		autoplanRegistry.VerifyWasCalledEventually(Times(2), 5*time.Second).Start(Any[models.PullRequest]())
+		return ReturnValues{command.ProjectCommandOutput{PlanSuccess: &models.PlanSuccess{}}}
+	})
+	When(projectCommandRunner.Plan(newCtx)).ThenReturn(command.ProjectCommandOutput{PlanSuccess: &models.PlanSuccess{}})
+
+	ch.RunAutoplanCommand(testdata.GithubRepo, testdata.GithubRepo, oldPull, testdata.User)
+	<-newAutoplanDone
+
+	projectCommandRunner.VerifyWasCalledOnce().Plan(newCtx)
+	// Only the superseded autoplan is cancelled, not the whole pull request.
+	cancellationTracker.VerifyWasCalled(Never()).Cancel(Any[models.PullRequest]())
+	vcsClient.VerifyWasCalledOnce().CreateComment(
+		Any[logging.SimpleLogging](), Eq(testdata.GithubRepo), Eq(testdata.Pull.Num),
+		Eq("Autoplan for commit `abc123` was superseded by a newer autoplan for commit `def456`. Its results have been discarded."),
+		Eq("plan"))
+	vcsClient.VerifyWasCalledOnce().HidePrevCommandComments(
+		Any[logging.SimpleLogging](), Eq(testdata.GithubRepo), Eq(testdata.Pull.Num), Eq("Plan"), Eq(""))
+	vcsClient.VerifyWasCalled(Times(2)).CreateComment(
+		Any[logging.SimpleLogging](), Eq(testdata.GithubRepo), Eq(testdata.Pull.Num), Any[string](), Eq("plan"))
+}
+
 func TestRunAutoplan_NoProjectsWritesCurrentEmptyPullStatus(t *testing.T) {
 	setup(t)
 	tmp := t.TempDir()
@@ -287,6 +287,9 @@ func (e *CommentParser) Parse(rawComment string, vcsHost models.VCSHostType) Com
 		name = command.Cancel
 		flagSet = pflag.NewFlagSet(command.Cancel.String(), pflag.ContinueOnError)
 		flagSet.SetOutput(io.Discard)
+		flagSet.StringVarP(&workspace, workspaceFlagLong, workspaceFlagShort, "", "Only cancel running operations in this Terraform workspace.")
+		flagSet.StringVarP(&dir, dirFlagLong, dirFlagShort, "", "Only cancel running operations in this directory, relative to root of repo, ex. 'child/dir'.")
+		flagSet.StringVarP(&project, projectFlagLong, projectFlagShort, "", "Only cancel running operations for this project. Refers to the name of the project configured in a repo config file. Cannot be used at same time as workspace or dir flags.")
 	case command.Version.String():
 		name = command.Version
 		flagSet = pflag.NewFlagSet(command.Version.String(), pflag.ContinueOnError)
@@ -310,6 +313,13 @@ func (e *CommentParser) Parse(rawComment string, vcsHost models.VCSHostType) Com
 		flagSet.StringVarP(&dir, dirFlagLong, dirFlagShort, "", "Which directory to run state command in relative to root of repo, ex. 'child/dir'.")
 		flagSet.StringVarP(&project, projectFlagLong, projectFlagShort, "", "Which project to run state command for. Refers to the name of the project configured in a repo config file. Cannot be used at same time as workspace or dir flags.")
 		flagSet.BoolVarP(&verbose, verboseFlagLong, verboseFlagShort, false, "Append Atlantis log to comment.")
+	case command.Drift.String():
+		name = command.Drift
+		flagSet = pflag.NewFlagSet(command.Drift.String(), pflag.ContinueOnError)
+		flagSet.SetOutput(io.Discard)
+		flagSet.StringVarP(&workspace, workspaceFlagLong, workspaceFlagShort, "", "Check this Terraform workspace for drift.")
+		flagSet.StringVarP(&dir, dirFlagLong, dirFlagShort, "", "Check this directory for drift, relative to root of repo, ex. 'child/dir'.")
+		flagSet.StringVarP(&project, projectFlagLong, projectFlagShort, "", "Check this project for drift. Refers to the name of the project configured in a repo config file. Cannot be used at same time as workspace or dir flags.")
 	default:
 		return CommentParseResult{CommentResponse: fmt.Sprintf("Error: unknown command %q – this is a bug", cmd)}
 	}
@@ -319,6 +329,12 @@ func (e *CommentParser) Parse(rawComment string, vcsHost models.VCSHostType) Com
 		return CommentParseResult{CommentResponse: errResult}
 	}
 
+	// Drift detection always runs a refresh-only plan of the base branch, so
+	// Terraform arguments from the comment are not passed through.
+	if name == command.Drift && len(extraArgs) > 0 {
+		return CommentParseResult{CommentResponse: e.errMarkdown("extra Terraform arguments are not supported", cmd, flagSet)}
+	}
+
 	dir, err = e.validateDir(dir)
 	if err != nil {
 		return CommentParseResult{CommentResponse: e.errMarkdown(err.Error(), cmd, flagSet)}
@@ -579,6 +595,7 @@ func (e *CommentParser) HelpComment() string {
 		AllowApprovePolicies bool
 		AllowImport          bool
 		AllowState           bool
+		AllowDrift           bool
 	}{
 		ExecutableName:       e.ExecutableName,
 		AllowVersion:         e.isAllowedCommand(command.Version.String()),
@@ -589,6 +606,7 @@ func (e *CommentParser) HelpComment() string {
 		AllowApprovePolicies: e.isAllowedCommand(command.ApprovePolicies.String()),
 		AllowImport:          e.isAllowedCommand(command.Import.String()),
 		AllowState:           e.isAllowedCommand(command.State.String()),
+		AllowDrift:           e.isAllowedCommand(command.Drift.String()),
 	}); err != nil {
 		return fmt.Sprintf("Failed to render template, this is a bug: %v", err)
 	}
@@ -629,8 +647,8 @@ Commands:
            To only apply a specific plan, use the -d, -w and -p flags.
 {{- end }}
 {{- if .AllowCancel }}
-  cancel   Cancels all queued commands for this pull request.
-           Already running commands are not interrupted.
+  cancel   Cancels all queued and running commands for this pull request.
+           To only interrupt a specific project, use the -d, -w and -p flags.
 {{- end }}
 {{- if .AllowUnlock }}
   unlock   Removes all atlantis locks and discards all plans for this PR.
@@ -652,6 +670,10 @@ Commands:
   state rm ADDRESS...
            Runs 'terraform state rm' for the passed address resource.
            To remove a specific project resource, use the -d, -w and -p flags.
+{{- end }}
+{{- if .AllowDrift }}
+  drift    Checks the base branch for drift with a refresh-only plan.
+           To check a specific project, use the -d, -w and -p flags.
 {{- end }}
   help     View help.
 
@@ -706,6 +706,46 @@ func TestParse_UsingProjectAtSameTimeAsWorkspaceOrDir(t *testing.T) {
 	}
 }
 
+func TestParse_Drift(t *testing.T) {
+	r := commentParser.Parse("atlantis drift -d dir -w workspace", models.Github)
+	Equals(t, "", r.CommentResponse)
+	Equals(t, command.Drift, r.Command.Name)
+	Equals(t, "dir", r.Command.RepoRelDir)
+	Equals(t, "workspace", r.Command.Workspace)
+
+	r = commentParser.Parse("atlantis drift -p project", models.Github)
+	Equals(t, "", r.CommentResponse)
+	Equals(t, "project", r.Command.ProjectName)
+
+	r = commentParser.Parse("atlantis drift -- -target=resource", models.Github)
+	Assert(t, strings.Contains(r.CommentResponse, "Error: extra Terraform arguments are not supported"),
+		"expected CommentResponse %q to reject extra args", r.CommentResponse)
+
+	r = commentParser.Parse("atlantis drift -p project -d dir", models.Github)
+	Assert(t, strings.Contains(r.CommentResponse, "Error: cannot use -p/--project at same time as -d/--dir or -w/--workspace"),
+		"expected CommentResponse %q to reject -p with -d", r.CommentResponse)
+}
+
+func TestParse_Cancel(t *testing.T) {
+	r := commentParser.Parse("atlantis cancel", models.Github)
+	Equals(t, "", r.CommentResponse)
+	Equals(t, command.Cancel, r.Command.Name)
+	Assert(t, !r.Command.IsForSpecificProject(), "expected cancel without flags to apply to the whole pull request")
+
+	r = commentParser.Parse("atlantis cancel -d dir -w workspace", models.Github)
+	Equals(t, "", r.CommentResponse)
+	Equals(t, "dir", r.Command.RepoRelDir)
+	Equals(t, "workspace", r.Command.Workspace)
+
+	r = commentParser.Parse("atlantis cancel -p project", models.Github)
+	Equals(t, "", r.CommentResponse)
+	Equals(t, "project", r.Command.ProjectName)
+
+	r = commentParser.Parse("atlantis cancel -p project -w workspace", models.Github)
+	Assert(t, strings.Contains(r.CommentResponse, "Error: cannot use -p/--project at same time as -d/--dir or -w/--workspace"),
+		"expected CommentResponse %q to reject -p with -w", r.CommentResponse)
+}
+
 func TestParse_Parsing(t *testing.T) {
 	cases := []struct {
 		flags        string
@@ -1133,8 +1173,8 @@ Commands:
            To plan a specific project, use the -d, -w and -p flags.
   apply    Runs 'terraform apply' on all unapplied plans from this pull request.
            To only apply a specific plan, use the -d, -w and -p flags.
-  cancel   Cancels all queued commands for this pull request.
-           Already running commands are not interrupted.
+  cancel   Cancels all queued and running commands for this pull request.
+           To only interrupt a specific project, use the -d, -w and -p flags.
   unlock   Removes all atlantis locks and discards all plans for this PR.
            To unlock a specific plan you can use the Atlantis UI.
   approve_policies
@@ -1146,6 +1186,8 @@ Commands:
   state rm ADDRESS...
            Runs 'terraform state rm' for the passed address resource.
            To remove a specific project resource, use the -d, -w and -p flags.
+  drift    Checks the base branch for drift with a refresh-only plan.
+           To check a specific project, use the -d, -w and -p flags.
   help     View help.
 
 Flags:
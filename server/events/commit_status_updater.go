@@ -6,6 +6,8 @@ package events
 
 import (
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"unicode/utf8"
 
@@ -34,11 +36,46 @@ type CommitStatusUpdater interface {
 	UpdatePostWorkflowHook(logger logging.SimpleLogging, pull models.PullRequest, status models.CommitStatus, hookDescription string, runtimeDescription string, url string) error
 }
 
+//go:generate go tool pegomock generate github.com/runatlantis/atlantis/server/events --package mocks -o mocks/mock_check_run_updater.go CheckRunUpdater
+
+// CheckRunUpdater creates and updates check runs on the head commit of pull
+// requests. It's implemented by the GitHub client.
+type CheckRunUpdater interface {
+	UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, checkRun models.CheckRun) error
+}
+
+// Identifiers of the actions of project check runs.
+const (
+	// CheckRunApplyAction applies the plan of the project of the check run.
+	CheckRunApplyAction = "apply"
+	// CheckRunUnlockAction discards the plans and locks of the pull request
+	// of the check run.
+	CheckRunUnlockAction = "unlock"
+)
+
+// CheckRunProject identifies the project of a check run. It's stored as the
+// external ID of the check run so its requested actions can be mapped back to
+// the project.
+type CheckRunProject struct {
+	PullNum     int    `json:"pull"`
+	RepoRelDir  string `json:"dir"`
+	Workspace   string `json:"workspace"`
+	ProjectName string `json:"project,omitempty"`
+}
+
 // DefaultCommitStatusUpdater implements CommitStatusUpdater.
 type DefaultCommitStatusUpdater struct {
 	Client vcs.Client
 	// StatusName is the name used to identify Atlantis when creating PR statuses.
 	StatusName string
+	// CheckRunUpdater, if set, is used to report the status of each project
+	// of GitHub pull requests as a check run instead of a commit status.
+	CheckRunUpdater CheckRunUpdater
+	// Renderer renders the output of project check runs.
+	Renderer *MarkdownRenderer
+	// ApplyDisabled is true if the Apply action must not be offered on
+	// check runs.
+	ApplyDisabled bool
 }
 
 // ensure DefaultCommitStatusUpdater implements runtime.StatusUpdater interface
@@ -126,7 +163,12 @@ func (d *DefaultCommitStatusUpdater) UpdateProject(ctx command.ProjectContext, c
 	case models.PendingCommitStatus:
 		descripWords = genProjectStatusDescription(cmdName.String(), "in progress...")
 	case models.FailedCommitStatus:
-		descripWords = genProjectStatusDescription(cmdName.String(), "failed.")
+		var timeoutErr *command.TimeoutError
+		if result != nil && errors.As(result.Error, &timeoutErr) {
+			descripWords = genProjectStatusDescription(cmdName.String(), timeoutErr.Error()+".")
+		} else {
+			descripWords = genProjectStatusDescription(cmdName.String(), "failed.")
+		}
 	case models.SuccessCommitStatus:
 		if result != nil && result.PlanSuccess != nil {
 			descripWords = result.PlanSuccess.DiffSummary()
@@ -134,9 +176,112 @@ func (d *DefaultCommitStatusUpdater) UpdateProject(ctx command.ProjectContext, c
 			descripWords = genProjectStatusDescription(cmdName.String(), "succeeded.")
 		}
 	}
+	if d.CheckRunUpdater != nil && ctx.BaseRepo.VCSHost.Type == models.Github {
+		return d.updateProjectCheckRun(ctx, cmdName, status, src, descripWords, url, result)
+	}
 	return d.Client.UpdateStatus(ctx.Log, ctx.BaseRepo, ctx.Pull, status, src, descripWords, url)
 }
 
+// updateProjectCheckRun reports the status of the project represented by ctx
+// as the check run name.
+func (d *DefaultCommitStatusUpdater) updateProjectCheckRun(ctx command.ProjectContext, cmdName command.Name, status models.CommitStatus, name string, title string, url string, result *command.ProjectCommandOutput) error {
+	externalID, err := json.Marshal(CheckRunProject{
+		PullNum:     ctx.Pull.Num,
+		RepoRelDir:  ctx.RepoRelDir,
+		Workspace:   ctx.Workspace,
+		ProjectName: ctx.ProjectName,
+	})
+	if err != nil {
+		return err
+	}
+	checkRun := models.CheckRun{
+		Name:       name,
+		ExternalID: string(externalID),
+		Status:     status,
+		DetailsURL: url,
+		Title:      title,
+		Summary:    checkRunSummary(ctx, result),
+	}
+	if result == nil {
+		return d.CheckRunUpdater.UpdateCheckRun(ctx.Log, ctx.BaseRepo, ctx.Pull, checkRun)
+	}
+
+	if d.Renderer != nil {
+		res := command.Result{
+			ProjectResults: []command.ProjectResult{{
+				ProjectCommandOutput: *result,
+				Command:              cmdName,
+				RepoRelDir:           ctx.RepoRelDir,
+				Workspace:            ctx.Workspace,
+				ProjectName:          ctx.ProjectName,
+			}},
+		}
+		cmdCtx := &command.Context{
+			HeadRepo: ctx.HeadRepo,
+			Pull:     ctx.Pull,
+			User:     ctx.User,
+			Log:      ctx.Log,
+		}
+		checkRun.Text = d.Renderer.Render(cmdCtx, res, &CommentCommand{Name: cmdName})
+	}
+	if result.PolicyCheckResults != nil {
+		for _, policySet := range result.PolicyCheckResults.PolicySetResults {
+			if policySet.Passed {
+				continue
+			}
+			checkRun.Annotations = append(checkRun.Annotations, models.CheckRunAnnotation{
+				Path:    ctx.RepoRelDir,
+				Level:   "failure",
+				Title:   fmt.Sprintf("Policy set %s failed", policySet.PolicySetName),
+				Message: policySet.PolicyOutput,
+			})
+		}
+	}
+	// Actions are only offered for pull requests. Non-PR workflows like merge
+	// groups have no pull request for them to run against.
+	if ctx.Pull.Num > 0 && status != models.PendingCommitStatus && (cmdName == command.Plan || cmdName == command.PolicyCheck) {
+		if status == models.SuccessCommitStatus && !d.ApplyDisabled && result.PlanSuccess != nil && !result.PlanSuccess.NoChanges() {
+			checkRun.Actions = append(checkRun.Actions, models.CheckRunAction{
+				Identifier:  CheckRunApplyAction,
+				Label:       "Apply",
+				Description: "Apply the plan of this project",
+			})
+		}
+		checkRun.Actions = append(checkRun.Actions, models.CheckRunAction{
+			Identifier:  CheckRunUnlockAction,
+			Label:       "Unlock",
+			Description: "Discard all plans and locks of the pull",
+		})
+	}
+	return d.CheckRunUpdater.UpdateCheckRun(ctx.Log, ctx.BaseRepo, ctx.Pull, checkRun)
+}
+
+// checkRunSummary is the summary of the check run of the project represented
+// by ctx.
+func checkRunSummary(ctx command.ProjectContext, result *command.ProjectCommandOutput) string {
+	summary := fmt.Sprintf("Dir: `%s` Workspace: `%s`", ctx.RepoRelDir, ctx.Workspace)
+	if ctx.ProjectName != "" {
+		summary = fmt.Sprintf("Project: `%s` %s", ctx.ProjectName, summary)
+	}
+	if result == nil {
+		return summary
+	}
+	switch {
+	case result.Error != nil:
+		summary += "\n\n**Error:** " + result.Error.Error()
+	case result.Failure != "":
+		summary += "\n\n**Failed:** " + result.Failure
+	case result.PlanSuccess != nil:
+		summary += "\n\n" + result.PlanSuccess.Summary()
+	}
+	if result.PolicyCheckResults != nil {
+		if policySummary := result.PolicyCheckResults.PolicySummary(); policySummary != "" {
+			summary += "\n\n" + policySummary
+		}
+	}
+	return summary
+}
+
 func genProjectStatusDescription(cmdName, description string) string {
 	return fmt.Sprintf("%s %s", cases.Title(language.English).String(cmdName), description)
 }
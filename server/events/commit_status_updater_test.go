@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 
 	. "github.com/petergtz/pegomock/v4"
 	"github.com/runatlantis/atlantis/server/events"
 	"github.com/runatlantis/atlantis/server/events/command"
+	eventMocks "github.com/runatlantis/atlantis/server/events/mocks"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/vcs/mocks"
 	"github.com/runatlantis/atlantis/server/logging"
@@ -338,6 +340,14 @@ func TestDefaultCommitStatusUpdater_UpdateProject(t *testing.T) {
 			cmd:        command.Plan,
 			expDescrip: "Plan failed.",
 		},
+		{
+			status: models.FailedCommitStatus,
+			cmd:    command.Apply,
+			result: &command.ProjectCommandOutput{
+				Error: fmt.Errorf("running apply: %w", &command.TimeoutError{Timeout: time.Hour}),
+			},
+			expDescrip: "Apply timed out after 1h0m0s.",
+		},
 		{
 			status: models.SuccessCommitStatus,
 			cmd:    command.Plan,
@@ -486,6 +496,145 @@ func TestDefaultCommitStatusUpdater_UpdateWorkflowHookTruncatedContextsRemainUni
 	}
 }
 
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRun(t *testing.T) {
+	RegisterMockTestingT(t)
+	ghRepo := models.Repo{FullName: "owner/repo", VCSHost: models.VCSHost{Type: models.Github}}
+	ctx := command.ProjectContext{
+		Log:        logging.NewNoopLogger(t),
+		BaseRepo:   ghRepo,
+		Pull:       models.PullRequest{Num: 1, BaseRepo: ghRepo},
+		RepoRelDir: "dir",
+		Workspace:  "default",
+	}
+	cases := []struct {
+		description    string
+		cmd            command.Name
+		status         models.CommitStatus
+		result         *command.ProjectCommandOutput
+		expTitle       string
+		expText        string
+		expAnnotations []models.CheckRunAnnotation
+		expActions     []string
+	}{
+		{
+			description: "pending plan",
+			cmd:         command.Plan,
+			status:      models.PendingCommitStatus,
+			expTitle:    "Plan in progress...",
+		},
+		{
+			description: "plan with changes",
+			cmd:         command.Plan,
+			status:      models.SuccessCommitStatus,
+			result: &command.ProjectCommandOutput{
+				PlanSuccess: &models.PlanSuccess{TerraformOutput: "Plan: 1 to add, 0 to change, 0 to destroy."},
+			},
+			expTitle:   "Plan: 1 to add, 0 to change, 0 to destroy.",
+			expText:    "Plan: 1 to add, 0 to change, 0 to destroy.",
+			expActions: []string{events.CheckRunApplyAction, events.CheckRunUnlockAction},
+		},
+		{
+			description: "plan without changes",
+			cmd:         command.Plan,
+			status:      models.SuccessCommitStatus,
+			result: &command.ProjectCommandOutput{
+				PlanSuccess: &models.PlanSuccess{TerraformOutput: "No changes. Your infrastructure matches the configuration."},
+			},
+			expTitle:   "No changes. Your infrastructure matches the configuration.",
+			expText:    "No changes.",
+			expActions: []string{events.CheckRunUnlockAction},
+		},
+		{
+			description: "failed policy check",
+			cmd:         command.PolicyCheck,
+			status:      models.FailedCommitStatus,
+			result: &command.ProjectCommandOutput{
+				Failure: "Some policy sets did not pass.",
+				PolicyCheckResults: &models.PolicyCheckResults{
+					PolicySetResults: []models.PolicySetResult{
+						{PolicySetName: "passing", PolicyOutput: "1 test, 1 passed", Passed: true},
+						{PolicySetName: "failing", PolicyOutput: "FAIL - deny", Passed: false},
+					},
+				},
+			},
+			expTitle: "Policy_check failed.",
+			expText:  "Some policy sets did not pass.",
+			expAnnotations: []models.CheckRunAnnotation{
+				{Path: "dir", Level: "failure", Title: "Policy set failing failed", Message: "FAIL - deny"},
+			},
+			expActions: []string{events.CheckRunUnlockAction},
+		},
+		{
+			description: "apply",
+			cmd:         command.Apply,
+			status:      models.SuccessCommitStatus,
+			result: &command.ProjectCommandOutput{
+				ApplySuccess: "Apply complete!",
+			},
+			expTitle: "Apply succeeded.",
+			expText:  "Apply complete!",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			client := mocks.NewMockClient()
+			checkRunUpdater := eventMocks.NewMockCheckRunUpdater()
+			s := events.DefaultCommitStatusUpdater{
+				Client:          client,
+				StatusName:      "atlantis",
+				CheckRunUpdater: checkRunUpdater,
+				Renderer:        events.NewMarkdownRenderer(false, false, false, false, false, false, "", "atlantis", false, false),
+			}
+			err := s.UpdateProject(ctx, c.cmd, c.status, "url", c.result)
+			Ok(t, err)
+
+			client.VerifyWasCalled(Never()).UpdateStatus(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+				Any[models.CommitStatus](), Any[string](), Any[string](), Any[string]())
+			_, repo, pull, checkRun := checkRunUpdater.VerifyWasCalledOnce().UpdateCheckRun(Any[logging.SimpleLogging](), Any[models.Repo](),
+				Any[models.PullRequest](), Any[models.CheckRun]()).GetCapturedArguments()
+			Equals(t, ghRepo, repo)
+			Equals(t, 1, pull.Num)
+			Equals(t, fmt.Sprintf("atlantis/%s: dir/default", c.cmd.String()), checkRun.Name)
+			Equals(t, `{"pull":1,"dir":"dir","workspace":"default"}`, checkRun.ExternalID)
+			Equals(t, c.status, checkRun.Status)
+			Equals(t, "url", checkRun.DetailsURL)
+			Equals(t, c.expTitle, checkRun.Title)
+			Assert(t, strings.HasPrefix(checkRun.Summary, "Dir: `dir` Workspace: `default`"), "unexpected summary %q", checkRun.Summary)
+			if c.expText == "" {
+				Equals(t, "", checkRun.Text)
+			} else {
+				Assert(t, strings.Contains(checkRun.Text, c.expText), "expected text %q to contain %q", checkRun.Text, c.expText)
+			}
+			Equals(t, c.expAnnotations, checkRun.Annotations)
+			var actions []string
+			for _, action := range checkRun.Actions {
+				actions = append(actions, action.Identifier)
+			}
+			Equals(t, c.expActions, actions)
+		})
+	}
+}
+
+// Test that projects of pull requests of other VCS hosts still get commit
+// statuses.
+func TestDefaultCommitStatusUpdater_UpdateProjectCheckRunNotGithub(t *testing.T) {
+	RegisterMockTestingT(t)
+	client := mocks.NewMockClient()
+	checkRunUpdater := eventMocks.NewMockCheckRunUpdater()
+	s := events.DefaultCommitStatusUpdater{Client: client, StatusName: "atlantis", CheckRunUpdater: checkRunUpdater}
+	glRepo := models.Repo{FullName: "owner/repo", VCSHost: models.VCSHost{Type: models.Gitlab}}
+	err := s.UpdateProject(command.ProjectContext{
+		BaseRepo:   glRepo,
+		RepoRelDir: ".",
+		Workspace:  "default",
+	}, command.Plan, models.PendingCommitStatus, "url", nil)
+	Ok(t, err)
+	client.VerifyWasCalledOnce().UpdateStatus(Any[logging.SimpleLogging](), Eq(glRepo), Eq(models.PullRequest{}),
+		Eq(models.PendingCommitStatus), Eq("atlantis/plan: ./default"), Eq("Plan in progress..."), Eq("url"))
+	checkRunUpdater.VerifyWasCalled(Never()).UpdateCheckRun(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[models.CheckRun]())
+}
+
 // Test that we can set the status name.
 func TestDefaultCommitStatusUpdater_UpdateProjectCustomStatusName(t *testing.T) {
 	RegisterMockTestingT(t)
@@ -0,0 +1,71 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs"
+)
+
+const driftDisabledComment = "Drift detection is not enabled on this Atlantis server. " +
+	"To enable it, set `--enable-drift-detection`."
+
+func NewDriftCommandRunner(
+	vcsClient vcs.Client,
+	markdownRenderer *MarkdownRenderer,
+) *DriftCommandRunner {
+	return &DriftCommandRunner{
+		VCSClient:        vcsClient,
+		MarkdownRenderer: markdownRenderer,
+	}
+}
+
+// DriftCommandRunner runs drift detection against the base branch of a pull
+// request and comments the results on it.
+type DriftCommandRunner struct {
+	VCSClient        vcs.Client
+	MarkdownRenderer *MarkdownRenderer
+	// Detector runs drift detection and stores its results. It is nil when
+	// drift detection is not enabled.
+	Detector drift.Detector
+}
+
+func (d *DriftCommandRunner) Run(ctx *command.Context, cmd *CommentCommand) {
+	if d.Detector == nil {
+		ctx.Log.Info("drift detection is not enabled, not running drift command")
+		if err := d.VCSClient.CreateComment(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull.Num, driftDisabledComment, command.Drift.String()); err != nil {
+			ctx.Log.Err("unable to comment: %s", err)
+		}
+		return
+	}
+
+	request := models.DriftDetectionRequest{
+		Repository:  ctx.Pull.BaseRepo.FullName,
+		Ref:         "refs/heads/" + ctx.Pull.BaseBranch,
+		Type:        ctx.Pull.BaseRepo.VCSHost.Type.String(),
+		RefreshOnly: true,
+	}
+	if cmd.ProjectName != "" {
+		request.Projects = []string{cmd.ProjectName}
+	} else if cmd.RepoRelDir != "" || cmd.Workspace != "" {
+		dir := cmd.RepoRelDir
+		if dir == "" {
+			dir = DefaultRepoRelDir
+		}
+		request.Paths = []models.DriftDetectionPath{{Directory: dir, Workspace: cmd.Workspace}}
+	}
+
+	ctx.Log.Info("running drift detection on %s", ctx.Pull.BaseBranch)
+	result, err := d.Detector.RunDriftDetection(request)
+	if err != nil {
+		ctx.Log.Err("drift detection failed: %s", err)
+	}
+
+	comment := d.MarkdownRenderer.RenderDrift(ctx, cmd, ctx.Pull.BaseBranch, result, err)
+	if err := d.VCSClient.CreateComment(ctx.Log, ctx.Pull.BaseRepo, ctx.Pull.Num, comment, command.Drift.String()); err != nil {
+		ctx.Log.Err("unable to comment: %s", err)
+	}
+}
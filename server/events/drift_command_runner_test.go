@@ -0,0 +1,94 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/petergtz/pegomock/v4"
+	driftmocks "github.com/runatlantis/atlantis/server/core/drift/mocks"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/models/testdata"
+	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func newDriftCommandContext(t *testing.T) *command.Context {
+	return &command.Context{
+		User:     testdata.User,
+		Log:      logging.NewNoopLogger(t),
+		Pull:     models.PullRequest{BaseRepo: testdata.GithubRepo, BaseBranch: "main", State: models.OpenPullState, Num: testdata.Pull.Num},
+		HeadRepo: testdata.GithubRepo,
+		Trigger:  command.CommentTrigger,
+	}
+}
+
+func TestDriftCommandRunner_Run(t *testing.T) {
+	RegisterMockTestingT(t)
+	vcsClient := vcsmocks.NewMockClient()
+	detector := driftmocks.NewMockDetector()
+	runner := events.NewDriftCommandRunner(vcsClient, events.NewMarkdownRenderer(false, false, false, false, false, false, "", "atlantis", false, false))
+	runner.Detector = detector
+
+	expRequest := models.DriftDetectionRequest{
+		Repository:  testdata.GithubRepo.FullName,
+		Ref:         "refs/heads/main",
+		Type:        "Github",
+		Paths:       []models.DriftDetectionPath{{Directory: ".", Workspace: "staging"}},
+		RefreshOnly: true,
+	}
+	result := models.NewDriftDetectionResult(testdata.GithubRepo.FullName)
+	result.AddProject(models.ProjectDrift{
+		Path:      ".",
+		Workspace: "staging",
+		Drift:     models.DriftSummary{HasDrift: true, Summary: "Objects have changed outside of Terraform"},
+	})
+	When(detector.RunDriftDetection(expRequest)).ThenReturn(result, nil)
+
+	ctx := newDriftCommandContext(t)
+	runner.Run(ctx, &events.CommentCommand{Name: command.Drift, Workspace: "staging"})
+
+	detector.VerifyWasCalledOnce().RunDriftDetection(expRequest)
+	_, _, _, comment, cmdName := vcsClient.VerifyWasCalledOnce().CreateComment(
+		Any[logging.SimpleLogging](), Eq(testdata.GithubRepo), Eq(testdata.Pull.Num), Any[string](), Any[string]()).GetCapturedArguments()
+	Equals(t, "drift", cmdName)
+	Assert(t, strings.Contains(comment, "Ran Drift on `main` for 1 projects:"), "got comment: %s", comment)
+	Assert(t, strings.Contains(comment, ":warning: **Drift detected**: Objects have changed outside of Terraform"), "got comment: %s", comment)
+}
+
+func TestDriftCommandRunner_RunProject(t *testing.T) {
+	RegisterMockTestingT(t)
+	vcsClient := vcsmocks.NewMockClient()
+	detector := driftmocks.NewMockDetector()
+	runner := events.NewDriftCommandRunner(vcsClient, events.NewMarkdownRenderer(false, false, false, false, false, false, "", "atlantis", false, false))
+	runner.Detector = detector
+
+	expRequest := models.DriftDetectionRequest{
+		Repository:  testdata.GithubRepo.FullName,
+		Ref:         "refs/heads/main",
+		Type:        "Github",
+		Projects:    []string{"app"},
+		RefreshOnly: true,
+	}
+	When(detector.RunDriftDetection(expRequest)).ThenReturn(models.NewDriftDetectionResult(testdata.GithubRepo.FullName), nil)
+
+	runner.Run(newDriftCommandContext(t), &events.CommentCommand{Name: command.Drift, ProjectName: "app"})
+	detector.VerifyWasCalledOnce().RunDriftDetection(expRequest)
+}
+
+func TestDriftCommandRunner_Disabled(t *testing.T) {
+	RegisterMockTestingT(t)
+	vcsClient := vcsmocks.NewMockClient()
+	runner := events.NewDriftCommandRunner(vcsClient, events.NewMarkdownRenderer(false, false, false, false, false, false, "", "atlantis", false, false))
+
+	runner.Run(newDriftCommandContext(t), &events.CommentCommand{Name: command.Drift})
+
+	_, _, _, comment, _ := vcsClient.VerifyWasCalledOnce().CreateComment(
+		Any[logging.SimpleLogging](), Eq(testdata.GithubRepo), Eq(testdata.Pull.Num), Any[string](), Eq("drift")).GetCapturedArguments()
+	Assert(t, strings.Contains(comment, "Drift detection is not enabled"), "got comment: %s", comment)
+}
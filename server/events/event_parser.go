@@ -215,6 +215,21 @@ type EventParsing interface {
 	ParseGithubIssueCommentEvent(logger logging.SimpleLogging, comment *github.IssueCommentEvent) (
 		baseRepo models.Repo, user models.User, pullNum int, err error)
 
+	// ParseGithubCheckRunEvent parses GitHub check run events of actions
+	// requested on the project check runs created by Atlantis.
+	// baseRepo is the repo of the check run.
+	// user is the user that requested the action.
+	// pullNum is the number of the pull request of the check run.
+	// cmd is the command that the requested action maps to.
+	ParseGithubCheckRunEvent(event *github.CheckRunEvent) (
+		baseRepo models.Repo, user models.User, pullNum int, cmd *CommentCommand, err error)
+
+	// ParseGithubMergeGroupEvent parses GitHub merge group events.
+	// mergeGroup is the merge group that checks were requested for.
+	// user is the user that triggered the event.
+	ParseGithubMergeGroupEvent(event *github.MergeGroupEvent) (
+		mergeGroup models.MergeGroup, user models.User, err error)
+
 	// ParseGithubPull parses the response from the GitHub API endpoint (not
 	// from a webhook) that returns a pull request.
 	// pull is the parsed pull request.
@@ -532,6 +547,82 @@ func (e *EventParser) ParseGithubIssueCommentEvent(logger logging.SimpleLogging,
 	return
 }
 
+// ParseGithubCheckRunEvent parses GitHub check run events.
+// See EventParsing for return value docs.
+func (e *EventParser) ParseGithubCheckRunEvent(event *github.CheckRunEvent) (baseRepo models.Repo, user models.User, pullNum int, cmd *CommentCommand, err error) {
+	baseRepo, err = e.ParseGithubRepo(event.Repo)
+	if err != nil {
+		return
+	}
+	if event.GetSender().GetLogin() == "" {
+		err = errors.New("sender.login is null")
+		return
+	}
+	user = models.User{
+		Username: event.GetSender().GetLogin(),
+	}
+	if event.GetCheckRun().GetExternalID() == "" {
+		err = errors.New("check_run.external_id is null")
+		return
+	}
+	var project CheckRunProject
+	if err = json.Unmarshal([]byte(event.GetCheckRun().GetExternalID()), &project); err != nil {
+		err = fmt.Errorf("parsing check_run.external_id: %w", err)
+		return
+	}
+	pullNum = project.PullNum
+	if pullNum == 0 {
+		err = errors.New("check_run.external_id has no pull number")
+		return
+	}
+
+	switch action := event.GetRequestedAction().GetIdentifier(); action {
+	case CheckRunApplyAction:
+		cmd = NewCommentCommand(project.RepoRelDir, nil, command.Apply, "", false, false, "", project.Workspace, project.ProjectName, "", false)
+	case CheckRunUnlockAction:
+		cmd = NewCommentCommand("", nil, command.Unlock, "", false, false, "", "", "", "", false)
+	default:
+		err = fmt.Errorf("unsupported requested_action.identifier %q", action)
+	}
+	return
+}
+
+// ParseGithubMergeGroupEvent parses GitHub merge group events.
+// See EventParsing for return value docs.
+func (e *EventParser) ParseGithubMergeGroupEvent(event *github.MergeGroupEvent) (mergeGroup models.MergeGroup, user models.User, err error) {
+	baseRepo, err := e.ParseGithubRepo(event.Repo)
+	if err != nil {
+		return
+	}
+	if event.GetSender().GetLogin() == "" {
+		err = errors.New("sender.login is null")
+		return
+	}
+	user = models.User{
+		Username: event.GetSender().GetLogin(),
+	}
+	group := event.GetMergeGroup()
+	if group.GetHeadSHA() == "" {
+		err = errors.New("merge_group.head_sha is null")
+		return
+	}
+	if group.GetHeadRef() == "" {
+		err = errors.New("merge_group.head_ref is null")
+		return
+	}
+	if group.GetBaseRef() == "" {
+		err = errors.New("merge_group.base_ref is null")
+		return
+	}
+	mergeGroup = models.MergeGroup{
+		BaseRepo:   baseRepo,
+		BaseBranch: strings.TrimPrefix(group.GetBaseRef(), "refs/heads/"),
+		HeadBranch: strings.TrimPrefix(group.GetHeadRef(), "refs/heads/"),
+		HeadCommit: group.GetHeadSHA(),
+	}
+	return
+}
+
 // ParseGithubPullEvent parses GitHub pull request events.
 // See EventParsing for return value docs.
 func (e *EventParser) ParseGithubPullEvent(logger logging.SimpleLogging, pullEvent *github.PullRequestEvent) (pull models.PullRequest, pullEventType models.PullRequestEventType, baseRepo models.Repo, headRepo models.Repo, user models.User, err error) {
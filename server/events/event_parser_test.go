@@ -110,6 +110,99 @@ func TestParseGithubIssueCommentEvent(t *testing.T) {
 	Equals(t, *comment.Issue.Number, pullNum)
 }
 
+func TestParseGithubCheckRunEvent(t *testing.T) {
+	event := github.CheckRunEvent{
+		Action: github.Ptr("requested_action"),
+		Repo:   &githubtestdata.Repo,
+		Sender: &github.User{Login: github.Ptr("clicking_user")},
+		CheckRun: &github.CheckRun{
+			ExternalID: github.Ptr(`{"pull":1,"dir":"dir","workspace":"staging","project":"proj"}`),
+		},
+		RequestedAction: &github.RequestedAction{Identifier: events.CheckRunApplyAction},
+	}
+
+	testEvent := deepcopy.Copy(event).(github.CheckRunEvent)
+	testEvent.Sender = nil
+	_, _, _, _, err := parser.ParseGithubCheckRunEvent(&testEvent)
+	ErrEquals(t, "sender.login is null", err)
+
+	testEvent = deepcopy.Copy(event).(github.CheckRunEvent)
+	testEvent.CheckRun.ExternalID = nil
+	_, _, _, _, err = parser.ParseGithubCheckRunEvent(&testEvent)
+	ErrEquals(t, "check_run.external_id is null", err)
+
+	testEvent = deepcopy.Copy(event).(github.CheckRunEvent)
+	testEvent.CheckRun.ExternalID = github.Ptr(`{"dir":"dir"}`)
+	_, _, _, _, err = parser.ParseGithubCheckRunEvent(&testEvent)
+	ErrEquals(t, "check_run.external_id has no pull number", err)
+
+	testEvent = deepcopy.Copy(event).(github.CheckRunEvent)
+	testEvent.RequestedAction.Identifier = "merge"
+	_, _, _, _, err = parser.ParseGithubCheckRunEvent(&testEvent)
+	ErrEquals(t, `unsupported requested_action.identifier "merge"`, err)
+
+	repo, user, pullNum, cmd, err := parser.ParseGithubCheckRunEvent(&event)
+	Ok(t, err)
+	Equals(t, *event.Repo.FullName, repo.FullName)
+	Equals(t, models.User{Username: "clicking_user"}, user)
+	Equals(t, 1, pullNum)
+	Equals(t, events.CommentCommand{
+		Name:        command.Apply,
+		RepoRelDir:  "dir",
+		Workspace:   "staging",
+		ProjectName: "proj",
+	}, *cmd)
+
+	testEvent = deepcopy.Copy(event).(github.CheckRunEvent)
+	testEvent.RequestedAction.Identifier = events.CheckRunUnlockAction
+	_, _, pullNum, cmd, err = parser.ParseGithubCheckRunEvent(&testEvent)
+	Ok(t, err)
+	Equals(t, 1, pullNum)
+	Equals(t, events.CommentCommand{Name: command.Unlock}, *cmd)
+}
+
+func TestParseGithubMergeGroupEvent(t *testing.T) {
+	event := github.MergeGroupEvent{
+		Action: github.Ptr("checks_requested"),
+		Repo:   &githubtestdata.Repo,
+		Sender: &github.User{Login: github.Ptr("merging_user")},
+		MergeGroup: &github.MergeGroup{
+			HeadSHA: github.Ptr("ec26c3e57ca3a959ca5aad62de7213c562f8c821"),
+			HeadRef: github.Ptr("refs/heads/gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b"),
+			BaseSHA: github.Ptr("b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b"),
+			BaseRef: github.Ptr("refs/heads/main"),
+		},
+	}
+
+	testEvent := deepcopy.Copy(event).(github.MergeGroupEvent)
+	testEvent.Sender = nil
+	_, _, err := parser.ParseGithubMergeGroupEvent(&testEvent)
+	ErrEquals(t, "sender.login is null", err)
+
+	testEvent = deepcopy.Copy(event).(github.MergeGroupEvent)
+	testEvent.MergeGroup.HeadSHA = nil
+	_, _, err = parser.ParseGithubMergeGroupEvent(&testEvent)
+	ErrEquals(t, "merge_group.head_sha is null", err)
+
+	testEvent = deepcopy.Copy(event).(github.MergeGroupEvent)
+	testEvent.MergeGroup.HeadRef = nil
+	_, _, err = parser.ParseGithubMergeGroupEvent(&testEvent)
+	ErrEquals(t, "merge_group.head_ref is null", err)
+
+	testEvent = deepcopy.Copy(event).(github.MergeGroupEvent)
+	testEvent.MergeGroup.BaseRef = nil
+	_, _, err = parser.ParseGithubMergeGroupEvent(&testEvent)
+	ErrEquals(t, "merge_group.base_ref is null", err)
+
+	mergeGroup, user, err := parser.ParseGithubMergeGroupEvent(&event)
+	Ok(t, err)
+	Equals(t, *event.Repo.FullName, mergeGroup.BaseRepo.FullName)
+	Equals(t, "main", mergeGroup.BaseBranch)
+	Equals(t, "gh-readonly-queue/main/pr-1-b45a3fcf09e96cb4e3ea4e2ff17ae1a3d1f39d5b", mergeGroup.HeadBranch)
+	Equals(t, "ec26c3e57ca3a959ca5aad62de7213c562f8c821", mergeGroup.HeadCommit)
+	Equals(t, models.User{Username: "merging_user"}, user)
+}
+
 func TestParseGithubPullEvent(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	_, _, _, _, _, err := parser.ParseGithubPullEvent(logger, &github.PullRequestEvent{})
@@ -0,0 +1,142 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+//go:generate go tool pegomock generate --package mocks -o mocks/mock_lifecycle_webhooks_sender.go LifecycleWebhooksSender
+
+// LifecycleWebhooksSender sends plan, policy check, lock and autoplan
+// webhooks.
+type LifecycleWebhooksSender interface {
+	// SendLifecycle queues the webhooks for event without waiting for them
+	// to be sent.
+	SendLifecycle(log logging.SimpleLogging, event webhooks.LifecycleEvent) error
+}
+
+// Autoplan skip reasons sent in autoplan_skipped webhooks.
+const (
+	autoplanSkippedUserNotAllowed  = "user is not allowed to plan"
+	autoplanSkippedPullNotEligible = "pull request is not eligible for autoplan"
+	autoplanSkippedDisabled        = "autoplan is disabled"
+	autoplanSkippedLabel           = "pull request has the disable autoplan label"
+	autoplanSkippedPreWorkflowHook = "pre-workflow hook failed"
+)
+
+func newPullLifecycleEvent(event string, pull models.PullRequest, user models.User) webhooks.LifecycleEvent {
+	return webhooks.LifecycleEvent{
+		Event:      event,
+		Time:       time.Now(),
+		Repository: pull.BaseRepo.FullName,
+		PullNum:    pull.Num,
+		PullURL:    pull.URL,
+		BaseBranch: pull.BaseBranch,
+		HeadCommit: pull.HeadCommit,
+		User:       user.Username,
+	}
+}
+
+func newProjectLifecycleEvent(event string, ctx command.ProjectContext) webhooks.LifecycleEvent {
+	e := newPullLifecycleEvent(event, ctx.Pull, ctx.User)
+	e.ProjectName = ctx.ProjectName
+	e.Path = ctx.RepoRelDir
+	e.Workspace = ctx.Workspace
+	return e
+}
+
+func newPlanFinishedEvent(ctx command.ProjectContext, out command.ProjectCommandOutput) webhooks.LifecycleEvent {
+	e := newProjectLifecycleEvent(webhooks.PlanFinishedEvent, ctx)
+	success := out.Error == nil && out.Failure == "" && out.PlanSuccess != nil
+	e.Success = &success
+	switch {
+	case out.Error != nil:
+		e.Error = out.Error.Error()
+	case out.Failure != "":
+		e.Error = out.Failure
+	}
+	if success {
+		stats := out.PlanSuccess.Stats()
+		e.PlanStats = &webhooks.PlanStats{
+			Import:         stats.Import,
+			Add:            stats.Add,
+			Change:         stats.Change,
+			Destroy:        stats.Destroy,
+			Forget:         stats.Forget,
+			Changes:        stats.Changes,
+			ChangesOutside: stats.ChangesOutside,
+		}
+	}
+	return e
+}
+
+// newPolicyCheckEvent returns the policy_check_passed or policy_check_failed
+// event for a policy check. It returns false if the policies weren't
+// evaluated, e.g. because another pull request holds the lock.
+func newPolicyCheckEvent(ctx command.ProjectContext, out command.ProjectCommandOutput) (webhooks.LifecycleEvent, bool) {
+	if out.Error == nil && out.PolicyCheckResults == nil {
+		return webhooks.LifecycleEvent{}, false
+	}
+	event := webhooks.PolicyCheckFailedEvent
+	if out.Error == nil && out.Failure == "" && out.PolicyCheckResults.PolicyCleared() {
+		event = webhooks.PolicyCheckPassedEvent
+	}
+	e := newProjectLifecycleEvent(event, ctx)
+	switch {
+	case out.Error != nil:
+		e.Error = out.Error.Error()
+	case out.Failure != "":
+		e.Error = out.Failure
+	}
+	if out.PolicyCheckResults != nil {
+		for _, result := range out.PolicyCheckResults.PolicySetResults {
+			e.PolicySets = append(e.PolicySets, webhooks.PolicySetResult{
+				Name:   result.PolicySetName,
+				Passed: result.Passed,
+			})
+		}
+	}
+	return e, true
+}
+
+// LockWebhookNotifier is a locking.LockListener that sends lock_acquired and
+// lock_released webhooks.
+type LockWebhookNotifier struct {
+	Webhooks LifecycleWebhooksSender
+	Logger   logging.SimpleLogging
+}
+
+var _ locking.LockListener = (*LockWebhookNotifier)(nil)
+
+func (l *LockWebhookNotifier) LockAcquired(lock models.ProjectLock) {
+	l.send(newLockLifecycleEvent(webhooks.LockAcquiredEvent, lock))
+}
+
+func (l *LockWebhookNotifier) LockReleased(lock models.ProjectLock) {
+	l.send(newLockLifecycleEvent(webhooks.LockReleasedEvent, lock))
+}
+
+func (l *LockWebhookNotifier) send(event webhooks.LifecycleEvent) {
+	if err := l.Webhooks.SendLifecycle(l.Logger, event); err != nil {
+		l.Logger.Warn("%s", err)
+	}
+}
+
+func newLockLifecycleEvent(event string, lock models.ProjectLock) webhooks.LifecycleEvent {
+	e := newPullLifecycleEvent(event, lock.Pull, lock.User)
+	if e.Repository == "" {
+		e.Repository = lock.Project.RepoFullName
+	}
+	e.ProjectName = lock.Project.ProjectName
+	e.Path = lock.Project.Path
+	e.Workspace = lock.Workspace
+	return e
+}
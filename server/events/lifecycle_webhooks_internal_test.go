@@ -0,0 +1,180 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+// recordingLifecycleWebhooks records the events it's asked to send.
+type recordingLifecycleWebhooks struct {
+	events []webhooks.LifecycleEvent
+}
+
+func (r *recordingLifecycleWebhooks) SendLifecycle(_ logging.SimpleLogging, event webhooks.LifecycleEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func lifecycleTestProjectContext() command.ProjectContext {
+	return command.ProjectContext{
+		Pull: models.PullRequest{
+			Num:        1,
+			URL:        "https://github.com/owner/repo/pull/1",
+			BaseBranch: "main",
+			HeadCommit: "abc123",
+			BaseRepo:   models.Repo{FullName: "owner/repo"},
+		},
+		User:        models.User{Username: "user"},
+		ProjectName: "vpc",
+		RepoRelDir:  "modules/vpc",
+		Workspace:   "default",
+	}
+}
+
+func TestNewPlanFinishedEvent_Success(t *testing.T) {
+	ctx := lifecycleTestProjectContext()
+	event := newPlanFinishedEvent(ctx, command.ProjectCommandOutput{
+		PlanSuccess: &models.PlanSuccess{
+			TerraformOutput: "Plan: 1 to add, 2 to change, 3 to destroy.",
+		},
+	})
+	Equals(t, webhooks.PlanFinishedEvent, event.Event)
+	Equals(t, "owner/repo", event.Repository)
+	Equals(t, 1, event.PullNum)
+	Equals(t, "main", event.BaseBranch)
+	Equals(t, "user", event.User)
+	Equals(t, "vpc", event.ProjectName)
+	Equals(t, "modules/vpc", event.Path)
+	Equals(t, "default", event.Workspace)
+	Equals(t, true, *event.Success)
+	Equals(t, "", event.Error)
+	Equals(t, &webhooks.PlanStats{Add: 1, Change: 2, Destroy: 3, Changes: true}, event.PlanStats)
+}
+
+func TestNewPlanFinishedEvent_Failure(t *testing.T) {
+	ctx := lifecycleTestProjectContext()
+	event := newPlanFinishedEvent(ctx, command.ProjectCommandOutput{Error: errors.New("init failed")})
+	Equals(t, false, *event.Success)
+	Equals(t, "init failed", event.Error)
+	Assert(t, event.PlanStats == nil, "expected no plan stats")
+
+	event = newPlanFinishedEvent(ctx, command.ProjectCommandOutput{Failure: "locked by #2"})
+	Equals(t, false, *event.Success)
+	Equals(t, "locked by #2", event.Error)
+}
+
+func TestNewPolicyCheckEvent(t *testing.T) {
+	ctx := lifecycleTestProjectContext()
+	cases := map[string]struct {
+		out      command.ProjectCommandOutput
+		expOK    bool
+		expEvent string
+		expError string
+	}{
+		"not evaluated": {
+			out:   command.ProjectCommandOutput{},
+			expOK: false,
+		},
+		"passed": {
+			out: command.ProjectCommandOutput{PolicyCheckResults: &models.PolicyCheckResults{
+				PolicySetResults: []models.PolicySetResult{{PolicySetName: "policies", Passed: true}},
+			}},
+			expOK:    true,
+			expEvent: webhooks.PolicyCheckPassedEvent,
+		},
+		"failed": {
+			out: command.ProjectCommandOutput{
+				PolicyCheckResults: &models.PolicyCheckResults{
+					PolicySetResults: []models.PolicySetResult{{PolicySetName: "policies", Passed: false, ReqApprovalCount: 1}},
+				},
+				Failure: "Some policy sets did not pass.",
+			},
+			expOK:    true,
+			expEvent: webhooks.PolicyCheckFailedEvent,
+			expError: "Some policy sets did not pass.",
+		},
+		"errored": {
+			out:      command.ProjectCommandOutput{Error: errors.New("conftest not found")},
+			expOK:    true,
+			expEvent: webhooks.PolicyCheckFailedEvent,
+			expError: "conftest not found",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			event, ok := newPolicyCheckEvent(ctx, c.out)
+			Equals(t, c.expOK, ok)
+			if !ok {
+				return
+			}
+			Equals(t, c.expEvent, event.Event)
+			Equals(t, c.expError, event.Error)
+			if c.out.PolicyCheckResults != nil {
+				Equals(t, []webhooks.PolicySetResult{{Name: "policies", Passed: c.out.PolicyCheckResults.PolicySetResults[0].Passed}}, event.PolicySets)
+			}
+		})
+	}
+}
+
+func TestLockWebhookNotifier(t *testing.T) {
+	sender := &recordingLifecycleWebhooks{}
+	notifier := LockWebhookNotifier{Webhooks: sender, Logger: logging.NewNoopLogger(t)}
+	lock := models.ProjectLock{
+		Project:   models.NewProject("owner/repo", "modules/vpc", "vpc"),
+		Workspace: "default",
+		User:      models.User{Username: "user"},
+		Pull:      models.PullRequest{Num: 1, BaseRepo: models.Repo{FullName: "owner/repo"}},
+	}
+	notifier.LockAcquired(lock)
+	notifier.LockReleased(lock)
+
+	Equals(t, 2, len(sender.events))
+	Equals(t, webhooks.LockAcquiredEvent, sender.events[0].Event)
+	Equals(t, webhooks.LockReleasedEvent, sender.events[1].Event)
+	for _, event := range sender.events {
+		Equals(t, "owner/repo", event.Repository)
+		Equals(t, "vpc", event.ProjectName)
+		Equals(t, "modules/vpc", event.Path)
+		Equals(t, "default", event.Workspace)
+		Equals(t, "user", event.User)
+	}
+}
+
+func TestDefaultProjectCommandRunner_SendLifecycleWebhook(t *testing.T) {
+	sender := &recordingLifecycleWebhooks{}
+	runner := DefaultProjectCommandRunner{LifecycleWebhooks: sender}
+	ctx := lifecycleTestProjectContext()
+	ctx.Log = logging.NewNoopLogger(t)
+
+	runner.sendLifecycleWebhook(ctx, newProjectLifecycleEvent(webhooks.PlanStartedEvent, ctx))
+	Equals(t, 1, len(sender.events))
+
+	// Synthetic API workflows don't send lifecycle webhooks.
+	ctx.SuppressApplyWebhooks = true
+	runner.sendLifecycleWebhook(ctx, newProjectLifecycleEvent(webhooks.PlanStartedEvent, ctx))
+	Equals(t, 1, len(sender.events))
+}
+
+func TestDefaultCommandRunner_SendAutoplanSkipped(t *testing.T) {
+	sender := &recordingLifecycleWebhooks{}
+	runner := DefaultCommandRunner{LifecycleWebhooks: sender}
+	pull := models.PullRequest{Num: 1, BaseBranch: "main", BaseRepo: models.Repo{FullName: "owner/repo"}}
+
+	runner.sendAutoplanSkipped(logging.NewNoopLogger(t), pull, models.User{Username: "user"}, autoplanSkippedLabel)
+	Equals(t, 1, len(sender.events))
+	Equals(t, webhooks.AutoplanSkippedEvent, sender.events[0].Event)
+	Equals(t, autoplanSkippedLabel, sender.events[0].Reason)
+	Equals(t, "", sender.events[0].Workspace)
+
+	// A runner without lifecycle webhooks doesn't panic.
+	(&DefaultCommandRunner{}).sendAutoplanSkipped(logging.NewNoopLogger(t), pull, models.User{}, autoplanSkippedDisabled)
+}
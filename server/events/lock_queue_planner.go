@@ -0,0 +1,45 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// LockQueuePlanner implements locking.LockQueueHandler by running plan for
+// the project on the next pull request waiting for its lock, as if
+// `atlantis plan` had been commented on it by the user that was blocked.
+type LockQueuePlanner struct {
+	// CommandRunner is set after construction because the command runner
+	// depends on the locker that depends on the planner.
+	CommandRunner CommandRunner
+	Logger        logging.SimpleLogging
+}
+
+// LockReleased starts a plan for next in the background, so the caller that
+// released the lock isn't blocked by it.
+func (l *LockQueuePlanner) LockReleased(lock models.ProjectLock, next models.LockWaiter) {
+	if l.CommandRunner == nil {
+		return
+	}
+	// Comments can target a project by name or by dir and workspace, but not
+	// both.
+	cmd := NewCommentCommand(next.Project.Path, nil, command.Plan, "", false, false, "", next.Workspace, "", "", false)
+	if next.Project.ProjectName != "" {
+		cmd = NewCommentCommand("", nil, command.Plan, "", false, false, "", "", next.Project.ProjectName, "", false)
+	}
+	l.Logger.Info("planning %s#%d for %s now that %s#%d released its lock",
+		next.Pull.BaseRepo.FullName, next.Pull.Num, models.GenerateLockKey(next.Project, next.Workspace), lock.Pull.BaseRepo.FullName, lock.Pull.Num)
+	// The pull request and head repo are fetched again for every VCS host
+	// except Bitbucket, which uses the pull request as it was queued and
+	// assumes it isn't from a fork.
+	pull := next.Pull
+	headRepo := next.Pull.BaseRepo
+	go l.CommandRunner.RunCommentCommand(next.Pull.BaseRepo, &headRepo, &pull, next.User, next.Pull.Num, cmd)
+}
+
+var _ locking.LockQueueHandler = (*LockQueuePlanner)(nil)
@@ -0,0 +1,71 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+type commentCommandCall struct {
+	pullNum int
+	user    models.User
+	cmd     *events.CommentCommand
+}
+
+type fakeCommentCommandRunner struct {
+	calls chan commentCommandCall
+}
+
+func (f *fakeCommentCommandRunner) RunCommentCommand(_ models.Repo, _ *models.Repo, _ *models.PullRequest, user models.User, pullNum int, cmd *events.CommentCommand) {
+	f.calls <- commentCommandCall{pullNum: pullNum, user: user, cmd: cmd}
+}
+
+func (f *fakeCommentCommandRunner) RunAutoplanCommand(models.Repo, models.Repo, models.PullRequest, models.User) {
+}
+
+func TestLockQueuePlanner_LockReleased(t *testing.T) {
+	repo := models.Repo{FullName: "owner/repo"}
+	cases := []struct {
+		description string
+		project     models.Project
+		expCmd      *events.CommentCommand
+	}{
+		{
+			description: "project without a name is planned by dir and workspace",
+			project:     models.NewProject("owner/repo", "modules/vpc", ""),
+			expCmd:      &events.CommentCommand{Name: command.Plan, RepoRelDir: "modules/vpc", Workspace: "staging"},
+		},
+		{
+			description: "named project is planned by name",
+			project:     models.NewProject("owner/repo", "modules/vpc", "vpc"),
+			expCmd:      &events.CommentCommand{Name: command.Plan, ProjectName: "vpc"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			runner := &fakeCommentCommandRunner{calls: make(chan commentCommandCall, 1)}
+			planner := &events.LockQueuePlanner{CommandRunner: runner, Logger: logging.NewNoopLogger(t)}
+			user := models.User{Username: "waiting-user"}
+			planner.LockReleased(
+				models.ProjectLock{Project: c.project, Workspace: "staging", Pull: models.PullRequest{Num: 1, BaseRepo: repo}},
+				models.LockWaiter{Project: c.project, Workspace: "staging", Pull: models.PullRequest{Num: 2, BaseRepo: repo}, User: user},
+			)
+			select {
+			case call := <-runner.calls:
+				Equals(t, 2, call.pullNum)
+				Equals(t, user, call.user)
+				Equals(t, c.expCmd, call.cmd)
+			case <-time.After(5 * time.Second):
+				t.Fatal("plan was not run for the waiting pull request")
+			}
+		})
+	}
+}
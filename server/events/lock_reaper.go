@@ -0,0 +1,122 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/core/locking"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/events/vcs"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/logging"
+	tally "github.com/uber-go/tally/v4"
+)
+
+const (
+	// LocksExpiredMetric counts locks released by the LockReaper.
+	LocksExpiredMetric = "locks_expired"
+	// LockReaperPeriod is how often the LockReaper looks for expired locks,
+	// so locks are released up to this long after they expire.
+	LockReaperPeriod = 10 * time.Minute
+	// LockReaperJobName names the LockReaper's job so only one replica runs
+	// it with distributed execution.
+	LockReaperJobName = "lock-reaper"
+)
+
+// LockReaper releases locks that were held for longer than the lock TTL
+// configured for their repo in the server-side repo config. It deletes the
+// lock's plan, comments on the pull request that held it and sends
+// lock_expired webhooks. It is registered with the scheduled executor
+// service.
+type LockReaper struct {
+	Locker         locking.Locker
+	WorkingDir     WorkingDir
+	PlanStore      planstore.PlanStore
+	GlobalCfg      valid.GlobalCfg
+	VCSClient      vcs.Client
+	WebhookSender  webhooks.LockExpiredSender
+	ExecutableName string
+	Scope          tally.Scope
+	Logger         logging.SimpleLogging
+}
+
+// Run releases every expired lock once.
+func (r *LockReaper) Run() {
+	locks, err := r.Locker.List()
+	if err != nil {
+		r.Logger.Err("listing locks to find expired locks: %s", err)
+		return
+	}
+	now := time.Now()
+	for key, lock := range locks {
+		ttl := r.GlobalCfg.LockTTL(lock.Pull.BaseRepo.ID())
+		if ttl <= 0 || now.Sub(lock.Time) < ttl {
+			continue
+		}
+		if err := r.expire(key, lock, ttl, now); err != nil {
+			r.Logger.Warn("releasing expired lock %q: %s", key, err)
+		}
+	}
+}
+
+func (r *LockReaper) expire(key string, lock models.ProjectLock, ttl time.Duration, now time.Time) error {
+	// The lock may have been released, and taken by another pull request,
+	// since it was listed.
+	released, err := r.Locker.UnlockIfOwnedByPull(lock.Project, lock.Workspace, lock.Pull.Num)
+	if err != nil {
+		return err
+	}
+	if released == nil {
+		return nil
+	}
+	r.Logger.Info("released lock %q held by %s#%d since %s, which expired after %s", key, lock.Pull.BaseRepo.FullName, lock.Pull.Num, lock.Time.Format(time.RFC3339), ttl)
+	if r.Scope != nil {
+		r.Scope.Tagged(map[string]string{"repo": lock.Pull.BaseRepo.FullName}).Counter(LocksExpiredMetric).Inc(1)
+	}
+
+	if err := r.WorkingDir.DeletePlan(r.Logger, lock.Pull.BaseRepo, lock.Pull, lock.Workspace, lock.Project.Path, lock.Project.ProjectName); err != nil {
+		r.Logger.Warn("deleting plan for expired lock %q: %s", key, err)
+	}
+	if r.PlanStore != nil {
+		if err := r.PlanStore.DeletePlanForProject(lock.Pull.BaseRepo.Owner, lock.Pull.BaseRepo.Name, lock.Pull.Num, lock.Workspace, lock.Project.Path, lock.Project.ProjectName); err != nil {
+			r.Logger.Warn("deleting plan for expired lock %q from external store: %s", key, err)
+		}
+	}
+
+	comment := fmt.Sprintf("The lock on %s in workspace `%s` was held for longer than %s and has expired, so it was released and its plan was deleted.\n\nComment `%s plan` to plan again.",
+		lockedProjectDescription(lock.Project), lock.Workspace, ttl, r.ExecutableName)
+	if err := r.VCSClient.CreateComment(r.Logger, lock.Pull.BaseRepo, lock.Pull.Num, comment, ""); err != nil {
+		r.Logger.Warn("commenting on %s#%d that its lock expired: %s", lock.Pull.BaseRepo.FullName, lock.Pull.Num, err)
+	}
+
+	if r.WebhookSender != nil {
+		result := webhooks.LockExpiredResult{
+			Repository:  lock.Pull.BaseRepo.FullName,
+			PullNum:     lock.Pull.Num,
+			PullURL:     lock.Pull.URL,
+			ProjectName: lock.Project.ProjectName,
+			Path:        lock.Project.Path,
+			Workspace:   lock.Workspace,
+			LockedBy:    lock.User.Username,
+			LockedAt:    lock.Time,
+			ExpiredAt:   now,
+			TTL:         ttl.String(),
+		}
+		if err := r.WebhookSender.Send(r.Logger, result); err != nil {
+			r.Logger.Warn("sending lock_expired webhook: %s", err)
+		}
+	}
+	return nil
+}
+
+func lockedProjectDescription(project models.Project) string {
+	if project.ProjectName != "" {
+		return fmt.Sprintf("project `%s`", project.ProjectName)
+	}
+	return fmt.Sprintf("dir `%s`", project.Path)
+}
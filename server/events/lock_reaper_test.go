@@ -0,0 +1,176 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/core/config/valid"
+	lockmocks "github.com/runatlantis/atlantis/server/core/locking/mocks"
+	"github.com/runatlantis/atlantis/server/events"
+	"github.com/runatlantis/atlantis/server/events/command"
+	"github.com/runatlantis/atlantis/server/events/mocks"
+	"github.com/runatlantis/atlantis/server/events/models"
+	vcsmocks "github.com/runatlantis/atlantis/server/events/vcs/mocks"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	webhookmocks "github.com/runatlantis/atlantis/server/events/webhooks/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"go.uber.org/mock/gomock"
+)
+
+type deletedProjectPlan struct {
+	owner, repo           string
+	pullNum               int
+	workspace, path, name string
+}
+
+type projectPlanDeletingStore struct {
+	deleted []deletedProjectPlan
+}
+
+func (s *projectPlanDeletingStore) Save(command.ProjectContext, string) error   { return nil }
+func (s *projectPlanDeletingStore) Load(command.ProjectContext, string) error   { return nil }
+func (s *projectPlanDeletingStore) Remove(command.ProjectContext, string) error { return nil }
+func (s *projectPlanDeletingStore) ListWorkspaces(string, string, int) ([]string, error) {
+	return nil, nil
+}
+func (s *projectPlanDeletingStore) RestorePlans(string, string, string, int) error { return nil }
+func (s *projectPlanDeletingStore) DeleteForPull(string, string, int) error        { return nil }
+func (s *projectPlanDeletingStore) DeletePlanForProject(owner, repo string, pullNum int, workspace, path, name string) error {
+	s.deleted = append(s.deleted, deletedProjectPlan{owner, repo, pullNum, workspace, path, name})
+	return nil
+}
+
+func TestLockReaper_Run(t *testing.T) {
+	RegisterMockTestingT(t)
+	ctrl := gomock.NewController(t)
+	logger := logging.NewNoopLogger(t)
+
+	repo := models.Repo{
+		FullName: "owner/repo",
+		Owner:    "owner",
+		Name:     "repo",
+		VCSHost:  models.VCSHost{Hostname: "github.com", Type: models.Github},
+	}
+	otherRepo := models.Repo{
+		FullName: "owner/other",
+		Owner:    "owner",
+		Name:     "other",
+		VCSHost:  models.VCSHost{Hostname: "github.com", Type: models.Github},
+	}
+	pull := models.PullRequest{Num: 1, BaseRepo: repo, URL: "https://github.com/owner/repo/pull/1"}
+	expired := models.ProjectLock{
+		Project:   models.NewProject("owner/repo", "modules/vpc", "vpc"),
+		Workspace: "default",
+		Pull:      pull,
+		User:      models.User{Username: "lkysow"},
+		Time:      time.Now().Add(-25 * time.Hour),
+	}
+	fresh := models.ProjectLock{
+		Project:   models.NewProject("owner/repo", "modules/ec2", ""),
+		Workspace: "default",
+		Pull:      models.PullRequest{Num: 2, BaseRepo: repo},
+		Time:      time.Now().Add(-time.Hour),
+	}
+	noTTL := models.ProjectLock{
+		Project:   models.NewProject("owner/other", ".", ""),
+		Workspace: "default",
+		Pull:      models.PullRequest{Num: 3, BaseRepo: otherRepo},
+		Time:      time.Now().Add(-100 * time.Hour),
+	}
+
+	locker := lockmocks.NewMockLocker(ctrl)
+	locker.EXPECT().List().Return(map[string]models.ProjectLock{
+		"owner/repo/modules/vpc/default": expired,
+		"owner/repo/modules/ec2/default": fresh,
+		"owner/other/./default":          noTTL,
+	}, nil)
+	locker.EXPECT().UnlockIfOwnedByPull(expired.Project, "default", 1).Return(&expired, nil)
+
+	workingDir := mocks.NewMockWorkingDir()
+	vcsClient := vcsmocks.NewMockClient()
+	sender := webhookmocks.NewMockLockExpiredSender()
+	planStore := &projectPlanDeletingStore{}
+
+	reaper := &events.LockReaper{
+		Locker:     locker,
+		WorkingDir: workingDir,
+		PlanStore:  planStore,
+		GlobalCfg: valid.GlobalCfg{
+			Repos: []valid.Repo{
+				{
+					ID:        "github.com/owner/repo",
+					RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnPlanMode, TTL: 24 * time.Hour},
+				},
+				{
+					IDRegex:   regexp.MustCompile("other"),
+					RepoLocks: &valid.RepoLocks{Mode: valid.RepoLocksOnPlanMode},
+				},
+			},
+		},
+		VCSClient:      vcsClient,
+		WebhookSender:  sender,
+		ExecutableName: "atlantis",
+		Logger:         logger,
+	}
+	reaper.Run()
+
+	workingDir.VerifyWasCalledOnce().DeletePlan(logger, repo, pull, "default", "modules/vpc", "vpc")
+	Equals(t, []deletedProjectPlan{{"owner", "repo", 1, "default", "modules/vpc", "vpc"}}, planStore.deleted)
+
+	_, _, pullNum, comment, _ := vcsClient.VerifyWasCalledOnce().CreateComment(
+		Any[logging.SimpleLogging](), Eq(repo), Any[int](), Any[string](), Any[string]()).GetCapturedArguments()
+	Equals(t, 1, pullNum)
+	Assert(t, strings.Contains(comment, "project `vpc`"), "got comment: %s", comment)
+	Assert(t, strings.Contains(comment, "`atlantis plan`"), "got comment: %s", comment)
+
+	_, sent := sender.VerifyWasCalledOnce().Send(Any[logging.SimpleLogging](), Any[webhooks.LockExpiredResult]()).GetCapturedArguments()
+	Equals(t, "owner/repo", sent.Repository)
+	Equals(t, 1, sent.PullNum)
+	Equals(t, "vpc", sent.ProjectName)
+	Equals(t, "lkysow", sent.LockedBy)
+	Equals(t, "24h0m0s", sent.TTL)
+}
+
+func TestLockReaper_RunLockTakenByAnotherPull(t *testing.T) {
+	RegisterMockTestingT(t)
+	ctrl := gomock.NewController(t)
+	logger := logging.NewNoopLogger(t)
+
+	repo := models.Repo{FullName: "owner/repo", VCSHost: models.VCSHost{Hostname: "github.com"}}
+	lock := models.ProjectLock{
+		Project:   models.NewProject("owner/repo", ".", ""),
+		Workspace: "default",
+		Pull:      models.PullRequest{Num: 1, BaseRepo: repo},
+		Time:      time.Now().Add(-2 * time.Hour),
+	}
+	locker := lockmocks.NewMockLocker(ctrl)
+	locker.EXPECT().List().Return(map[string]models.ProjectLock{"owner/repo/./default": lock}, nil)
+	// The lock was released and taken by another pull request after it was
+	// listed, so nothing is unlocked.
+	locker.EXPECT().UnlockIfOwnedByPull(lock.Project, "default", 1).Return(nil, nil)
+
+	workingDir := mocks.NewMockWorkingDir()
+	vcsClient := vcsmocks.NewMockClient()
+	reaper := &events.LockReaper{
+		Locker:     locker,
+		WorkingDir: workingDir,
+		GlobalCfg: valid.GlobalCfg{
+			Repos: []valid.Repo{{IDRegex: regexp.MustCompile(".*"), RepoLocks: &valid.RepoLocks{TTL: time.Hour}}},
+		},
+		VCSClient: vcsClient,
+		Logger:    logger,
+	}
+	reaper.Run()
+
+	workingDir.VerifyWasCalled(Never()).DeletePlan(
+		Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](), Any[string](), Any[string](), Any[string]())
+	vcsClient.VerifyWasCalled(Never()).CreateComment(
+		Any[logging.SimpleLogging](), Any[models.Repo](), Any[int](), Any[string](), Any[string]())
+}
@@ -130,6 +130,20 @@ type policyCheckResultsData struct {
 	commonData
 }
 
+type driftResultData struct {
+	Results []projectResultTmplData
+	commonData
+	Ref                     string
+	NumProjectsWithDrift    int
+	NumProjectsWithoutDrift int
+	NumProjectErrors        int
+}
+
+type driftProjectData struct {
+	models.ProjectDrift
+	Wrapped bool
+}
+
 type projectResultTmplData struct {
 	Workspace    string
 	RepoRelDir   string
@@ -198,8 +212,53 @@ func NewMarkdownRenderer(
 // Render formats the data into a markdown string.
 // nolint: interfacer
 func (m *MarkdownRenderer) Render(ctx *command.Context, res command.Result, cmd PullCommand) string {
+	common := m.newCommonData(ctx, cmd, res.PlansDeleted)
+	templates := m.markdownTemplates
+
+	if res.Error != nil {
+		return m.renderTemplateTrimSpace(templates.Lookup("unwrappedErrWithLog"), newErrData(res.Error, "", common))
+	}
+	if res.Failure != "" {
+		return m.renderTemplateTrimSpace(templates.Lookup("failureWithLog"), failureData{res.Failure, "", common})
+	}
+	return m.renderProjectResults(ctx, res.ProjectResults, common)
+}
+
+// RenderDrift formats the result of drift detection on ref, run by the drift
+// comment command, into a markdown string.
+func (m *MarkdownRenderer) RenderDrift(ctx *command.Context, cmd PullCommand, ref string, res *models.DriftDetectionResult, err error) string {
+	common := m.newCommonData(ctx, cmd, false)
+	templates := m.markdownTemplates
+
+	if err != nil {
+		return m.renderTemplateTrimSpace(templates.Lookup("unwrappedErrWithLog"), newErrData(err, "", common))
+	}
+
+	data := driftResultData{Ref: ref, commonData: common}
+	for _, project := range res.Projects {
+		switch {
+		case project.Error != "":
+			data.NumProjectErrors++
+		case project.Drift.HasDrift:
+			data.NumProjectsWithDrift++
+		default:
+			data.NumProjectsWithoutDrift++
+		}
+		project.PlanOutput = strings.TrimSpace(project.PlanOutput)
+		data.Results = append(data.Results, projectResultTmplData{
+			Workspace:    project.Workspace,
+			RepoRelDir:   project.Path,
+			ProjectName:  project.ProjectName,
+			Rendered:     m.renderTemplateTrimSpace(templates.Lookup("driftProjectResult"), driftProjectData{project, m.shouldUseWrappedTmpl(ctx.Pull.BaseRepo.VCSHost.Type, project.PlanOutput)}),
+			NoChanges:    !project.Drift.HasDrift,
+			IsSuccessful: project.Error == "",
+		})
+	}
+	return m.renderTemplateTrimSpace(templates.Lookup("drift"), data)
+}
+
+func (m *MarkdownRenderer) newCommonData(ctx *command.Context, cmd PullCommand, plansDeleted bool) commonData {
 	commandNameStr := cmd.CommandName().String()
-	commandStr := m.translator.CommandTitle(commandNameStr)
 	var vcsRequestType string
 	if ctx.Pull.BaseRepo.VCSHost.Type == models.Gitlab {
 		vcsRequestType = m.translator.MergeRequestLabel()
@@ -207,13 +266,13 @@ func (m *MarkdownRenderer) Render(ctx *command.Context, res command.Result, cmd
 		vcsRequestType = m.translator.PullRequestLabel()
 	}
 
-	common := commonData{
-		Command:                   commandStr,
+	return commonData{
+		Command:                   m.translator.CommandTitle(commandNameStr),
 		CommandName:               commandNameStr,
 		SubCommand:                cmd.SubCommandName(),
 		Verbose:                   cmd.IsVerbose(),
 		Log:                       ctx.Log.GetHistory(),
-		PlansDeleted:              res.PlansDeleted,
+		PlansDeleted:              plansDeleted,
 		DisableApplyAll:           m.disableApplyAll || m.disableApply,
 		DisableApply:              m.disableApply,
 		DisableRepoLocking:        m.disableRepoLocking,
@@ -223,16 +282,6 @@ func (m *MarkdownRenderer) Render(ctx *command.Context, res command.Result, cmd
 		QuietPolicyChecks:         m.quietPolicyChecks,
 		VcsRequestType:            vcsRequestType,
 	}
-
-	templates := m.markdownTemplates
-
-	if res.Error != nil {
-		return m.renderTemplateTrimSpace(templates.Lookup("unwrappedErrWithLog"), newErrData(res.Error, "", common))
-	}
-	if res.Failure != "" {
-		return m.renderTemplateTrimSpace(templates.Lookup("failureWithLog"), failureData{res.Failure, "", common})
-	}
-	return m.renderProjectResults(ctx, res.ProjectResults, common)
 }
 
 func (m *MarkdownRenderer) renderProjectResults(ctx *command.Context, results []command.ProjectResult, common commonData) string {
@@ -351,7 +400,15 @@ func (m *MarkdownRenderer) renderProjectResults(ctx *command.Context, results []
 			resultData.Rendered = "Found no template. This is a bug!"
 		}
 		// Render error or failure templates. Done outside of previous block so that other context can be rendered for use here.
-		if result.Error != nil {
+		var timeoutErr *command.TimeoutError
+		if errors.As(result.Error, &timeoutErr) {
+			// The output of a command that timed out is mostly noise, the
+			// full log is still available from the job URL.
+			resultData.Rendered = m.renderTemplateTrimSpace(templates.Lookup("failure"), failureData{timeoutErr.Error() + " and was terminated.", resultData.Rendered, common})
+			if common.CommandName == applyCommandTitle {
+				numApplyErrors++
+			}
+		} else if result.Error != nil {
 			tmpl := templates.Lookup("unwrappedErr")
 			if m.shouldUseWrappedTmpl(vcsHost, result.Error.Error()) {
 				tmpl = templates.Lookup("wrappedErr")
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/events"
 	"github.com/runatlantis/atlantis/server/events/command"
@@ -1372,6 +1373,26 @@ $$$
 Ran Plan for dir: $path$ workspace: $workspace$
 
 **Plan Failed**: failure
+`,
+		},
+		{
+			"single timed out plan",
+			command.Plan,
+			"",
+			[]command.ProjectResult{
+				{
+					RepoRelDir: "path",
+					Workspace:  "workspace",
+					ProjectCommandOutput: command.ProjectCommandOutput{
+						Error: fmt.Errorf("running 'sh' '-c' 'terraform plan' in 'path': %w", &command.TimeoutError{Timeout: 30 * time.Minute}),
+					},
+				},
+			},
+			models.Github,
+			`
+Ran Plan for dir: $path$ workspace: $workspace$
+
+**Plan Failed**: timed out after 30m0s and was terminated.
 `,
 		},
 		{
@@ -5146,3 +5167,80 @@ Ran Plan for 3 projects:
 		})
 	}
 }
+
+func TestRenderDrift(t *testing.T) {
+	r := events.NewMarkdownRenderer(
+		false,      // gitlabSupportsCommonMark
+		false,      // disableApplyAll
+		false,      // disableApply
+		false,      // disableMarkdownFolding
+		false,      // disableRepoLocking
+		false,      // enableDiffMarkdownFormat
+		"",         // markdownTemplateOverridesDir
+		"atlantis", // executableName
+		false,      // hideUnchangedPlanComments
+		false,      // quietPolicyChecks
+	)
+	logger := logging.NewNoopLogger(t).WithHistory()
+	ctx := &command.Context{
+		Log: logger,
+		Pull: models.PullRequest{
+			BaseRepo: models.Repo{
+				VCSHost: models.VCSHost{
+					Type: models.Github,
+				},
+			},
+		},
+	}
+	cmd := &events.CommentCommand{Name: command.Drift}
+
+	t.Run("results", func(t *testing.T) {
+		res := &models.DriftDetectionResult{
+			Projects: []models.ProjectDrift{
+				{
+					ProjectName: "app",
+					Path:        "app",
+					Workspace:   "default",
+					Drift:       models.DriftSummary{HasDrift: true, Summary: "Objects have changed outside of Terraform"},
+					PlanOutput:  "~ resource changed\n",
+				},
+				{Path: "db", Workspace: "default"},
+				{Path: "broken", Workspace: "default", Error: "init failed"},
+			},
+		}
+		s := r.RenderDrift(ctx, cmd, "main", res, nil)
+		Equals(t, normalize(`Ran Drift on `+"`main`"+` for 3 projects:
+
+1. project: `+"`app`"+` dir: `+"`app`"+` workspace: `+"`default`"+`
+1. dir: `+"`db`"+` workspace: `+"`default`"+`
+1. dir: `+"`broken`"+` workspace: `+"`default`"+`
+---
+
+### 1. project: `+"`app`"+` dir: `+"`app`"+` workspace: `+"`default`"+`
+:warning: **Drift detected**: Objects have changed outside of Terraform
+`+"```diff"+`
+~ resource changed
+`+"```"+`
+
+---
+### 2. dir: `+"`db`"+` workspace: `+"`default`"+`
+:white_check_mark: No drift detected.
+
+---
+### 3. dir: `+"`broken`"+` workspace: `+"`default`"+`
+**Drift Detection Error**
+`+"```"+`
+init failed
+`+"```"+`
+
+---
+### Drift Summary
+
+3 projects, 1 with drift, 1 without drift, 1 failed`), normalize(s))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := r.RenderDrift(ctx, cmd, "main", nil, errors.New("repository is not in the allowlist"))
+		Equals(t, "**Drift Error**\n```\nrepository is not in the allowlist\n```", normalize(s))
+	})
+}
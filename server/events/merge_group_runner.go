@@ -0,0 +1,19 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/runatlantis/atlantis/server/events/models"
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+// MergeGroupRunner runs Atlantis against the temporary branch of a GitHub
+// merge queue group and reports the plan and apply statuses on its head
+// commit. It is implemented by the API controller so merge groups use the
+// same non-PR ref checkout as API requests.
+//
+//go:generate go tool pegomock generate github.com/runatlantis/atlantis/server/events --package mocks -o mocks/mock_merge_group_runner.go MergeGroupRunner
+type MergeGroupRunner interface {
+	RunMergeGroup(logger logging.SimpleLogging, mergeGroup models.MergeGroup)
+}
@@ -0,0 +1,181 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events (interfaces: AutoplanRegistry)
+
+package events
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	models "github.com/runatlantis/atlantis/server/events/models"
+	"reflect"
+	"time"
+)
+
+type MockAutoplanRegistry struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockAutoplanRegistry(options ...pegomock.Option) *MockAutoplanRegistry {
+	mock := &MockAutoplanRegistry{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockAutoplanRegistry) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockAutoplanRegistry) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockAutoplanRegistry) Finish(pull models.PullRequest) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockAutoplanRegistry().")
+	}
+	_params := []pegomock.Param{pull}
+	pegomock.GetGenericMockFrom(mock).Invoke("Finish", _params, []reflect.Type{})
+}
+
+func (mock *MockAutoplanRegistry) Latest(pull models.PullRequest) string {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockAutoplanRegistry().")
+	}
+	_params := []pegomock.Param{pull}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("Latest", _params, []reflect.Type{reflect.TypeOf((*string)(nil)).Elem()})
+	var _ret0 string
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(string)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockAutoplanRegistry) Start(pull models.PullRequest) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockAutoplanRegistry().")
+	}
+	_params := []pegomock.Param{pull}
+	pegomock.GetGenericMockFrom(mock).Invoke("Start", _params, []reflect.Type{})
+}
+
+func (mock *MockAutoplanRegistry) VerifyWasCalledOnce() *VerifierMockAutoplanRegistry {
+	return &VerifierMockAutoplanRegistry{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockAutoplanRegistry) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockAutoplanRegistry {
+	return &VerifierMockAutoplanRegistry{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockAutoplanRegistry) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockAutoplanRegistry {
+	return &VerifierMockAutoplanRegistry{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockAutoplanRegistry) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockAutoplanRegistry {
+	return &VerifierMockAutoplanRegistry{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockAutoplanRegistry struct {
+	mock                   *MockAutoplanRegistry
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockAutoplanRegistry) Finish(pull models.PullRequest) *MockAutoplanRegistry_Finish_OngoingVerification {
+	_params := []pegomock.Param{pull}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Finish", _params, verifier.timeout)
+	return &MockAutoplanRegistry_Finish_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockAutoplanRegistry_Finish_OngoingVerification struct {
+	mock              *MockAutoplanRegistry
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockAutoplanRegistry_Finish_OngoingVerification) GetCapturedArguments() models.PullRequest {
+	pull := c.GetAllCapturedArguments()
+	return pull[len(pull)-1]
+}
+
+func (c *MockAutoplanRegistry_Finish_OngoingVerification) GetAllCapturedArguments() (_param0 []models.PullRequest) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]models.PullRequest, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(models.PullRequest)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockAutoplanRegistry) Latest(pull models.PullRequest) *MockAutoplanRegistry_Latest_OngoingVerification {
+	_params := []pegomock.Param{pull}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Latest", _params, verifier.timeout)
+	return &MockAutoplanRegistry_Latest_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockAutoplanRegistry_Latest_OngoingVerification struct {
+	mock              *MockAutoplanRegistry
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockAutoplanRegistry_Latest_OngoingVerification) GetCapturedArguments() models.PullRequest {
+	pull := c.GetAllCapturedArguments()
+	return pull[len(pull)-1]
+}
+
+func (c *MockAutoplanRegistry_Latest_OngoingVerification) GetAllCapturedArguments() (_param0 []models.PullRequest) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]models.PullRequest, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(models.PullRequest)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockAutoplanRegistry) Start(pull models.PullRequest) *MockAutoplanRegistry_Start_OngoingVerification {
+	_params := []pegomock.Param{pull}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Start", _params, verifier.timeout)
+	return &MockAutoplanRegistry_Start_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockAutoplanRegistry_Start_OngoingVerification struct {
+	mock              *MockAutoplanRegistry
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockAutoplanRegistry_Start_OngoingVerification) GetCapturedArguments() models.PullRequest {
+	pull := c.GetAllCapturedArguments()
+	return pull[len(pull)-1]
+}
+
+func (c *MockAutoplanRegistry_Start_OngoingVerification) GetAllCapturedArguments() (_param0 []models.PullRequest) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]models.PullRequest, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(models.PullRequest)
+			}
+		}
+	}
+	return
+}
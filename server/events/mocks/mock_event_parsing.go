@@ -8,6 +8,7 @@ import (
 	azuredevops "github.com/drmaxgit/go-azuredevops/azuredevops"
 	github "github.com/google/go-github/v88/github"
 	pegomock "github.com/petergtz/pegomock/v4"
+	events "github.com/runatlantis/atlantis/server/events"
 	models "github.com/runatlantis/atlantis/server/events/models"
 	gitea0 "github.com/runatlantis/atlantis/server/events/vcs/gitea"
 	logging "github.com/runatlantis/atlantis/server/logging"
@@ -382,6 +383,37 @@ func (mock *MockEventParsing) ParseGiteaPullRequestEvent(event gitea.PullRequest
 	return _ret0, _ret1, _ret2, _ret3, _ret4, _ret5
 }
 
+func (mock *MockEventParsing) ParseGithubCheckRunEvent(event *github.CheckRunEvent) (models.Repo, models.User, int, *events.CommentCommand, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockEventParsing().")
+	}
+	_params := []pegomock.Param{event}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ParseGithubCheckRunEvent", _params, []reflect.Type{reflect.TypeOf((*models.Repo)(nil)).Elem(), reflect.TypeOf((*models.User)(nil)).Elem(), reflect.TypeOf((*int)(nil)).Elem(), reflect.TypeOf((**events.CommentCommand)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 models.Repo
+	var _ret1 models.User
+	var _ret2 int
+	var _ret3 *events.CommentCommand
+	var _ret4 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(models.Repo)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(models.User)
+		}
+		if _result[2] != nil {
+			_ret2 = _result[2].(int)
+		}
+		if _result[3] != nil {
+			_ret3 = _result[3].(*events.CommentCommand)
+		}
+		if _result[4] != nil {
+			_ret4 = _result[4].(error)
+		}
+	}
+	return _ret0, _ret1, _ret2, _ret3, _ret4
+}
+
 func (mock *MockEventParsing) ParseGithubIssueCommentEvent(logger logging.SimpleLogging, comment *github.IssueCommentEvent) (models.Repo, models.User, int, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockEventParsing().")
@@ -409,6 +441,29 @@ func (mock *MockEventParsing) ParseGithubIssueCommentEvent(logger logging.Simple
 	return _ret0, _ret1, _ret2, _ret3
 }
 
+func (mock *MockEventParsing) ParseGithubMergeGroupEvent(event *github.MergeGroupEvent) (models.MergeGroup, models.User, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockEventParsing().")
+	}
+	_params := []pegomock.Param{event}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("ParseGithubMergeGroupEvent", _params, []reflect.Type{reflect.TypeOf((*models.MergeGroup)(nil)).Elem(), reflect.TypeOf((*models.User)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 models.MergeGroup
+	var _ret1 models.User
+	var _ret2 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(models.MergeGroup)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(models.User)
+		}
+		if _result[2] != nil {
+			_ret2 = _result[2].(error)
+		}
+	}
+	return _ret0, _ret1, _ret2
+}
+
 func (mock *MockEventParsing) ParseGithubPull(logger logging.SimpleLogging, ghPull *github.PullRequest) (models.PullRequest, models.Repo, models.Repo, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockEventParsing().")
@@ -1024,6 +1079,35 @@ func (c *MockEventParsing_ParseGiteaPullRequestEvent_OngoingVerification) GetAll
 	return
 }
 
+func (verifier *VerifierMockEventParsing) ParseGithubCheckRunEvent(event *github.CheckRunEvent) *MockEventParsing_ParseGithubCheckRunEvent_OngoingVerification {
+	_params := []pegomock.Param{event}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ParseGithubCheckRunEvent", _params, verifier.timeout)
+	return &MockEventParsing_ParseGithubCheckRunEvent_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockEventParsing_ParseGithubCheckRunEvent_OngoingVerification struct {
+	mock              *MockEventParsing
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockEventParsing_ParseGithubCheckRunEvent_OngoingVerification) GetCapturedArguments() *github.CheckRunEvent {
+	event := c.GetAllCapturedArguments()
+	return event[len(event)-1]
+}
+
+func (c *MockEventParsing_ParseGithubCheckRunEvent_OngoingVerification) GetAllCapturedArguments() (_param0 []*github.CheckRunEvent) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]*github.CheckRunEvent, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(*github.CheckRunEvent)
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockEventParsing) ParseGithubIssueCommentEvent(logger logging.SimpleLogging, comment *github.IssueCommentEvent) *MockEventParsing_ParseGithubIssueCommentEvent_OngoingVerification {
 	_params := []pegomock.Param{logger, comment}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ParseGithubIssueCommentEvent", _params, verifier.timeout)
@@ -1059,6 +1143,35 @@ func (c *MockEventParsing_ParseGithubIssueCommentEvent_OngoingVerification) GetA
 	return
 }
 
+func (verifier *VerifierMockEventParsing) ParseGithubMergeGroupEvent(event *github.MergeGroupEvent) *MockEventParsing_ParseGithubMergeGroupEvent_OngoingVerification {
+	_params := []pegomock.Param{event}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ParseGithubMergeGroupEvent", _params, verifier.timeout)
+	return &MockEventParsing_ParseGithubMergeGroupEvent_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockEventParsing_ParseGithubMergeGroupEvent_OngoingVerification struct {
+	mock              *MockEventParsing
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockEventParsing_ParseGithubMergeGroupEvent_OngoingVerification) GetCapturedArguments() *github.MergeGroupEvent {
+	event := c.GetAllCapturedArguments()
+	return event[len(event)-1]
+}
+
+func (c *MockEventParsing_ParseGithubMergeGroupEvent_OngoingVerification) GetAllCapturedArguments() (_param0 []*github.MergeGroupEvent) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]*github.MergeGroupEvent, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(*github.MergeGroupEvent)
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockEventParsing) ParseGithubPull(logger logging.SimpleLogging, ghPull *github.PullRequest) *MockEventParsing_ParseGithubPull_OngoingVerification {
 	_params := []pegomock.Param{logger, ghPull}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "ParseGithubPull", _params, verifier.timeout)
@@ -0,0 +1,114 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events (interfaces: LifecycleWebhooksSender)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	webhooks "github.com/runatlantis/atlantis/server/events/webhooks"
+	logging "github.com/runatlantis/atlantis/server/logging"
+	"reflect"
+	"time"
+)
+
+type MockLifecycleWebhooksSender struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockLifecycleWebhooksSender(options ...pegomock.Option) *MockLifecycleWebhooksSender {
+	mock := &MockLifecycleWebhooksSender{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockLifecycleWebhooksSender) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockLifecycleWebhooksSender) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockLifecycleWebhooksSender) SendLifecycle(log logging.SimpleLogging, event webhooks.LifecycleEvent) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockLifecycleWebhooksSender().")
+	}
+	_params := []pegomock.Param{log, event}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("SendLifecycle", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockLifecycleWebhooksSender) VerifyWasCalledOnce() *VerifierMockLifecycleWebhooksSender {
+	return &VerifierMockLifecycleWebhooksSender{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockLifecycleWebhooksSender) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockLifecycleWebhooksSender {
+	return &VerifierMockLifecycleWebhooksSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockLifecycleWebhooksSender) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockLifecycleWebhooksSender {
+	return &VerifierMockLifecycleWebhooksSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockLifecycleWebhooksSender) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockLifecycleWebhooksSender {
+	return &VerifierMockLifecycleWebhooksSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockLifecycleWebhooksSender struct {
+	mock                   *MockLifecycleWebhooksSender
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockLifecycleWebhooksSender) SendLifecycle(log logging.SimpleLogging, event webhooks.LifecycleEvent) *MockLifecycleWebhooksSender_SendLifecycle_OngoingVerification {
+	_params := []pegomock.Param{log, event}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SendLifecycle", _params, verifier.timeout)
+	return &MockLifecycleWebhooksSender_SendLifecycle_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockLifecycleWebhooksSender_SendLifecycle_OngoingVerification struct {
+	mock              *MockLifecycleWebhooksSender
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockLifecycleWebhooksSender_SendLifecycle_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, webhooks.LifecycleEvent) {
+	log, event := c.GetAllCapturedArguments()
+	return log[len(log)-1], event[len(event)-1]
+}
+
+func (c *MockLifecycleWebhooksSender_SendLifecycle_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []webhooks.LifecycleEvent) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]webhooks.LifecycleEvent, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(webhooks.LifecycleEvent)
+			}
+		}
+	}
+	return
+}
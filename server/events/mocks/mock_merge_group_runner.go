@@ -0,0 +1,107 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events (interfaces: MergeGroupRunner)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	models "github.com/runatlantis/atlantis/server/events/models"
+	logging "github.com/runatlantis/atlantis/server/logging"
+	"reflect"
+	"time"
+)
+
+type MockMergeGroupRunner struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockMergeGroupRunner(options ...pegomock.Option) *MockMergeGroupRunner {
+	mock := &MockMergeGroupRunner{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockMergeGroupRunner) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockMergeGroupRunner) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockMergeGroupRunner) RunMergeGroup(logger logging.SimpleLogging, mergeGroup models.MergeGroup) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockMergeGroupRunner().")
+	}
+	_params := []pegomock.Param{logger, mergeGroup}
+	pegomock.GetGenericMockFrom(mock).Invoke("RunMergeGroup", _params, []reflect.Type{})
+}
+
+func (mock *MockMergeGroupRunner) VerifyWasCalledOnce() *VerifierMockMergeGroupRunner {
+	return &VerifierMockMergeGroupRunner{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockMergeGroupRunner) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockMergeGroupRunner {
+	return &VerifierMockMergeGroupRunner{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockMergeGroupRunner) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockMergeGroupRunner {
+	return &VerifierMockMergeGroupRunner{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockMergeGroupRunner) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockMergeGroupRunner {
+	return &VerifierMockMergeGroupRunner{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockMergeGroupRunner struct {
+	mock                   *MockMergeGroupRunner
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockMergeGroupRunner) RunMergeGroup(logger logging.SimpleLogging, mergeGroup models.MergeGroup) *MockMergeGroupRunner_RunMergeGroup_OngoingVerification {
+	_params := []pegomock.Param{logger, mergeGroup}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RunMergeGroup", _params, verifier.timeout)
+	return &MockMergeGroupRunner_RunMergeGroup_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockMergeGroupRunner_RunMergeGroup_OngoingVerification struct {
+	mock              *MockMergeGroupRunner
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockMergeGroupRunner_RunMergeGroup_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, models.MergeGroup) {
+	logger, mergeGroup := c.GetAllCapturedArguments()
+	return logger[len(logger)-1], mergeGroup[len(mergeGroup)-1]
+}
+
+func (c *MockMergeGroupRunner_RunMergeGroup_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []models.MergeGroup) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]models.MergeGroup, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(models.MergeGroup)
+			}
+		}
+	}
+	return
+}
@@ -31,6 +31,9 @@ type DriftSummary struct {
 	Summary string `json:"summary"`
 	// ChangesOutside indicates if Terraform detected changes made outside of Terraform.
 	ChangesOutside bool `json:"changes_outside"`
+	// Ignored is the number of drifted resources left out of the counts
+	// above by drift ignore rules.
+	Ignored int `json:"ignored,omitempty"`
 }
 
 // TotalChanges returns the total number of resource changes.
@@ -38,6 +41,28 @@ func (d DriftSummary) TotalChanges() int {
 	return d.ToAdd + d.ToChange + d.ToDestroy + d.ToImport + d.ToForget
 }
 
+// ResourceDrift describes a single resource that a drift detection plan
+// would change, taken from `terraform show -json` of the plan file.
+type ResourceDrift struct {
+	// Address is the resource address, e.g. aws_security_group.web.
+	Address string `json:"address"`
+	// Action is one of create, update, delete, replace, import or forget.
+	Action string `json:"action"`
+	// ChangedAttributes lists the top-level attributes whose values differ
+	// between the current and planned state. Only set for updates and
+	// replacements.
+	ChangedAttributes []string `json:"changed_attributes,omitempty"`
+	// ChangedPaths lists the paths of the nested values that changed, e.g.
+	// tags.CostCenter or scaling_config.0.desired_size, for drift ignore
+	// rules to match against. They're not stored or returned by the API.
+	ChangedPaths []string `json:"-"`
+	// Outside is true if the resource was changed outside of Terraform and
+	// the plan won't change it back, so it isn't part of the plan's counts.
+	Outside bool `json:"outside,omitempty"`
+	// Ignored is true if a drift ignore rule matched the resource.
+	Ignored bool `json:"ignored,omitempty"`
+}
+
 // RequiresBaseBranchForRef reports whether a drift API ref needs an explicit branch context.
 func RequiresBaseBranchForRef(ref string) bool {
 	ref = strings.TrimSpace(ref)
@@ -312,13 +337,16 @@ type ProjectDrift struct {
 	DetectionID string `json:"detection_id,omitempty"`
 	// Drift contains the drift summary for this project.
 	Drift DriftSummary `json:"drift"`
+	// Resources lists the resources that drifted. It is empty if the plan
+	// JSON could not be read, e.g. for remote or custom plan workflows.
+	Resources []ResourceDrift `json:"resources,omitempty"`
 	// PlanOutput contains the Terraform plan output for this project, if
 	// detection ran a plan successfully. For the built-in plan step this is
 	// typically whitespace-normalized for diff rendering; a custom workflow
 	// run step can put arbitrary, unnormalized output here instead, so exact
 	// content depends on the configured workflow. This is transient: it is
 	// populated for the immediate detect response only. The storage layer
-	// (InMemoryStorage.Store) strips it before persisting, so it is never
+	// (drift.Storage.Store implementations) strips it before persisting, so it is never
 	// persisted regardless of what a caller passes in. Not tagged for JSON
 	// since it is never marshaled directly; the API layer copies it onto
 	// DriftProjectAPI explicitly when the caller opts in via IncludePlanOutput.
@@ -391,6 +419,9 @@ type DriftDetectionRequest struct {
 	// (potentially large) Terraform plan text for each project. Defaults to
 	// false; set to true to receive plan_output on this detect response.
 	IncludePlanOutput bool `json:"include_plan_output,omitempty"`
+	// RefreshOnly runs a refresh-only plan, which reports only changes made
+	// outside of Terraform rather than differences from the configuration.
+	RefreshOnly bool `json:"refresh_only,omitempty"`
 }
 
 // Validate checks the request and returns any validation errors.
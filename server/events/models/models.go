@@ -247,6 +247,95 @@ type PullRequestOptions struct {
 	MergeMethod string
 }
 
+// CreatePullRequestOptions describes a pull request that Atlantis opens. Most
+// VCS hosts reject pull requests without changes, so the head branch is created
+// from the base branch with commits that add Files.
+type CreatePullRequestOptions struct {
+	// Title is the title of the pull request.
+	Title string
+	// Body is the description of the pull request, in markdown.
+	Body string
+	// BaseBranch is the branch the pull request merges into.
+	BaseBranch string
+	// HeadBranch is the branch to create. It must not already exist.
+	HeadBranch string
+	// CommitMessage is the message of the commits that add Files.
+	CommitMessage string
+	// Files are the files to add. There must be at least one.
+	Files []PullRequestFile
+}
+
+// PullRequestFile is a file added by a pull request that Atlantis opens.
+type PullRequestFile struct {
+	// Path is the repo-relative path of the file. A file that already exists
+	// on the base branch is overwritten.
+	Path string
+	// Content is the content of the file.
+	Content string
+}
+
+// CheckRun describes a check run that Atlantis reports on the head commit of
+// a pull request. Check runs are only supported by GitHub.
+type CheckRun struct {
+	// Name identifies the check run on the commit. Reporting a check run with
+	// the same name again updates it.
+	Name string
+	// ExternalID is stored with the check run and sent back to Atlantis when
+	// one of its actions is requested.
+	ExternalID string
+	// Status is the state of the check run. Pending check runs are in
+	// progress, the others are completed.
+	Status CommitStatus
+	// DetailsURL is an optional link to more information about the check run.
+	DetailsURL string
+	// Title, Summary and Text are the output of the check run. Summary and
+	// Text are markdown.
+	Title   string
+	Summary string
+	Text    string
+	// Annotations are shown next to the output of the check run.
+	Annotations []CheckRunAnnotation
+	// Actions are the buttons users can click on the check run.
+	Actions []CheckRunAction
+}
+
+// CheckRunAnnotation is an annotation on a file of a check run.
+type CheckRunAnnotation struct {
+	// Path is the repo-relative path of the annotated file or directory.
+	Path string
+	// Level is either "notice", "warning" or "failure".
+	Level   string
+	Title   string
+	Message string
+}
+
+// CheckRunAction is a button on a check run.
+type CheckRunAction struct {
+	// Identifier is sent back to Atlantis when the action is requested. It
+	// must be at most 20 characters.
+	Identifier string
+	// Label is the text of the button. It must be at most 20 characters.
+	Label string
+	// Description explains what the action does. It must be at most 40
+	// characters.
+	Description string
+}
+
+// MergeGroup is a group of pull requests in a GitHub merge queue. GitHub
+// merges the group into a temporary branch and waits for the required
+// statuses of its head commit before merging it into the base branch.
+type MergeGroup struct {
+	// BaseRepo is the repo the merge group is merged into.
+	BaseRepo Repo
+	// BaseBranch is the branch the merge group is merged into, ex. "main".
+	BaseBranch string
+	// HeadBranch is the temporary branch GitHub created for the merge
+	// group, ex. "gh-readonly-queue/main/pr-1-<sha>".
+	HeadBranch string
+	// HeadCommit is the SHA of the head commit of the merge group.
+	HeadCommit string
+}
+
 type PullRequestState int
 
 const (
@@ -301,6 +390,21 @@ type ProjectLock struct {
 	Time time.Time
 }
 
+// LockWaiter is a pull request waiting for a lock on a project and workspace
+// that another pull request holds.
+type LockWaiter struct {
+	// Project is the project whose lock is being waited for.
+	Project Project
+	// Workspace is the Terraform workspace whose lock is being waited for.
+	Workspace string
+	// Pull is the pull request that is waiting.
+	Pull PullRequest
+	// User is the user that ran the command that couldn't get the lock.
+	User User
+	// Time is the time at which the pull request started waiting.
+	Time time.Time
+}
+
 // Project represents a Terraform project. Since there may be multiple
 // Terraform projects in a single repo we also include Path to the project
 // root relative to the repo root.
@@ -441,6 +545,9 @@ type PlanSuccess struct {
 	// branch we're merging into had been updated, and we had to merge again
 	// before planning
 	MergedAgain bool
+	// PlanJSON is the output of `terraform show -json` for the plan file.
+	// It's only set when the project context has CapturePlanJSON set.
+	PlanJSON string
 }
 
 func NewPolicySetResult(policySetName string, policyOutput string, passed bool, reqApprovalCount int, policyItemRegex string) (*PolicySetResult, error) {
@@ -867,6 +974,12 @@ const (
 	// PassedPolicyCheckStatus means that all policy checks passed or were
 	// approved.
 	PassedPolicyCheckStatus
+	// CancelledPlanStatus means that the plan was cancelled with
+	// `atlantis cancel` before it finished.
+	CancelledPlanStatus
+	// CancelledApplyStatus means that a plan has been generated but applying
+	// it was cancelled with `atlantis cancel` before it finished.
+	CancelledApplyStatus
 )
 
 // String returns a string representation of the status.
@@ -888,6 +1001,10 @@ func (p ProjectPlanStatus) String() string {
 		return "policy_check_errored"
 	case PassedPolicyCheckStatus:
 		return "policy_check_passed"
+	case CancelledPlanStatus:
+		return "plan_cancelled"
+	case CancelledApplyStatus:
+		return "apply_cancelled"
 	default:
 		panic("missing String() impl for ProjectPlanStatus")
 	}
@@ -17,12 +17,15 @@ const (
 	RemediationPlanOnly RemediationAction = "plan"
 	// RemediationAutoApply runs both plan and apply to fix drift automatically.
 	RemediationAutoApply RemediationAction = "apply"
+	// RemediationPullRequest runs a plan and opens a pull request with the drift
+	// summary and plan output so the drift can be codified through review.
+	RemediationPullRequest RemediationAction = "pr"
 )
 
 // IsValid returns true if the action is a recognized value.
 func (a RemediationAction) IsValid() bool {
 	switch a {
-	case RemediationPlanOnly, RemediationAutoApply, "":
+	case RemediationPlanOnly, RemediationAutoApply, RemediationPullRequest, "":
 		return true
 	default:
 		return false
@@ -81,7 +84,7 @@ type RemediationRequest struct {
 	BaseBranch string `json:"base_branch,omitempty"`
 	// Type is the VCS provider type (Github/Gitlab). Required.
 	Type string `json:"type"`
-	// Action specifies plan-only or auto-apply. Defaults to "plan".
+	// Action specifies plan-only, auto-apply or pull request. Defaults to "plan".
 	Action RemediationAction `json:"action"`
 	// Projects is a list of project names to remediate. If empty, all stored
 	// projects matching the request filters are remediated. To restrict to
@@ -128,7 +131,7 @@ func (r *RemediationRequest) Validate() []FieldError {
 		errors = append(errors, FieldError{Field: "repository", Message: "repository must be a valid repository path for the VCS type"})
 	}
 	if !r.Action.IsValid() {
-		errors = append(errors, FieldError{Field: "action", Message: "action must be 'plan' or 'apply'"})
+		errors = append(errors, FieldError{Field: "action", Message: "action must be 'plan', 'apply' or 'pr'"})
 	}
 	for _, project := range r.Projects {
 		if strings.TrimSpace(project) == "" {
@@ -222,6 +225,12 @@ type RemediationResult struct {
 	FailureCount int `json:"failure_count"`
 	// Projects contains the remediation result for each project.
 	Projects []ProjectRemediationResult `json:"projects"`
+	// PullRequestNum is the number of the pull request opened by a "pr"
+	// remediation, or 0 if none was opened.
+	PullRequestNum int `json:"pull_request_num,omitempty"`
+	// PullRequestURL is the URL of the pull request opened by a "pr"
+	// remediation.
+	PullRequestURL string `json:"pull_request_url,omitempty"`
 	// Error contains any top-level error message.
 	Error string `json:"error,omitempty"`
 }
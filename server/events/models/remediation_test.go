@@ -25,6 +25,35 @@ func TestRemediationResultCompletedAtJSON(t *testing.T) {
 	Assert(t, strings.Contains(string(body), "completed_at"), "expected completed remediation to include completed_at: %s", body)
 }
 
+func TestRemediationRequestValidateActions(t *testing.T) {
+	for _, c := range []struct {
+		action models.RemediationAction
+		valid  bool
+	}{
+		{action: "", valid: true},
+		{action: models.RemediationPlanOnly, valid: true},
+		{action: models.RemediationAutoApply, valid: true},
+		{action: models.RemediationPullRequest, valid: true},
+		{action: "destroy", valid: false},
+	} {
+		t.Run(string(c.action), func(t *testing.T) {
+			request := models.RemediationRequest{
+				Repository: "owner/repo",
+				Ref:        "main",
+				Type:       "Github",
+				Action:     c.action,
+			}
+			errs := request.Validate()
+			if c.valid {
+				Equals(t, 0, len(errs))
+				return
+			}
+			Equals(t, 1, len(errs))
+			Equals(t, "action must be 'plan', 'apply' or 'pr'", errs[0].Message)
+		})
+	}
+}
+
 func TestRemediationRequestValidateRequiresBaseBranchForSHAAndTagRefs(t *testing.T) {
 	for _, c := range []struct {
 		name string
@@ -107,11 +107,26 @@ type PlanCommandRunner struct {
 	pullReqStatusFetcher  vcs.PullReqStatusFetcher
 	SilencePRComments     []string
 	PendingApplyStatus    bool
+	// AutoplanSuperseder lets a new autoplan supersede the autoplans already
+	// queued or running for the same pull request. If nil, autoplans run one
+	// after another.
+	AutoplanSuperseder *AutoplanSuperseder
 }
 
 func (p *PlanCommandRunner) runAutoplan(ctx *command.Context) {
 	baseRepo := ctx.Pull.BaseRepo
 	pull := ctx.Pull
+	var run *autoplanRun
+	var supersededPrevious bool
+	if p.AutoplanSuperseder != nil {
+		run, supersededPrevious = p.AutoplanSuperseder.start(pull)
+		defer p.AutoplanSuperseder.finish(pull, run)
+		if supersededPrevious {
+			if !p.waitForSupersededAutoplan(ctx, run) {
+				return
+			}
+		}
+	}
 	unlockPullPlan, ok := p.lockPullForPlan(ctx, AutoplanCommand{})
 	if !ok {
 		return
@@ -165,6 +180,13 @@ func (p *PlanCommandRunner) runAutoplan(ctx *command.Context) {
 		}
 		return
 	}
+	if run != nil {
+		p.AutoplanSuperseder.setProjects(run, projectCmds)
+		if supersededBy := p.AutoplanSuperseder.supersededBy(run); supersededBy != "" {
+			ctx.Log.Info("autoplan was superseded by commit %s before planning, dropping it", supersededBy)
+			return
+		}
+	}
 	p.updatePendingCommitStatus(ctx, command.Plan)
 
 	// discard previous plans that might not be relevant anymore
@@ -177,7 +199,34 @@ func (p *PlanCommandRunner) runAutoplan(ctx *command.Context) {
 		return
 	}
 
-	result := runProjectCmdsWithCancellationTracker(ctx, projectCmds, p.cancellationTracker, p.parallelPoolSize, p.isParallelEnabled(projectCmds), p.prjCmdRunner.Plan)
+	cancellationTracker := p.cancellationTracker
+	if run != nil {
+		cancellationTracker = supersededRunTracker{
+			CancellationTracker: p.cancellationTracker,
+			superseder:          p.AutoplanSuperseder,
+			run:                 run,
+		}
+	}
+	result := runProjectCmdsWithCancellationTracker(ctx, projectCmds, cancellationTracker, p.parallelPoolSize, p.isParallelEnabled(projectCmds), p.prjCmdRunner.Plan)
+
+	if run != nil {
+		if supersededBy := p.AutoplanSuperseder.supersededBy(run); supersededBy != "" {
+			// The newer autoplan deletes these plans again and reports its own
+			// results, so only leave a note behind.
+			ctx.Log.Info("autoplan was superseded by commit %s, discarding its results", supersededBy)
+			if err := p.vcsClient.CreateComment(ctx.Log, baseRepo, pull.Num, supersededComment(run, supersededBy), command.Plan.String()); err != nil {
+				ctx.Log.Err("unable to comment: %s", err)
+			}
+			return
+		}
+		if supersededPrevious && !p.pullUpdater.HidePrevPlanComments {
+			// Hide the comments of the autoplans this one superseded. With
+			// HidePrevPlanComments the pull updater already takes care of it.
+			if err := p.vcsClient.HidePrevCommandComments(ctx.Log, baseRepo, pull.Num, command.Plan.TitleString(), ""); err != nil {
+				ctx.Log.Err("unable to hide superseded comments: %s", err)
+			}
+		}
+	}
 
 	if p.autoMerger.automergeEnabled(projectCmds) && result.HasErrors() {
 		ctx.Log.Info("deleting plans because there were errors and automerge requires all plans succeed")
@@ -213,6 +262,19 @@ func (p *PlanCommandRunner) runAutoplan(ctx *command.Context) {
 	}
 }
 
+// waitForSupersededAutoplan waits for the autoplan run superseded to exit.
+// The superseded autoplan stops after its running execution order group. It
+// returns false if run was itself superseded in the meantime.
+func (p *PlanCommandRunner) waitForSupersededAutoplan(ctx *command.Context, run *autoplanRun) bool {
+	ctx.Log.Info("superseding the previous autoplan for this pull request")
+	p.AutoplanSuperseder.waitForPrevious(run)
+	if supersededBy := p.AutoplanSuperseder.supersededBy(run); supersededBy != "" {
+		ctx.Log.Info("autoplan was superseded by commit %s while queued, dropping it", supersededBy)
+		return false
+	}
+	return true
+}
+
 func (p *PlanCommandRunner) run(ctx *command.Context, cmd *CommentCommand) {
 	var err error
 	baseRepo := ctx.Pull.BaseRepo
@@ -433,7 +495,7 @@ func (p *PlanCommandRunner) updateCommitStatus(ctx *command.Context, pullStatus
 
 	switch commandName {
 	case command.Plan:
-		numErrored = pullStatus.StatusCount(models.ErroredPlanStatus)
+		numErrored = pullStatus.StatusCount(models.ErroredPlanStatus) + pullStatus.StatusCount(models.CancelledPlanStatus)
 		// We consider anything that isn't a plan error as a plan success.
 		// For example, if there is an apply error, that means that at least a
 		// plan was generated successfully.
@@ -445,7 +507,7 @@ func (p *PlanCommandRunner) updateCommitStatus(ctx *command.Context, pullStatus
 	case command.Apply:
 		numNoChanges = pullStatus.StatusCount(models.PlannedNoChangesPlanStatus)
 		numSuccess = pullStatus.StatusCount(models.AppliedPlanStatus) + numNoChanges
-		numErrored = pullStatus.StatusCount(models.ErroredApplyStatus)
+		numErrored = pullStatus.StatusCount(models.ErroredApplyStatus) + pullStatus.StatusCount(models.CancelledApplyStatus)
 
 		if numErrored > 0 {
 			status = models.FailedCommitStatus
@@ -738,7 +738,7 @@ func (p *DefaultProjectCommandBuilder) getMergedProjectCfgs(ctx *command.Context
 				return nil, fmt.Errorf("looking for Terraform Cloud workspace from configuration in '%s': %w", absProjectDir, err)
 			}
 
-			pCfg := p.GlobalCfg.DefaultProjCfg(ctx.Log, ctx.Pull.BaseRepo.ID(), mp.Path, pWorkspace)
+			pCfg := p.GlobalCfg.DefaultProjCfg(ctx.Log, ctx.Pull.BaseRepo.ID(), mp.Path, pWorkspace, repoCfg.DriftIgnore)
 			mergedCfgs = append(mergedCfgs, pCfg)
 		}
 	}
@@ -771,7 +771,7 @@ func (p *DefaultProjectCommandBuilder) getAllMergedProjectCfgs(ctx *command.Cont
 			return nil, fmt.Errorf("looking for Terraform Cloud workspace from configuration in '%s': %w", absProjectDir, err)
 		}
 
-		mergedCfgs = append(mergedCfgs, p.GlobalCfg.DefaultProjCfg(ctx.Log, ctx.Pull.BaseRepo.ID(), projectDir, workspace))
+		mergedCfgs = append(mergedCfgs, p.GlobalCfg.DefaultProjCfg(ctx.Log, ctx.Pull.BaseRepo.ID(), projectDir, workspace, repoCfg.DriftIgnore))
 	}
 	return mergedCfgs, nil
 }
@@ -1477,7 +1477,7 @@ func shortSHA(sha string) string {
 func statusAllowedForDiscoveredPlan(status models.ProjectPlanStatus) bool {
 	switch status {
 	case models.PlannedPlanStatus, models.PassedPolicyCheckStatus, models.ErroredApplyStatus,
-		models.PlannedNoChangesPlanStatus, models.ErroredPolicyCheckStatus:
+		models.CancelledApplyStatus, models.PlannedNoChangesPlanStatus, models.ErroredPolicyCheckStatus:
 		return true
 	default:
 		return false
@@ -1487,7 +1487,7 @@ func statusAllowedForDiscoveredPlan(status models.ProjectPlanStatus) bool {
 func statusRequiresPlanFileForGenericApply(status models.ProjectPlanStatus) bool {
 	switch status {
 	case models.PlannedPlanStatus, models.PassedPolicyCheckStatus, models.ErroredApplyStatus,
-		models.ErroredPolicyCheckStatus:
+		models.CancelledApplyStatus, models.ErroredPolicyCheckStatus:
 		return true
 	default:
 		return false
@@ -1496,7 +1496,7 @@ func statusRequiresPlanFileForGenericApply(status models.ProjectPlanStatus) bool
 
 func statusBlocksGenericApplyWithoutPlan(status models.ProjectPlanStatus) bool {
 	switch status {
-	case models.ErroredPlanStatus:
+	case models.ErroredPlanStatus, models.CancelledPlanStatus:
 		return true
 	default:
 		return false
@@ -1782,7 +1782,11 @@ func (p *DefaultProjectCommandBuilder) buildProjectCommandCtxWithCfg(ctx *comman
 			return []command.ProjectContext{}, nil
 		}
 
-		projCfg = p.GlobalCfg.DefaultProjCfg(ctx.Log, ctx.Pull.BaseRepo.ID(), repoRelDir, workspace)
+		var driftIgnore []valid.DriftIgnoreRule
+		if repoCfgPtr != nil {
+			driftIgnore = repoCfgPtr.DriftIgnore
+		}
+		projCfg = p.GlobalCfg.DefaultProjCfg(ctx.Log, ctx.Pull.BaseRepo.ID(), repoRelDir, workspace, driftIgnore)
 		projCtxs = append(projCtxs,
 			p.ProjectCommandContextBuilder.BuildProjectContext(
 				ctx,
@@ -2117,6 +2117,90 @@ func TestDefaultProjectCommandBuilder_ShouldIgnoreTargetedDirMergeCheckoutWithou
 	vcsClient.VerifyWasCalled(Never()).GetFileContent(Any[logging.SimpleLogging](), Any[models.Repo](), Any[string](), Any[string]())
 }
 
+// Test that the repo-level drift.ignore rules of atlantis.yaml also apply to
+// autodiscovered projects that aren't configured in it.
+func TestDefaultProjectCommandBuilder_AutodiscoveredProjectsInheritRepoDriftIgnore(t *testing.T) {
+	RegisterMockTestingT(t)
+
+	atlantisYAML := `
+version: 3
+autodiscover:
+  mode: enabled
+drift:
+  ignore:
+  - attributes: ["tags"]
+projects:
+- dir: configured
+`
+	tmpDir := DirStructure(t, map[string]any{
+		"configured": map[string]any{
+			"main.tf": nil,
+		},
+		"discovered": map[string]any{
+			"main.tf": nil,
+		},
+	})
+	err := os.WriteFile(filepath.Join(tmpDir, valid.DefaultAtlantisFile), []byte(atlantisYAML), 0600)
+	Ok(t, err)
+
+	workingDir := mocks.NewMockWorkingDir()
+	When(workingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Any[string]())).ThenReturn(tmpDir, nil)
+	When(workingDir.GetWorkingDir(Any[models.Repo](), Any[models.PullRequest](), Any[string]())).ThenReturn(tmpDir, nil)
+	vcsClient := vcsmocks.NewMockClient()
+	When(vcsClient.GetModifiedFiles(Any[logging.SimpleLogging](), Any[models.Repo](),
+		Any[models.PullRequest]())).ThenReturn([]string{"configured/main.tf", "discovered/main.tf"}, nil)
+
+	logger := logging.NewNoopLogger(t)
+	scope := metricstest.NewLoggingScope(t, logger, "atlantis")
+	globalCfg := valid.NewGlobalCfgFromArgs(valid.GlobalCfgArgs{AllowAllRepoSettings: true})
+	terraformClient := tfclientmocks.NewMockClient()
+	userConfig := defaultUserConfig
+
+	builder := events.NewProjectCommandBuilder(
+		false,
+		&config.ParserValidator{},
+		&events.DefaultProjectFinder{},
+		vcsClient,
+		workingDir,
+		events.NewDefaultWorkingDirLocker(),
+		globalCfg,
+		&events.DefaultPendingPlanFinder{},
+		&events.CommentParser{ExecutableName: "atlantis"},
+		userConfig.SkipCloneNoChanges,
+		userConfig.EnableRegExpCmd,
+		userConfig.EnableAutoMerge,
+		userConfig.EnableParallelPlan,
+		userConfig.EnableParallelApply,
+		userConfig.AutoDetectModuleFiles,
+		userConfig.AutoplanFileList,
+		userConfig.RestrictFileList,
+		userConfig.DefaultTFDistribution,
+		userConfig.SilenceNoProjects,
+		userConfig.IncludeGitUntrackedFiles,
+		userConfig.AutoDiscoverMode,
+		scope,
+		terraformClient, &runtime.LocalPlanStore{},
+	)
+	expDriftIgnore := []valid.DriftIgnoreRule{{Attributes: []string{"tags"}}}
+
+	ctxs, err := builder.BuildAutoplanCommands(&command.Context{Log: logger, Scope: scope})
+	Ok(t, err)
+	Equals(t, 2, len(ctxs))
+	for _, ctx := range ctxs {
+		Equals(t, expDriftIgnore, ctx.DriftIgnore)
+	}
+
+	ctxs, err = builder.BuildPlanCommands(&command.Context{Log: logger, Scope: scope}, &events.CommentCommand{
+		Name:       command.Plan,
+		RepoRelDir: "discovered",
+		Workspace:  "default",
+	})
+	Ok(t, err)
+	Equals(t, 1, len(ctxs))
+	Equals(t, expDriftIgnore, ctxs[0].DriftIgnore)
+}
+
 // Test that autodiscover.ignore_paths set in repo-level atlantis.yaml blocks
 // targeted plan/apply -d commands for non-configured projects.
 func TestDefaultProjectCommandBuilder_BuildTargetedCommand_IgnorePathsRepoCfg(t *testing.T) {
@@ -304,6 +304,8 @@ func newProjectCommandContext(ctx *command.Context,
 		AutoplanEnabled:                 projCfg.AutoplanEnabled,
 		AutoplanWhenModified:            projCfg.AutoplanWhenModified,
 		Steps:                           steps,
+		PlanTimeout:                     projCfg.Workflow.PlanTimeout,
+		ApplyTimeout:                    projCfg.Workflow.ApplyTimeout,
 		RequiresAtlantisManagedPlanFile: requiresAtlantisManagedPlanFile(projCfg.Workflow),
 		HeadRepo:                        ctx.HeadRepo,
 		Log:                             ctx.Log,
@@ -332,12 +334,15 @@ func newProjectCommandContext(ctx *command.Context,
 		ExecutionOrderGroup:             projCfg.ExecutionOrderGroup,
 		AbortOnExecutionOrderFail:       abortOnExecutionOrderFail,
 		SilencePRComments:               projCfg.SilencePRComments,
+		DriftIgnore:                     projCfg.DriftIgnore,
 		TeamAllowlistChecker:            teamAllowlistChecker,
 		API:                             ctx.API,
+		MergeGroup:                      ctx.MergeGroup,
 		SkipPRRequirements:              ctx.SkipPRRequirements,
 		RunPolicyChecks:                 ctx.RunPolicyChecks,
 		SuppressVCSStatus:               ctx.SuppressVCSStatus,
 		SuppressJobOutput:               ctx.SuppressJobOutput,
+		CapturePlanJSON:                 ctx.CapturePlanJSON,
 		SuppressApplyWebhooks:           ctx.SuppressApplyWebhooks,
 		FailOnMissingDependencies:       ctx.FailOnMissingDependencies,
 	}
@@ -4,7 +4,6 @@
 package events
 
 import (
-	"fmt"
 	"sort"
 	"sync"
 
@@ -29,6 +28,7 @@ func RunOneProjectCmd(
 		Workspace:            cmd.Workspace,
 		ProjectName:          cmd.ProjectName,
 		SilencePRComments:    cmd.SilencePRComments,
+		DriftIgnore:          cmd.DriftIgnore,
 	}
 }
 
@@ -81,7 +81,7 @@ func runProjectCmdsParallel(
 			results = append(results, command.ProjectResult{
 				Command: pCmd.CommandName,
 				ProjectCommandOutput: command.ProjectCommandOutput{
-					Error: fmt.Errorf("operation cancelled via `atlantis cancel` command"),
+					Error: command.ErrCancelled,
 				},
 				RepoRelDir:  pCmd.RepoRelDir,
 				Workspace:   pCmd.Workspace,
@@ -227,7 +227,7 @@ func createCancelledResults(remainingGroups [][]command.ProjectContext) []comman
 			cancelledResults = append(cancelledResults, command.ProjectResult{
 				Command: cmd.CommandName,
 				ProjectCommandOutput: command.ProjectCommandOutput{
-					Error: fmt.Errorf("operation cancelled via `atlantis cancel` command"),
+					Error: command.ErrCancelled,
 				},
 				RepoRelDir:  cmd.RepoRelDir,
 				Workspace:   cmd.Workspace,
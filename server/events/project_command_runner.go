@@ -12,9 +12,11 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 	"github.com/runatlantis/atlantis/server/core/runtime"
+	runtimemodels "github.com/runatlantis/atlantis/server/core/runtime/models"
 	"github.com/runatlantis/atlantis/server/events/command"
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/vcs"
@@ -339,12 +341,20 @@ type DefaultProjectCommandRunner struct {
 	PullApprovedChecker       runtime.PullApprovedChecker
 	WorkingDir                WorkingDir
 	Webhooks                  WebhooksSender
+	LifecycleWebhooks         LifecycleWebhooksSender
 	WorkingDirLocker          WorkingDirLocker
 	ProjectJobURLGenerator    jobs.ProjectJobURLGenerator
 	CommandRequirementHandler CommandRequirementHandler
 	CancellationTracker       CancellationTracker
-	ApplyPlanValidator        ApplyPlanValidator
-	PlanStore                 runtime.PlanStore
+	// ProcessTracker records the processes run for project commands so
+	// `atlantis cancel` can interrupt them.
+	ProcessTracker     *runtimemodels.ProcessTracker
+	ApplyPlanValidator ApplyPlanValidator
+	PlanStore          runtime.PlanStore
+	// DefaultPlanTimeout and DefaultApplyTimeout are used for projects whose
+	// workflow doesn't set plan_timeout or apply_timeout. Zero means no limit.
+	DefaultPlanTimeout  time.Duration
+	DefaultApplyTimeout time.Duration
 }
 
 func (p *DefaultProjectCommandRunner) workingDirLockMetadata(ctx command.ProjectContext) WorkingDirLockMetadata {
@@ -361,27 +371,61 @@ func (p *DefaultProjectCommandRunner) workingDirLockMetadata(ctx command.Project
 
 // Plan runs terraform plan for the project described by ctx.
 func (p *DefaultProjectCommandRunner) Plan(ctx command.ProjectContext) command.ProjectCommandOutput {
+	p.sendLifecycleWebhook(ctx, newProjectLifecycleEvent(webhooks.PlanStartedEvent, ctx))
 	planSuccess, failure, err := p.doPlan(ctx)
-	return command.ProjectCommandOutput{
+	failure, err = p.checkCancelled(ctx, failure, err)
+	out := command.ProjectCommandOutput{
 		PlanSuccess: planSuccess,
 		Error:       err,
 		Failure:     failure,
 	}
+	p.sendLifecycleWebhook(ctx, newPlanFinishedEvent(ctx, out))
+	return out
 }
 
 // PolicyCheck evaluates policies defined with Rego for the project described by ctx.
 func (p *DefaultProjectCommandRunner) PolicyCheck(ctx command.ProjectContext) command.ProjectCommandOutput {
 	policySuccess, failure, err := p.doPolicyCheck(ctx)
-	return command.ProjectCommandOutput{
+	failure, err = p.checkCancelled(ctx, failure, err)
+	out := command.ProjectCommandOutput{
 		PolicyCheckResults: policySuccess,
 		Error:              err,
 		Failure:            failure,
 	}
+	if event, ok := newPolicyCheckEvent(ctx, out); ok {
+		p.sendLifecycleWebhook(ctx, event)
+	}
+	return out
+}
+
+// checkCancelled replaces the failure or error of a project command with
+// command.ErrCancelled if `atlantis cancel` interrupted one of its processes,
+// so the project is reported as cancelled rather than failed.
+func (p *DefaultProjectCommandRunner) checkCancelled(ctx command.ProjectContext, failure string, err error) (string, error) {
+	if !p.ProcessTracker.TakeInterrupted(runtimemodels.NewProcessKey(ctx)) || (err == nil && failure == "") {
+		return failure, err
+	}
+	if err == nil {
+		err = errors.New(failure)
+	}
+	return "", fmt.Errorf("%w: %s", command.ErrCancelled, err)
+}
+
+// sendLifecycleWebhook sends event unless ctx is a synthetic API workflow
+// that suppresses webhooks.
+func (p *DefaultProjectCommandRunner) sendLifecycleWebhook(ctx command.ProjectContext, event webhooks.LifecycleEvent) {
+	if ctx.SuppressApplyWebhooks || p.LifecycleWebhooks == nil {
+		return
+	}
+	if err := p.LifecycleWebhooks.SendLifecycle(ctx.Log, event); err != nil {
+		ctx.Log.Warn("%s", err)
+	}
 }
 
 // Apply runs terraform apply for the project described by ctx.
 func (p *DefaultProjectCommandRunner) Apply(ctx command.ProjectContext) command.ProjectCommandOutput {
 	applyOut, applyURL, failure, err := p.doApply(ctx)
+	failure, err = p.checkCancelled(ctx, failure, err)
 	return command.ProjectCommandOutput{
 		Failure:         failure,
 		Error:           err,
@@ -401,6 +445,7 @@ func (p *DefaultProjectCommandRunner) ApprovePolicies(ctx command.ProjectContext
 
 func (p *DefaultProjectCommandRunner) Version(ctx command.ProjectContext) command.ProjectCommandOutput {
 	versionOut, failure, err := p.doVersion(ctx)
+	failure, err = p.checkCancelled(ctx, failure, err)
 	return command.ProjectCommandOutput{
 		Failure:        failure,
 		Error:          err,
@@ -411,6 +456,7 @@ func (p *DefaultProjectCommandRunner) Version(ctx command.ProjectContext) comman
 // Import runs terraform import for the project described by ctx.
 func (p *DefaultProjectCommandRunner) Import(ctx command.ProjectContext) command.ProjectCommandOutput {
 	importSuccess, failure, err := p.doImport(ctx)
+	failure, err = p.checkCancelled(ctx, failure, err)
 	return command.ProjectCommandOutput{
 		ImportSuccess: importSuccess,
 		Error:         err,
@@ -421,6 +467,7 @@ func (p *DefaultProjectCommandRunner) Import(ctx command.ProjectContext) command
 // StateRm runs terraform state rm for the project described by ctx.
 func (p *DefaultProjectCommandRunner) StateRm(ctx command.ProjectContext) command.ProjectCommandOutput {
 	stateRmSuccess, failure, err := p.doStateRm(ctx)
+	failure, err = p.checkCancelled(ctx, failure, err)
 	return command.ProjectCommandOutput{
 		StateRmSuccess: stateRmSuccess,
 		Error:          err,
@@ -544,7 +591,7 @@ func (p *DefaultProjectCommandRunner) doPolicyCheck(ctx command.ProjectContext)
 	// we will attempt to capture the lock here but fail to get the working directory
 	// at which point we will unlock again to preserve functionality
 	// If we fail to capture the lock here (super unlikely) then we error out and the user is forced to replan
-	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), ctx.RepoLocksMode == valid.RepoLocksOnPlanMode)
+	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), repoLocking(ctx, valid.RepoLocksOnPlanMode))
 
 	if err != nil {
 		return nil, "", fmt.Errorf("acquiring lock: %w", err)
@@ -794,9 +841,15 @@ func (p *DefaultProjectCommandRunner) doPolicyCheck(ctx command.ProjectContext)
 	return result, failure, nil
 }
 
+// repoLocking returns whether the project lock of ctx is acquired by commands
+// that take it in the given repo locks mode.
+func repoLocking(ctx command.ProjectContext, mode valid.RepoLocksMode) bool {
+	return ctx.RepoLocksMode == mode && !ctx.MergeGroup
+}
+
 func (p *DefaultProjectCommandRunner) doPlan(ctx command.ProjectContext) (*models.PlanSuccess, string, error) {
 	// Acquire Atlantis lock for this repo/dir/workspace.
-	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), ctx.RepoLocksMode == valid.RepoLocksOnPlanMode)
+	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), repoLocking(ctx, valid.RepoLocksOnPlanMode))
 	if err != nil {
 		return nil, "", fmt.Errorf("acquiring lock: %w", err)
 	}
@@ -869,12 +922,24 @@ func (p *DefaultProjectCommandRunner) doPlan(ctx command.ProjectContext) (*model
 		return nil, "", errorWithStepOutput(err, outputs)
 	}
 
+	var planJSON string
+	if ctx.CapturePlanJSON {
+		// The plan JSON is only used for extra detail, so failing to get it
+		// doesn't fail the plan.
+		planJSON, err = p.ShowStepRunner.Run(ctx, nil, projAbsPath, map[string]string{})
+		if err != nil {
+			ctx.Log.Warn("unable to capture plan JSON: %s", err)
+			planJSON = ""
+		}
+	}
+
 	return &models.PlanSuccess{
 		LockURL:         p.LockURLGenerator.GenerateLockURL(lockAttempt.LockKey),
 		TerraformOutput: strings.Join(outputs, "\n"),
 		RePlanCmd:       ctx.RePlanCmd,
 		ApplyCmd:        ctx.ApplyCmd,
 		MergedAgain:     mergedAgain,
+		PlanJSON:        planJSON,
 	}, "", nil
 }
 
@@ -912,7 +977,7 @@ func (p *DefaultProjectCommandRunner) doApply(ctx command.ProjectContext) (apply
 	}
 
 	// Acquire Atlantis lock for this repo/dir/workspace.
-	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), ctx.RepoLocksMode == valid.RepoLocksOnApplyMode)
+	lockAttempt, err := p.Locker.TryLock(ctx.Log, ctx.Pull, ctx.User, ctx.Workspace, models.NewProject(ctx.Pull.BaseRepo.FullName, ctx.RepoRelDir, ctx.ProjectName), repoLocking(ctx, valid.RepoLocksOnApplyMode))
 	if err != nil {
 		return "", "", "", fmt.Errorf("acquiring lock: %w", err)
 	}
@@ -932,6 +997,10 @@ func (p *DefaultProjectCommandRunner) doApply(ctx command.ProjectContext) (apply
 	// Targeted apply re-clones without RestorePlans; Load must run before
 	// ValidateProjectPlan (which stats the local file) and before hashing.
 	if err := p.ensurePlanLoaded(ctx, absPath); err != nil {
+		var verifyErr *runtime.PlanVerificationError
+		if errors.As(err, &verifyErr) {
+			return "", "", fmt.Sprintf("Refusing to apply: the %s, so it may not be the plan that was reviewed. Run `atlantis plan` again to create a new plan.", verifyErr), nil
+		}
 		return "", "", "", err
 	}
 
@@ -1148,10 +1217,30 @@ func (p *DefaultProjectCommandRunner) runSteps(steps []valid.Step, ctx command.P
 	unlock := p.WorkingDir.GitReadLock(ctx.Pull.BaseRepo, ctx.Pull, ctx.Workspace)
 	defer unlock()
 
+	if ctx.PlanTimeout == 0 {
+		ctx.PlanTimeout = p.DefaultPlanTimeout
+	}
+	if ctx.ApplyTimeout == 0 {
+		ctx.ApplyTimeout = p.DefaultApplyTimeout
+	}
+
+	// The stage timeout covers all of its steps, so every process they start
+	// inherits the deadline.
+	switch ctx.CommandName {
+	case command.Plan:
+		ctx.StageTimeout = ctx.PlanTimeout
+	case command.Apply:
+		ctx.StageTimeout = ctx.ApplyTimeout
+	}
+	if ctx.StageTimeout > 0 {
+		ctx.StageDeadline = time.Now().Add(ctx.StageTimeout)
+	}
+
 	envs := make(map[string]string)
 	for _, step := range steps {
 		var out string
 		var err error
+		ctx.StepTimeout = step.Timeout
 		switch step.StepName {
 		case "init":
 			out, err = p.InitStepRunner.Run(ctx, step.ExtraArgs, absPath, envs)
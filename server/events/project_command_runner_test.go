@@ -15,11 +15,13 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	. "github.com/petergtz/pegomock/v4"
 	"github.com/runatlantis/atlantis/server/core/boltdb"
 	"github.com/runatlantis/atlantis/server/core/config/valid"
+	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/core/runtime"
 	"github.com/runatlantis/atlantis/server/core/terraform"
 	tmocks "github.com/runatlantis/atlantis/server/core/terraform/mocks"
@@ -125,6 +127,193 @@ func TestDefaultProjectCommandRunner_Plan(t *testing.T) {
 	}
 }
 
+// Test that merge groups can plan projects that are still locked by the
+// pull requests in the merge queue.
+func TestDefaultProjectCommandRunner_PlanMergeGroupIgnoresQueuedPullLocks(t *testing.T) {
+	RegisterMockTestingT(t)
+	database, err := boltdb.New(t.TempDir())
+	Ok(t, err)
+	t.Cleanup(func() { database.Close() }) // nolint: errcheck
+	lockingClient := locking.NewClient(database)
+	mockWorkingDir := mocks.NewMockWorkingDir()
+	mockPlan := mocks.NewMockStepRunner()
+	runner := events.DefaultProjectCommandRunner{
+		Locker: &events.DefaultProjectLocker{
+			Locker:         lockingClient,
+			NoOpLocker:     locking.NewNoOpLocker(),
+			VCSClient:      vcsmocks.NewMockClient(),
+			ExecutableName: "atlantis",
+		},
+		LockURLGenerator:          mockURLGenerator{},
+		PlanStepRunner:            mockPlan,
+		WorkingDir:                mockWorkingDir,
+		WorkingDirLocker:          events.NewDefaultWorkingDirLocker(),
+		CommandRequirementHandler: mocks.NewMockCommandRequirementHandler(),
+	}
+	repoDir := t.TempDir()
+	When(mockWorkingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Any[string]())).ThenReturn(repoDir, nil)
+	When(mockWorkingDir.GitReadLock(Any[models.Repo](), Any[models.PullRequest](), Any[string]())).ThenReturn(func() {})
+	When(mockPlan.Run(Any[command.ProjectContext](), Any[[]string](), Any[string](), Any[map[string]string]())).ThenReturn("plan", nil)
+
+	repo := models.Repo{FullName: "owner/repo"}
+	queuedPull := models.PullRequest{Num: 1, BaseRepo: repo}
+	lockAttempt, err := lockingClient.TryLock(models.NewProject(repo.FullName, ".", ""), "default", queuedPull, models.User{Username: "author"})
+	Ok(t, err)
+	Assert(t, lockAttempt.LockAcquired, "exp queued pull to lock the project")
+
+	ctx := command.ProjectContext{
+		Log:           logging.NewNoopLogger(t),
+		Steps:         []valid.Step{{StepName: "plan"}},
+		Workspace:     "default",
+		RepoRelDir:    ".",
+		RepoLocksMode: valid.RepoLocksOnPlanMode,
+		BaseRepo:      repo,
+		HeadRepo:      repo,
+		Pull:          models.PullRequest{Num: -1, BaseRepo: repo},
+		API:           true,
+	}
+	res := runner.Plan(ctx)
+	Assert(t, res.PlanSuccess == nil, "exp plan to fail without merge group")
+	Assert(t, strings.Contains(res.Failure, "This project is currently locked"), "exp lock failure, got %q", res.Failure)
+
+	ctx.MergeGroup = true
+	res = runner.Plan(ctx)
+	Ok(t, res.Error)
+	Equals(t, "", res.Failure)
+	Assert(t, res.PlanSuccess != nil, "exp plan success")
+	Equals(t, "plan", res.PlanSuccess.TerraformOutput)
+
+	// The queued pull request still owns its lock.
+	lock, err := lockingClient.GetLock(models.GenerateLockKey(models.NewProject(repo.FullName, ".", ""), "default"))
+	Ok(t, err)
+	Equals(t, 1, lock.Pull.Num)
+}
+
+func TestDefaultProjectCommandRunner_PlanTimeouts(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockPlan := mocks.NewMockStepRunner()
+	mockRun := mocks.NewMockCustomStepRunner()
+	mockWorkingDir := mocks.NewMockWorkingDir()
+	mockLocker := mocks.NewMockProjectLocker()
+
+	runner := events.DefaultProjectCommandRunner{
+		Locker:                    mockLocker,
+		LockURLGenerator:          mockURLGenerator{},
+		PlanStepRunner:            mockPlan,
+		RunStepRunner:             mockRun,
+		WorkingDir:                mockWorkingDir,
+		WorkingDirLocker:          events.NewDefaultWorkingDirLocker(),
+		CommandRequirementHandler: mocks.NewMockCommandRequirementHandler(),
+		DefaultPlanTimeout:        30 * time.Minute,
+		DefaultApplyTimeout:       time.Hour,
+	}
+
+	repoDir := t.TempDir()
+	When(mockWorkingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+		Any[string]())).ThenReturn(repoDir, nil)
+	When(mockWorkingDir.GitReadLock(Any[models.Repo](), Any[models.PullRequest](), Any[string]())).ThenReturn(func() {})
+	When(mockLocker.TryLock(Any[logging.SimpleLogging](), Any[models.PullRequest](), Any[models.User](), Any[string](),
+		Any[models.Project](), AnyBool())).ThenReturn(&events.TryLockResponse{LockAcquired: true, LockKey: "lock-key"}, nil)
+
+	ctx := command.ProjectContext{
+		Log: logging.NewNoopLogger(t),
+		Steps: []valid.Step{
+			{
+				StepName:   "run",
+				RunCommand: "./generate.sh",
+				Timeout:    5 * time.Minute,
+			},
+			{
+				StepName: "plan",
+			},
+		},
+		CommandName: command.Plan,
+		Workspace:   "default",
+		RepoRelDir:  ".",
+		PlanTimeout: 10 * time.Minute,
+	}
+	var runCtx, planCtx command.ProjectContext
+	When(mockRun.Run(Any[command.ProjectContext](), Eq[*valid.CommandShell](nil), Eq("./generate.sh"), Eq(repoDir), Any[map[string]string](), Eq(true), Eq[[]valid.PostProcessRunOutputOption](nil), Eq[[]*regexp.Regexp](nil))).
+		Then(func(args []Param) ReturnValues {
+			runCtx = args[0].(command.ProjectContext)
+			return ReturnValues{"run", nil}
+		})
+	When(mockPlan.Run(Any[command.ProjectContext](), Eq[[]string](nil), Eq(repoDir), Any[map[string]string]())).
+		Then(func(args []Param) ReturnValues {
+			planCtx = args[0].(command.ProjectContext)
+			return ReturnValues{"plan", nil}
+		})
+	start := time.Now()
+	res := runner.Plan(ctx)
+
+	Assert(t, res.PlanSuccess != nil, "exp plan success, got error %v", res.Error)
+	// The workflow's plan_timeout wins over the server default, which is
+	// used for apply_timeout.
+	Equals(t, 10*time.Minute, planCtx.PlanTimeout)
+	Equals(t, time.Hour, planCtx.ApplyTimeout)
+	// Both steps share the deadline of the plan stage, and only the run step
+	// has a timeout of its own.
+	Equals(t, 10*time.Minute, planCtx.StageTimeout)
+	Assert(t, !planCtx.StageDeadline.Before(start.Add(10*time.Minute)), "expected the deadline to be 10m after the stage started, got %s", planCtx.StageDeadline)
+	Equals(t, planCtx.StageDeadline, runCtx.StageDeadline)
+	Equals(t, 5*time.Minute, runCtx.StepTimeout)
+	Equals(t, time.Duration(0), planCtx.StepTimeout)
+}
+
+func TestDefaultProjectCommandRunner_PlanCapturesPlanJSON(t *testing.T) {
+	cases := []struct {
+		name        string
+		showOutput  string
+		showErr     error
+		expPlanJSON string
+	}{
+		{name: "show succeeds", showOutput: `{"format_version":"1.2"}`, expPlanJSON: `{"format_version":"1.2"}`},
+		{name: "show fails", showOutput: "partial", showErr: errors.New("no plan file"), expPlanJSON: ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			RegisterMockTestingT(t)
+			mockPlan := mocks.NewMockStepRunner()
+			mockShow := mocks.NewMockStepRunner()
+			mockWorkingDir := mocks.NewMockWorkingDir()
+			mockLocker := mocks.NewMockProjectLocker()
+			runner := events.DefaultProjectCommandRunner{
+				Locker:                    mockLocker,
+				LockURLGenerator:          mockURLGenerator{},
+				PlanStepRunner:            mockPlan,
+				ShowStepRunner:            mockShow,
+				WorkingDir:                mockWorkingDir,
+				WorkingDirLocker:          events.NewDefaultWorkingDirLocker(),
+				CommandRequirementHandler: mocks.NewMockCommandRequirementHandler(),
+			}
+
+			repoDir := t.TempDir()
+			When(mockWorkingDir.Clone(Any[logging.SimpleLogging](), Any[models.Repo](), Any[models.PullRequest](),
+				Any[string]())).ThenReturn(repoDir, nil)
+			When(mockWorkingDir.GitReadLock(Any[models.Repo](), Any[models.PullRequest](), Any[string]())).ThenReturn(func() {})
+			When(mockLocker.TryLock(Any[logging.SimpleLogging](), Any[models.PullRequest](), Any[models.User](), Any[string](),
+				Any[models.Project](), AnyBool())).ThenReturn(&events.TryLockResponse{LockAcquired: true, LockKey: "lock-key"}, nil)
+
+			ctx := command.ProjectContext{
+				Log:             logging.NewNoopLogger(t),
+				Steps:           []valid.Step{{StepName: "plan"}},
+				Workspace:       "default",
+				RepoRelDir:      ".",
+				CapturePlanJSON: true,
+			}
+			When(mockPlan.Run(ctx, nil, repoDir, map[string]string{})).ThenReturn("plan", nil)
+			When(mockShow.Run(ctx, nil, repoDir, map[string]string{})).ThenReturn(c.showOutput, c.showErr)
+
+			res := runner.Plan(ctx)
+			Ok(t, res.Error)
+			Assert(t, res.PlanSuccess != nil, "exp plan success")
+			Equals(t, "plan", res.PlanSuccess.TerraformOutput)
+			Equals(t, c.expPlanJSON, res.PlanSuccess.PlanJSON)
+		})
+	}
+}
+
 func TestDefaultProjectCommandRunner_ProjectLockJobURL(t *testing.T) {
 	const jobURL = "https://atlantis.example.com/jobs/job-id"
 	tests := []struct {
@@ -2131,6 +2320,63 @@ func TestProjectCommandRunner_ApplyLoadsPlanFromStoreBeforeValidation(t *testing
 	mockApply.VerifyWasCalledOnce().Run(Any[command.ProjectContext](), Any[[]string](), Any[string](), Any[map[string]string]())
 }
 
+// A plan whose signed manifest doesn't match is reported as a failure on the
+// pull request rather than applied.
+func TestProjectCommandRunner_ApplyRefusesTamperedSignedPlan(t *testing.T) {
+	RegisterMockTestingT(t)
+	mockApply := mocks.NewMockStepRunner()
+	mockWorkingDir := mocks.NewMockWorkingDir()
+	mockLocker := mocks.NewMockProjectLocker()
+	db := newTestBoltDB(t)
+	repoDir := t.TempDir()
+	store := runtime.NewSignedPlanStore(&runtime.LocalPlanStore{}, []byte(strings.Repeat("k", 32)), logging.NewNoopLogger(t))
+	runner := &events.DefaultProjectCommandRunner{
+		Locker:                    mockLocker,
+		LockURLGenerator:          mockURLGenerator{},
+		ApplyStepRunner:           mockApply,
+		WorkingDir:                mockWorkingDir,
+		WorkingDirLocker:          events.NewDefaultWorkingDirLocker(),
+		CommandRequirementHandler: &events.DefaultCommandRequirementHandler{WorkingDir: mockWorkingDir},
+		ApplyPlanValidator:        &events.DefaultApplyPlanValidator{PullStatusFetcher: db},
+		PlanStore:                 store,
+	}
+	ctx := command.ProjectContext{
+		Log:                             logging.NewNoopLogger(t),
+		CommandName:                     command.Apply,
+		Steps:                           valid.DefaultApplyStage.Steps,
+		RequiresAtlantisManagedPlanFile: true,
+		Workspace:                       "default",
+		RepoRelDir:                      ".",
+		ProjectName:                     "projA",
+		Pull: models.PullRequest{
+			Num:        1,
+			HeadCommit: "abc123",
+			BaseRepo:   models.Repo{FullName: "runatlantis/atlantis", Owner: "runatlantis", Name: "atlantis"},
+		},
+		PullStatus: &models.PullStatus{
+			Pull: models.PullRequest{HeadCommit: "abc123"},
+			Projects: []models.ProjectStatus{
+				{Workspace: "default", RepoRelDir: ".", ProjectName: "projA", Status: models.PlannedPlanStatus},
+			},
+		},
+	}
+	_, err := db.UpdatePullWithResults(ctx.Pull, []command.ProjectResult{plannedProjectResult(ctx.RepoRelDir, ctx.Workspace, ctx.ProjectName)})
+	Ok(t, err)
+	planPath := filepath.Join(repoDir, runtime.GetPlanFilename(ctx.Workspace, ctx.ProjectName))
+	Ok(t, os.WriteFile(planPath, []byte("reviewed-plan"), 0o600))
+	Ok(t, store.Save(ctx, planPath))
+	Ok(t, os.WriteFile(planPath, []byte("swapped-plan"), 0o600))
+	When(mockWorkingDir.GetWorkingDir(ctx.Pull.BaseRepo, ctx.Pull, ctx.Workspace)).ThenReturn(repoDir, nil)
+	When(mockLocker.TryLock(Any[logging.SimpleLogging](), Eq(ctx.Pull), Any[models.User](), Eq(ctx.Workspace), Any[models.Project](), AnyBool())).
+		ThenReturn(&events.TryLockResponse{LockAcquired: true, LockKey: "lock-key"}, nil)
+
+	res := runner.Apply(ctx)
+
+	Ok(t, res.Error)
+	Equals(t, "Refusing to apply: the plan file was modified after it was created, so it may not be the plan that was reviewed. Run `atlantis plan` again to create a new plan.", res.Failure)
+	mockApply.VerifyWasCalled(Never()).Run(Any[command.ProjectContext](), Any[[]string](), Any[string](), Any[map[string]string]())
+}
+
 func TestProjectCommandRunner_ApplyDoesNotRunTerraformWhenLiveHeadChangedAfterCommandStart(t *testing.T) {
 	RegisterMockTestingT(t)
 	mockApply := mocks.NewMockStepRunner()
@@ -71,6 +71,13 @@ func (p *DefaultProjectLocker) TryLock(log logging.SimpleLogging, pull models.Pu
 			link,
 			link,
 			p.ExecutableName)
+		if lockAttempt.QueuePosition > 0 {
+			failureMsg = fmt.Sprintf(
+				"This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nThis pull request is at position **%d** in the queue for this lock and will be planned again automatically once the lock is released.",
+				link,
+				link,
+				lockAttempt.QueuePosition)
+		}
 		return &TryLockResponse{
 			LockAcquired:      false,
 			LockFailureReason: failureMsg,
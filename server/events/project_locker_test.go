@@ -94,6 +94,38 @@ func TestDefaultProjectLocker_TryLockWhenLockedCustomExecutableName(t *testing.T
 	}, res)
 }
 
+func TestDefaultProjectLocker_TryLockWhenLockedAndQueued(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	var githubClient *github.Client
+	mockClient := vcs.NewClientProxy(githubClient, nil, nil, nil, nil, nil)
+	mockLocker := mocks.NewMockLocker(ctrl)
+	locker := events.DefaultProjectLocker{
+		Locker:         mockLocker,
+		VCSClient:      mockClient,
+		ExecutableName: "atlantis",
+	}
+	lockingPull := models.PullRequest{
+		Num: 2,
+	}
+	mockLocker.EXPECT().TryLock(models.Project{}, "default", models.PullRequest{}, models.User{}).Return(
+		locking.TryLockResponse{
+			LockAcquired: false,
+			CurrLock: models.ProjectLock{
+				Pull: lockingPull,
+			},
+			QueuePosition: 2,
+		},
+		nil,
+	)
+	res, err := locker.TryLock(logging.NewNoopLogger(t), models.PullRequest{}, models.User{}, "default", models.Project{}, true)
+	link, _ := mockClient.MarkdownPullLink(lockingPull)
+	Ok(t, err)
+	Equals(t, &events.TryLockResponse{
+		LockAcquired:      false,
+		LockFailureReason: fmt.Sprintf("This project is currently locked by an unapplied plan from pull %s. To continue, delete the lock from %s or apply that plan and merge the pull request.\n\nThis pull request is at position **2** in the queue for this lock and will be planned again automatically once the lock is released.", link, link),
+	}, res)
+}
+
 func TestDefaultProjectLocker_TryLockWhenLockedSamePull(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	var githubClient *github.Client
@@ -24,6 +24,9 @@ type Client struct {
 	Client   *azuredevops.Client
 	ctx      context.Context
 	UserName string
+	// httpClient makes requests whose status code matters, since
+	// azuredevops.Client doesn't return the response of failed requests.
+	httpClient *http.Client
 }
 
 // NewClient returns a valid Azure DevOps client.
@@ -49,9 +52,10 @@ func New(hostname string, userName string, token string) (*Client, error) {
 	}
 
 	client := &Client{
-		Client:   adClient,
-		UserName: userName,
-		ctx:      context.Background(),
+		Client:     adClient,
+		UserName:   userName,
+		ctx:        context.Background(),
+		httpClient: httpClient,
 	}
 
 	return client, nil
@@ -386,6 +390,173 @@ func (g *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest
 	return nil
 }
 
+// gitPush is the body of a request to the pushes API, which the client
+// library doesn't support.
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pushes/create?view=azure-devops-rest-5.1
+type gitPush struct {
+	RefUpdates []gitPushRefUpdate `json:"refUpdates"`
+	Commits    []gitPushCommit    `json:"commits"`
+}
+
+type gitPushRefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId,omitempty"`
+}
+
+type gitPushCommit struct {
+	Comment string          `json:"comment"`
+	Changes []gitPushChange `json:"changes"`
+}
+
+type gitPushChange struct {
+	ChangeType string `json:"changeType"`
+	Item       struct {
+		Path string `json:"path"`
+	} `json:"item"`
+	NewContent struct {
+		Content     string `json:"content"`
+		ContentType string `json:"contentType"`
+	} `json:"newContent"`
+}
+
+// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+// opts.Files to it and opens a pull request. The branch is deleted again if
+// the pull request can't be opened.
+func (g *Client) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	logger.Debug("Creating Azure DevOps pull request from %s into %s", opts.HeadBranch, opts.BaseBranch)
+	owner, project, repoName := SplitAzureDevopsRepoFullName(repo.FullName)
+
+	baseRefName := "refs/heads/" + opts.BaseBranch
+	refsURL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/refs?filter=%s&api-version=5.1",
+		owner, project, repoName, url.QueryEscape(strings.TrimPrefix(baseRefName, "refs/")))
+	req, err := g.Client.NewRequest("GET", refsURL, nil)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	refs := new(azuredevops.GitRefsResponse)
+	if _, err := g.Client.Execute(g.ctx, req, refs); err != nil {
+		return models.PullRequest{}, fmt.Errorf("getting ref of base branch %q: %w", opts.BaseBranch, err)
+	}
+	// The filter matches by prefix so we look for the exact ref.
+	var baseCommit string
+	for _, ref := range refs.GitRefs {
+		if ref.GetName() == baseRefName {
+			baseCommit = ref.GetObjectID()
+			break
+		}
+	}
+	if baseCommit == "" {
+		return models.PullRequest{}, fmt.Errorf("base branch %q not found", opts.BaseBranch)
+	}
+
+	// Pushing a commit to a ref whose old object is the base commit creates
+	// the new branch and the commit at once.
+	changes := make([]gitPushChange, 0, len(opts.Files))
+	for _, file := range opts.Files {
+		change := gitPushChange{ChangeType: "add"}
+		change.Item.Path = "/" + strings.TrimPrefix(file.Path, "/")
+		// Adding a file that exists fails, so existing files are edited.
+		exists, err := g.fileExists(owner, project, repoName, change.Item.Path, baseCommit)
+		if err != nil {
+			return models.PullRequest{}, fmt.Errorf("getting %q on branch %q: %w", file.Path, opts.BaseBranch, err)
+		}
+		if exists {
+			change.ChangeType = "edit"
+		}
+		change.NewContent.Content = file.Content
+		change.NewContent.ContentType = "rawtext"
+		changes = append(changes, change)
+	}
+	push := gitPush{
+		RefUpdates: []gitPushRefUpdate{{Name: "refs/heads/" + opts.HeadBranch, OldObjectID: baseCommit}},
+		Commits:    []gitPushCommit{{Comment: opts.CommitMessage, Changes: changes}},
+	}
+	pushURL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pushes?api-version=5.1", owner, project, repoName)
+	req, err = g.Client.NewRequest("POST", pushURL, push)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	pushed := new(gitPush)
+	if _, err := g.Client.Execute(g.ctx, req, pushed); err != nil {
+		return models.PullRequest{}, fmt.Errorf("committing files to branch %q: %w", opts.HeadBranch, err)
+	}
+
+	// PullRequests.Create prefixes branch names containing a slash with
+	// refs/heads/ even if they already have it, so we make the request here.
+	pullsURL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests?api-version=5.1-preview.1", owner, project, repoName)
+	req, err = g.Client.NewRequest("POST", pullsURL, &azuredevops.GitPullRequest{
+		Title:         azuredevops.String(opts.Title),
+		Description:   azuredevops.String(opts.Body),
+		SourceRefName: azuredevops.String("refs/heads/" + opts.HeadBranch),
+		TargetRefName: azuredevops.String(baseRefName),
+	})
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	pull := new(azuredevops.GitPullRequest)
+	if _, err := g.Client.Execute(g.ctx, req, pull); err != nil {
+		// Pushing created the branch, so it's deleted again to not leave it
+		// behind.
+		if len(pushed.RefUpdates) > 0 {
+			g.deleteBranch(logger, owner, project, repoName, opts.HeadBranch, pushed.RefUpdates[0].NewObjectID)
+		}
+		return models.PullRequest{}, fmt.Errorf("creating pull request: %w", err)
+	}
+
+	return models.PullRequest{
+		Num:        pull.GetPullRequestID(),
+		HeadCommit: pull.GetLastMergeSourceCommit().GetCommitID(),
+		URL:        pull.GetURL(),
+		HeadBranch: opts.HeadBranch,
+		BaseBranch: opts.BaseBranch,
+		Body:       opts.Body,
+		State:      models.OpenPullState,
+		BaseRepo:   repo,
+	}, nil
+}
+
+// fileExists returns whether the file at path exists at commit.
+func (g *Client) fileExists(owner, project, repoName, path, commit string) (bool, error) {
+	itemURL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items?path=%s&versionDescriptor.version=%s&versionDescriptor.versionType=commit&api-version=5.1",
+		owner, project, repoName, url.QueryEscape(path), url.QueryEscape(commit))
+	req, err := g.Client.NewRequest("GET", itemURL, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := g.httpClient.Do(req.WithContext(g.ctx))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("request to %s responded with status %d", req.URL, resp.StatusCode)
+	}
+}
+
+// deleteBranch deletes a branch that CreatePullRequest created and that
+// points at commit. Errors are only logged since the pull request already
+// failed.
+func (g *Client) deleteBranch(logger logging.SimpleLogging, owner, project, repoName, branch, commit string) {
+	refsURL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/refs?api-version=5.1", owner, project, repoName)
+	req, err := g.Client.NewRequest("POST", refsURL, []gitPushRefUpdate{{
+		Name:        "refs/heads/" + branch,
+		OldObjectID: commit,
+		NewObjectID: "0000000000000000000000000000000000000000",
+	}})
+	if err == nil {
+		_, err = g.Client.Execute(g.ctx, req, nil)
+	}
+	if err != nil {
+		logger.Warn("unable to delete branch %q after failing to open a pull request: %s", branch, err)
+	}
+}
+
 // MarkdownPullLink specifies the string used in a pull request comment to reference another pull request.
 func (g *Client) MarkdownPullLink(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("!%d", pull.Num), nil
@@ -455,6 +626,19 @@ func (g *Client) GetPullLabels(_ logging.SimpleLogging, _ models.Repo, _ models.
 	return nil, fmt.Errorf("not yet implemented")
 }
 
+// GetPullState returns whether the pull request is open or closed. Completed
+// and abandoned pull requests are closed.
+func (g *Client) GetPullState(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	adPull, err := g.GetPullRequest(logger, repo, pull.Num)
+	if err != nil {
+		return models.OpenPullState, fmt.Errorf("getting pull request: %w", err)
+	}
+	if adPull.GetStatus() == azuredevops.PullActive.String() {
+		return models.OpenPullState, nil
+	}
+	return models.ClosedPullState, nil
+}
+
 func (g *Client) GetChildTeams(_ logging.SimpleLogging, _ models.Repo, _ string) ([]string, error) {
 	return nil, nil
 }
@@ -5,6 +5,7 @@ package azuredevops_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -149,6 +150,115 @@ func TestAzureDevopsClient_MergePull(t *testing.T) {
 	}
 }
 
+func TestAzureDevopsClient_CreatePullRequest(t *testing.T) {
+	var pushBody, pullBody map[string]any
+	testServer := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "GET /owner/project/_apis/git/repositories/repo/refs?filter=heads%2Fmain&api-version=5.1":
+				w.Write([]byte(`{"count":2,"value":[{"name":"refs/heads/main-old","objectId":"othersha"},{"name":"refs/heads/main","objectId":"basesha"}]}`)) // nolint: errcheck
+			case "GET /owner/project/_apis/git/repositories/repo/items?path=%2F.atlantis%2Fdrift.md&versionDescriptor.version=basesha&versionDescriptor.versionType=commit&api-version=5.1":
+				http.Error(w, "not found", http.StatusNotFound)
+			case "POST /owner/project/_apis/git/repositories/repo/pushes?api-version=5.1":
+				Ok(t, json.NewDecoder(r.Body).Decode(&pushBody))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{}`)) // nolint: errcheck
+			case "POST /owner/project/_apis/git/repositories/repo/pullrequests?api-version=5.1-preview.1":
+				Ok(t, json.NewDecoder(r.Body).Decode(&pullBody))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"pullRequestId":4,"url":"https://dev.azure.com/owner/project/_git/repo/pullrequest/4","lastMergeSourceCommit":{"commitId":"headsha"}}`)) // nolint: errcheck
+			default:
+				t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+
+	testServerURL, err := url.Parse(testServer.URL)
+	Ok(t, err)
+	client, err := azuredevopsclient.New(testServerURL.Host, "user", "token")
+	Ok(t, err)
+	defer common.DisableSSLVerification()()
+
+	pull, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName: "owner/project/repo",
+		Owner:    "owner",
+		Name:     "repo",
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		Body:          "body",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: ".atlantis/drift.md", Content: "report"}},
+	})
+	Ok(t, err)
+
+	Equals(t, []any{map[string]any{"name": "refs/heads/atlantis/drift", "oldObjectId": "basesha"}}, pushBody["refUpdates"])
+	commit := pushBody["commits"].([]any)[0].(map[string]any)
+	Equals(t, "Record drift", commit["comment"])
+	change := commit["changes"].([]any)[0].(map[string]any)
+	Equals(t, "add", change["changeType"])
+	Equals(t, map[string]any{"path": "/.atlantis/drift.md"}, change["item"])
+	Equals(t, map[string]any{"content": "report", "contentType": "rawtext"}, change["newContent"])
+	Equals(t, "refs/heads/atlantis/drift", pullBody["sourceRefName"])
+	Equals(t, "refs/heads/main", pullBody["targetRefName"])
+	Equals(t, 4, pull.Num)
+	Equals(t, "https://dev.azure.com/owner/project/_git/repo/pullrequest/4", pull.URL)
+	Equals(t, "headsha", pull.HeadCommit)
+}
+
+func TestAzureDevopsClient_CreatePullRequest_EditsFileAndDeletesBranchOnError(t *testing.T) {
+	var pushBody map[string]any
+	var deleteBody []any
+	testServer := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "GET /owner/project/_apis/git/repositories/repo/refs?filter=heads%2Fmain&api-version=5.1":
+				w.Write([]byte(`{"count":1,"value":[{"name":"refs/heads/main","objectId":"basesha"}]}`)) // nolint: errcheck
+			case "GET /owner/project/_apis/git/repositories/repo/items?path=%2Fdrift.tf&versionDescriptor.version=basesha&versionDescriptor.versionType=commit&api-version=5.1":
+				w.Write([]byte(`{"path":"/drift.tf"}`)) // nolint: errcheck
+			case "POST /owner/project/_apis/git/repositories/repo/pushes?api-version=5.1":
+				Ok(t, json.NewDecoder(r.Body).Decode(&pushBody))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"refUpdates":[{"name":"refs/heads/atlantis/drift","oldObjectId":"basesha","newObjectId":"headsha"}]}`)) // nolint: errcheck
+			case "POST /owner/project/_apis/git/repositories/repo/pullrequests?api-version=5.1-preview.1":
+				http.Error(w, "conflict", http.StatusConflict)
+			case "POST /owner/project/_apis/git/repositories/repo/refs?api-version=5.1":
+				Ok(t, json.NewDecoder(r.Body).Decode(&deleteBody))
+				w.Write([]byte(`{"count":1,"value":[{"success":true}]}`)) // nolint: errcheck
+			default:
+				t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+
+	testServerURL, err := url.Parse(testServer.URL)
+	Ok(t, err)
+	client, err := azuredevopsclient.New(testServerURL.Host, "user", "token")
+	Ok(t, err)
+	defer common.DisableSSLVerification()()
+
+	_, err = client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName: "owner/project/repo",
+		Owner:    "owner",
+		Name:     "repo",
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: "drift.tf", Content: "# drift"}},
+	})
+	ErrContains(t, "creating pull request", err)
+	change := pushBody["commits"].([]any)[0].(map[string]any)["changes"].([]any)[0].(map[string]any)
+	Equals(t, "edit", change["changeType"])
+	Equals(t, []any{map[string]any{
+		"name":        "refs/heads/atlantis/drift",
+		"oldObjectId": "headsha",
+		"newObjectId": "0000000000000000000000000000000000000000",
+	}}, deleteBody)
+}
+
 func TestAzureDevopsClient_UpdateStatus(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	cases := []struct {
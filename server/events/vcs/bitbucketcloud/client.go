@@ -351,6 +351,85 @@ func (b *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest
 	return err
 }
 
+// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+// opts.Files to it and opens a pull request. Existing files are overwritten.
+// The branch is deleted again if the pull request can't be opened.
+func (b *Client) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	logger.Debug("Creating Bitbucket Cloud pull request from %s into %s", opts.HeadBranch, opts.BaseBranch)
+	path := fmt.Sprintf("%s/2.0/repositories/%s/refs/branches/%s", b.BaseURL, repo.FullName, url.PathEscape(opts.BaseBranch))
+	resp, err := b.makeRequest("GET", path, nil)
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("getting base branch %q: %w", opts.BaseBranch, err)
+	}
+	var baseBranch struct {
+		Target Commit `json:"target"`
+	}
+	if err := json.Unmarshal(resp, &baseBranch); err != nil {
+		return models.PullRequest{}, fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+	if baseBranch.Target.Hash == nil {
+		return models.PullRequest{}, fmt.Errorf("response %q was missing the base branch commit", string(resp))
+	}
+
+	// Committing to a branch that doesn't exist yet creates it on top of the
+	// parent commit.
+	form := url.Values{}
+	form.Set("branch", opts.HeadBranch)
+	form.Set("parents", *baseBranch.Target.Hash)
+	form.Set("message", opts.CommitMessage)
+	for _, file := range opts.Files {
+		form.Set(file.Path, file.Content)
+	}
+	path = fmt.Sprintf("%s/2.0/repositories/%s/src", b.BaseURL, repo.FullName)
+	if _, err := b.makeFormRequest(path, form); err != nil {
+		return models.PullRequest{}, fmt.Errorf("committing files to branch %q: %w", opts.HeadBranch, err)
+	}
+
+	bodyBytes, err := json.Marshal(map[string]any{
+		"title":       opts.Title,
+		"description": opts.Body,
+		"source":      map[string]any{"branch": map[string]string{"name": opts.HeadBranch}},
+		"destination": map[string]any{"branch": map[string]string{"name": opts.BaseBranch}},
+	})
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("json encoding: %w", err)
+	}
+	path = fmt.Sprintf("%s/2.0/repositories/%s/pullrequests", b.BaseURL, repo.FullName)
+	resp, err = b.makeRequest("POST", path, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		// Committing created the branch, so it's deleted again to not leave
+		// it behind.
+		path = fmt.Sprintf("%s/2.0/repositories/%s/refs/branches/%s", b.BaseURL, repo.FullName, url.PathEscape(opts.HeadBranch))
+		if _, deleteErr := b.makeRequest("DELETE", path, nil); deleteErr != nil {
+			logger.Warn("unable to delete branch %q after failing to open a pull request: %s", opts.HeadBranch, deleteErr)
+		}
+		return models.PullRequest{}, fmt.Errorf("creating pull request: %w", err)
+	}
+	var pullResp PullRequest
+	if err := json.Unmarshal(resp, &pullResp); err != nil {
+		return models.PullRequest{}, fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+	if pullResp.ID == nil {
+		return models.PullRequest{}, fmt.Errorf("response %q was missing the pull request id", string(resp))
+	}
+
+	pull := models.PullRequest{
+		Num:        *pullResp.ID,
+		HeadBranch: opts.HeadBranch,
+		BaseBranch: opts.BaseBranch,
+		Body:       opts.Body,
+		State:      models.OpenPullState,
+		BaseRepo:   repo,
+	}
+	if pullResp.Links != nil && pullResp.Links.HTML != nil && pullResp.Links.HTML.HREF != nil {
+		pull.URL = *pullResp.Links.HTML.HREF
+	}
+	if pullResp.Source != nil && pullResp.Source.Commit != nil && pullResp.Source.Commit.Hash != nil {
+		pull.HeadCommit = *pullResp.Source.Commit.Hash
+	}
+	return pull, nil
+}
+
 // MarkdownPullLink specifies the character used in a pull request comment.
 func (b *Client) MarkdownPullLink(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("#%d", pull.Num), nil
@@ -383,12 +462,27 @@ func (b *Client) makeRequest(method string, path string, reqBody io.Reader) ([]b
 	if err != nil {
 		return nil, fmt.Errorf("constructing request: %w", err)
 	}
+	return b.doRequest(req)
+}
+
+// makeFormRequest POSTs form to path. Some endpoints, like the one that
+// creates commits, only accept form data.
+func (b *Client) makeFormRequest(path string, form url.Values) ([]byte, error) {
+	req, err := b.prepRequest("POST", path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("constructing request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return b.doRequest(req)
+}
+
+func (b *Client) doRequest(req *http.Request) ([]byte, error) {
 	resp, err := b.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close() // nolint: errcheck
-	requestStr := fmt.Sprintf("%s %s", method, path)
+	requestStr := fmt.Sprintf("%s %s", req.Method, req.URL)
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
 		respBody, _ := io.ReadAll(resp.Body)
@@ -425,6 +519,27 @@ func (b *Client) GetPullLabels(_ logging.SimpleLogging, _ models.Repo, _ models.
 	return nil, fmt.Errorf("not yet implemented")
 }
 
+// GetPullState returns whether the pull request is open or closed. Merged,
+// declined and superseded pull requests are closed.
+func (b *Client) GetPullState(_ logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	path := fmt.Sprintf("%s/2.0/repositories/%s/pullrequests/%d", b.BaseURL, repo.FullName, pull.Num)
+	resp, err := b.makeRequest("GET", path, nil)
+	if err != nil {
+		return models.OpenPullState, err
+	}
+	var pullResp PullRequest
+	if err := json.Unmarshal(resp, &pullResp); err != nil {
+		return models.OpenPullState, fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+	if pullResp.State == nil {
+		return models.OpenPullState, fmt.Errorf("response %q was missing the pull request state", string(resp))
+	}
+	if *pullResp.State == "OPEN" {
+		return models.OpenPullState, nil
+	}
+	return models.ClosedPullState, nil
+}
+
 func (b *Client) GetChildTeams(_ logging.SimpleLogging, _ models.Repo, _ string) ([]string, error) {
 	return nil, nil
 }
@@ -5,8 +5,10 @@ package bitbucketcloud_test
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -492,6 +494,123 @@ func TestClient_PullIsMergeable_SSRFPrevented(t *testing.T) {
 	Assert(t, strings.Contains(err.Error(), "does not match base URL origin"), "error should mention origin mismatch, got: %s", err.Error())
 }
 
+func TestClient_GetPullState(t *testing.T) {
+	cases := map[string]models.PullRequestState{
+		"OPEN":       models.OpenPullState,
+		"MERGED":     models.ClosedPullState,
+		"DECLINED":   models.ClosedPullState,
+		"SUPERSEDED": models.ClosedPullState,
+	}
+	for bbState, expState := range cases {
+		t.Run(bbState, func(t *testing.T) {
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.RequestURI {
+				case "/2.0/repositories/owner/repo/pullrequests/1":
+					w.Write([]byte(`{"id":1,"state":"` + bbState + `"}`)) // nolint: errcheck
+				default:
+					t.Errorf("got unexpected request at %q", r.RequestURI)
+					http.Error(w, "not found", http.StatusNotFound)
+				}
+			}))
+			defer testServer.Close()
+
+			client := bitbucketcloud.New(http.DefaultClient, "user", "pass", "", "runatlantis.io")
+			client.BaseURL = testServer.URL
+			state, err := client.GetPullState(logging.NewNoopLogger(t), models.Repo{FullName: "owner/repo"}, models.PullRequest{Num: 1})
+			Ok(t, err)
+			Equals(t, expState, state)
+		})
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	var commitForm url.Values
+	var pullBody string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /2.0/repositories/owner/repo/refs/branches/main":
+			w.Write([]byte(`{"name":"main","target":{"hash":"basesha"}}`)) // nolint: errcheck
+		case "POST /2.0/repositories/owner/repo/src":
+			Equals(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+			Ok(t, r.ParseForm())
+			commitForm = r.PostForm
+			w.WriteHeader(http.StatusCreated)
+		case "POST /2.0/repositories/owner/repo/pullrequests":
+			body, err := io.ReadAll(r.Body)
+			Ok(t, err)
+			pullBody = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":3,"links":{"html":{"href":"https://bitbucket.org/owner/repo/pull-requests/3"}},"source":{"commit":{"hash":"headsha"}}}`)) // nolint: errcheck
+		default:
+			t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	client := bitbucketcloud.New(http.DefaultClient, "user", "pass", "", "runatlantis.io")
+	client.BaseURL = testServer.URL
+	pull, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName: "owner/repo",
+		Owner:    "owner",
+		Name:     "repo",
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		Body:          "body",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: ".atlantis/drift.md", Content: "report"}},
+	})
+	Ok(t, err)
+
+	Equals(t, "atlantis/drift", commitForm.Get("branch"))
+	Equals(t, "basesha", commitForm.Get("parents"))
+	Equals(t, "Record drift", commitForm.Get("message"))
+	Equals(t, "report", commitForm.Get(".atlantis/drift.md"))
+	Equals(t, `{"description":"body","destination":{"branch":{"name":"main"}},"source":{"branch":{"name":"atlantis/drift"}},"title":"Codify drift"}`, pullBody)
+	Equals(t, 3, pull.Num)
+	Equals(t, "https://bitbucket.org/owner/repo/pull-requests/3", pull.URL)
+	Equals(t, "headsha", pull.HeadCommit)
+}
+
+func TestClient_CreatePullRequest_DeletesBranchOnError(t *testing.T) {
+	deleted := false
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "GET /2.0/repositories/owner/repo/refs/branches/main":
+			w.Write([]byte(`{"name":"main","target":{"hash":"basesha"}}`)) // nolint: errcheck
+		case "POST /2.0/repositories/owner/repo/src":
+			w.WriteHeader(http.StatusCreated)
+		case "POST /2.0/repositories/owner/repo/pullrequests":
+			http.Error(w, `{"error":{"message":"There are no changes to be pulled"}}`, http.StatusBadRequest)
+		case "DELETE /2.0/repositories/owner/repo/refs/branches/atlantis%2Fdrift":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	client := bitbucketcloud.New(http.DefaultClient, "user", "pass", "", "runatlantis.io")
+	client.BaseURL = testServer.URL
+	_, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName: "owner/repo",
+		Owner:    "owner",
+		Name:     "repo",
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: "drift.tf", Content: "# drift"}},
+	})
+	ErrContains(t, "creating pull request", err)
+	Assert(t, deleted, "expected the branch to be deleted")
+}
+
 func TestClient_MarkdownPullLink(t *testing.T) {
 	client := bitbucketcloud.New(http.DefaultClient, "user", "pass", "", "runatlantis.io")
 	pull := models.PullRequest{Num: 1}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -335,6 +336,185 @@ func (b *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest
 	return err
 }
 
+// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+// opts.Files to it and opens a pull request. The branch is deleted again if
+// the files can't be committed or the pull request can't be opened.
+func (b *Client) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	logger.Debug("Creating Bitbucket Server pull request from %s into %s", opts.HeadBranch, opts.BaseBranch)
+	projectKey, err := b.GetProjectKey(repo.Name, repo.SanitizedCloneURL)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	bodyBytes, err := json.Marshal(map[string]string{
+		"name":       opts.HeadBranch,
+		"startPoint": "refs/heads/" + opts.BaseBranch,
+	})
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("json encoding: %w", err)
+	}
+	path := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/branches", b.BaseURL, projectKey, repo.Name)
+	resp, err := b.makeRequest("POST", path, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("creating branch %q: %w", opts.HeadBranch, err)
+	}
+	opened := false
+	defer func() {
+		if !opened {
+			b.deleteBranch(logger, projectKey, repo, opts.HeadBranch)
+		}
+	}()
+	var branchResp struct {
+		LatestCommit string `json:"latestCommit"`
+	}
+	if err := json.Unmarshal(resp, &branchResp); err != nil {
+		return models.PullRequest{}, fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+
+	headCommit := branchResp.LatestCommit
+	for _, file := range opts.Files {
+		if headCommit, err = b.commitFile(projectKey, repo, opts.HeadBranch, headCommit, opts.CommitMessage, file); err != nil {
+			return models.PullRequest{}, fmt.Errorf("committing %q to branch %q: %w", file.Path, opts.HeadBranch, err)
+		}
+	}
+
+	repoRef := map[string]any{
+		"slug":    repo.Name,
+		"project": map[string]string{"key": projectKey},
+	}
+	bodyBytes, err = json.Marshal(map[string]any{
+		"title":       opts.Title,
+		"description": opts.Body,
+		"fromRef":     map[string]any{"id": "refs/heads/" + opts.HeadBranch, "repository": repoRef},
+		"toRef":       map[string]any{"id": "refs/heads/" + opts.BaseBranch, "repository": repoRef},
+	})
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("json encoding: %w", err)
+	}
+	path = fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests", b.BaseURL, projectKey, repo.Name)
+	resp, err = b.makeRequest("POST", path, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("creating pull request: %w", err)
+	}
+	opened = true
+	var pullResp struct {
+		PullRequest
+		Links struct {
+			Self []struct {
+				HREF string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal(resp, &pullResp); err != nil {
+		return models.PullRequest{}, fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+	if pullResp.ID == nil {
+		return models.PullRequest{}, fmt.Errorf("response %q was missing the pull request id", string(resp))
+	}
+
+	pull := models.PullRequest{
+		Num:        *pullResp.ID,
+		HeadBranch: opts.HeadBranch,
+		BaseBranch: opts.BaseBranch,
+		Body:       opts.Body,
+		State:      models.OpenPullState,
+		BaseRepo:   repo,
+	}
+	if len(pullResp.Links.Self) > 0 {
+		pull.URL = pullResp.Links.Self[0].HREF
+	}
+	if pullResp.FromRef != nil && pullResp.FromRef.LatestCommit != nil {
+		pull.HeadCommit = *pullResp.FromRef.LatestCommit
+	}
+	return pull, nil
+}
+
+// commitFile commits file to branch, whose latest commit is headCommit, with
+// the file edit endpoint, which commits one file at a time. It returns the
+// new latest commit of branch.
+func (b *Client) commitFile(projectKey string, repo models.Repo, branch string, headCommit string, message string, file models.PullRequestFile) (string, error) {
+	path := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/browse/%s", b.BaseURL, projectKey, repo.Name, file.Path)
+	fields := map[string]string{
+		"branch":  branch,
+		"message": message,
+		"content": file.Content,
+	}
+	order := []string{"branch", "message", "content"}
+	// Overwriting a file needs the commit that the new content is based on.
+	exists, err := b.fileExists(path, headCommit)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		fields["sourceCommitId"] = headCommit
+		order = append(order, "sourceCommitId")
+	}
+
+	// The file edit endpoint only accepts multipart form data.
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	for _, field := range order {
+		if err := writer.WriteField(field, fields[field]); err != nil {
+			return "", fmt.Errorf("encoding form: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("encoding form: %w", err)
+	}
+	req, err := b.prepRequest("PUT", path, &form)
+	if err != nil {
+		return "", fmt.Errorf("constructing request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err := b.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(resp, &commit); err != nil {
+		return "", fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+	return commit.ID, nil
+}
+
+// fileExists returns whether the file at the browse endpoint path exists at
+// commit.
+func (b *Client) fileExists(path string, commit string) (bool, error) {
+	req, err := b.prepRequest("GET", path+"?type=true&at="+url.QueryEscape(commit), nil)
+	if err != nil {
+		return false, fmt.Errorf("constructing request: %w", err)
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		respBody, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("making request %q unexpected status code: %d, body: %s", req.Method+" "+req.URL.String(), resp.StatusCode, string(respBody))
+	}
+}
+
+// deleteBranch deletes a branch that CreatePullRequest created. Errors are
+// only logged since the pull request already failed.
+func (b *Client) deleteBranch(logger logging.SimpleLogging, projectKey string, repo models.Repo, branch string) {
+	bodyBytes, err := json.Marshal(DeleteSourceBranch{Name: "refs/heads/" + branch, DryRun: false})
+	if err == nil {
+		path := fmt.Sprintf("%s/rest/branch-utils/1.0/projects/%s/repos/%s/branches", b.BaseURL, projectKey, repo.Name)
+		_, err = b.makeRequest("DELETE", path, bytes.NewBuffer(bodyBytes))
+	}
+	if err != nil {
+		logger.Warn("unable to delete branch %q after failing to open a pull request: %s", branch, err)
+	}
+}
+
 // MarkdownPullLink specifies the character used in a pull request comment.
 func (b *Client) MarkdownPullLink(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("#%d", pull.Num), nil
@@ -365,12 +545,16 @@ func (b *Client) makeRequest(method string, path string, reqBody io.Reader) ([]b
 	if err != nil {
 		return nil, fmt.Errorf("constructing request: %w", err)
 	}
+	return b.doRequest(req)
+}
+
+func (b *Client) doRequest(req *http.Request) ([]byte, error) {
 	resp, err := b.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close() // nolint: errcheck
-	requestStr := fmt.Sprintf("%s %s", method, path)
+	requestStr := fmt.Sprintf("%s %s", req.Method, req.URL)
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != 204 {
 		respBody, _ := io.ReadAll(resp.Body)
@@ -407,6 +591,31 @@ func (b *Client) GetPullLabels(_ logging.SimpleLogging, _ models.Repo, _ models.
 	return nil, fmt.Errorf("not yet implemented")
 }
 
+// GetPullState returns whether the pull request is open or closed. Merged and
+// declined pull requests are closed.
+func (b *Client) GetPullState(_ logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	projectKey, err := b.GetProjectKey(repo.Name, repo.SanitizedCloneURL)
+	if err != nil {
+		return models.OpenPullState, err
+	}
+	path := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", b.BaseURL, projectKey, repo.Name, pull.Num)
+	resp, err := b.makeRequest("GET", path, nil)
+	if err != nil {
+		return models.OpenPullState, err
+	}
+	var pullResp PullRequest
+	if err := json.Unmarshal(resp, &pullResp); err != nil {
+		return models.OpenPullState, fmt.Errorf("parsing response %q: %w", string(resp), err)
+	}
+	if pullResp.State == nil {
+		return models.OpenPullState, fmt.Errorf("response %q was missing the pull request state", string(resp))
+	}
+	if *pullResp.State == "OPEN" {
+		return models.OpenPullState, nil
+	}
+	return models.ClosedPullState, nil
+}
+
 func (b *Client) GetChildTeams(_ logging.SimpleLogging, _ models.Repo, _ string) ([]string, error) {
 	return nil, nil
 }
@@ -259,6 +259,103 @@ func TestClient_MergePullDeleteSourceBranch(t *testing.T) {
 	Ok(t, err)
 }
 
+func TestClient_CreatePullRequest(t *testing.T) {
+	var branchBody, pullBody map[string]any
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "POST /rest/api/1.0/projects/ow/repos/repo/branches":
+			Ok(t, json.NewDecoder(r.Body).Decode(&branchBody))
+			w.Write([]byte(`{"id":"refs/heads/atlantis/drift","latestCommit":"basesha"}`)) // nolint: errcheck
+		case "GET /rest/api/1.0/projects/ow/repos/repo/browse/.atlantis/drift.md?type=true&at=basesha":
+			http.Error(w, "not found", http.StatusNotFound)
+		case "PUT /rest/api/1.0/projects/ow/repos/repo/browse/.atlantis/drift.md":
+			Ok(t, r.ParseMultipartForm(1<<20))
+			Equals(t, "atlantis/drift", r.FormValue("branch"))
+			Equals(t, "Record drift", r.FormValue("message"))
+			Equals(t, "report", r.FormValue("content"))
+			Equals(t, "", r.FormValue("sourceCommitId"))
+			w.Write([]byte(`{"id":"headsha"}`)) // nolint: errcheck
+		case "POST /rest/api/1.0/projects/ow/repos/repo/pull-requests":
+			Ok(t, json.NewDecoder(r.Body).Decode(&pullBody))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":9,"fromRef":{"latestCommit":"headsha"},"links":{"self":[{"href":"https://bitbucket.example.com/projects/ow/repos/repo/pull-requests/9"}]}}`)) // nolint: errcheck
+		default:
+			t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	client, err := bitbucketserver.NewClient(http.DefaultClient, "user", "pass", testServer.URL, "runatlantis.io")
+	Ok(t, err)
+
+	pull, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName:          "owner/repo",
+		Owner:             "owner",
+		Name:              "repo",
+		SanitizedCloneURL: fmt.Sprintf("%s/scm/ow/repo.git", testServer.URL),
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		Body:          "body",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: ".atlantis/drift.md", Content: "report"}},
+	})
+	Ok(t, err)
+
+	Equals(t, map[string]any{"name": "atlantis/drift", "startPoint": "refs/heads/main"}, branchBody)
+	Equals(t, "Codify drift", pullBody["title"])
+	Equals(t, "refs/heads/atlantis/drift", pullBody["fromRef"].(map[string]any)["id"])
+	Equals(t, "refs/heads/main", pullBody["toRef"].(map[string]any)["id"])
+	Equals(t, 9, pull.Num)
+	Equals(t, "https://bitbucket.example.com/projects/ow/repos/repo/pull-requests/9", pull.URL)
+	Equals(t, "headsha", pull.HeadCommit)
+}
+
+func TestClient_CreatePullRequest_OverwritesFileAndDeletesBranchOnError(t *testing.T) {
+	var deleteBody map[string]any
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.RequestURI {
+		case "POST /rest/api/1.0/projects/ow/repos/repo/branches":
+			w.Write([]byte(`{"id":"refs/heads/atlantis/drift","latestCommit":"basesha"}`)) // nolint: errcheck
+		case "GET /rest/api/1.0/projects/ow/repos/repo/browse/drift.tf?type=true&at=basesha":
+			w.Write([]byte(`{"type":"FILE"}`)) // nolint: errcheck
+		case "PUT /rest/api/1.0/projects/ow/repos/repo/browse/drift.tf":
+			Ok(t, r.ParseMultipartForm(1<<20))
+			Equals(t, "basesha", r.FormValue("sourceCommitId"))
+			w.Write([]byte(`{"id":"headsha"}`)) // nolint: errcheck
+		case "POST /rest/api/1.0/projects/ow/repos/repo/pull-requests":
+			http.Error(w, `{"errors":[{"message":"Only one pull request may be open for a given source and target branch"}]}`, http.StatusConflict)
+		case "DELETE /rest/branch-utils/1.0/projects/ow/repos/repo/branches":
+			Ok(t, json.NewDecoder(r.Body).Decode(&deleteBody))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	client, err := bitbucketserver.NewClient(http.DefaultClient, "user", "pass", testServer.URL, "runatlantis.io")
+	Ok(t, err)
+
+	_, err = client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName:          "owner/repo",
+		Owner:             "owner",
+		Name:              "repo",
+		SanitizedCloneURL: fmt.Sprintf("%s/scm/ow/repo.git", testServer.URL),
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: "drift.tf", Content: "# drift"}},
+	})
+	ErrContains(t, "creating pull request", err)
+	Equals(t, "refs/heads/atlantis/drift", deleteBody["name"])
+}
+
 func TestClient_MarkdownPullLink(t *testing.T) {
 	client, err := bitbucketserver.NewClient(nil, "u", "p", "https://base-url", "atlantis-url")
 	Ok(t, err)
@@ -45,7 +45,19 @@ type Client interface {
 	// GetPullLabels returns the labels of a pull request
 	GetPullLabels(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) ([]string, error)
 
+	// GetPullState returns whether the pull request is open or closed.
+	// Merged and declined pull requests are closed.
+	GetPullState(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error)
+
 	// GetChildTeams returns the slugs of all teams that are children of the given team.
 	// Returns nil, nil for VCS providers that don't support team hierarchies.
 	GetChildTeams(logger logging.SimpleLogging, repo models.Repo, teamSlug string) ([]string, error)
+
+	// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+	// opts.Files to it and opens a pull request into opts.BaseBranch.
+	// If the files can't be committed or the pull request can't be opened,
+	// the branch is deleted again.
+	// The returned pull request has at least Num, URL, HeadBranch, BaseBranch
+	// and BaseRepo set.
+	CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error)
 }
@@ -197,6 +197,30 @@ func (c *InstrumentedClient) MergePull(logger logging.SimpleLogging, pull models
 	return nil
 }
 
+func (c *InstrumentedClient) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	scope := c.StatsScope.SubScope("create_pull_request")
+	// The pull request number isn't known until it has been created.
+	scope = scope.Tagged(map[string]string{
+		"base_repo": repo.FullName,
+	})
+
+	executionTime := scope.Timer(metrics.ExecutionTimeMetric).Start()
+	defer executionTime.Stop()
+
+	executionSuccess := scope.Counter(metrics.ExecutionSuccessMetric)
+	executionError := scope.Counter(metrics.ExecutionErrorMetric)
+
+	pull, err := c.Client.CreatePullRequest(logger, repo, opts)
+	if err != nil {
+		executionError.Inc(1)
+		logger.Err("Unable to create pull request, error: %s", err.Error())
+		return pull, err
+	}
+
+	executionSuccess.Inc(1)
+	return pull, nil
+}
+
 func SetGitScopeTags(scope tally.Scope, repoFullName string, pullNum int) tally.Scope {
 	return scope.Tagged(map[string]string{
 		"base_repo": repoFullName,
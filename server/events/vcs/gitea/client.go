@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -438,6 +439,100 @@ func (c *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest
 	return nil
 }
 
+// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+// opts.Files to it and opens a pull request. The branch is deleted again if
+// the files can't be committed or the pull request can't be opened.
+func (c *Client) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	logger.Debug("Creating Gitea pull request from %s into %s", opts.HeadBranch, opts.BaseBranch)
+	// The branch is created with the first file.
+	created, opened := false, false
+	defer func() {
+		if created && !opened {
+			c.deleteBranch(logger, repo, opts.HeadBranch)
+		}
+	}()
+	for i, file := range opts.Files {
+		fileOpts := gitea.FileOptions{
+			Message:    opts.CommitMessage,
+			BranchName: opts.HeadBranch,
+		}
+		// Creating the first file with a new branch name creates the new
+		// branch as well.
+		if i == 0 {
+			fileOpts.BranchName = opts.BaseBranch
+			fileOpts.NewBranchName = opts.HeadBranch
+		}
+		content := base64.StdEncoding.EncodeToString([]byte(file.Content))
+		// Overwriting a file needs the SHA of its current content.
+		existing, resp, err := c.giteaClient.GetContents(repo.Owner, repo.Name, opts.BaseBranch, file.Path)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return models.PullRequest{}, fmt.Errorf("getting %q on branch %q: %w", file.Path, opts.BaseBranch, err)
+		}
+		method := "POST"
+		if err == nil && existing != nil {
+			method = "PUT"
+			_, resp, err = c.giteaClient.UpdateFile(repo.Owner, repo.Name, file.Path, gitea.UpdateFileOptions{
+				FileOptions: fileOpts,
+				SHA:         existing.SHA,
+				Content:     content,
+			})
+		} else {
+			_, resp, err = c.giteaClient.CreateFile(repo.Owner, repo.Name, file.Path, gitea.CreateFileOptions{
+				FileOptions: fileOpts,
+				Content:     content,
+			})
+		}
+		if err != nil {
+			status := "no response"
+			if resp != nil {
+				status = fmt.Sprintf("%d", resp.StatusCode)
+			}
+			logger.Debug("%s /repos/%v/%v/contents/%s returned: %v", method, repo.Owner, repo.Name, file.Path, status)
+			return models.PullRequest{}, fmt.Errorf("committing %q to branch %q: %w", file.Path, opts.HeadBranch, err)
+		}
+		created = true
+	}
+
+	pull, resp, err := c.giteaClient.CreatePullRequest(repo.Owner, repo.Name, gitea.CreatePullRequestOption{
+		Head:  opts.HeadBranch,
+		Base:  opts.BaseBranch,
+		Title: opts.Title,
+		Body:  opts.Body,
+	})
+	if err != nil {
+		status := "no response"
+		if resp != nil {
+			status = fmt.Sprintf("%d", resp.StatusCode)
+		}
+		logger.Debug("POST /repos/%v/%v/pulls returned: %v", repo.Owner, repo.Name, status)
+		return models.PullRequest{}, fmt.Errorf("creating pull request: %w", err)
+	}
+	opened = true
+
+	headCommit := ""
+	if pull.Head != nil {
+		headCommit = pull.Head.Sha
+	}
+	return models.PullRequest{
+		Num:        int(pull.Index),
+		HeadCommit: headCommit,
+		URL:        pull.HTMLURL,
+		HeadBranch: opts.HeadBranch,
+		BaseBranch: opts.BaseBranch,
+		Body:       opts.Body,
+		State:      models.OpenPullState,
+		BaseRepo:   repo,
+	}, nil
+}
+
+// deleteBranch deletes a branch that CreatePullRequest created. Errors are
+// only logged since the pull request already failed.
+func (c *Client) deleteBranch(logger logging.SimpleLogging, repo models.Repo, branch string) {
+	if _, _, err := c.giteaClient.DeleteRepoBranch(repo.Owner, repo.Name, branch); err != nil {
+		logger.Warn("unable to delete branch %q after failing to open a pull request: %s", branch, err)
+	}
+}
+
 // MarkdownPullLink specifies the string used in a pull request comment to reference another pull request.
 func (c *Client) MarkdownPullLink(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("#%d", pull.Num), nil
@@ -542,6 +637,19 @@ func (c *Client) GetPullLabels(logger logging.SimpleLogging, repo models.Repo, p
 	return results, nil
 }
 
+// GetPullState returns whether the pull request is open or closed. Merged
+// pull requests are closed.
+func (c *Client) GetPullState(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	pr, err := c.GetPullRequest(logger, repo, pull.Num)
+	if err != nil {
+		return models.OpenPullState, err
+	}
+	if pr.State == gitea.StateOpen {
+		return models.OpenPullState, nil
+	}
+	return models.ClosedPullState, nil
+}
+
 func (c *Client) GetChildTeams(_ logging.SimpleLogging, _ models.Repo, _ string) ([]string, error) {
 	return nil, nil
 }
@@ -4,6 +4,7 @@
 package gitea_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -338,6 +339,91 @@ func TestClient_GetModifiedFilesPaginationEmergencyBreak(t *testing.T) {
 	Equals(t, 500, pages[len(pages)-1])
 }
 
+func TestClient_CreatePullRequest(t *testing.T) {
+	var createdFile, secondFile, createdPull map[string]any
+	client := newTestClient(t, 2, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/contents/.atlantis/drift.md", "GET /repos/owner/repo/contents/app/drift.tf":
+			Equals(t, "main", r.URL.Query().Get("ref"))
+			http.Error(w, "not found", http.StatusNotFound)
+		case "POST /repos/owner/repo/contents/.atlantis/drift.md":
+			Ok(t, json.NewDecoder(r.Body).Decode(&createdFile))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		case "POST /repos/owner/repo/contents/app/drift.tf":
+			Ok(t, json.NewDecoder(r.Body).Decode(&secondFile))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		case "POST /repos/owner/repo/pulls":
+			Ok(t, json.NewDecoder(r.Body).Decode(&createdPull))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number":8,"html_url":"https://gitea.example.com/owner/repo/pulls/8","head":{"sha":"headsha"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+
+	pull, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{Owner: "owner", Name: "repo"}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		Body:          "body",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files: []models.PullRequestFile{
+			{Path: ".atlantis/drift.md", Content: "report"},
+			{Path: "app/drift.tf", Content: "# drift"},
+		},
+	})
+	Ok(t, err)
+
+	Equals(t, "main", createdFile["branch"])
+	Equals(t, "atlantis/drift", createdFile["new_branch"])
+	Equals(t, "Record drift", createdFile["message"])
+	Equals(t, "cmVwb3J0", createdFile["content"])
+	Equals(t, "atlantis/drift", secondFile["branch"])
+	Equals(t, "", secondFile["new_branch"])
+	Equals(t, "atlantis/drift", createdPull["head"])
+	Equals(t, "main", createdPull["base"])
+	Equals(t, 8, pull.Num)
+	Equals(t, "https://gitea.example.com/owner/repo/pulls/8", pull.URL)
+	Equals(t, "headsha", pull.HeadCommit)
+}
+
+func TestClient_CreatePullRequest_UpdatesFileAndDeletesBranchOnError(t *testing.T) {
+	var updatedFile map[string]any
+	deleted := false
+	client := newTestClient(t, 2, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/contents/drift.tf":
+			_, _ = w.Write([]byte(`{"type":"file","path":"drift.tf","sha":"oldsha"}`))
+		case "PUT /repos/owner/repo/contents/drift.tf":
+			Ok(t, json.NewDecoder(r.Body).Decode(&updatedFile))
+			_, _ = w.Write([]byte(`{}`))
+		case "POST /repos/owner/repo/pulls":
+			http.Error(w, "conflict", http.StatusConflict)
+		case "DELETE /repos/owner/repo/branches/atlantis/drift":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+
+	_, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{Owner: "owner", Name: "repo"}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: "drift.tf", Content: "# drift"}},
+	})
+	ErrContains(t, "creating pull request", err)
+	Equals(t, "oldsha", updatedFile["sha"])
+	Equals(t, "atlantis/drift", updatedFile["new_branch"])
+	Assert(t, deleted, "expected the branch to be deleted")
+}
+
 func newTestClient(t *testing.T, pageSize int, apiHandler http.HandlerFunc) *gitea.Client {
 	t.Helper()
 	versionResponse := mustReadTestData(t, "version-response.json")
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofri/go-github-ratelimit/v2/github_ratelimit"
 	"github.com/google/go-github/v88/github"
@@ -1002,6 +1003,127 @@ func (g *Client) UpdateStatus(logger logging.SimpleLogging, repo models.Repo, pu
 	return err
 }
 
+// maxCheckRunOutputLength is the maximum number of chars allowed by GitHub in
+// the summary and text of a check run.
+const maxCheckRunOutputLength = 65535
+
+// maxCheckRunAnnotations is the maximum number of annotations GitHub accepts
+// in a single check run request.
+const maxCheckRunAnnotations = 50
+
+// UpdateCheckRun creates checkRun on the head commit of pull, or updates the
+// check run with the same name if there already is one.
+// See https://docs.github.com/en/rest/checks/runs.
+func (g *Client) UpdateCheckRun(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest, checkRun models.CheckRun) error {
+	status := "completed"
+	var conclusion *string
+	var completedAt *github.Timestamp
+	switch checkRun.Status {
+	case models.PendingCommitStatus:
+		status = "in_progress"
+	case models.SuccessCommitStatus:
+		conclusion = github.Ptr("success")
+		completedAt = &github.Timestamp{Time: time.Now()}
+	default:
+		conclusion = github.Ptr("failure")
+		completedAt = &github.Timestamp{Time: time.Now()}
+	}
+
+	output := &github.CheckRunOutput{
+		Title:   github.Ptr(checkRun.Title),
+		Summary: github.Ptr(truncateCheckRunOutput(checkRun.Summary)),
+	}
+	if checkRun.Text != "" {
+		output.Text = github.Ptr(truncateCheckRunOutput(checkRun.Text))
+	}
+	for _, annotation := range checkRun.Annotations {
+		if len(output.Annotations) == maxCheckRunAnnotations {
+			break
+		}
+		output.Annotations = append(output.Annotations, &github.CheckRunAnnotation{
+			Path:            github.Ptr(annotation.Path),
+			StartLine:       github.Ptr(1),
+			EndLine:         github.Ptr(1),
+			AnnotationLevel: github.Ptr(annotation.Level),
+			Title:           github.Ptr(annotation.Title),
+			Message:         github.Ptr(truncateCheckRunOutput(annotation.Message)),
+		})
+	}
+	actions := []*github.CheckRunAction{}
+	for _, action := range checkRun.Actions {
+		actions = append(actions, &github.CheckRunAction{
+			Identifier:  action.Identifier,
+			Label:       action.Label,
+			Description: action.Description,
+		})
+	}
+	var detailsURL *string
+	if checkRun.DetailsURL != "" {
+		detailsURL = github.Ptr(checkRun.DetailsURL)
+	}
+
+	logger.Info("Updating GitHub check run '%s' to '%s'", checkRun.Name, status)
+
+	existing, resp, err := g.client.Checks.ListCheckRunsForRef(g.ctx, repo.Owner, repo.Name, pull.HeadCommit, &github.ListCheckRunsOptions{
+		CheckName: github.Ptr(checkRun.Name),
+		Filter:    github.Ptr("latest"),
+	})
+	if resp != nil {
+		logger.Debug("GET /repos/%v/%v/commits/%s/check-runs returned: %v", repo.Owner, repo.Name, pull.HeadCommit, resp.StatusCode)
+	}
+	if err != nil {
+		return fmt.Errorf("listing check runs: %w", err)
+	}
+
+	if len(existing.CheckRuns) == 0 {
+		_, resp, err = g.client.Checks.CreateCheckRun(g.ctx, repo.Owner, repo.Name, github.CreateCheckRunOptions{
+			Name:        checkRun.Name,
+			HeadSHA:     pull.HeadCommit,
+			DetailsURL:  detailsURL,
+			ExternalID:  github.Ptr(checkRun.ExternalID),
+			Status:      github.Ptr(status),
+			Conclusion:  conclusion,
+			CompletedAt: completedAt,
+			Output:      output,
+			Actions:     actions,
+		})
+		if resp != nil {
+			logger.Debug("POST /repos/%v/%v/check-runs returned: %v", repo.Owner, repo.Name, resp.StatusCode)
+		}
+		return err
+	}
+
+	checkRunID := existing.CheckRuns[0].GetID()
+	_, resp, err = g.client.Checks.UpdateCheckRun(g.ctx, repo.Owner, repo.Name, checkRunID, github.UpdateCheckRunOptions{
+		Name:        checkRun.Name,
+		DetailsURL:  detailsURL,
+		ExternalID:  github.Ptr(checkRun.ExternalID),
+		Status:      github.Ptr(status),
+		Conclusion:  conclusion,
+		CompletedAt: completedAt,
+		Output:      output,
+		Actions:     actions,
+	})
+	if resp != nil {
+		logger.Debug("PATCH /repos/%v/%v/check-runs/%d returned: %v", repo.Owner, repo.Name, checkRunID, resp.StatusCode)
+	}
+	return err
+}
+
+// truncateCheckRunOutput shortens s to the maximum length of check run
+// output, keeping its beginning.
+func truncateCheckRunOutput(s string) string {
+	if len(s) <= maxCheckRunOutputLength {
+		return s
+	}
+	const truncated = "\n\n...(truncated)"
+	cut := maxCheckRunOutputLength - len(truncated)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncated
+}
+
 // MergePull merges the pull request.
 func (g *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest, pullOptions models.PullRequestOptions) error {
 	logger.Debug("Merging GitHub pull request %d", pull.Num)
@@ -1079,6 +1201,101 @@ func (g *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest
 	return nil
 }
 
+// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+// opts.Files to it and opens a pull request. The branch is deleted again if
+// the files can't be committed or the pull request can't be opened.
+func (g *Client) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	logger.Debug("Creating GitHub pull request from %s into %s", opts.HeadBranch, opts.BaseBranch)
+	baseRef, resp, err := g.client.Git.GetRef(g.ctx, repo.Owner, repo.Name, "refs/heads/"+opts.BaseBranch)
+	if resp != nil {
+		logger.Debug("GET /repos/%v/%v/git/ref/heads/%s returned: %v", repo.Owner, repo.Name, opts.BaseBranch, resp.StatusCode)
+	}
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("getting ref of base branch %q: %w", opts.BaseBranch, err)
+	}
+
+	_, resp, err = g.client.Git.CreateRef(g.ctx, repo.Owner, repo.Name, github.CreateRef{
+		Ref: "refs/heads/" + opts.HeadBranch,
+		SHA: baseRef.GetObject().GetSHA(),
+	})
+	if resp != nil {
+		logger.Debug("POST /repos/%v/%v/git/refs returned: %v", repo.Owner, repo.Name, resp.StatusCode)
+	}
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("creating branch %q: %w", opts.HeadBranch, err)
+	}
+	opened := false
+	defer func() {
+		if !opened {
+			g.deleteBranch(logger, repo, opts.HeadBranch)
+		}
+	}()
+
+	// The contents API commits one file at a time.
+	for _, file := range opts.Files {
+		fileOpts := &github.RepositoryContentFileOptions{
+			Message: github.Ptr(opts.CommitMessage),
+			Content: []byte(file.Content),
+			Branch:  github.Ptr(opts.HeadBranch),
+		}
+		// Overwriting a file needs the SHA of its current content.
+		existing, _, resp, err := g.client.Repositories.GetContents(g.ctx, repo.Owner, repo.Name, file.Path, &github.RepositoryContentGetOptions{Ref: opts.HeadBranch})
+		if resp != nil {
+			logger.Debug("GET /repos/%v/%v/contents/%s returned: %v", repo.Owner, repo.Name, file.Path, resp.StatusCode)
+		}
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return models.PullRequest{}, fmt.Errorf("getting %q on branch %q: %w", file.Path, opts.HeadBranch, err)
+		}
+		if err == nil && existing != nil {
+			fileOpts.SHA = existing.SHA
+		}
+		_, resp, err = g.client.Repositories.CreateFile(g.ctx, repo.Owner, repo.Name, file.Path, fileOpts)
+		if resp != nil {
+			logger.Debug("PUT /repos/%v/%v/contents/%s returned: %v", repo.Owner, repo.Name, file.Path, resp.StatusCode)
+		}
+		if err != nil {
+			return models.PullRequest{}, fmt.Errorf("committing %q to branch %q: %w", file.Path, opts.HeadBranch, err)
+		}
+	}
+
+	pull, resp, err := g.client.PullRequests.Create(g.ctx, repo.Owner, repo.Name, &github.NewPullRequest{
+		Title: github.Ptr(opts.Title),
+		Head:  github.Ptr(opts.HeadBranch),
+		Base:  github.Ptr(opts.BaseBranch),
+		Body:  github.Ptr(opts.Body),
+	})
+	if resp != nil {
+		logger.Debug("POST /repos/%v/%v/pulls returned: %v", repo.Owner, repo.Name, resp.StatusCode)
+	}
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("creating pull request: %w", err)
+	}
+	opened = true
+
+	return models.PullRequest{
+		Num:        pull.GetNumber(),
+		HeadCommit: pull.GetHead().GetSHA(),
+		URL:        pull.GetHTMLURL(),
+		HeadBranch: opts.HeadBranch,
+		BaseBranch: opts.BaseBranch,
+		Body:       opts.Body,
+		State:      models.OpenPullState,
+		BaseRepo:   repo,
+	}, nil
+}
+
+// deleteBranch deletes a branch that CreatePullRequest created. Errors are
+// only logged since the pull request already failed.
+func (g *Client) deleteBranch(logger logging.SimpleLogging, repo models.Repo, branch string) {
+	resp, err := g.client.Git.DeleteRef(g.ctx, repo.Owner, repo.Name, "heads/"+branch)
+	if resp != nil {
+		logger.Debug("DELETE /repos/%v/%v/git/refs/heads/%s returned: %v", repo.Owner, repo.Name, branch, resp.StatusCode)
+	}
+	if err != nil {
+		logger.Warn("unable to delete branch %q after failing to open a pull request: %s", branch, err)
+	}
+}
+
 // MarkdownPullLink specifies the string used in a pull request comment to reference another pull request.
 func (g *Client) MarkdownPullLink(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("#%d", pull.Num), nil
@@ -1277,3 +1494,21 @@ func (g *Client) GetPullLabels(logger logging.SimpleLogging, repo models.Repo, p
 
 	return labels, nil
 }
+
+// GetPullState returns whether the pull request is open or closed. It calls
+// the API once rather than going through GetPullRequest, whose retries are
+// only useful right after a webhook.
+func (g *Client) GetPullState(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	logger.Debug("Getting state of GitHub pull request %d", pull.Num)
+	pullDetails, resp, err := g.client.PullRequests.Get(g.ctx, repo.Owner, repo.Name, pull.Num)
+	if resp != nil {
+		logger.Debug("GET /repos/%v/%v/pulls/%d returned: %v", repo.Owner, repo.Name, pull.Num, resp.StatusCode)
+	}
+	if err != nil {
+		return models.OpenPullState, err
+	}
+	if pullDetails.GetState() == "open" {
+		return models.OpenPullState, nil
+	}
+	return models.ClosedPullState, nil
+}
@@ -458,6 +458,103 @@ func TestClient_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestClient_UpdateCheckRun(t *testing.T) {
+	logger := logging.NewNoopLogger(t)
+	checkRun := models.CheckRun{
+		Name:       "atlantis/plan: dir/default",
+		ExternalID: "external-id",
+		Status:     models.SuccessCommitStatus,
+		Title:      "Plan succeeded.",
+		Summary:    "summary",
+		Text:       "text",
+		Annotations: []models.CheckRunAnnotation{
+			{Path: "dir", Level: "failure", Title: "Policy set p failed", Message: "message"},
+		},
+		Actions: []models.CheckRunAction{
+			{Identifier: "unlock", Label: "Unlock", Description: "description"},
+		},
+	}
+	cases := []struct {
+		description  string
+		existingRuns string
+		expMethod    string
+		expURI       string
+	}{
+		{
+			"creates check run",
+			`{"total_count":0,"check_runs":[]}`,
+			http.MethodPost,
+			"/api/v3/repos/owner/repo/check-runs",
+		},
+		{
+			"updates existing check run",
+			`{"total_count":1,"check_runs":[{"id":5,"name":"atlantis/plan: dir/default"}]}`,
+			http.MethodPatch,
+			"/api/v3/repos/owner/repo/check-runs/5",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			called := false
+			testServer := httptest.NewTLSServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.RequestURI {
+					case "/api/v3/repos/owner/repo/commits/sha/check-runs?check_name=atlantis%2Fplan%3A+dir%2Fdefault&filter=latest":
+						w.Write([]byte(c.existingRuns)) // nolint: errcheck
+					case c.expURI:
+						Equals(t, c.expMethod, r.Method)
+						var body map[string]any
+						Ok(t, json.NewDecoder(r.Body).Decode(&body))
+						Equals(t, "atlantis/plan: dir/default", body["name"])
+						Equals(t, "external-id", body["external_id"])
+						Equals(t, "completed", body["status"])
+						Equals(t, "success", body["conclusion"])
+						output := body["output"].(map[string]any)
+						Equals(t, "Plan succeeded.", output["title"])
+						Equals(t, "summary", output["summary"])
+						Equals(t, "text", output["text"])
+						annotations := output["annotations"].([]any)
+						Equals(t, 1, len(annotations))
+						Equals(t, "failure", annotations[0].(map[string]any)["annotation_level"])
+						actions := body["actions"].([]any)
+						Equals(t, 1, len(actions))
+						Equals(t, "unlock", actions[0].(map[string]any)["identifier"])
+						called = true
+						w.Write([]byte(`{"id":5}`)) // nolint: errcheck
+					default:
+						t.Errorf("got unexpected request at %q", r.RequestURI)
+						http.Error(w, "not found", http.StatusNotFound)
+						return
+					}
+				}))
+
+			testServerURL, err := url.Parse(testServer.URL)
+			Ok(t, err)
+			client, err := github.New(testServerURL.Host, &github.UserCredentials{"user", "pass", ""}, github.Config{}, 0, logging.NewNoopLogger(t))
+			Ok(t, err)
+			defer disableSSLVerification()()
+
+			err = client.UpdateCheckRun(
+				logger,
+				models.Repo{
+					FullName: "owner/repo",
+					Owner:    "owner",
+					Name:     "repo",
+					VCSHost: models.VCSHost{
+						Type:     models.Github,
+						Hostname: "github.com",
+					},
+				}, models.PullRequest{
+					Num:        1,
+					HeadCommit: "sha",
+				}, checkRun)
+			Ok(t, err)
+			Assert(t, called, "expected check run to be written")
+		})
+	}
+}
+
 func TestClient_PullIsApproved(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	respTemplate := `[
@@ -1400,6 +1497,150 @@ func TestClient_MergePullCorrectMethod(t *testing.T) {
 	}
 }
 
+func TestClient_CreatePullRequest(t *testing.T) {
+	var createdRef, createdFile, createdPull map[string]any
+	testServer := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "GET /api/v3/repos/owner/repo/git/ref/heads/main":
+				w.Write([]byte(`{"ref":"refs/heads/main","object":{"sha":"basesha","type":"commit"}}`)) // nolint: errcheck
+			case "POST /api/v3/repos/owner/repo/git/refs":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdRef))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"ref":"refs/heads/atlantis/drift","object":{"sha":"basesha"}}`)) // nolint: errcheck
+			case "GET /api/v3/repos/owner/repo/contents/.atlantis/drift.md?ref=atlantis%2Fdrift":
+				http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			case "PUT /api/v3/repos/owner/repo/contents/.atlantis/drift.md":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdFile))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"commit":{"sha":"headsha"}}`)) // nolint: errcheck
+			case "POST /api/v3/repos/owner/repo/pulls":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdPull))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"number":12,"html_url":"https://github.com/owner/repo/pull/12","head":{"sha":"headsha"}}`)) // nolint: errcheck
+			default:
+				t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+
+	testServerURL, err := url.Parse(testServer.URL)
+	Ok(t, err)
+	client, err := github.New(testServerURL.Host, &github.UserCredentials{"user", "pass", ""}, github.Config{}, 0, logging.NewNoopLogger(t))
+	Ok(t, err)
+	defer disableSSLVerification()()
+
+	repo := models.Repo{
+		FullName: "owner/repo",
+		Owner:    "owner",
+		Name:     "repo",
+		VCSHost: models.VCSHost{
+			Type:     models.Github,
+			Hostname: "github.com",
+		},
+	}
+	pull, err := client.CreatePullRequest(logging.NewNoopLogger(t), repo, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		Body:          "body",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: ".atlantis/drift.md", Content: "report"}},
+	})
+	Ok(t, err)
+
+	Equals(t, "refs/heads/atlantis/drift", createdRef["ref"])
+	Equals(t, "basesha", createdRef["sha"])
+	Equals(t, "atlantis/drift", createdFile["branch"])
+	Equals(t, "Record drift", createdFile["message"])
+	Equals(t, "cmVwb3J0", createdFile["content"])
+	Equals(t, nil, createdFile["sha"])
+	Equals(t, "atlantis/drift", createdPull["head"])
+	Equals(t, "main", createdPull["base"])
+	Equals(t, "Codify drift", createdPull["title"])
+	Equals(t, 12, pull.Num)
+	Equals(t, "https://github.com/owner/repo/pull/12", pull.URL)
+	Equals(t, "headsha", pull.HeadCommit)
+	Equals(t, "atlantis/drift", pull.HeadBranch)
+	Equals(t, repo, pull.BaseRepo)
+}
+
+func TestClient_CreatePullRequest_OverwritesFileAndDeletesBranchOnError(t *testing.T) {
+	var createdFile map[string]any
+	deleted := false
+	testServer := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "GET /api/v3/repos/owner/repo/git/ref/heads/main":
+				w.Write([]byte(`{"ref":"refs/heads/main","object":{"sha":"basesha","type":"commit"}}`)) // nolint: errcheck
+			case "POST /api/v3/repos/owner/repo/git/refs":
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"ref":"refs/heads/atlantis/drift","object":{"sha":"basesha"}}`)) // nolint: errcheck
+			case "GET /api/v3/repos/owner/repo/contents/drift.tf?ref=atlantis%2Fdrift":
+				w.Write([]byte(`{"type":"file","path":"drift.tf","sha":"oldsha"}`)) // nolint: errcheck
+			case "PUT /api/v3/repos/owner/repo/contents/drift.tf":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdFile))
+				w.Write([]byte(`{"commit":{"sha":"headsha"}}`)) // nolint: errcheck
+			case "POST /api/v3/repos/owner/repo/pulls":
+				http.Error(w, `{"message":"Validation Failed"}`, http.StatusUnprocessableEntity)
+			case "DELETE /api/v3/repos/owner/repo/git/refs/heads/atlantis/drift":
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+
+	testServerURL, err := url.Parse(testServer.URL)
+	Ok(t, err)
+	client, err := github.New(testServerURL.Host, &github.UserCredentials{"user", "pass", ""}, github.Config{}, 0, logging.NewNoopLogger(t))
+	Ok(t, err)
+	defer disableSSLVerification()()
+
+	_, err = client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{FullName: "owner/repo", Owner: "owner", Name: "repo"}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: "drift.tf", Content: "# drift"}},
+	})
+	ErrContains(t, "creating pull request", err)
+	Equals(t, "oldsha", createdFile["sha"])
+	Assert(t, deleted, "expected the branch to be deleted")
+}
+
+func TestClient_GetPullState(t *testing.T) {
+	cases := map[string]models.PullRequestState{
+		"open":   models.OpenPullState,
+		"closed": models.ClosedPullState,
+	}
+	for ghState, expState := range cases {
+		t.Run(ghState, func(t *testing.T) {
+			testServer := httptest.NewTLSServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.RequestURI {
+					case "/api/v3/repos/owner/repo/pulls/1":
+						w.Write([]byte(`{"number":1,"state":"` + ghState + `"}`)) // nolint: errcheck
+					default:
+						t.Errorf("got unexpected request at %q", r.RequestURI)
+						http.Error(w, "not found", http.StatusNotFound)
+					}
+				}))
+
+			testServerURL, err := url.Parse(testServer.URL)
+			Ok(t, err)
+			client, err := github.New(testServerURL.Host, &github.UserCredentials{"user", "pass", ""}, github.Config{}, 0, logging.NewNoopLogger(t))
+			Ok(t, err)
+			defer disableSSLVerification()()
+
+			state, err := client.GetPullState(logging.NewNoopLogger(t), models.Repo{FullName: "owner/repo", Owner: "owner", Name: "repo"}, models.PullRequest{Num: 1})
+			Ok(t, err)
+			Equals(t, expState, state)
+		})
+	}
+}
+
 func TestClient_GetFileContent(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	repo := models.Repo{
@@ -762,6 +762,79 @@ func (g *Client) MergePull(logger logging.SimpleLogging, pull models.PullRequest
 	return nil
 }
 
+// CreatePullRequest creates opts.HeadBranch from opts.BaseBranch, commits
+// opts.Files to it and opens a merge request. The branch is deleted again if
+// the merge request can't be opened.
+func (g *Client) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	logger.Debug("Creating GitLab merge request from %s into %s", opts.HeadBranch, opts.BaseBranch)
+	actions := make([]*gitlab.CommitActionOptions, 0, len(opts.Files))
+	for _, file := range opts.Files {
+		// Commit actions fail if a created file exists or an updated one
+		// doesn't.
+		action := gitlab.FileCreate
+		_, resp, err := g.Client.RepositoryFiles.GetFileMetaData(repo.FullName, file.Path, &gitlab.GetFileMetaDataOptions{Ref: gitlab.Ptr(opts.BaseBranch)})
+		if resp != nil {
+			logger.Debug("HEAD /projects/%s/repository/files/%s returned: %d", repo.FullName, file.Path, resp.StatusCode)
+		}
+		if err == nil {
+			action = gitlab.FileUpdate
+		} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return models.PullRequest{}, fmt.Errorf("getting %q on branch %q: %w", file.Path, opts.BaseBranch, err)
+		}
+		actions = append(actions, &gitlab.CommitActionOptions{
+			Action:   gitlab.Ptr(action),
+			FilePath: gitlab.Ptr(file.Path),
+			Content:  gitlab.Ptr(file.Content),
+		})
+	}
+	// Creating a commit with a start branch creates the new branch as well.
+	_, resp, err := g.Client.Commits.CreateCommit(repo.FullName, &gitlab.CreateCommitOptions{
+		Branch:        gitlab.Ptr(opts.HeadBranch),
+		StartBranch:   gitlab.Ptr(opts.BaseBranch),
+		CommitMessage: gitlab.Ptr(opts.CommitMessage),
+		Actions:       actions,
+	})
+	if resp != nil {
+		logger.Debug("POST /projects/%s/repository/commits returned: %d", repo.FullName, resp.StatusCode)
+	}
+	if err != nil {
+		return models.PullRequest{}, fmt.Errorf("committing files to branch %q: %w", opts.HeadBranch, err)
+	}
+
+	mr, resp, err := g.Client.MergeRequests.CreateMergeRequest(repo.FullName, &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr(opts.Title),
+		Description:  gitlab.Ptr(opts.Body),
+		SourceBranch: gitlab.Ptr(opts.HeadBranch),
+		TargetBranch: gitlab.Ptr(opts.BaseBranch),
+	})
+	if resp != nil {
+		logger.Debug("POST /projects/%s/merge_requests returned: %d", repo.FullName, resp.StatusCode)
+	}
+	if err != nil {
+		// Committing created the branch, so it's deleted again to not leave
+		// it behind.
+		resp, deleteErr := g.Client.Branches.DeleteBranch(repo.FullName, opts.HeadBranch)
+		if resp != nil {
+			logger.Debug("DELETE /projects/%s/repository/branches/%s returned: %d", repo.FullName, opts.HeadBranch, resp.StatusCode)
+		}
+		if deleteErr != nil {
+			logger.Warn("unable to delete branch %q after failing to open a merge request: %s", opts.HeadBranch, deleteErr)
+		}
+		return models.PullRequest{}, fmt.Errorf("creating merge request: %w", err)
+	}
+
+	return models.PullRequest{
+		Num:        mr.IID,
+		HeadCommit: mr.SHA,
+		URL:        mr.WebURL,
+		HeadBranch: opts.HeadBranch,
+		BaseBranch: opts.BaseBranch,
+		Body:       opts.Body,
+		State:      models.OpenPullState,
+		BaseRepo:   repo,
+	}, nil
+}
+
 // MarkdownPullLink specifies the string used in a pull request comment to reference another pull request.
 func (g *Client) MarkdownPullLink(pull models.PullRequest) (string, error) {
 	return fmt.Sprintf("!%d", pull.Num), nil
@@ -909,6 +982,19 @@ func (g *Client) GetPullLabels(logger logging.SimpleLogging, repo models.Repo, p
 	return mr.Labels, nil
 }
 
+// GetPullState returns whether the merge request is open or closed. Merged
+// merge requests are closed.
+func (g *Client) GetPullState(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	mr, err := g.GetMergeRequest(logger, repo.FullName, pull.Num)
+	if err != nil {
+		return models.OpenPullState, err
+	}
+	if mr.State == "opened" {
+		return models.OpenPullState, nil
+	}
+	return models.ClosedPullState, nil
+}
+
 func (g *Client) GetChildTeams(_ logging.SimpleLogging, _ models.Repo, _ string) ([]string, error) {
 	return nil, nil
 }
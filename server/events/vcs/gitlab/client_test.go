@@ -323,6 +323,115 @@ func TestClient_MergePull(t *testing.T) {
 	}
 }
 
+func TestClient_CreatePullRequest(t *testing.T) {
+	var createdCommit, createdMR map[string]any
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "HEAD /api/v4/projects/runatlantis%2Fatlantis/repository/files/%2Eatlantis%2Fdrift%2Emd?ref=main":
+				w.WriteHeader(http.StatusNotFound)
+			case "POST /api/v4/projects/runatlantis%2Fatlantis/repository/commits":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdCommit))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":"headsha"}`)) // nolint: errcheck
+			case "POST /api/v4/projects/runatlantis%2Fatlantis/merge_requests":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdMR))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"iid":5,"web_url":"https://gitlab.com/runatlantis/atlantis/-/merge_requests/5","sha":"headsha"}`)) // nolint: errcheck
+			case "GET /api/v4/":
+				// Rate limiter requests.
+				w.WriteHeader(http.StatusOK)
+			default:
+				t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+
+	internalClient, err := gitlab.NewClient("token", gitlab.WithBaseURL(testServer.URL))
+	Ok(t, err)
+	client := &Client{
+		Client:  internalClient,
+		Version: nil,
+	}
+
+	pull, err := client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName: "runatlantis/atlantis",
+		Owner:    "runatlantis",
+		Name:     "atlantis",
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		Body:          "body",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: ".atlantis/drift.md", Content: "report"}},
+	})
+	Ok(t, err)
+
+	Equals(t, "atlantis/drift", createdCommit["branch"])
+	Equals(t, "main", createdCommit["start_branch"])
+	Equals(t, "Record drift", createdCommit["commit_message"])
+	actions := createdCommit["actions"].([]any)
+	Equals(t, 1, len(actions))
+	Equals(t, map[string]any{"action": "create", "file_path": ".atlantis/drift.md", "content": "report"}, actions[0])
+	Equals(t, "atlantis/drift", createdMR["source_branch"])
+	Equals(t, "main", createdMR["target_branch"])
+	Equals(t, 5, pull.Num)
+	Equals(t, "https://gitlab.com/runatlantis/atlantis/-/merge_requests/5", pull.URL)
+	Equals(t, "headsha", pull.HeadCommit)
+}
+
+func TestClient_CreatePullRequest_UpdatesFileAndDeletesBranchOnError(t *testing.T) {
+	var createdCommit map[string]any
+	deleted := false
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method + " " + r.RequestURI {
+			case "HEAD /api/v4/projects/runatlantis%2Fatlantis/repository/files/drift%2Etf?ref=main":
+				w.Header().Set("X-Gitlab-Blob-Id", "oldsha")
+				w.WriteHeader(http.StatusOK)
+			case "POST /api/v4/projects/runatlantis%2Fatlantis/repository/commits":
+				Ok(t, json.NewDecoder(r.Body).Decode(&createdCommit))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":"headsha"}`)) // nolint: errcheck
+			case "POST /api/v4/projects/runatlantis%2Fatlantis/merge_requests":
+				http.Error(w, `{"message":"Another open merge request already exists"}`, http.StatusConflict)
+			case "DELETE /api/v4/projects/runatlantis%2Fatlantis/repository/branches/atlantis%2Fdrift":
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			case "GET /api/v4/":
+				// Rate limiter requests.
+				w.WriteHeader(http.StatusOK)
+			default:
+				t.Errorf("got unexpected request %s %q", r.Method, r.RequestURI)
+				http.Error(w, "not found", http.StatusNotFound)
+			}
+		}))
+
+	internalClient, err := gitlab.NewClient("token", gitlab.WithBaseURL(testServer.URL))
+	Ok(t, err)
+	client := &Client{
+		Client:  internalClient,
+		Version: nil,
+	}
+
+	_, err = client.CreatePullRequest(logging.NewNoopLogger(t), models.Repo{
+		FullName: "runatlantis/atlantis",
+		Owner:    "runatlantis",
+		Name:     "atlantis",
+	}, models.CreatePullRequestOptions{
+		Title:         "Codify drift",
+		BaseBranch:    "main",
+		HeadBranch:    "atlantis/drift",
+		CommitMessage: "Record drift",
+		Files:         []models.PullRequestFile{{Path: "drift.tf", Content: "# drift"}},
+	})
+	ErrContains(t, "creating merge request", err)
+	actions := createdCommit["actions"].([]any)
+	Equals(t, "update", actions[0].(map[string]any)["action"])
+	Assert(t, deleted, "expected the branch to be deleted")
+}
+
 func TestClient_UpdateStatus(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 
@@ -2030,6 +2139,37 @@ func TestClient_GetPullLabels(t *testing.T) {
 	Equals(t, []string{"work in progress"}, labels)
 }
 
+func TestClient_GetPullState(t *testing.T) {
+	cases := map[string]models.PullRequestState{
+		"opened": models.OpenPullState,
+		"merged": models.ClosedPullState,
+		"closed": models.ClosedPullState,
+	}
+	for glState, expState := range cases {
+		t.Run(glState, func(t *testing.T) {
+			testServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.RequestURI {
+					case "/api/v4/projects/runatlantis%2Fatlantis/merge_requests/1":
+						w.Write([]byte(`{"iid":1,"state":"` + glState + `"}`)) // nolint: errcheck
+					default:
+						t.Errorf("got unexpected request at %q", r.RequestURI)
+						http.Error(w, "not found", http.StatusNotFound)
+					}
+				}))
+			defer testServer.Close()
+
+			internalClient, err := gitlab.NewClient("token", gitlab.WithBaseURL(testServer.URL))
+			Ok(t, err)
+			client := &Client{Client: internalClient}
+
+			state, err := client.GetPullState(logging.NewNoopLogger(t), models.Repo{FullName: "runatlantis/atlantis"}, models.PullRequest{Num: 1})
+			Ok(t, err)
+			Equals(t, expState, state)
+		})
+	}
+}
+
 func TestClient_GetPullLabels_EmptyResponse(t *testing.T) {
 	logger := logging.NewNoopLogger(t)
 	pipelineSuccess, err := os.ReadFile("testdata/pipeline-success.json")
@@ -70,6 +70,14 @@ func (a *NotConfiguredVCSClient) GetPullLabels(_ logging.SimpleLogging, _ models
 	return nil, a.err()
 }
 
+func (a *NotConfiguredVCSClient) GetPullState(_ logging.SimpleLogging, _ models.Repo, _ models.PullRequest) (models.PullRequestState, error) {
+	return models.OpenPullState, a.err()
+}
+
 func (a *NotConfiguredVCSClient) GetChildTeams(_ logging.SimpleLogging, _ models.Repo, _ string) ([]string, error) {
 	return nil, nil
 }
+
+func (a *NotConfiguredVCSClient) CreatePullRequest(_ logging.SimpleLogging, _ models.Repo, _ models.CreatePullRequestOptions) (models.PullRequest, error) {
+	return models.PullRequest{}, a.err()
+}
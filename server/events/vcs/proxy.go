@@ -108,6 +108,14 @@ func (d *ClientProxy) GetPullLabels(logger logging.SimpleLogging, repo models.Re
 	return d.clients[repo.VCSHost.Type].GetPullLabels(logger, repo, pull)
 }
 
+func (d *ClientProxy) GetPullState(logger logging.SimpleLogging, repo models.Repo, pull models.PullRequest) (models.PullRequestState, error) {
+	return d.clients[repo.VCSHost.Type].GetPullState(logger, repo, pull)
+}
+
 func (d *ClientProxy) GetChildTeams(logger logging.SimpleLogging, repo models.Repo, teamSlug string) ([]string, error) {
 	return d.clients[repo.VCSHost.Type].GetChildTeams(logger, repo, teamSlug)
 }
+
+func (d *ClientProxy) CreatePullRequest(logger logging.SimpleLogging, repo models.Repo, opts models.CreatePullRequestOptions) (models.PullRequest, error) {
+	return d.clients[repo.VCSHost.Type].CreatePullRequest(logger, repo, opts)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxDeadLetters is how many dead letters a DeadLetterStore keeps. Older
+// ones are dropped when new ones are added.
+const MaxDeadLetters = 500
+
+// DeadLetter is an HTTP webhook that failed every delivery attempt.
+type DeadLetter struct {
+	ID    string `json:"id"`
+	Event string `json:"event"`
+	// URL is the scheme and host of the webhook URL. The path and query are
+	// left out because they often contain credentials.
+	URL string `json:"url"`
+	// Payload is the request body that was sent.
+	Payload   string    `json:"payload"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error"`
+	FailedAt  time.Time `json:"failed_at"`
+}
+
+//go:generate go tool pegomock generate --package mocks -o mocks/mock_dead_letter_store.go DeadLetterStore
+
+// DeadLetterStore persists dead letters so they can be inspected through the
+// API after the webhooks failed.
+type DeadLetterStore interface {
+	// Add records a dead letter, dropping the oldest ones beyond
+	// MaxDeadLetters.
+	Add(letter DeadLetter) error
+	// List returns the dead letters, newest first.
+	List() ([]DeadLetter, error)
+}
+
+// InMemoryDeadLetterStore keeps dead letters in memory.
+// They are lost on server restart.
+type InMemoryDeadLetterStore struct {
+	mu      sync.RWMutex
+	letters []DeadLetter // oldest first
+}
+
+// NewInMemoryDeadLetterStore creates a new in-memory dead letter store.
+func NewInMemoryDeadLetterStore() *InMemoryDeadLetterStore {
+	return &InMemoryDeadLetterStore{}
+}
+
+// Add records a dead letter.
+func (s *InMemoryDeadLetterStore) Add(letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters = append(s.letters, letter)
+	if len(s.letters) > MaxDeadLetters {
+		s.letters = slices.Clone(s.letters[len(s.letters)-MaxDeadLetters:])
+	}
+	return nil
+}
+
+// List returns the dead letters, newest first.
+func (s *InMemoryDeadLetterStore) List() ([]DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := slices.Clone(s.letters)
+	slices.Reverse(letters)
+	if letters == nil {
+		letters = []DeadLetter{}
+	}
+	return letters, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	discordSuccessColour = 0x2EB67D
+	discordFailureColour = 0xE01E5A
+	// maxDiscordFieldValue is Discord's limit on the length of an embed field
+	// value.
+	maxDiscordFieldValue = 1024
+)
+
+// DiscordWebhook sends apply notifications to a Discord channel webhook.
+type DiscordWebhook struct {
+	Client         *HttpClient
+	WorkspaceRegex *regexp.Regexp
+	BranchRegex    *regexp.Regexp
+	URL            string
+}
+
+// Send posts the apply result as an embed if workspace and branch match their
+// respective regex.
+func (d *DiscordWebhook) Send(_ logging.SimpleLogging, applyResult ApplyResult) error {
+	if !d.WorkspaceRegex.MatchString(applyResult.Workspace) || !d.BranchRegex.MatchString(applyResult.Pull.BaseBranch) {
+		return nil
+	}
+	return d.Client.postMessage(ApplyEvent, d.URL, newDiscordApplyMessage(applyResult))
+}
+
+// DriftDiscordWebhook sends drift notifications to a Discord channel webhook.
+type DriftDiscordWebhook struct {
+	Client *HttpClient
+	URL    string
+}
+
+// Send posts the drift result as an embed.
+func (d *DriftDiscordWebhook) Send(_ logging.SimpleLogging, result DriftResult) error {
+	return d.Client.postMessage(DriftEvent, d.URL, newDiscordDriftMessage(result))
+}
+
+// discordMessage is the body of a Discord webhook request carrying a single
+// embed.
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// newDiscordField returns an embed field. Discord rejects empty values so
+// they're replaced with "-".
+func newDiscordField(name string, value string, inline bool) discordField {
+	if value == "" {
+		value = "-"
+	}
+	return discordField{
+		Name:   name,
+		Value:  truncateGraphemeClusters(value, maxDiscordFieldValue),
+		Inline: inline,
+	}
+}
+
+func newDiscordApplyMessage(applyResult ApplyResult) discordMessage {
+	colour := discordSuccessColour
+	if !applyResult.Success {
+		colour = discordFailureColour
+	}
+	var fields []discordField
+	if applyResult.ProjectName != "" {
+		fields = append(fields, newDiscordField("Project", applyResult.ProjectName, true))
+	}
+	fields = append(fields,
+		newDiscordField("Workspace", applyResult.Workspace, true),
+		newDiscordField("Directory", applyDirectoryText(applyResult), true),
+		newDiscordField("Branch", applyResult.Pull.HeadBranch, true),
+		newDiscordField("User", applyResult.User.Username, true),
+		newDiscordField("Pull Request", fmt.Sprintf("[#%d](%s)", applyResult.Pull.Num, applyResult.Pull.URL), true),
+	)
+	return discordMessage{Embeds: []discordEmbed{{
+		Title:       applyTitle(applyResult),
+		URL:         applyResult.Pull.URL,
+		Description: truncateGraphemeClusters(applyResult.Pull.Body, maxDescriptionGraphemeClusters),
+		Color:       colour,
+		Fields:      fields,
+	}}}
+}
+
+func newDiscordDriftMessage(result DriftResult) discordMessage {
+	colour := discordSuccessColour
+	if result.ProjectsWithDrift > 0 {
+		colour = discordFailureColour
+	}
+	fields := []discordField{
+		newDiscordField("Repository", result.Repository, true),
+		newDiscordField("Ref", result.Ref, true),
+		newDiscordField("Projects with drift", fmt.Sprintf("%d / %d", result.ProjectsWithDrift, result.TotalProjects), true),
+		newDiscordField("Detection ID", result.DetectionID, true),
+	}
+	if resources := driftResourcesText(result); resources != "" {
+		fields = append(fields, newDiscordField("Drifted resources", resources, false))
+	}
+	return discordMessage{Embeds: []discordEmbed{{
+		Title:  driftTitle(result),
+		Color:  colour,
+		Fields: fields,
+	}}}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+// discordMessage is the subset of a Discord webhook body the tests inspect.
+type discordMessage struct {
+	Embeds []struct {
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		Description string `json:"description"`
+		Color       int    `json:"color"`
+		Fields      []struct {
+			Name   string `json:"name"`
+			Value  string `json:"value"`
+			Inline bool   `json:"inline"`
+		} `json:"fields"`
+	} `json:"embeds"`
+}
+
+func receiveDiscordMessage(t *testing.T, msg *discordMessage) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Equals(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		Ok(t, err)
+		Ok(t, json.Unmarshal(body, msg))
+		// Discord answers successful webhook executions with 204.
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func TestDiscordWebhook_Send(t *testing.T) {
+	var msg discordMessage
+	server := receiveDiscordMessage(t, &msg)
+	defer server.Close()
+
+	webhook := webhooks.DiscordWebhook{
+		Client:         &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:            server.URL,
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	result := httpApplyResult
+	result.ProjectName = "network"
+	result.Directory = "infra/network"
+	result.Pull.Body = strings.Repeat("a", 2000)
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), result))
+
+	Equals(t, 1, len(msg.Embeds))
+	embed := msg.Embeds[0]
+	Equals(t, "Apply succeeded for runatlantis/atlantis", embed.Title)
+	Equals(t, "url", embed.URL)
+	Equals(t, 0x2EB67D, embed.Color)
+	Equals(t, 1000, len([]rune(embed.Description)))
+	fields := map[string]string{}
+	for _, f := range embed.Fields {
+		fields[f.Name] = f.Value
+	}
+	Equals(t, "network", fields["Project"])
+	Equals(t, "production", fields["Workspace"])
+	Equals(t, "infra/network", fields["Directory"])
+	// Discord rejects empty field values.
+	Equals(t, "-", fields["Branch"])
+	Equals(t, "[#1](url)", fields["Pull Request"])
+}
+
+func TestDiscordWebhook_NoRegexMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Assert(t, false, "webhook should not be sent")
+	}))
+	defer server.Close()
+
+	webhook := webhooks.DiscordWebhook{
+		Client:         &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:            server.URL,
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile("other"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+}
+
+func TestDriftDiscordWebhook_Send(t *testing.T) {
+	var msg discordMessage
+	server := receiveDiscordMessage(t, &msg)
+	defer server.Close()
+
+	webhook := webhooks.DriftDiscordWebhook{
+		Client: &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:    server.URL,
+	}
+	result := driftResult
+	result.ProjectsWithDrift = 0
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), result))
+
+	embed := msg.Embeds[0]
+	Equals(t, "No drift in owner/repo", embed.Title)
+	Equals(t, 0x2EB67D, embed.Color)
+	fields := map[string]string{}
+	for _, f := range embed.Fields {
+		fields[f.Name] = f.Value
+	}
+	Equals(t, "owner/repo", fields["Repository"])
+	Equals(t, "0 / 2", fields["Projects with drift"])
+}
+
+func TestDiscordWebhook_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhook := webhooks.DriftDiscordWebhook{
+		Client: &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:    server.URL,
+	}
+	err := webhook.Send(logging.NewNoopLogger(t), driftResult)
+	Assert(t, err != nil, "expected error")
+}
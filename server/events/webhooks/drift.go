@@ -6,6 +6,7 @@ package webhooks
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/runatlantis/atlantis/server/logging"
 )
@@ -34,7 +35,21 @@ type DriftProjectResult struct {
 	ToImport    int    `json:"to_import"`
 	ToForget    int    `json:"to_forget"`
 	Summary     string `json:"summary"`
-	Error       string `json:"error,omitempty"`
+	// Ignored is the number of drifted resources excluded by drift ignore
+	// rules.
+	Ignored int `json:"ignored,omitempty"`
+	// Resources lists the resources that drifted, if known.
+	Resources []DriftResource `json:"resources,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// DriftResource describes a single drifted resource.
+type DriftResource struct {
+	Address           string   `json:"address"`
+	Action            string   `json:"action"`
+	ChangedAttributes []string `json:"changed_attributes,omitempty"`
+	Outside           bool     `json:"outside,omitempty"`
+	Ignored           bool     `json:"ignored,omitempty"`
 }
 
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_drift_sender.go DriftSender
@@ -88,12 +103,29 @@ func NewDriftWebhookSender(configs []Config, clients Clients) (*DriftWebhookSend
 			if c.URL == "" {
 				return nil, errors.New("must specify \"url\" for drift webhook of \"kind: http\"")
 			}
-			senders = append(senders, &DriftHttpWebhook{
+			endpoint, err := newHttpEndpoint(c, clients.Http)
+			if err != nil {
+				return nil, err
+			}
+			senders = append(senders, &DriftHttpWebhook{HttpEndpoint: endpoint})
+		case MSTeamsKind:
+			if c.URL == "" {
+				return nil, errors.New("must specify \"url\" for drift webhook of \"kind: msteams\"")
+			}
+			senders = append(senders, &DriftMSTeamsWebhook{
+				Client: clients.Http,
+				URL:    c.URL,
+			})
+		case DiscordKind:
+			if c.URL == "" {
+				return nil, errors.New("must specify \"url\" for drift webhook of \"kind: discord\"")
+			}
+			senders = append(senders, &DriftDiscordWebhook{
 				Client: clients.Http,
 				URL:    c.URL,
 			})
 		default:
-			return nil, fmt.Errorf("\"kind: %s\" not supported for drift webhooks. Only \"kind: %s\" and \"kind: %s\" are supported", c.Kind, SlackKind, HttpKind)
+			return nil, fmt.Errorf("\"kind: %s\" not supported for drift webhooks. Supported kinds are: %s", c.Kind, strings.Join([]string{SlackKind, HttpKind, MSTeamsKind, DiscordKind}, ", "))
 		}
 	}
 	return &DriftWebhookSender{Webhooks: senders}, nil
@@ -4,11 +4,6 @@
 package webhooks
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
@@ -23,36 +18,12 @@ var (
 
 // DriftHttpWebhook sends drift notifications to an HTTP endpoint.
 type DriftHttpWebhook struct {
-	Client *HttpClient
-	URL    string
+	HttpEndpoint
 }
 
 // Send sends the drift result to the configured HTTP endpoint.
 func (h *DriftHttpWebhook) Send(_ logging.SimpleLogging, result DriftResult) error {
-	body, err := json.Marshal(result)
-	if err != nil {
-		return err
-	}
-	req, err := http.NewRequest("POST", h.URL, bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("creating drift webhook request for %q: %s", sanitizeDriftWebhookURL(h.URL), sanitizeDriftWebhookError(err.Error()))
-	}
-	req.Header.Set("Content-Type", "application/json")
-	for header, values := range h.Client.Headers {
-		for _, value := range values {
-			req.Header.Add(header, value)
-		}
-	}
-	resp, err := h.Client.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("sending drift webhook to %q: %s", sanitizeDriftWebhookURL(h.URL), sanitizeDriftWebhookError(err.Error()))
-	}
-	defer resp.Body.Close()
-	if !isSuccessStatus(resp.StatusCode) {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("drift webhook to %q returned status %d: %s", sanitizeDriftWebhookURL(h.URL), resp.StatusCode, sanitizeDriftWebhookError(string(respBody)))
-	}
-	return nil
+	return h.post(DriftEvent, result)
 }
 
 func sanitizeDriftWebhookURL(rawURL string) string {
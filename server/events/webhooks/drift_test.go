@@ -210,3 +210,23 @@ func TestNewDriftWebhookSender_MixedConfigs(t *testing.T) {
 	Ok(t, err)
 	Equals(t, 2, len(sender.Webhooks))
 }
+
+func TestNewDriftWebhookSender_ChatKinds(t *testing.T) {
+	clients := webhooks.Clients{
+		Http: &webhooks.HttpClient{Client: http.DefaultClient},
+	}
+	for _, kind := range []string{webhooks.MSTeamsKind, webhooks.DiscordKind} {
+		t.Run(kind, func(t *testing.T) {
+			sender, err := webhooks.NewDriftWebhookSender([]webhooks.Config{
+				{Event: webhooks.DriftEvent, Kind: kind, URL: "https://example.com/webhook"},
+			}, clients)
+			Ok(t, err)
+			Equals(t, 1, len(sender.Webhooks))
+
+			_, err = webhooks.NewDriftWebhookSender([]webhooks.Config{
+				{Event: webhooks.DriftEvent, Kind: kind},
+			}, clients)
+			ErrEquals(t, "must specify \"url\" for drift webhook of \"kind: "+kind+"\"", err)
+		})
+	}
+}
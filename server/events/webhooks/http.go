@@ -5,21 +5,73 @@ package webhooks
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
+	"sync/atomic"
+	"text/template"
+	"time"
 
+	"github.com/Masterminds/sprig/v3"
+	"github.com/google/uuid"
 	"github.com/runatlantis/atlantis/server/logging"
 )
 
+const (
+	// MaxPendingRetries is how many failed webhooks an HttpClient retries at
+	// once. Webhooks that fail while as many are being retried are recorded
+	// as dead letters right away.
+	MaxPendingRetries = 100
+	// SignatureHeader holds the HMAC-SHA256 signature of signed webhooks, in
+	// the form sha256=<hex digest>. The digest covers the timestamp, a
+	// period and the request body.
+	SignatureHeader = "X-Atlantis-Signature"
+	// TimestampHeader holds the Unix time a signed webhook was sent at, so
+	// receivers can reject replayed requests.
+	TimestampHeader = "X-Atlantis-Timestamp"
+)
+
+// HttpEndpoint is the destination of an http webhook. The http webhooks of
+// every event embed it.
+type HttpEndpoint struct {
+	Client *HttpClient
+	URL    string
+	// Secret signs requests if set.
+	Secret string
+	// Template renders the request body if set.
+	Template *template.Template
+}
+
+// newHttpEndpoint returns the endpoint of the http webhook config c.
+func newHttpEndpoint(c Config, client *HttpClient) (HttpEndpoint, error) {
+	tmpl, err := ParsePayloadTemplate(c.PayloadTemplate)
+	if err != nil {
+		return HttpEndpoint{}, fmt.Errorf("%s webhook: %w", c.Event, err)
+	}
+	return HttpEndpoint{
+		Client:   client,
+		URL:      c.URL,
+		Secret:   c.Secret,
+		Template: tmpl,
+	}, nil
+}
+
+// post sends payload for event to the endpoint.
+func (e HttpEndpoint) post(event string, payload any) error {
+	return e.Client.post(event, e.URL, e.Secret, e.Template, payload)
+}
+
 // HttpWebhook sends webhooks to any HTTP destination.
 type HttpWebhook struct {
-	Client         *HttpClient
+	HttpEndpoint
 	WorkspaceRegex *regexp.Regexp
 	BranchRegex    *regexp.Regexp
-	URL            string
 }
 
 // Send sends the webhook to URL if workspace and branch matches their respective regex.
@@ -27,41 +79,203 @@ func (h *HttpWebhook) Send(_ logging.SimpleLogging, applyResult ApplyResult) err
 	if !h.WorkspaceRegex.MatchString(applyResult.Workspace) || !h.BranchRegex.MatchString(applyResult.Pull.BaseBranch) {
 		return nil
 	}
-	if err := h.doSend(applyResult); err != nil {
-		return fmt.Errorf("sending webhook to %q: %w", h.URL, err)
+	if err := h.post(ApplyEvent, applyResult); err != nil {
+		return fmt.Errorf("sending webhook to %q: %w", sanitizeDriftWebhookURL(h.URL), err)
 	}
 	return nil
 }
 
-func (h *HttpWebhook) doSend(applyResult ApplyResult) error {
-	body, err := json.Marshal(applyResult)
+// HttpClient wraps http.Client allowing to add arbitrary Headers to a request.
+type HttpClient struct {
+	Client  *http.Client
+	Headers map[string][]string
+	// Retry controls how failed requests are retried. The zero value sends
+	// each webhook once.
+	Retry RetryPolicy
+	// DeadLetters records webhooks that failed every attempt. It may be nil.
+	DeadLetters DeadLetterStore
+	// Logger logs webhooks that failed every retry. It may be nil.
+	Logger logging.SimpleLogging
+
+	pendingRetries atomic.Int32
+}
+
+// RetryPolicy retries failed webhook requests with exponential backoff.
+// Requests are retried if they couldn't be sent or the endpoint returned a
+// 429 or 5xx status.
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is retried after it first fails.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. It doubles for
+	// each following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+}
+
+// backoff returns the delay before the given retry, counting from 1.
+func (r RetryPolicy) backoff(retry int) time.Duration {
+	delay := r.InitialBackoff
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if r.MaxBackoff > 0 && delay >= r.MaxBackoff {
+			break
+		}
+	}
+	if r.MaxBackoff > 0 && delay > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return delay
+}
+
+// ParsePayloadTemplate parses a webhook payload template. Templates are
+// executed with the event payload and can use the sprig functions, e.g.
+// toJson.
+func ParsePayloadTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("payload").Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(text)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("parsing payload template: %w", err)
 	}
-	req, err := http.NewRequest("POST", h.URL, bytes.NewBuffer(body))
+	return tmpl, nil
+}
+
+// post sends payload for event to rawURL, rendered with tmpl if set and as
+// JSON otherwise. Errors never include credentials from the URL or response.
+func (c *HttpClient) post(event string, rawURL string, secret string, tmpl *template.Template, payload any) error {
+	body, err := renderPayload(tmpl, payload)
+	if err != nil {
+		return fmt.Errorf("rendering %s webhook: %w", event, err)
+	}
+	return c.deliver(event, rawURL, secret, c.Headers, body)
+}
+
+// postMessage posts a chat message as JSON to an incoming webhook URL, like
+// those of Microsoft Teams and Discord. The configured headers are meant for
+// the user's own endpoints, so they aren't sent.
+func (c *HttpClient) postMessage(event string, rawURL string, message any) error {
+	body, err := json.Marshal(message)
 	if err != nil {
+		return fmt.Errorf("rendering %s webhook: %w", event, err)
+	}
+	return c.deliver(event, rawURL, "", nil, body)
+}
+
+// deliver sends body to rawURL. Failed requests worth retrying are retried
+// in the background according to the client's RetryPolicy, so that callers,
+// which often hold locks or are running commands, don't wait for the
+// backoff. It returns nil once a retry is scheduled. If every attempt fails
+// the request is recorded as a dead letter.
+func (c *HttpClient) deliver(event string, rawURL string, secret string, headers map[string][]string, body []byte) error {
+	retryable, err := c.send(event+" webhook", rawURL, secret, headers, body)
+	if err == nil {
+		return nil
+	}
+	if !retryable || c.Retry.MaxRetries == 0 {
+		c.addDeadLetter(event, rawURL, body, 1, err)
 		return err
 	}
+	if c.pendingRetries.Add(1) > MaxPendingRetries {
+		c.pendingRetries.Add(-1)
+		c.addDeadLetter(event, rawURL, body, 1, err)
+		return fmt.Errorf("%w (not retried because %d webhooks are already being retried)", err, MaxPendingRetries)
+	}
+	go func() {
+		defer c.pendingRetries.Add(-1)
+		c.retry(event, rawURL, secret, headers, body)
+	}()
+	return nil
+}
+
+// retry retries a request whose first attempt failed until it succeeds, it
+// fails with an error not worth retrying or the retries run out.
+func (c *HttpClient) retry(event string, rawURL string, secret string, headers map[string][]string, body []byte) {
+	attempts := 1
+	for {
+		time.Sleep(c.Retry.backoff(attempts))
+		attempts++
+		retryable, err := c.send(event+" webhook", rawURL, secret, headers, body)
+		if err == nil {
+			return
+		}
+		if !retryable || attempts > c.Retry.MaxRetries {
+			c.addDeadLetter(event, rawURL, body, attempts, err)
+			if c.Logger != nil {
+				c.Logger.Warn("giving up on %s webhook after %d attempts: %s", event, attempts, err)
+			}
+			return
+		}
+	}
+}
+
+// send makes a single request. It returns whether a failed request is worth
+// retrying.
+func (c *HttpClient) send(name string, rawURL string, secret string, headers map[string][]string, body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", rawURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("creating %s request for %q: %s", name, sanitizeDriftWebhookURL(rawURL), sanitizeDriftWebhookError(err.Error()))
+	}
 	req.Header.Set("Content-Type", "application/json")
-	for header, values := range h.Client.Headers {
+	for header, values := range headers {
 		for _, value := range values {
 			req.Header.Add(header, value)
 		}
 	}
-	resp, err := h.Client.Client.Do(req)
+	if secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	}
+	resp, err := c.Client.Do(req)
 	if err != nil {
-		return err
+		return true, fmt.Errorf("sending %s to %q: %s", name, sanitizeDriftWebhookURL(rawURL), sanitizeDriftWebhookError(err.Error()))
 	}
 	defer resp.Body.Close()
 	if !isSuccessStatus(resp.StatusCode) {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("returned status code %d with response %q", resp.StatusCode, respBody)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5
+		return retryable, fmt.Errorf("%s to %q returned status %d: %s", name, sanitizeDriftWebhookURL(rawURL), resp.StatusCode, sanitizeDriftWebhookError(string(respBody)))
 	}
-	return nil
+	return false, nil
 }
 
-// HttpClient wraps http.Client allowing to add arbitrary Headers to a request.
-type HttpClient struct {
-	Client  *http.Client
-	Headers map[string][]string
+func (c *HttpClient) addDeadLetter(event string, rawURL string, body []byte, attempts int, sendErr error) {
+	if c.DeadLetters == nil {
+		return
+	}
+	// The webhook error is already reported, so a failure to record it is
+	// not reported separately.
+	c.DeadLetters.Add(DeadLetter{ // nolint: errcheck
+		ID:        uuid.NewString(),
+		Event:     event,
+		URL:       sanitizeDriftWebhookURL(rawURL),
+		Payload:   string(body),
+		Attempts:  attempts,
+		LastError: sendErr.Error(),
+		FailedAt:  time.Now(),
+	})
+}
+
+func renderPayload(tmpl *template.Template, payload any) ([]byte, error) {
+	if tmpl == nil {
+		return json.Marshal(payload)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Sign returns the signature header value for body sent at timestamp.
+// Receivers recompute it with their copy of the secret and compare it to
+// the SignatureHeader in constant time.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10))) // nolint: errcheck
+	mac.Write([]byte("."))                              // nolint: errcheck
+	mac.Write(body)                                     // nolint: errcheck
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
 }
@@ -4,15 +4,22 @@
 package webhooks_test
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/events/models"
 	"github.com/runatlantis/atlantis/server/events/webhooks"
 	"github.com/runatlantis/atlantis/server/logging"
 	. "github.com/runatlantis/atlantis/testing"
+	"github.com/stretchr/testify/require"
 )
 
 var httpApplyResult = webhooks.ApplyResult{
@@ -47,8 +54,10 @@ func TestHttpWebhookWithHeaders(t *testing.T) {
 	defer server.Close()
 
 	webhook := webhooks.HttpWebhook{
-		Client:         &webhooks.HttpClient{Client: http.DefaultClient, Headers: expectedHeaders},
-		URL:            server.URL,
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient, Headers: expectedHeaders},
+			URL:    server.URL,
+		},
 		WorkspaceRegex: regexp.MustCompile(".*"),
 		BranchRegex:    regexp.MustCompile(".*"),
 	}
@@ -65,8 +74,10 @@ func TestHttpWebhookNoHeaders(t *testing.T) {
 	defer server.Close()
 
 	webhook := webhooks.HttpWebhook{
-		Client:         &webhooks.HttpClient{Client: http.DefaultClient},
-		URL:            server.URL,
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:    server.URL,
+		},
 		WorkspaceRegex: regexp.MustCompile(".*"),
 		BranchRegex:    regexp.MustCompile(".*"),
 	}
@@ -82,8 +93,10 @@ func TestHttpWebhook202(t *testing.T) {
 	defer server.Close()
 
 	webhook := webhooks.HttpWebhook{
-		Client:         &webhooks.HttpClient{Client: http.DefaultClient},
-		URL:            server.URL,
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:    server.URL,
+		},
 		WorkspaceRegex: regexp.MustCompile(".*"),
 		BranchRegex:    regexp.MustCompile(".*"),
 	}
@@ -99,8 +112,10 @@ func TestHttpWebhook500(t *testing.T) {
 	defer server.Close()
 
 	webhook := webhooks.HttpWebhook{
-		Client:         &webhooks.HttpClient{Client: http.DefaultClient},
-		URL:            server.URL,
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:    server.URL,
+		},
 		WorkspaceRegex: regexp.MustCompile(".*"),
 		BranchRegex:    regexp.MustCompile(".*"),
 	}
@@ -135,8 +150,10 @@ func TestHttpNoRegexMatch(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			webhook := webhooks.HttpWebhook{
-				Client:         &webhooks.HttpClient{Client: http.DefaultClient},
-				URL:            server.URL,
+				HttpEndpoint: webhooks.HttpEndpoint{
+					Client: &webhooks.HttpClient{Client: http.DefaultClient},
+					URL:    server.URL,
+				},
 				WorkspaceRegex: tc.wr,
 				BranchRegex:    tc.br,
 			}
@@ -145,3 +162,193 @@ func TestHttpNoRegexMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpWebhook_Signed(t *testing.T) {
+	var body []byte
+	var signature, timestamp string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		Ok(t, err)
+		signature = r.Header.Get(webhooks.SignatureHeader)
+		timestamp = r.Header.Get(webhooks.TimestampHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhook := webhooks.HttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:    server.URL,
+			Secret: "secret",
+		},
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	Ok(t, err)
+	Assert(t, time.Since(time.Unix(sentAt, 0)) < time.Minute, "expected a current timestamp, got %s", timestamp)
+	Equals(t, webhooks.Sign("secret", sentAt, body), signature)
+	Assert(t, webhooks.Sign("other", sentAt, body) != signature, "expected the signature to depend on the secret")
+}
+
+func TestHttpWebhook_Unsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Equals(t, "", r.Header.Get(webhooks.SignatureHeader))
+		Equals(t, "", r.Header.Get(webhooks.TimestampHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhook := webhooks.HttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:    server.URL,
+		},
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+}
+
+func TestSign(t *testing.T) {
+	// printf "1700000000.{}" | openssl dgst -sha256 -hmac secret
+	Equals(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", webhooks.Sign("secret", 1700000000, []byte("{}")))
+}
+
+func TestHttpWebhook_Template(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		Ok(t, err)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tmpl, err := webhooks.ParsePayloadTemplate(`{"text": {{ printf "%s applied %s" .User.Username .Workspace | toJson }}}`)
+	Ok(t, err)
+	webhook := webhooks.HttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client:   &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:      server.URL,
+			Template: tmpl,
+		},
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+	Equals(t, `{"text": "lkysow applied production"}`, string(body))
+}
+
+func TestParsePayloadTemplate(t *testing.T) {
+	tmpl, err := webhooks.ParsePayloadTemplate("")
+	Ok(t, err)
+	Assert(t, tmpl == nil, "expected no template")
+
+	_, err = webhooks.ParsePayloadTemplate("{{ .Workspace ")
+	ErrContains(t, "parsing payload template", err)
+}
+
+func TestHttpWebhook_RetriesThenSucceeds(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	deadLetters := webhooks.NewInMemoryDeadLetterStore()
+	webhook := webhooks.HttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{
+				Client:      http.DefaultClient,
+				Retry:       webhooks.RetryPolicy{MaxRetries: 3, InitialBackoff: 50 * time.Millisecond},
+				DeadLetters: deadLetters,
+			},
+			URL: server.URL,
+		},
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+	// Retries don't block the sender.
+	Equals(t, int32(1), requests.Load())
+	require.Eventually(t, func() bool { return requests.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+	letters, err := deadLetters.List()
+	Ok(t, err)
+	Equals(t, 0, len(letters))
+}
+
+func TestHttpWebhook_DeadLetterAfterRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	deadLetters := webhooks.NewInMemoryDeadLetterStore()
+	webhook := webhooks.HttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{
+				Client:      http.DefaultClient,
+				Retry:       webhooks.RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond},
+				DeadLetters: deadLetters,
+			},
+			URL: server.URL + "/hook?token=secret",
+		},
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+
+	var letters []webhooks.DeadLetter
+	require.Eventually(t, func() bool {
+		var err error
+		letters, err = deadLetters.List()
+		return err == nil && len(letters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	Equals(t, int32(3), requests.Load())
+	Equals(t, "apply", letters[0].Event)
+	Equals(t, server.URL, letters[0].URL)
+	Equals(t, 3, letters[0].Attempts)
+	Assert(t, letters[0].ID != "", "expected an ID")
+	Assert(t, strings.Contains(letters[0].Payload, `"Workspace":"production"`), "got payload %s", letters[0].Payload)
+	ErrContains(t, "returned status 500", errors.New(letters[0].LastError))
+}
+
+func TestHttpWebhook_NoRetryOnClientError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	deadLetters := webhooks.NewInMemoryDeadLetterStore()
+	webhook := webhooks.HttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{
+				Client:      http.DefaultClient,
+				Retry:       webhooks.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond},
+				DeadLetters: deadLetters,
+			},
+			URL: server.URL,
+		},
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	err := webhook.Send(logging.NewNoopLogger(t), httpApplyResult)
+	ErrContains(t, "returned status 400", err)
+	Equals(t, int32(1), requests.Load())
+	letters, err := deadLetters.List()
+	Ok(t, err)
+	Equals(t, 1, len(letters))
+	Equals(t, 1, letters[0].Attempts)
+}
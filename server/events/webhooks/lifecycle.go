@@ -0,0 +1,192 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	PlanStartedEvent       = "plan_started"
+	PlanFinishedEvent      = "plan_finished"
+	PolicyCheckPassedEvent = "policy_check_passed"
+	PolicyCheckFailedEvent = "policy_check_failed"
+	LockAcquiredEvent      = "lock_acquired"
+	LockReleasedEvent      = "lock_released"
+	AutoplanSkippedEvent   = "autoplan_skipped"
+)
+
+// LifecycleEvents are the events sent by MultiWebhookSender.SendLifecycle.
+var LifecycleEvents = []string{
+	PlanStartedEvent,
+	PlanFinishedEvent,
+	PolicyCheckPassedEvent,
+	PolicyCheckFailedEvent,
+	LockAcquiredEvent,
+	LockReleasedEvent,
+	AutoplanSkippedEvent,
+}
+
+// IsLifecycleEvent returns true if event is sent by
+// MultiWebhookSender.SendLifecycle.
+func IsLifecycleEvent(event string) bool {
+	return slices.Contains(LifecycleEvents, event)
+}
+
+// LifecycleEvent is the payload sent to webhooks for plan, policy check, lock
+// and autoplan events. Fields that don't apply to an event are omitted.
+type LifecycleEvent struct {
+	Event      string    `json:"event"`
+	Time       time.Time `json:"time"`
+	Repository string    `json:"repository"`
+	PullNum    int       `json:"pull_num"`
+	PullURL    string    `json:"pull_url"`
+	BaseBranch string    `json:"base_branch"`
+	HeadCommit string    `json:"head_commit,omitempty"`
+	// User is the user who ran the command or holds the lock.
+	User        string `json:"user,omitempty"`
+	ProjectName string `json:"project_name,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Workspace   string `json:"workspace,omitempty"`
+	// Success is set for plan_finished events.
+	Success *bool  `json:"success,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// PlanStats is set for successful plan_finished events.
+	PlanStats *PlanStats `json:"plan_stats,omitempty"`
+	// PolicySets is set for policy check events.
+	PolicySets []PolicySetResult `json:"policy_sets,omitempty"`
+	// Reason is set for autoplan_skipped events.
+	Reason string `json:"reason,omitempty"`
+}
+
+// PlanStats counts the changes in a plan.
+type PlanStats struct {
+	Import         int  `json:"import"`
+	Add            int  `json:"add"`
+	Change         int  `json:"change"`
+	Destroy        int  `json:"destroy"`
+	Forget         int  `json:"forget"`
+	Changes        bool `json:"changes"`
+	ChangesOutside bool `json:"changes_outside"`
+}
+
+// PolicySetResult is the outcome of one policy set in a policy check.
+type PolicySetResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+}
+
+//go:generate go tool pegomock generate --package mocks -o mocks/mock_lifecycle_sender.go LifecycleSender
+
+// LifecycleSender sends the lifecycle webhook for one config.
+type LifecycleSender interface {
+	Send(log logging.SimpleLogging, event LifecycleEvent) error
+}
+
+// newLifecycleWebhook returns the webhook for the lifecycle event config c.
+func newLifecycleWebhook(c Config, clients Clients) (LifecycleSender, error) {
+	filter, err := newLifecycleFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	switch c.Kind {
+	case SlackKind:
+		if !clients.Slack.TokenIsSet() {
+			return nil, fmt.Errorf("must specify top-level \"slack-token\" if using a %s webhook of \"kind: slack\"", c.Event)
+		}
+		if c.Channel == "" {
+			return nil, fmt.Errorf("must specify \"channel\" for %s webhook of \"kind: slack\"", c.Event)
+		}
+		return &LifecycleSlackWebhook{
+			Client:  clients.Slack,
+			Channel: c.Channel,
+			Filter:  filter,
+		}, nil
+	case HttpKind:
+		if c.URL == "" {
+			return nil, fmt.Errorf("must specify \"url\" for %s webhook of \"kind: http\"", c.Event)
+		}
+		endpoint, err := newHttpEndpoint(c, clients.Http)
+		if err != nil {
+			return nil, err
+		}
+		return &LifecycleHttpWebhook{
+			HttpEndpoint: endpoint,
+			Filter:       filter,
+		}, nil
+	default:
+		return nil, fmt.Errorf("\"kind: %s\" not supported for %s webhooks. Only \"kind: %s\" and \"kind: %s\" are supported", c.Kind, c.Event, SlackKind, HttpKind)
+	}
+}
+
+// LifecycleFilter selects the lifecycle events a webhook is sent for.
+type LifecycleFilter struct {
+	Event          string
+	WorkspaceRegex *regexp.Regexp
+	BranchRegex    *regexp.Regexp
+	ProjectRegex   *regexp.Regexp
+}
+
+func newLifecycleFilter(c Config) (LifecycleFilter, error) {
+	filter := LifecycleFilter{Event: c.Event}
+	var err error
+	if filter.WorkspaceRegex, err = regexp.Compile(c.WorkspaceRegex); err != nil {
+		return filter, err
+	}
+	if filter.BranchRegex, err = regexp.Compile(c.BranchRegex); err != nil {
+		return filter, err
+	}
+	if filter.ProjectRegex, err = regexp.Compile(c.ProjectRegex); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// Matches returns true if the webhook should be sent for event. Events that
+// aren't about a project, like autoplan_skipped, are only checked against the
+// branch.
+func (f LifecycleFilter) Matches(event LifecycleEvent) bool {
+	if event.Event != f.Event || !f.BranchRegex.MatchString(event.BaseBranch) {
+		return false
+	}
+	if event.Workspace == "" {
+		return true
+	}
+	return f.WorkspaceRegex.MatchString(event.Workspace) && f.ProjectRegex.MatchString(event.ProjectName)
+}
+
+// LifecycleSlackWebhook sends lifecycle events to Slack.
+type LifecycleSlackWebhook struct {
+	Client  SlackClient
+	Channel string
+	Filter  LifecycleFilter
+}
+
+// Send sends the event to Slack if it matches the filter.
+func (s *LifecycleSlackWebhook) Send(_ logging.SimpleLogging, event LifecycleEvent) error {
+	if !s.Filter.Matches(event) {
+		return nil
+	}
+	return s.Client.PostLifecycleMessage(s.Channel, event)
+}
+
+// LifecycleHttpWebhook sends lifecycle events to an HTTP endpoint.
+type LifecycleHttpWebhook struct {
+	HttpEndpoint
+	Filter LifecycleFilter
+}
+
+// Send posts the event as JSON to the configured URL if it matches the
+// filter.
+func (h *LifecycleHttpWebhook) Send(_ logging.SimpleLogging, event LifecycleEvent) error {
+	if !h.Filter.Matches(event) {
+		return nil
+	}
+	return h.post(event.Event, event)
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/events/webhooks/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+	"github.com/stretchr/testify/require"
+)
+
+var planSucceeded = true
+
+var planFinishedEvent = webhooks.LifecycleEvent{
+	Event:       webhooks.PlanFinishedEvent,
+	Time:        time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+	Repository:  "owner/repo",
+	PullNum:     42,
+	PullURL:     "https://github.com/owner/repo/pull/42",
+	BaseBranch:  "main",
+	HeadCommit:  "abc123",
+	User:        "lkysow",
+	ProjectName: "vpc",
+	Path:        "modules/vpc",
+	Workspace:   "default",
+	Success:     &planSucceeded,
+	PlanStats:   &webhooks.PlanStats{Add: 1, Change: 2, Changes: true},
+}
+
+func TestSendLifecycle_ContinuesAfterSenderFailure(t *testing.T) {
+	RegisterMockTestingT(t)
+	failing := mocks.NewMockLifecycleSender()
+	succeeding := mocks.NewMockLifecycleSender()
+	manager := webhooks.MultiWebhookSender{
+		LifecycleWebhooks: []webhooks.LifecycleSender{failing, succeeding},
+	}
+	logger := logging.NewNoopLogger(t)
+	When(failing.Send(logger, planFinishedEvent)).ThenReturn(errors.New("failure"))
+
+	err := manager.SendLifecycle(logger, planFinishedEvent)
+	Ok(t, err)
+	failing.VerifyWasCalledEventually(Once(), 5*time.Second).Send(logger, planFinishedEvent)
+	succeeding.VerifyWasCalledEventually(Once(), 5*time.Second).Send(logger, planFinishedEvent)
+}
+
+func TestSendLifecycle_DoesNotWaitForWebhooks(t *testing.T) {
+	RegisterMockTestingT(t)
+	sender := mocks.NewMockLifecycleSender()
+	manager := webhooks.MultiWebhookSender{
+		LifecycleWebhooks: []webhooks.LifecycleSender{sender},
+	}
+	logger := logging.NewNoopLogger(t)
+	release := make(chan struct{})
+	defer close(release)
+	var sent []string
+	var sentMutex sync.Mutex
+	When(sender.Send(Any[logging.SimpleLogging](), Any[webhooks.LifecycleEvent]())).Then(func(params []Param) ReturnValues {
+		<-release
+		sentMutex.Lock()
+		defer sentMutex.Unlock()
+		sent = append(sent, params[1].(webhooks.LifecycleEvent).Event)
+		return ReturnValues{nil}
+	})
+
+	// The webhook is stuck, but sending doesn't block.
+	Ok(t, manager.SendLifecycle(logger, webhooks.LifecycleEvent{Event: webhooks.PlanStartedEvent}))
+	Ok(t, manager.SendLifecycle(logger, webhooks.LifecycleEvent{Event: webhooks.PlanFinishedEvent}))
+	release <- struct{}{}
+	release <- struct{}{}
+	require.Eventually(t, func() bool {
+		sentMutex.Lock()
+		defer sentMutex.Unlock()
+		return len(sent) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	Equals(t, []string{webhooks.PlanStartedEvent, webhooks.PlanFinishedEvent}, sent)
+}
+
+func TestNewMultiWebhookSender_LifecycleWebhooks(t *testing.T) {
+	RegisterMockTestingT(t)
+	slackClient := mocks.NewMockSlackClient()
+	When(slackClient.TokenIsSet()).ThenReturn(true)
+
+	configs := []webhooks.Config{
+		{Event: webhooks.ApplyEvent, Kind: webhooks.SlackKind, Channel: "applies"},
+		{Event: webhooks.LockExpiredEvent, Kind: webhooks.HttpKind, URL: "http://example.com/locks"},
+		{Event: webhooks.PlanFinishedEvent, Kind: webhooks.SlackKind, Channel: "plans"},
+		{Event: webhooks.PolicyCheckFailedEvent, Kind: webhooks.HttpKind, URL: "http://example.com/policies"},
+		{Event: webhooks.AutoplanSkippedEvent, Kind: webhooks.HttpKind, URL: "http://example.com/autoplan"},
+	}
+	clients := webhooks.Clients{
+		Slack: slackClient,
+		Http:  &webhooks.HttpClient{Client: http.DefaultClient},
+	}
+	sender, err := webhooks.NewMultiWebhookSender(configs, clients)
+	Ok(t, err)
+	Equals(t, 1, len(sender.Webhooks))
+	Equals(t, 3, len(sender.LifecycleWebhooks))
+}
+
+func TestNewMultiWebhookSender_LifecycleErrors(t *testing.T) {
+	RegisterMockTestingT(t)
+	slackClient := mocks.NewMockSlackClient()
+	When(slackClient.TokenIsSet()).ThenReturn(true)
+	clients := webhooks.Clients{Slack: slackClient}
+
+	cases := map[string]struct {
+		config webhooks.Config
+		expErr string
+	}{
+		"slack without channel": {
+			config: webhooks.Config{Event: webhooks.PlanStartedEvent, Kind: webhooks.SlackKind},
+			expErr: "channel",
+		},
+		"http without url": {
+			config: webhooks.Config{Event: webhooks.LockAcquiredEvent, Kind: webhooks.HttpKind},
+			expErr: "url",
+		},
+		"unsupported kind": {
+			config: webhooks.Config{Event: webhooks.LockReleasedEvent, Kind: "unsupported"},
+			expErr: "unsupported",
+		},
+		"invalid project regex": {
+			config: webhooks.Config{Event: webhooks.PlanFinishedEvent, Kind: webhooks.HttpKind, URL: "http://example.com", ProjectRegex: "("},
+			expErr: "missing closing )",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := webhooks.NewMultiWebhookSender([]webhooks.Config{c.config}, clients)
+			ErrContains(t, c.expErr, err)
+		})
+	}
+}
+
+func TestLifecycleFilter_Matches(t *testing.T) {
+	configs := map[string]webhooks.Config{
+		"match all":       {Event: webhooks.PlanFinishedEvent},
+		"other event":     {Event: webhooks.PlanStartedEvent},
+		"branch":          {Event: webhooks.PlanFinishedEvent, BranchRegex: "^main$"},
+		"other branch":    {Event: webhooks.PlanFinishedEvent, BranchRegex: "^release$"},
+		"workspace":       {Event: webhooks.PlanFinishedEvent, WorkspaceRegex: "^default$"},
+		"other workspace": {Event: webhooks.PlanFinishedEvent, WorkspaceRegex: "^production$"},
+		"project":         {Event: webhooks.PlanFinishedEvent, ProjectRegex: "^v"},
+		"other project":   {Event: webhooks.PlanFinishedEvent, ProjectRegex: "^networking"},
+	}
+	exp := map[string]bool{
+		"match all":       true,
+		"other event":     false,
+		"branch":          true,
+		"other branch":    false,
+		"workspace":       true,
+		"other workspace": false,
+		"project":         true,
+		"other project":   false,
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			sender, err := webhooks.NewMultiWebhookSender([]webhooks.Config{{
+				Event:          config.Event,
+				Kind:           webhooks.HttpKind,
+				URL:            "http://example.com",
+				BranchRegex:    config.BranchRegex,
+				WorkspaceRegex: config.WorkspaceRegex,
+				ProjectRegex:   config.ProjectRegex,
+			}}, webhooks.Clients{})
+			Ok(t, err)
+			filter := sender.LifecycleWebhooks[0].(*webhooks.LifecycleHttpWebhook).Filter
+			Equals(t, exp[name], filter.Matches(planFinishedEvent))
+		})
+	}
+}
+
+func TestLifecycleFilter_Matches_PullEvent(t *testing.T) {
+	sender, err := webhooks.NewMultiWebhookSender([]webhooks.Config{{
+		Event:          webhooks.AutoplanSkippedEvent,
+		Kind:           webhooks.HttpKind,
+		URL:            "http://example.com",
+		WorkspaceRegex: "^production$",
+		ProjectRegex:   "^networking",
+	}}, webhooks.Clients{})
+	Ok(t, err)
+	filter := sender.LifecycleWebhooks[0].(*webhooks.LifecycleHttpWebhook).Filter
+
+	// autoplan_skipped isn't about a project so only the branch is checked.
+	Equals(t, true, filter.Matches(webhooks.LifecycleEvent{
+		Event:      webhooks.AutoplanSkippedEvent,
+		BaseBranch: "main",
+		Reason:     "autoplan is disabled",
+	}))
+}
+
+func TestLifecycleHttpWebhook_Send(t *testing.T) {
+	var received webhooks.LifecycleEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Equals(t, "POST", r.Method)
+		Equals(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		Ok(t, err)
+		Ok(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender, err := webhooks.NewMultiWebhookSender([]webhooks.Config{{
+		Event: webhooks.PlanFinishedEvent,
+		Kind:  webhooks.HttpKind,
+		URL:   server.URL,
+	}}, webhooks.Clients{Http: &webhooks.HttpClient{Client: http.DefaultClient}})
+	Ok(t, err)
+	Ok(t, sender.LifecycleWebhooks[0].Send(logging.NewNoopLogger(t), planFinishedEvent))
+	Equals(t, planFinishedEvent, received)
+}
+
+func TestLifecycleHttpWebhook_SendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sender, err := webhooks.NewMultiWebhookSender([]webhooks.Config{{
+		Event: webhooks.PlanFinishedEvent,
+		Kind:  webhooks.HttpKind,
+		URL:   server.URL,
+	}}, webhooks.Clients{Http: &webhooks.HttpClient{Client: http.DefaultClient}})
+	Ok(t, err)
+	err = sender.LifecycleWebhooks[0].Send(logging.NewNoopLogger(t), planFinishedEvent)
+	ErrContains(t, "plan_finished webhook", err)
+	ErrContains(t, "returned status 500", err)
+}
+
+func TestLifecycleSlackWebhook_Send(t *testing.T) {
+	RegisterMockTestingT(t)
+	client := mocks.NewMockSlackClient()
+	When(client.TokenIsSet()).ThenReturn(true)
+	sender, err := webhooks.NewMultiWebhookSender([]webhooks.Config{
+		{Event: webhooks.PlanFinishedEvent, Kind: webhooks.SlackKind, Channel: "plans", ProjectRegex: "^networking"},
+		{Event: webhooks.PlanFinishedEvent, Kind: webhooks.SlackKind, Channel: "all-plans"},
+	}, webhooks.Clients{Slack: client})
+	Ok(t, err)
+	Ok(t, sender.SendLifecycle(logging.NewNoopLogger(t), planFinishedEvent))
+	client.VerifyWasCalledEventually(Once(), 5*time.Second).PostLifecycleMessage("all-plans", planFinishedEvent)
+	client.VerifyWasCalled(Never()).PostLifecycleMessage("plans", planFinishedEvent)
+}
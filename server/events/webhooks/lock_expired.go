@@ -0,0 +1,112 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const LockExpiredEvent = "lock_expired"
+
+// LockExpiredResult is the payload sent to lock_expired webhooks when a lock
+// is released because it was held for longer than its repo's lock TTL.
+type LockExpiredResult struct {
+	Repository  string    `json:"repository"`
+	PullNum     int       `json:"pull_num"`
+	PullURL     string    `json:"pull_url"`
+	ProjectName string    `json:"project_name"`
+	Path        string    `json:"path"`
+	Workspace   string    `json:"workspace"`
+	LockedBy    string    `json:"locked_by"`
+	LockedAt    time.Time `json:"locked_at"`
+	ExpiredAt   time.Time `json:"expired_at"`
+	TTL         string    `json:"ttl"`
+}
+
+//go:generate go tool pegomock generate --package mocks -o mocks/mock_lock_expired_sender.go LockExpiredSender
+
+// LockExpiredSender sends lock_expired webhooks.
+type LockExpiredSender interface {
+	Send(log logging.SimpleLogging, result LockExpiredResult) error
+}
+
+// LockExpiredWebhookSender distributes lock expiry notifications to all
+// configured senders.
+type LockExpiredWebhookSender struct {
+	Webhooks []LockExpiredSender
+}
+
+// Send sends the result to all configured webhook senders.
+// Errors are logged but not propagated (fire-and-forget).
+func (l *LockExpiredWebhookSender) Send(log logging.SimpleLogging, result LockExpiredResult) error {
+	for _, w := range l.Webhooks {
+		if err := w.Send(log, result); err != nil {
+			log.Warn("error sending lock_expired webhook: %s", err)
+		}
+	}
+	return nil
+}
+
+// NewLockExpiredWebhookSender creates a LockExpiredWebhookSender from the
+// webhook configs with event: lock_expired. It returns a sender with no
+// webhooks if there are none.
+func NewLockExpiredWebhookSender(configs []Config, clients Clients) (*LockExpiredWebhookSender, error) {
+	var senders []LockExpiredSender
+	for _, c := range configs {
+		if c.Event != LockExpiredEvent {
+			continue
+		}
+		switch c.Kind {
+		case SlackKind:
+			if !clients.Slack.TokenIsSet() {
+				return nil, errors.New("must specify top-level \"slack-token\" if using a lock_expired webhook of \"kind: slack\"")
+			}
+			if c.Channel == "" {
+				return nil, errors.New("must specify \"channel\" for lock_expired webhook of \"kind: slack\"")
+			}
+			senders = append(senders, &LockExpiredSlackWebhook{
+				Client:  clients.Slack,
+				Channel: c.Channel,
+			})
+		case HttpKind:
+			if c.URL == "" {
+				return nil, errors.New("must specify \"url\" for lock_expired webhook of \"kind: http\"")
+			}
+			endpoint, err := newHttpEndpoint(c, clients.Http)
+			if err != nil {
+				return nil, err
+			}
+			senders = append(senders, &LockExpiredHttpWebhook{HttpEndpoint: endpoint})
+		default:
+			return nil, fmt.Errorf("\"kind: %s\" not supported for lock_expired webhooks. Only \"kind: %s\" and \"kind: %s\" are supported", c.Kind, SlackKind, HttpKind)
+		}
+	}
+	return &LockExpiredWebhookSender{Webhooks: senders}, nil
+}
+
+// LockExpiredSlackWebhook sends lock expiry notifications to Slack.
+type LockExpiredSlackWebhook struct {
+	Client  SlackClient
+	Channel string
+}
+
+// Send sends the result to Slack.
+func (s *LockExpiredSlackWebhook) Send(_ logging.SimpleLogging, result LockExpiredResult) error {
+	return s.Client.PostLockExpiredMessage(s.Channel, result)
+}
+
+// LockExpiredHttpWebhook sends lock expiry notifications to an HTTP
+// endpoint.
+type LockExpiredHttpWebhook struct {
+	HttpEndpoint
+}
+
+// Send posts the result as JSON to the configured URL.
+func (h *LockExpiredHttpWebhook) Send(_ logging.SimpleLogging, result LockExpiredResult) error {
+	return h.post(LockExpiredEvent, result)
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/petergtz/pegomock/v4"
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/events/webhooks/mocks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+var lockExpiredResult = webhooks.LockExpiredResult{
+	Repository:  "owner/repo",
+	PullNum:     42,
+	PullURL:     "https://github.com/owner/repo/pull/42",
+	ProjectName: "vpc",
+	Path:        "modules/vpc",
+	Workspace:   "default",
+	LockedBy:    "lkysow",
+	LockedAt:    time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+	ExpiredAt:   time.Date(2025, 1, 13, 9, 10, 0, 0, time.UTC),
+	TTL:         "168h0m0s",
+}
+
+func TestLockExpiredWebhookSender_Send_ContinuesAfterSenderFailure(t *testing.T) {
+	RegisterMockTestingT(t)
+	failing := mocks.NewMockLockExpiredSender()
+	succeeding := mocks.NewMockLockExpiredSender()
+	manager := webhooks.LockExpiredWebhookSender{
+		Webhooks: []webhooks.LockExpiredSender{failing, succeeding},
+	}
+	logger := logging.NewNoopLogger(t)
+	When(failing.Send(logger, lockExpiredResult)).ThenReturn(errors.New("failure"))
+
+	err := manager.Send(logger, lockExpiredResult)
+	Ok(t, err)
+	failing.VerifyWasCalledOnce().Send(logger, lockExpiredResult)
+	succeeding.VerifyWasCalledOnce().Send(logger, lockExpiredResult)
+}
+
+func TestNewLockExpiredWebhookSender(t *testing.T) {
+	RegisterMockTestingT(t)
+	slackClient := mocks.NewMockSlackClient()
+	When(slackClient.TokenIsSet()).ThenReturn(true)
+
+	configs := []webhooks.Config{
+		{Event: webhooks.ApplyEvent, Kind: webhooks.SlackKind, Channel: "applies"},
+		{Event: webhooks.DriftEvent, Kind: webhooks.HttpKind, URL: "http://example.com/drift"},
+		{Event: webhooks.LockExpiredEvent, Kind: webhooks.SlackKind, Channel: "locks"},
+		{Event: webhooks.LockExpiredEvent, Kind: webhooks.HttpKind, URL: "http://example.com/locks"},
+	}
+	clients := webhooks.Clients{
+		Slack: slackClient,
+		Http:  &webhooks.HttpClient{Client: http.DefaultClient},
+	}
+	sender, err := webhooks.NewLockExpiredWebhookSender(configs, clients)
+	Ok(t, err)
+	Equals(t, 2, len(sender.Webhooks))
+}
+
+func TestNewLockExpiredWebhookSender_Errors(t *testing.T) {
+	RegisterMockTestingT(t)
+	slackClient := mocks.NewMockSlackClient()
+	When(slackClient.TokenIsSet()).ThenReturn(true)
+	clients := webhooks.Clients{Slack: slackClient}
+
+	cases := map[string]struct {
+		config webhooks.Config
+		expErr string
+	}{
+		"slack without channel": {
+			config: webhooks.Config{Event: webhooks.LockExpiredEvent, Kind: webhooks.SlackKind},
+			expErr: "channel",
+		},
+		"http without url": {
+			config: webhooks.Config{Event: webhooks.LockExpiredEvent, Kind: webhooks.HttpKind},
+			expErr: "url",
+		},
+		"unsupported kind": {
+			config: webhooks.Config{Event: webhooks.LockExpiredEvent, Kind: "unsupported"},
+			expErr: "unsupported",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := webhooks.NewLockExpiredWebhookSender([]webhooks.Config{c.config}, clients)
+			ErrContains(t, c.expErr, err)
+		})
+	}
+}
+
+func TestLockExpiredHttpWebhook_Send(t *testing.T) {
+	var received webhooks.LockExpiredResult
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Equals(t, "POST", r.Method)
+		Equals(t, "application/json", r.Header.Get("Content-Type"))
+		Equals(t, "Bearer token", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		Ok(t, err)
+		Ok(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	hook := webhooks.LockExpiredHttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient, Headers: map[string][]string{"Authorization": {"Bearer token"}}},
+			URL:    server.URL,
+		},
+	}
+	Ok(t, hook.Send(logging.NewNoopLogger(t), lockExpiredResult))
+	Equals(t, lockExpiredResult, received)
+}
+
+func TestLockExpiredHttpWebhook_SendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	hook := webhooks.LockExpiredHttpWebhook{
+		HttpEndpoint: webhooks.HttpEndpoint{
+			Client: &webhooks.HttpClient{Client: http.DefaultClient},
+			URL:    server.URL,
+		},
+	}
+	err := hook.Send(logging.NewNoopLogger(t), lockExpiredResult)
+	ErrContains(t, "lock_expired webhook", err)
+	ErrContains(t, "returned status 500", err)
+}
+
+func TestLockExpiredSlackWebhook_Send(t *testing.T) {
+	RegisterMockTestingT(t)
+	client := mocks.NewMockSlackClient()
+	hook := webhooks.LockExpiredSlackWebhook{
+		Client:  client,
+		Channel: "locks",
+	}
+	Ok(t, hook.Send(logging.NewNoopLogger(t), lockExpiredResult))
+	client.VerifyWasCalledOnce().PostLockExpiredMessage("locks", lockExpiredResult)
+}
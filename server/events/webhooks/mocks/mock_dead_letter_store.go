@@ -0,0 +1,143 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events/webhooks (interfaces: DeadLetterStore)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	webhooks "github.com/runatlantis/atlantis/server/events/webhooks"
+	"reflect"
+	"time"
+)
+
+type MockDeadLetterStore struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockDeadLetterStore(options ...pegomock.Option) *MockDeadLetterStore {
+	mock := &MockDeadLetterStore{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockDeadLetterStore) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockDeadLetterStore) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockDeadLetterStore) Add(letter webhooks.DeadLetter) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockDeadLetterStore().")
+	}
+	_params := []pegomock.Param{letter}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("Add", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockDeadLetterStore) List() ([]webhooks.DeadLetter, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockDeadLetterStore().")
+	}
+	_params := []pegomock.Param{}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("List", _params, []reflect.Type{reflect.TypeOf((*[]webhooks.DeadLetter)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 []webhooks.DeadLetter
+	var _ret1 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].([]webhooks.DeadLetter)
+		}
+		if _result[1] != nil {
+			_ret1 = _result[1].(error)
+		}
+	}
+	return _ret0, _ret1
+}
+
+func (mock *MockDeadLetterStore) VerifyWasCalledOnce() *VerifierMockDeadLetterStore {
+	return &VerifierMockDeadLetterStore{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockDeadLetterStore) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockDeadLetterStore {
+	return &VerifierMockDeadLetterStore{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockDeadLetterStore) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockDeadLetterStore {
+	return &VerifierMockDeadLetterStore{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockDeadLetterStore) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockDeadLetterStore {
+	return &VerifierMockDeadLetterStore{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockDeadLetterStore struct {
+	mock                   *MockDeadLetterStore
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockDeadLetterStore) Add(letter webhooks.DeadLetter) *MockDeadLetterStore_Add_OngoingVerification {
+	_params := []pegomock.Param{letter}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Add", _params, verifier.timeout)
+	return &MockDeadLetterStore_Add_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockDeadLetterStore_Add_OngoingVerification struct {
+	mock              *MockDeadLetterStore
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockDeadLetterStore_Add_OngoingVerification) GetCapturedArguments() webhooks.DeadLetter {
+	letter := c.GetAllCapturedArguments()
+	return letter[len(letter)-1]
+}
+
+func (c *MockDeadLetterStore_Add_OngoingVerification) GetAllCapturedArguments() (_param0 []webhooks.DeadLetter) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]webhooks.DeadLetter, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(webhooks.DeadLetter)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockDeadLetterStore) List() *MockDeadLetterStore_List_OngoingVerification {
+	_params := []pegomock.Param{}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "List", _params, verifier.timeout)
+	return &MockDeadLetterStore_List_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockDeadLetterStore_List_OngoingVerification struct {
+	mock              *MockDeadLetterStore
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockDeadLetterStore_List_OngoingVerification) GetCapturedArguments() {
+}
+
+func (c *MockDeadLetterStore_List_OngoingVerification) GetAllCapturedArguments() {
+}
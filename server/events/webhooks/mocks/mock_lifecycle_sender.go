@@ -0,0 +1,114 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events/webhooks (interfaces: LifecycleSender)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	webhooks "github.com/runatlantis/atlantis/server/events/webhooks"
+	logging "github.com/runatlantis/atlantis/server/logging"
+	"reflect"
+	"time"
+)
+
+type MockLifecycleSender struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockLifecycleSender(options ...pegomock.Option) *MockLifecycleSender {
+	mock := &MockLifecycleSender{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockLifecycleSender) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockLifecycleSender) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockLifecycleSender) Send(log logging.SimpleLogging, event webhooks.LifecycleEvent) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockLifecycleSender().")
+	}
+	_params := []pegomock.Param{log, event}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("Send", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockLifecycleSender) VerifyWasCalledOnce() *VerifierMockLifecycleSender {
+	return &VerifierMockLifecycleSender{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockLifecycleSender) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockLifecycleSender {
+	return &VerifierMockLifecycleSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockLifecycleSender) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockLifecycleSender {
+	return &VerifierMockLifecycleSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockLifecycleSender) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockLifecycleSender {
+	return &VerifierMockLifecycleSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockLifecycleSender struct {
+	mock                   *MockLifecycleSender
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockLifecycleSender) Send(log logging.SimpleLogging, event webhooks.LifecycleEvent) *MockLifecycleSender_Send_OngoingVerification {
+	_params := []pegomock.Param{log, event}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Send", _params, verifier.timeout)
+	return &MockLifecycleSender_Send_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockLifecycleSender_Send_OngoingVerification struct {
+	mock              *MockLifecycleSender
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockLifecycleSender_Send_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, webhooks.LifecycleEvent) {
+	log, event := c.GetAllCapturedArguments()
+	return log[len(log)-1], event[len(event)-1]
+}
+
+func (c *MockLifecycleSender_Send_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []webhooks.LifecycleEvent) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]webhooks.LifecycleEvent, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(webhooks.LifecycleEvent)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+// Code generated by pegomock. DO NOT EDIT.
+// Source: github.com/runatlantis/atlantis/server/events/webhooks (interfaces: LockExpiredSender)
+
+package mocks
+
+import (
+	pegomock "github.com/petergtz/pegomock/v4"
+	webhooks "github.com/runatlantis/atlantis/server/events/webhooks"
+	logging "github.com/runatlantis/atlantis/server/logging"
+	"reflect"
+	"time"
+)
+
+type MockLockExpiredSender struct {
+	fail func(message string, callerSkip ...int)
+}
+
+func NewMockLockExpiredSender(options ...pegomock.Option) *MockLockExpiredSender {
+	mock := &MockLockExpiredSender{}
+	for _, option := range options {
+		option.Apply(mock)
+	}
+	return mock
+}
+
+func (mock *MockLockExpiredSender) SetFailHandler(fh pegomock.FailHandler) { mock.fail = fh }
+func (mock *MockLockExpiredSender) FailHandler() pegomock.FailHandler      { return mock.fail }
+
+func (mock *MockLockExpiredSender) Send(log logging.SimpleLogging, result webhooks.LockExpiredResult) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockLockExpiredSender().")
+	}
+	_params := []pegomock.Param{log, result}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("Send", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockLockExpiredSender) VerifyWasCalledOnce() *VerifierMockLockExpiredSender {
+	return &VerifierMockLockExpiredSender{
+		mock:                   mock,
+		invocationCountMatcher: pegomock.Times(1),
+	}
+}
+
+func (mock *MockLockExpiredSender) VerifyWasCalled(invocationCountMatcher pegomock.InvocationCountMatcher) *VerifierMockLockExpiredSender {
+	return &VerifierMockLockExpiredSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+	}
+}
+
+func (mock *MockLockExpiredSender) VerifyWasCalledInOrder(invocationCountMatcher pegomock.InvocationCountMatcher, inOrderContext *pegomock.InOrderContext) *VerifierMockLockExpiredSender {
+	return &VerifierMockLockExpiredSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		inOrderContext:         inOrderContext,
+	}
+}
+
+func (mock *MockLockExpiredSender) VerifyWasCalledEventually(invocationCountMatcher pegomock.InvocationCountMatcher, timeout time.Duration) *VerifierMockLockExpiredSender {
+	return &VerifierMockLockExpiredSender{
+		mock:                   mock,
+		invocationCountMatcher: invocationCountMatcher,
+		timeout:                timeout,
+	}
+}
+
+type VerifierMockLockExpiredSender struct {
+	mock                   *MockLockExpiredSender
+	invocationCountMatcher pegomock.InvocationCountMatcher
+	inOrderContext         *pegomock.InOrderContext
+	timeout                time.Duration
+}
+
+func (verifier *VerifierMockLockExpiredSender) Send(log logging.SimpleLogging, result webhooks.LockExpiredResult) *MockLockExpiredSender_Send_OngoingVerification {
+	_params := []pegomock.Param{log, result}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Send", _params, verifier.timeout)
+	return &MockLockExpiredSender_Send_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockLockExpiredSender_Send_OngoingVerification struct {
+	mock              *MockLockExpiredSender
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockLockExpiredSender_Send_OngoingVerification) GetCapturedArguments() (logging.SimpleLogging, webhooks.LockExpiredResult) {
+	log, result := c.GetAllCapturedArguments()
+	return log[len(log)-1], result[len(result)-1]
+}
+
+func (c *MockLockExpiredSender_Send_OngoingVerification) GetAllCapturedArguments() (_param0 []logging.SimpleLogging, _param1 []webhooks.LockExpiredResult) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]logging.SimpleLogging, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(logging.SimpleLogging)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]webhooks.LockExpiredResult, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(webhooks.LockExpiredResult)
+			}
+		}
+	}
+	return
+}
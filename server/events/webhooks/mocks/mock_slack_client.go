@@ -55,6 +55,36 @@ func (mock *MockSlackClient) PostDriftMessage(channel string, driftResult webhoo
 	return _ret0
 }
 
+func (mock *MockSlackClient) PostLifecycleMessage(channel string, event webhooks.LifecycleEvent) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
+	}
+	_params := []pegomock.Param{channel, event}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("PostLifecycleMessage", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
+func (mock *MockSlackClient) PostLockExpiredMessage(channel string, result webhooks.LockExpiredResult) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
+	}
+	_params := []pegomock.Param{channel, result}
+	_result := pegomock.GetGenericMockFrom(mock).Invoke("PostLockExpiredMessage", _params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var _ret0 error
+	if len(_result) != 0 {
+		if _result[0] != nil {
+			_ret0 = _result[0].(error)
+		}
+	}
+	return _ret0
+}
+
 func (mock *MockSlackClient) PostMessage(channel string, applyResult webhooks.ApplyResult) error {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockSlackClient().")
@@ -174,6 +204,76 @@ func (c *MockSlackClient_PostDriftMessage_OngoingVerification) GetAllCapturedArg
 	return
 }
 
+func (verifier *VerifierMockSlackClient) PostLifecycleMessage(channel string, event webhooks.LifecycleEvent) *MockSlackClient_PostLifecycleMessage_OngoingVerification {
+	_params := []pegomock.Param{channel, event}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostLifecycleMessage", _params, verifier.timeout)
+	return &MockSlackClient_PostLifecycleMessage_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockSlackClient_PostLifecycleMessage_OngoingVerification struct {
+	mock              *MockSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockSlackClient_PostLifecycleMessage_OngoingVerification) GetCapturedArguments() (string, webhooks.LifecycleEvent) {
+	channel, event := c.GetAllCapturedArguments()
+	return channel[len(channel)-1], event[len(event)-1]
+}
+
+func (c *MockSlackClient_PostLifecycleMessage_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []webhooks.LifecycleEvent) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]webhooks.LifecycleEvent, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(webhooks.LifecycleEvent)
+			}
+		}
+	}
+	return
+}
+
+func (verifier *VerifierMockSlackClient) PostLockExpiredMessage(channel string, result webhooks.LockExpiredResult) *MockSlackClient_PostLockExpiredMessage_OngoingVerification {
+	_params := []pegomock.Param{channel, result}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostLockExpiredMessage", _params, verifier.timeout)
+	return &MockSlackClient_PostLockExpiredMessage_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type MockSlackClient_PostLockExpiredMessage_OngoingVerification struct {
+	mock              *MockSlackClient
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *MockSlackClient_PostLockExpiredMessage_OngoingVerification) GetCapturedArguments() (string, webhooks.LockExpiredResult) {
+	channel, result := c.GetAllCapturedArguments()
+	return channel[len(channel)-1], result[len(result)-1]
+}
+
+func (c *MockSlackClient_PostLockExpiredMessage_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []webhooks.LockExpiredResult) {
+	_params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(_params) > 0 {
+		if len(_params) > 0 {
+			_param0 = make([]string, len(c.methodInvocations))
+			for u, param := range _params[0] {
+				_param0[u] = param.(string)
+			}
+		}
+		if len(_params) > 1 {
+			_param1 = make([]webhooks.LockExpiredResult, len(c.methodInvocations))
+			for u, param := range _params[1] {
+				_param1[u] = param.(webhooks.LockExpiredResult)
+			}
+		}
+	}
+	return
+}
+
 func (verifier *VerifierMockSlackClient) PostMessage(channel string, applyResult webhooks.ApplyResult) *MockSlackClient_PostMessage_OngoingVerification {
 	_params := []pegomock.Param{channel, applyResult}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "PostMessage", _params, verifier.timeout)
@@ -0,0 +1,163 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	msTeamsSuccessColour = "Good"
+	msTeamsFailureColour = "Attention"
+)
+
+// MSTeamsWebhook sends apply notifications to a Microsoft Teams incoming
+// webhook.
+type MSTeamsWebhook struct {
+	Client         *HttpClient
+	WorkspaceRegex *regexp.Regexp
+	BranchRegex    *regexp.Regexp
+	URL            string
+}
+
+// Send posts the apply result as an Adaptive Card if workspace and branch
+// match their respective regex.
+func (m *MSTeamsWebhook) Send(_ logging.SimpleLogging, applyResult ApplyResult) error {
+	if !m.WorkspaceRegex.MatchString(applyResult.Workspace) || !m.BranchRegex.MatchString(applyResult.Pull.BaseBranch) {
+		return nil
+	}
+	return m.Client.postMessage(ApplyEvent, m.URL, newMSTeamsApplyMessage(applyResult))
+}
+
+// DriftMSTeamsWebhook sends drift notifications to a Microsoft Teams
+// incoming webhook.
+type DriftMSTeamsWebhook struct {
+	Client *HttpClient
+	URL    string
+}
+
+// Send posts the drift result as an Adaptive Card.
+func (m *DriftMSTeamsWebhook) Send(_ logging.SimpleLogging, result DriftResult) error {
+	return m.Client.postMessage(DriftEvent, m.URL, newMSTeamsDriftMessage(result))
+}
+
+// msTeamsMessage is the body of a Teams incoming webhook request carrying a
+// single Adaptive Card.
+type msTeamsMessage struct {
+	Type        string              `json:"type"`
+	Attachments []msTeamsAttachment `json:"attachments"`
+}
+
+type msTeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []any                `json:"body"`
+	Actions []adaptiveCardAction `json:"actions,omitempty"`
+}
+
+type adaptiveTextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+type adaptiveFactSet struct {
+	Type  string         `json:"type"`
+	Facts []adaptiveFact `json:"facts"`
+}
+
+type adaptiveFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type adaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// newMSTeamsMessage builds a card with a coloured title, a list of facts and
+// optionally extra text and a link.
+func newMSTeamsMessage(title string, colour string, facts []adaptiveFact, text string, link adaptiveCardAction) msTeamsMessage {
+	card := adaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []any{
+			adaptiveTextBlock{Type: "TextBlock", Text: title, Weight: "Bolder", Size: "Medium", Color: colour, Wrap: true},
+			adaptiveFactSet{Type: "FactSet", Facts: facts},
+		},
+	}
+	if text != "" {
+		card.Body = append(card.Body, adaptiveTextBlock{Type: "TextBlock", Text: text, Wrap: true})
+	}
+	if link.URL != "" {
+		card.Actions = []adaptiveCardAction{link}
+	}
+	return msTeamsMessage{
+		Type: "message",
+		Attachments: []msTeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+func newMSTeamsApplyMessage(applyResult ApplyResult) msTeamsMessage {
+	colour := msTeamsSuccessColour
+	if !applyResult.Success {
+		colour = msTeamsFailureColour
+	}
+	var facts []adaptiveFact
+	if applyResult.ProjectName != "" {
+		facts = append(facts, adaptiveFact{Title: "Project", Value: applyResult.ProjectName})
+	}
+	facts = append(facts,
+		adaptiveFact{Title: "Workspace", Value: applyResult.Workspace},
+		adaptiveFact{Title: "Directory", Value: applyDirectoryText(applyResult)},
+		adaptiveFact{Title: "Branch", Value: applyResult.Pull.HeadBranch},
+		adaptiveFact{Title: "User", Value: applyResult.User.Username},
+		adaptiveFact{Title: "Pull Request", Value: fmt.Sprintf("[#%d](%s)", applyResult.Pull.Num, applyResult.Pull.URL)},
+	)
+	return newMSTeamsMessage(
+		applyTitle(applyResult),
+		colour,
+		facts,
+		truncateGraphemeClusters(applyResult.Pull.Body, maxDescriptionGraphemeClusters),
+		adaptiveCardAction{Type: "Action.OpenUrl", Title: "View pull request", URL: applyResult.Pull.URL},
+	)
+}
+
+func newMSTeamsDriftMessage(result DriftResult) msTeamsMessage {
+	colour := msTeamsSuccessColour
+	if result.ProjectsWithDrift > 0 {
+		colour = msTeamsFailureColour
+	}
+	facts := []adaptiveFact{
+		{Title: "Repository", Value: result.Repository},
+		{Title: "Ref", Value: result.Ref},
+		{Title: "Projects with drift", Value: fmt.Sprintf("%d / %d", result.ProjectsWithDrift, result.TotalProjects)},
+		{Title: "Detection ID", Value: result.DetectionID},
+	}
+	var text string
+	if resources := driftResourcesText(result); resources != "" {
+		// Adaptive Cards only break lines on blank lines.
+		text = "**Drifted resources**\n\n" + strings.ReplaceAll(resources, "\n", "\n\n")
+	}
+	return newMSTeamsMessage(driftTitle(result), colour, facts, text, adaptiveCardAction{})
+}
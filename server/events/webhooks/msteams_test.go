@@ -0,0 +1,128 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/events/webhooks"
+	"github.com/runatlantis/atlantis/server/logging"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+// msTeamsCard is the subset of an Adaptive Card message the tests inspect.
+type msTeamsCard struct {
+	Type        string `json:"type"`
+	Attachments []struct {
+		ContentType string `json:"contentType"`
+		Content     struct {
+			Type string `json:"type"`
+			Body []struct {
+				Type  string `json:"type"`
+				Text  string `json:"text"`
+				Color string `json:"color"`
+				Facts []struct {
+					Title string `json:"title"`
+					Value string `json:"value"`
+				} `json:"facts"`
+			} `json:"body"`
+			Actions []struct {
+				Type string `json:"type"`
+				URL  string `json:"url"`
+			} `json:"actions"`
+		} `json:"content"`
+	} `json:"attachments"`
+}
+
+func receiveMSTeamsCard(t *testing.T, card *msTeamsCard) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Equals(t, "application/json", r.Header.Get("Content-Type"))
+		Equals(t, "", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		Ok(t, err)
+		Ok(t, json.Unmarshal(body, card))
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestMSTeamsWebhook_Send(t *testing.T) {
+	var card msTeamsCard
+	server := receiveMSTeamsCard(t, &card)
+	defer server.Close()
+
+	webhook := webhooks.MSTeamsWebhook{
+		// Custom headers are meant for generic http webhooks and must not be
+		// forwarded to Teams.
+		Client:         &webhooks.HttpClient{Client: http.DefaultClient, Headers: map[string][]string{"Authorization": {"Bearer token"}}},
+		URL:            server.URL,
+		WorkspaceRegex: regexp.MustCompile(".*"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	result := httpApplyResult
+	result.Success = false
+	result.Directory = "."
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), result))
+
+	Equals(t, "message", card.Type)
+	Equals(t, 1, len(card.Attachments))
+	Equals(t, "application/vnd.microsoft.card.adaptive", card.Attachments[0].ContentType)
+	content := card.Attachments[0].Content
+	Equals(t, "AdaptiveCard", content.Type)
+	Equals(t, "Apply failed for runatlantis/atlantis", content.Body[0].Text)
+	Equals(t, "Attention", content.Body[0].Color)
+	facts := map[string]string{}
+	for _, f := range content.Body[1].Facts {
+		facts[f.Title] = f.Value
+	}
+	Equals(t, "production", facts["Workspace"])
+	Equals(t, "/", facts["Directory"])
+	Equals(t, "lkysow", facts["User"])
+	Equals(t, "[#1](url)", facts["Pull Request"])
+	Equals(t, "Action.OpenUrl", content.Actions[0].Type)
+	Equals(t, "url", content.Actions[0].URL)
+}
+
+func TestMSTeamsWebhook_NoRegexMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Assert(t, false, "webhook should not be sent")
+	}))
+	defer server.Close()
+
+	webhook := webhooks.MSTeamsWebhook{
+		Client:         &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:            server.URL,
+		WorkspaceRegex: regexp.MustCompile("other"),
+		BranchRegex:    regexp.MustCompile(".*"),
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), httpApplyResult))
+}
+
+func TestDriftMSTeamsWebhook_Send(t *testing.T) {
+	var card msTeamsCard
+	server := receiveMSTeamsCard(t, &card)
+	defer server.Close()
+
+	webhook := webhooks.DriftMSTeamsWebhook{
+		Client: &webhooks.HttpClient{Client: http.DefaultClient},
+		URL:    server.URL,
+	}
+	Ok(t, webhook.Send(logging.NewNoopLogger(t), driftResult))
+
+	content := card.Attachments[0].Content
+	Equals(t, "Drift detected in owner/repo", content.Body[0].Text)
+	Equals(t, "Attention", content.Body[0].Color)
+	facts := map[string]string{}
+	for _, f := range content.Body[1].Facts {
+		facts[f.Title] = f.Value
+	}
+	Equals(t, "main", facts["Ref"])
+	Equals(t, "1 / 2", facts["Projects with drift"])
+	Equals(t, "det-123", facts["Detection ID"])
+	Equals(t, 0, len(content.Actions))
+}
@@ -6,6 +6,7 @@ package webhooks
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/rivo/uniseg"
 	"github.com/slack-go/slack"
@@ -14,6 +15,9 @@ import (
 const (
 	slackSuccessColour = "good"
 	slackFailureColour = "danger"
+	// maxDriftResources caps how many drifted resources are listed in a drift
+	// message.
+	maxDriftResources = 10
 	// maxDescriptionGraphemeClusters is a readability cap for the pull request
 	// description, counted in user-visible grapheme clusters. It includes the
 	// trailing ellipsis when the description is truncated.
@@ -28,6 +32,8 @@ type SlackClient interface {
 	TokenIsSet() bool
 	PostMessage(channel string, applyResult ApplyResult) error
 	PostDriftMessage(channel string, driftResult DriftResult) error
+	PostLockExpiredMessage(channel string, result LockExpiredResult) error
+	PostLifecycleMessage(channel string, event LifecycleEvent) error
 }
 
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_underlying_slack_client.go UnderlyingSlackClient
@@ -83,6 +89,148 @@ func (d *DefaultSlackClient) PostDriftMessage(channel string, driftResult DriftR
 	return err
 }
 
+func (d *DefaultSlackClient) PostLockExpiredMessage(channel string, result LockExpiredResult) error {
+	attachment := slack.Attachment{
+		Color: slackFailureColour,
+		Text:  fmt.Sprintf("Lock expired in %s after %s", result.Repository, result.TTL),
+		Fields: []slack.AttachmentField{
+			{
+				Title: "Repository",
+				Value: result.Repository,
+				Short: true,
+			},
+			{
+				Title: "Pull Request",
+				Value: fmt.Sprintf("<%s|#%d>", result.PullURL, result.PullNum),
+				Short: true,
+			},
+			{
+				Title: "Project",
+				Value: lockExpiredProjectText(result),
+				Short: true,
+			},
+			{
+				Title: "Workspace",
+				Value: result.Workspace,
+				Short: true,
+			},
+			{
+				Title: "Locked By",
+				Value: result.LockedBy,
+				Short: true,
+			},
+		},
+	}
+	_, _, err := d.Slack.PostMessage(
+		channel,
+		slack.MsgOptionAsUser(true),
+		slack.MsgOptionText("", false),
+		slack.MsgOptionAttachments(attachment),
+	)
+	return err
+}
+
+func lockExpiredProjectText(result LockExpiredResult) string {
+	if result.ProjectName != "" {
+		return result.ProjectName
+	}
+	return result.Path
+}
+
+func (d *DefaultSlackClient) PostLifecycleMessage(channel string, event LifecycleEvent) error {
+	_, _, err := d.Slack.PostMessage(
+		channel,
+		slack.MsgOptionAsUser(true),
+		slack.MsgOptionText("", false),
+		slack.MsgOptionAttachments(d.createLifecycleAttachment(event)),
+	)
+	return err
+}
+
+func (d *DefaultSlackClient) createLifecycleAttachment(event LifecycleEvent) slack.Attachment {
+	var colour string
+	var text string
+	switch event.Event {
+	case PlanStartedEvent:
+		text = "Plan started"
+	case PlanFinishedEvent:
+		if event.Success != nil && *event.Success {
+			colour = slackSuccessColour
+			text = "Plan succeeded"
+		} else {
+			colour = slackFailureColour
+			text = "Plan failed"
+		}
+	case PolicyCheckPassedEvent:
+		colour = slackSuccessColour
+		text = "Policy check passed"
+	case PolicyCheckFailedEvent:
+		colour = slackFailureColour
+		text = "Policy check failed"
+	case LockAcquiredEvent:
+		text = "Lock acquired"
+	case LockReleasedEvent:
+		text = "Lock released"
+	case AutoplanSkippedEvent:
+		text = "Autoplan skipped"
+	default:
+		text = event.Event
+	}
+
+	fields := []slack.AttachmentField{
+		{
+			Title: "Repository",
+			Value: event.Repository,
+			Short: true,
+		},
+		{
+			Title: "Pull Request",
+			Value: fmt.Sprintf("<%s|#%d>", event.PullURL, event.PullNum),
+			Short: true,
+		},
+	}
+	if event.Workspace != "" {
+		project := event.ProjectName
+		if project == "" {
+			project = event.Path
+		}
+		fields = append(fields, slack.AttachmentField{
+			Title: "Project",
+			Value: project,
+			Short: true,
+		}, slack.AttachmentField{
+			Title: "Workspace",
+			Value: event.Workspace,
+			Short: true,
+		})
+	}
+	if event.User != "" {
+		fields = append(fields, slack.AttachmentField{
+			Title: "User",
+			Value: event.User,
+			Short: true,
+		})
+	}
+	if event.PlanStats != nil {
+		fields = append(fields, slack.AttachmentField{
+			Title: "Changes",
+			Value: fmt.Sprintf("%d to add, %d to change, %d to destroy", event.PlanStats.Add, event.PlanStats.Change, event.PlanStats.Destroy),
+			Short: true,
+		})
+	}
+	if event.Reason != "" {
+		fields = append(fields, slack.AttachmentField{
+			Title: "Reason",
+			Value: event.Reason,
+		})
+	}
+	return slack.Attachment{
+		Color:  colour,
+		Text:   fmt.Sprintf("%s in %s", text, event.Repository),
+		Fields: fields,
+	}
+}
+
 func (d *DefaultSlackClient) createDriftAttachment(result DriftResult) slack.Attachment {
 	var colour string
 	var text string
@@ -94,7 +242,7 @@ func (d *DefaultSlackClient) createDriftAttachment(result DriftResult) slack.Att
 		text = fmt.Sprintf("No drift in %s", result.Repository)
 	}
 
-	return slack.Attachment{
+	attachment := slack.Attachment{
 		Color: colour,
 		Text:  text,
 		Fields: []slack.AttachmentField{
@@ -120,6 +268,61 @@ func (d *DefaultSlackClient) createDriftAttachment(result DriftResult) slack.Att
 			},
 		},
 	}
+	if resources := driftResourcesText(result); resources != "" {
+		attachment.Fields = append(attachment.Fields, slack.AttachmentField{
+			Title: "Drifted resources",
+			Value: resources,
+		})
+	}
+	return attachment
+}
+
+// applyTitle summarises an apply result in plain text for the kinds that
+// render their own link to the pull request.
+func applyTitle(applyResult ApplyResult) string {
+	if applyResult.Success {
+		return fmt.Sprintf("Apply succeeded for %s", applyResult.Repo.FullName)
+	}
+	return fmt.Sprintf("Apply failed for %s", applyResult.Repo.FullName)
+}
+
+// applyDirectoryText returns the project directory, replacing "." with "/"
+// since "." looks weird and "/" makes it clear this is the root.
+func applyDirectoryText(applyResult ApplyResult) string {
+	if applyResult.Directory == "." {
+		return "/"
+	}
+	return applyResult.Directory
+}
+
+// driftTitle summarises a drift result in plain text.
+func driftTitle(result DriftResult) string {
+	if result.ProjectsWithDrift > 0 {
+		return fmt.Sprintf("Drift detected in %s", result.Repository)
+	}
+	return fmt.Sprintf("No drift in %s", result.Repository)
+}
+
+// driftResourcesText lists up to maxDriftResources drifted resources, one per
+// line.
+func driftResourcesText(result DriftResult) string {
+	var lines []string
+	total := 0
+	for _, p := range result.Projects {
+		for _, r := range p.Resources {
+			if r.Ignored {
+				continue
+			}
+			total++
+			if len(lines) < maxDriftResources {
+				lines = append(lines, fmt.Sprintf("`%s` (%s)", r.Address, r.Action))
+			}
+		}
+	}
+	if total > len(lines) {
+		lines = append(lines, fmt.Sprintf("and %d more", total-len(lines)))
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (d *DefaultSlackClient) createAttachments(applyResult ApplyResult) []slack.Attachment {
@@ -134,11 +337,7 @@ func (d *DefaultSlackClient) createAttachments(applyResult ApplyResult) []slack.
 	}
 
 	text := fmt.Sprintf("Apply %s for <%s|%s>", successWord, applyResult.Pull.URL, applyResult.Repo.FullName)
-	directory := applyResult.Directory
-	// Since "." looks weird, replace it with "/" to make it clear this is the root.
-	if directory == "." {
-		directory = "/"
-	}
+	directory := applyDirectoryText(applyResult)
 
 	attachment := slack.Attachment{
 		Color: colour,
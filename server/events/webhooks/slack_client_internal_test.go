@@ -103,3 +103,72 @@ func TestCreateAttachments_TruncatesDescriptionAtGraphemeBoundary(t *testing.T)
 	Assert(t, strings.HasSuffix(field.Value, "🧑‍💻…"), "expected truncation to preserve the final emoji grapheme cluster")
 	Assert(t, !strings.Contains(field.Value, "b"), "expected truncation before the next grapheme cluster")
 }
+
+func TestCreateDriftAttachment_ListsDriftedResources(t *testing.T) {
+	c := DefaultSlackClient{}
+	result := DriftResult{
+		Repository:        "owner/repo",
+		ProjectsWithDrift: 1,
+		TotalProjects:     1,
+		Projects: []DriftProjectResult{{
+			HasDrift: true,
+			Resources: []DriftResource{
+				{Address: "aws_security_group.web", Action: "update", ChangedAttributes: []string{"ingress"}},
+				{Address: "aws_instance.web", Action: "replace"},
+			},
+		}},
+	}
+	field, ok := attachmentField([]slack.Attachment{c.createDriftAttachment(result)}, "Drifted resources")
+	Assert(t, ok, "expected a Drifted resources field")
+	Equals(t, "`aws_security_group.web` (update)\n`aws_instance.web` (replace)", field.Value)
+}
+
+func TestCreateDriftAttachment_TruncatesDriftedResources(t *testing.T) {
+	c := DefaultSlackClient{}
+	var resources []DriftResource
+	for range maxDriftResources + 2 {
+		resources = append(resources, DriftResource{Address: "null_resource.r", Action: "create"})
+	}
+	result := DriftResult{Projects: []DriftProjectResult{{HasDrift: true, Resources: resources}}}
+	field, ok := attachmentField([]slack.Attachment{c.createDriftAttachment(result)}, "Drifted resources")
+	Assert(t, ok, "expected a Drifted resources field")
+	Assert(t, strings.HasSuffix(field.Value, "\nand 2 more"), "got %q", field.Value)
+}
+
+func TestCreateDriftAttachment_NoResources(t *testing.T) {
+	c := DefaultSlackClient{}
+	_, ok := attachmentField([]slack.Attachment{c.createDriftAttachment(DriftResult{Repository: "owner/repo"})}, "Drifted resources")
+	Assert(t, !ok, "expected no Drifted resources field")
+}
+
+func TestCreateLifecycleAttachment_PlanFinished(t *testing.T) {
+	c := DefaultSlackClient{}
+	success := false
+	attachment := c.createLifecycleAttachment(LifecycleEvent{
+		Event:       PlanFinishedEvent,
+		Repository:  "owner/repo",
+		ProjectName: "vpc",
+		Workspace:   "default",
+		Success:     &success,
+	})
+	Equals(t, slackFailureColour, attachment.Color)
+	Equals(t, "Plan failed in owner/repo", attachment.Text)
+	field, ok := attachmentField([]slack.Attachment{attachment}, "Project")
+	Assert(t, ok, "expected a Project field")
+	Equals(t, "vpc", field.Value)
+}
+
+func TestCreateLifecycleAttachment_AutoplanSkipped(t *testing.T) {
+	c := DefaultSlackClient{}
+	attachment := c.createLifecycleAttachment(LifecycleEvent{
+		Event:      AutoplanSkippedEvent,
+		Repository: "owner/repo",
+		Reason:     "autoplan is disabled",
+	})
+	Equals(t, "Autoplan skipped in owner/repo", attachment.Text)
+	_, ok := attachmentField([]slack.Attachment{attachment}, "Project")
+	Assert(t, !ok, "expected no Project field")
+	field, ok := attachmentField([]slack.Attachment{attachment}, "Reason")
+	Assert(t, ok, "expected a Reason field")
+	Equals(t, "autoplan is disabled", field.Value)
+}
@@ -7,6 +7,8 @@ package webhooks
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"sync"
 
 	"errors"
 
@@ -16,8 +18,14 @@ import (
 
 const SlackKind = "slack"
 const HttpKind = "http"
+const MSTeamsKind = "msteams"
+const DiscordKind = "discord"
 const ApplyEvent = "apply"
 
+// lifecycleQueueSize is how many lifecycle events can wait to be sent before
+// new ones are dropped.
+const lifecycleQueueSize = 1000
+
 // isSuccessStatus returns true if the given HTTP status code is a 2xx.
 func isSuccessStatus(code int) bool {
 	return code/100 == 2
@@ -45,15 +53,33 @@ type ApplyResult struct {
 // MultiWebhookSender sends multiple webhooks for each one it's configured for.
 type MultiWebhookSender struct {
 	Webhooks []Sender
+	// LifecycleWebhooks are sent plan, policy check, lock and autoplan events.
+	LifecycleWebhooks []LifecycleSender
+
+	lifecycleOnce  sync.Once
+	lifecycleQueue chan queuedLifecycleEvent
+}
+
+// queuedLifecycleEvent is a lifecycle event waiting to be sent.
+type queuedLifecycleEvent struct {
+	log   logging.SimpleLogging
+	event LifecycleEvent
 }
 
 type Config struct {
 	Event          string
 	WorkspaceRegex string
 	BranchRegex    string
-	Kind           string
-	Channel        string
-	URL            string
+	// ProjectRegex filters lifecycle events by project name.
+	ProjectRegex string
+	Kind         string
+	Channel      string
+	URL          string
+	// Secret signs http webhooks with HMAC-SHA256.
+	Secret string
+	// PayloadTemplate is a Go template that renders the body of http
+	// webhooks instead of the default JSON payload.
+	PayloadTemplate string
 }
 
 type Clients struct {
@@ -63,6 +89,7 @@ type Clients struct {
 
 func NewMultiWebhookSender(configs []Config, clients Clients) (*MultiWebhookSender, error) {
 	var webhooks []Sender
+	var lifecycleWebhooks []LifecycleSender
 	for _, c := range configs {
 		if c.Kind == "" || c.Event == "" {
 			return nil, errors.New("must specify \"kind\" and \"event\" keys for webhooks")
@@ -70,8 +97,20 @@ func NewMultiWebhookSender(configs []Config, clients Clients) (*MultiWebhookSend
 		if c.Event == DriftEvent {
 			continue // drift events are handled by DriftWebhookSender
 		}
+		if c.Event == LockExpiredEvent {
+			continue // lock_expired events are handled by LockExpiredWebhookSender
+		}
+		if IsLifecycleEvent(c.Event) {
+			lifecycleWebhook, err := newLifecycleWebhook(c, clients)
+			if err != nil {
+				return nil, err
+			}
+			lifecycleWebhooks = append(lifecycleWebhooks, lifecycleWebhook)
+			continue
+		}
 		if c.Event != ApplyEvent {
-			return nil, fmt.Errorf("\"event: %s\" not supported. Only \"event: %s\" and \"event: %s\" are supported", c.Event, ApplyEvent, DriftEvent)
+			supported := append([]string{ApplyEvent, DriftEvent, LockExpiredEvent}, LifecycleEvents...)
+			return nil, fmt.Errorf("\"event: %s\" not supported. Supported events are: %s", c.Event, strings.Join(supported, ", "))
 		}
 		wr, err := regexp.Compile(c.WorkspaceRegex)
 		if err != nil {
@@ -98,20 +137,44 @@ func NewMultiWebhookSender(configs []Config, clients Clients) (*MultiWebhookSend
 			if c.URL == "" {
 				return nil, errors.New("must specify \"url\" if using a webhook of \"kind: http\"")
 			}
+			endpoint, err := newHttpEndpoint(c, clients.Http)
+			if err != nil {
+				return nil, err
+			}
 			httpWebhook := &HttpWebhook{
+				HttpEndpoint:   endpoint,
+				WorkspaceRegex: wr,
+				BranchRegex:    br,
+			}
+			webhooks = append(webhooks, httpWebhook)
+		case MSTeamsKind:
+			if c.URL == "" {
+				return nil, errors.New("must specify \"url\" if using a webhook of \"kind: msteams\"")
+			}
+			webhooks = append(webhooks, &MSTeamsWebhook{
 				Client:         clients.Http,
 				WorkspaceRegex: wr,
 				BranchRegex:    br,
 				URL:            c.URL,
+			})
+		case DiscordKind:
+			if c.URL == "" {
+				return nil, errors.New("must specify \"url\" if using a webhook of \"kind: discord\"")
 			}
-			webhooks = append(webhooks, httpWebhook)
+			webhooks = append(webhooks, &DiscordWebhook{
+				Client:         clients.Http,
+				WorkspaceRegex: wr,
+				BranchRegex:    br,
+				URL:            c.URL,
+			})
 		default:
-			return nil, fmt.Errorf("\"kind: %s\" not supported. Only \"kind: %s\" and \"kind: %s\" are supported right now", c.Kind, SlackKind, HttpKind)
+			return nil, fmt.Errorf("\"kind: %s\" not supported. Supported kinds are: %s", c.Kind, strings.Join([]string{SlackKind, HttpKind, MSTeamsKind, DiscordKind}, ", "))
 		}
 	}
 
 	return &MultiWebhookSender{
-		Webhooks: webhooks,
+		Webhooks:          webhooks,
+		LifecycleWebhooks: lifecycleWebhooks,
 	}, nil
 }
 
@@ -124,3 +187,35 @@ func (w *MultiWebhookSender) Send(log logging.SimpleLogging, result ApplyResult)
 	}
 	return nil
 }
+
+// SendLifecycle queues event for the lifecycle webhooks and returns without
+// waiting for them, so slow webhooks don't hold up the plan or lock that sent
+// it. A single background worker sends the queued events in order. If too
+// many events are waiting, event is dropped and an error is returned.
+func (w *MultiWebhookSender) SendLifecycle(log logging.SimpleLogging, event LifecycleEvent) error {
+	if len(w.LifecycleWebhooks) == 0 {
+		return nil
+	}
+	w.lifecycleOnce.Do(func() {
+		w.lifecycleQueue = make(chan queuedLifecycleEvent, lifecycleQueueSize)
+		go w.sendLifecycleEvents()
+	})
+	select {
+	case w.lifecycleQueue <- queuedLifecycleEvent{log: log, event: event}:
+		return nil
+	default:
+		return fmt.Errorf("dropping %s webhook: %d lifecycle webhooks are already waiting to be sent", event.Event, lifecycleQueueSize)
+	}
+}
+
+// sendLifecycleEvents sends the queued lifecycle events to every lifecycle
+// webhook.
+func (w *MultiWebhookSender) sendLifecycleEvents() {
+	for queued := range w.lifecycleQueue {
+		for _, l := range w.LifecycleWebhooks {
+			if err := l.Send(queued.log, queued.event); err != nil {
+				queued.log.Warn("error sending %s webhook: %s", queued.event.Event, err)
+			}
+		}
+	}
+}
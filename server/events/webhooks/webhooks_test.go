@@ -119,7 +119,7 @@ func TestNewWebhooksManager_UnsupportedEvent(t *testing.T) {
 	configs[0].Event = unsupportedEvent
 	_, err := webhooks.NewMultiWebhookSender(configs, clients)
 	Assert(t, err != nil, "expected error")
-	Equals(t, "\"event: badevent\" not supported. Only \"event: apply\" and \"event: drift\" are supported", err.Error())
+	Equals(t, "\"event: badevent\" not supported. Supported events are: apply, drift, lock_expired, plan_started, plan_finished, policy_check_passed, policy_check_failed, lock_acquired, lock_released, autoplan_skipped", err.Error())
 }
 
 func TestNewWebhooksManager_NoKind(t *testing.T) {
@@ -143,7 +143,21 @@ func TestNewWebhooksManager_UnsupportedKind(t *testing.T) {
 	configs[0].Kind = unsupportedKind
 	_, err := webhooks.NewMultiWebhookSender(configs, clients)
 	Assert(t, err != nil, "expected error")
-	Equals(t, "\"kind: badkind\" not supported. Only \"kind: slack\" and \"kind: http\" are supported right now", err.Error())
+	Equals(t, "\"kind: badkind\" not supported. Supported kinds are: slack, http, msteams, discord", err.Error())
+}
+
+func TestNewWebhooksManager_InvalidPayloadTemplate(t *testing.T) {
+	t.Log("When given an invalid payload template for an http webhook, an error is returned")
+	RegisterMockTestingT(t)
+	clients := validClients()
+	configs := []webhooks.Config{{
+		Event:           webhooks.ApplyEvent,
+		Kind:            webhooks.HttpKind,
+		URL:             "https://example.com",
+		PayloadTemplate: "{{ .Workspace",
+	}}
+	_, err := webhooks.NewMultiWebhookSender(configs, clients)
+	ErrContains(t, "apply webhook: parsing payload template", err)
 }
 
 func TestNewWebhooksManager_NoConfigSuccess(t *testing.T) {
@@ -224,3 +238,24 @@ func TestSend_MultipleSuccess(t *testing.T) {
 		s.VerifyWasCalledOnce().Send(logger, result)
 	}
 }
+
+func TestNewWebhooksManager_ChatKinds(t *testing.T) {
+	t.Log("msteams and discord webhooks need a url")
+	clients := webhooks.Clients{
+		Http: &webhooks.HttpClient{Client: http.DefaultClient},
+	}
+	for _, kind := range []string{webhooks.MSTeamsKind, webhooks.DiscordKind} {
+		t.Run(kind, func(t *testing.T) {
+			m, err := webhooks.NewMultiWebhookSender([]webhooks.Config{
+				{Event: webhooks.ApplyEvent, Kind: kind, URL: "https://example.com/webhook"},
+			}, clients)
+			Ok(t, err)
+			Equals(t, 1, len(m.Webhooks)) // nolint: staticcheck
+
+			_, err = webhooks.NewMultiWebhookSender([]webhooks.Config{
+				{Event: webhooks.ApplyEvent, Kind: kind},
+			}, clients)
+			ErrEquals(t, "must specify \"url\" if using a webhook of \"kind: "+kind+"\"", err)
+		})
+	}
+}
@@ -25,6 +25,9 @@ type Router struct {
 	// LockViewRouteIDQueryParam is the query parameter needed to construct the
 	// lock view: underlying.Get(LockViewRouteName).URL(LockViewRouteIDQueryParam, "my id").
 	LockViewRouteIDQueryParam string
+	// DriftRemediationViewRouteName is the named route for the drift
+	// remediation view. It is only registered when drift detection is enabled.
+	DriftRemediationViewRouteName string
 	// AtlantisURL is the fully qualified URL that Atlantis is
 	// accessible from externally.
 	AtlantisURL *url.URL
@@ -65,3 +68,18 @@ func (r *Router) GenerateProjectWorkflowHookURL(hookID string) (string, error) {
 
 	return r.AtlantisURL.String() + jobURL.String(), nil
 }
+
+// GenerateRemediationURL returns a fully qualified URL to view the drift
+// remediation with the given id.
+func (r *Router) GenerateRemediationURL(id string) (string, error) {
+	route := r.Underlying.Get(r.DriftRemediationViewRouteName)
+	if route == nil {
+		return "", fmt.Errorf("drift remediation view is not enabled")
+	}
+	remediationURL, err := route.URL("id", id)
+	if err != nil {
+		return "", fmt.Errorf("creating drift remediation url for %s: %w", id, err)
+	}
+
+	return r.AtlantisURL.String() + remediationURL.String(), nil
+}
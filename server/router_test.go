@@ -113,3 +113,22 @@ func TestGenerateProjectJobURL_ShouldReturnErrorWhenJobIDNotSpecified(t *testing
 	require.EqualError(t, err, expectedErrString)
 	Equals(t, "", gotURL)
 }
+
+func TestRouter_GenerateRemediationURL(t *testing.T) {
+	atlantisURL, err := server.ParseAtlantisURL("http://localhost:4141/basepath")
+	Ok(t, err)
+
+	underlyingRouter := mux.NewRouter()
+	router := &server.Router{
+		AtlantisURL:                   atlantisURL,
+		Underlying:                    underlyingRouter,
+		DriftRemediationViewRouteName: "drift-remediation-detail",
+	}
+	_, err = router.GenerateRemediationURL("abc")
+	ErrEquals(t, "drift remediation view is not enabled", err)
+
+	underlyingRouter.HandleFunc("/drift/remediations/{id}", func(_ http.ResponseWriter, _ *http.Request) {}).Methods("GET").Name("drift-remediation-detail")
+	gotURL, err := router.GenerateRemediationURL("abc")
+	Ok(t, err)
+	Equals(t, "http://localhost:4141/basepath/drift/remediations/abc", gotURL)
+}
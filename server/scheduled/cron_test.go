@@ -0,0 +1,66 @@
+// Copyright 2025 The Atlantis Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduled_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/scheduled"
+	. "github.com/runatlantis/atlantis/testing"
+)
+
+func TestParseCron_Errors(t *testing.T) {
+	cases := map[string]string{
+		"* * * *":        "must have 5 fields",
+		"60 * * * *":     "minute: value 60 out of range",
+		"* 24 * * *":     "hour: value 24 out of range",
+		"* * 0 * *":      "day of month: value 0 out of range",
+		"* * * 13 *":     "month: value 13 out of range",
+		"* * * * 8":      "day of week: value 8 out of range",
+		"*/0 * * * *":    "invalid step",
+		"5-1 * * * *":    "invalid range",
+		"a * * * *":      "invalid value",
+		"0 0 30 2 *":     "never matches",
+		"@fortnightly":   "must have 5 fields",
+		"0 0 31 4,6 *":   "never matches",
+		"1,x * * * *":    "invalid value",
+		"0-10/x * * * *": "invalid step",
+	}
+	for expr, expErr := range cases {
+		t.Run(expr, func(t *testing.T) {
+			_, err := scheduled.ParseCron(expr)
+			ErrContains(t, expErr, err)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Wednesday.
+	from := time.Date(2025, 1, 15, 10, 17, 30, 0, time.UTC)
+	cases := []struct {
+		expr string
+		exp  time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2025, 1, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// When both day fields are restricted either one matching is enough.
+		{"0 0 20 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			schedule, err := scheduled.ParseCron(c.expr)
+			Ok(t, err)
+			Equals(t, c.exp, schedule.Next(from))
+			Assert(t, schedule.Matches(c.exp), "expected %s to match %s", c.expr, c.exp)
+		})
+	}
+}
@@ -18,6 +18,9 @@ import (
 type ExecutorService struct {
 	log logging.SimpleLogging
 
+	// lease is set when named jobs must only run on one of several replicas.
+	lease Lease
+
 	// jobs
 	jobs []JobDefinition
 }
@@ -45,11 +48,25 @@ func (s *ExecutorService) AddJob(jd JobDefinition) {
 	s.jobs = append(s.jobs, jd)
 }
 
+// SetLease makes named jobs run only on the replica that holds their lease.
+func (s *ExecutorService) SetLease(lease Lease) {
+	s.lease = lease
+}
+
 type JobDefinition struct {
 	Job    Job
 	Period time.Duration
+	// Name identifies the job's lease. Named jobs only run on one replica
+	// when the executor service has a lease; unnamed jobs, like token
+	// rotation, always run on every replica.
+	Name string
 }
 
+// LeasePeriods is how many periods of a named job its lease lasts. The
+// holder renews the lease every time the job is due, so another replica
+// only takes over once the holder has missed that many runs.
+const LeasePeriods = 3
+
 func (s *ExecutorService) Run() {
 	s.log.Info("Scheduled Executor Service started")
 
@@ -96,14 +113,45 @@ func (s *ExecutorService) runScheduledJob(ctx context.Context, wg *sync.WaitGrou
 				s.log.Warn("Received interrupt, cancelling job")
 				return
 			case <-ticker.C:
-				jd.Job.Run()
+				s.runJob(jd)
 			}
 		}
 	})
 
 }
 
+func (s *ExecutorService) runJob(jd JobDefinition) {
+	if jd.Name != "" && s.lease != nil {
+		held, err := s.lease.Hold(jd.Name, LeasePeriods*jd.Period)
+		if err != nil {
+			s.log.Err("holding lease for job %q, skipping this run: %s", jd.Name, err)
+		}
+		if !held {
+			if skipper, ok := jd.Job.(Skipper); ok {
+				skipper.Skip()
+			}
+			return
+		}
+	}
+	jd.Job.Run()
+}
+
 //go:generate go tool pegomock generate --package mocks -o mocks/mock_executor_service_job.go Job
 type Job interface {
 	Run()
 }
+
+// Skipper is implemented by jobs that need to know when a run was skipped
+// because another replica holds their lease, for example to stay on schedule
+// if they take over later.
+type Skipper interface {
+	Skip()
+}
+
+// Lease elects the replica that runs a named job when several Atlantis
+// replicas share work.
+type Lease interface {
+	// Hold takes or renews the lease on name for ttl and reports whether
+	// this replica holds it.
+	Hold(name string, ttl time.Duration) (bool, error)
+}
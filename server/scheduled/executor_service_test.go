@@ -10,6 +10,7 @@ import (
 	pegomock "github.com/petergtz/pegomock/v4"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/scheduled/mocks"
+	. "github.com/runatlantis/atlantis/testing"
 )
 
 func TestExecutorService_Run(t *testing.T) {
@@ -49,3 +50,46 @@ func TestExecutorService_Run(t *testing.T) {
 		})
 	}
 }
+
+type fakeLease struct {
+	held  bool
+	names []string
+	ttls  []time.Duration
+}
+
+func (l *fakeLease) Hold(name string, ttl time.Duration) (bool, error) {
+	l.names = append(l.names, name)
+	l.ttls = append(l.ttls, ttl)
+	return l.held, nil
+}
+
+type skippingJob struct {
+	runs  int
+	skips int
+}
+
+func (j *skippingJob) Run()  { j.runs++ }
+func (j *skippingJob) Skip() { j.skips++ }
+
+func TestExecutorService_RunJobWithLease(t *testing.T) {
+	lease := &fakeLease{}
+	s := &ExecutorService{log: logging.NewNoopLogger(t)}
+	s.SetLease(lease)
+	job := &skippingJob{}
+	named := JobDefinition{Job: job, Period: time.Minute, Name: "job"}
+
+	s.runJob(named)
+	Equals(t, 0, job.runs)
+	Equals(t, 1, job.skips)
+
+	lease.held = true
+	s.runJob(named)
+	Equals(t, 1, job.runs)
+	Equals(t, 1, job.skips)
+
+	// Unnamed jobs run on every replica without taking a lease.
+	s.runJob(JobDefinition{Job: job, Period: time.Minute})
+	Equals(t, 2, job.runs)
+	Equals(t, []string{"job", "job"}, lease.names)
+	Equals(t, LeasePeriods*time.Minute, lease.ttls[0])
+}
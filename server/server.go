@@ -39,6 +39,8 @@ import (
 	"github.com/runatlantis/atlantis/server/core/config/valid"
 	"github.com/runatlantis/atlantis/server/core/db"
 	"github.com/runatlantis/atlantis/server/core/drift"
+	"github.com/runatlantis/atlantis/server/core/planstore"
+	"github.com/runatlantis/atlantis/server/core/postgres"
 	"github.com/runatlantis/atlantis/server/core/redis"
 	"github.com/runatlantis/atlantis/server/core/terraform/tfclient"
 	"github.com/runatlantis/atlantis/server/jobs"
@@ -52,6 +54,7 @@ import (
 	"github.com/runatlantis/atlantis/server/controllers/websocket"
 	"github.com/runatlantis/atlantis/server/core/locking"
 	"github.com/runatlantis/atlantis/server/core/runtime"
+	runtimemodels "github.com/runatlantis/atlantis/server/core/runtime/models"
 	"github.com/runatlantis/atlantis/server/core/runtime/policy"
 	"github.com/runatlantis/atlantis/server/core/terraform"
 	"github.com/runatlantis/atlantis/server/events"
@@ -81,6 +84,9 @@ const (
 	LockViewRouteIDQueryParam = "id"
 	// ProjectJobsViewRouteName is the named route in mux.Router for the log stream view.
 	ProjectJobsViewRouteName = "project-jobs-detail"
+	// DriftRemediationViewRouteName is the named route in mux.Router for the
+	// drift remediation view.
+	DriftRemediationViewRouteName = "drift-remediation-detail"
 	// binDirName is the name of the directory inside our data dir where
 	// we download binaries.
 	BinDirName = "bin"
@@ -110,6 +116,7 @@ type Server struct {
 	StatusController               *controllers.StatusController
 	JobsController                 *controllers.JobsController
 	APIController                  *controllers.APIController
+	DriftController                *controllers.DriftController
 	IndexTemplate                  web_templates.TemplateWriter
 	LockDetailTemplate             web_templates.TemplateWriter
 	ProjectJobsTemplate            web_templates.TemplateWriter
@@ -127,7 +134,13 @@ type Server struct {
 	ScheduledExecutorService       *scheduled.ExecutorService
 	DisableGlobalApplyLock         bool
 	EnableProfilingAPI             bool
-	database                       db.Database
+	// WorkQueue is set when commands are claimed from a queue shared with
+	// other replicas.
+	WorkQueue *redis.WorkQueue
+	// CancelRelay is set when cancel commands are relayed to the other
+	// replicas.
+	CancelRelay *redis.CancelRelay
+	database    db.Database
 }
 
 // Config holds config for server that isn't passed in by the user.
@@ -153,6 +166,9 @@ type WebhookConfig struct {
 	// that is being modified for this event. If the regex matches, we'll
 	// send the webhook, ex. "main.*".
 	BranchRegex string `mapstructure:"branch-regex"`
+	// ProjectRegex is a regex that is used to match against the project name
+	// for plan and policy check lifecycle events, ex. "networking-.*".
+	ProjectRegex string `mapstructure:"project-regex"`
 	// Kind is the type of webhook we should send, ex. slack or http.
 	Kind string `mapstructure:"kind"`
 	// Channel is the channel to send this webhook to. It only applies to
@@ -161,6 +177,12 @@ type WebhookConfig struct {
 	// URL is the URL where to deliver this webhook. It only applies to
 	// http webhooks.
 	URL string `mapstructure:"url"`
+	// Secret signs http webhooks with HMAC-SHA256 so receivers can verify
+	// them.
+	Secret string `mapstructure:"secret"`
+	// PayloadTemplate is a Go template that renders the body of http
+	// webhooks instead of the default JSON payload.
+	PayloadTemplate string `mapstructure:"payload-template"`
 }
 
 //go:embed static
@@ -389,12 +411,15 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	var webhooksConfig []webhooks.Config
 	for _, c := range userConfig.Webhooks {
 		config := webhooks.Config{
-			Channel:        c.Channel,
-			BranchRegex:    c.BranchRegex,
-			Event:          c.Event,
-			Kind:           c.Kind,
-			WorkspaceRegex: c.WorkspaceRegex,
-			URL:            c.URL,
+			Channel:         c.Channel,
+			BranchRegex:     c.BranchRegex,
+			Event:           c.Event,
+			Kind:            c.Kind,
+			WorkspaceRegex:  c.WorkspaceRegex,
+			ProjectRegex:    c.ProjectRegex,
+			URL:             c.URL,
+			Secret:          c.Secret,
+			PayloadTemplate: c.PayloadTemplate,
 		}
 		webhooksConfig = append(webhooksConfig, config)
 	}
@@ -404,7 +429,16 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 	}
 	webhookClients := webhooks.Clients{
 		Slack: webhooks.NewSlackClient(userConfig.SlackToken),
-		Http:  &webhooks.HttpClient{Client: http.DefaultClient, Headers: webhookHeaders},
+		Http: &webhooks.HttpClient{
+			Client:  http.DefaultClient,
+			Headers: webhookHeaders,
+			Retry: webhooks.RetryPolicy{
+				MaxRetries:     userConfig.WebhookHttpMaxRetries,
+				InitialBackoff: time.Second,
+				MaxBackoff:     30 * time.Second,
+			},
+			Logger: logger,
+		},
 	}
 	webhooksManager, err := webhooks.NewMultiWebhookSender(webhooksConfig, webhookClients)
 	if err != nil {
@@ -432,11 +466,89 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 
 	underlyingRouter := mux.NewRouter()
 	router := &Router{
-		AtlantisURL:               parsedURL,
-		LockViewRouteIDQueryParam: LockViewRouteIDQueryParam,
-		LockViewRouteName:         LockViewRouteName,
-		ProjectJobsViewRouteName:  ProjectJobsViewRouteName,
-		Underlying:                underlyingRouter,
+		AtlantisURL:                   parsedURL,
+		LockViewRouteIDQueryParam:     LockViewRouteIDQueryParam,
+		LockViewRouteName:             LockViewRouteName,
+		ProjectJobsViewRouteName:      ProjectJobsViewRouteName,
+		DriftRemediationViewRouteName: DriftRemediationViewRouteName,
+		Underlying:                    underlyingRouter,
+	}
+
+	var lockingClient locking.Locker
+	var applyLockingClient locking.ApplyLocker
+	var database db.Database
+
+	switch dbtype := userConfig.LockingDBType; dbtype {
+	case "redis":
+		var clusterAddrs []string
+		if userConfig.RedisClusterAddresses != "" {
+			for addr := range strings.SplitSeq(userConfig.RedisClusterAddresses, ",") {
+				trimmed := strings.TrimSpace(addr)
+				if trimmed == "" {
+					continue
+				}
+				clusterAddrs = append(clusterAddrs, trimmed)
+			}
+		}
+		switch {
+		case len(clusterAddrs) > 0:
+			logger.Info("Utilizing Redis DB in cluster mode, addresses: %s", strings.Join(clusterAddrs, ", "))
+		default:
+			logger.Info("Utilizing Redis DB in single-node mode, host: %s, port: %d", userConfig.RedisHost, userConfig.RedisPort)
+		}
+		database, err = redis.NewWithConfig(redis.Config{
+			Hostname:           userConfig.RedisHost,
+			Port:               userConfig.RedisPort,
+			Password:           userConfig.RedisPassword,
+			Username:           userConfig.RedisUsername,
+			TLSEnabled:         userConfig.RedisTLSEnabled,
+			InsecureSkipVerify: userConfig.RedisInsecureSkipVerify,
+			DB:                 userConfig.RedisDB,
+			ClusterAddresses:   clusterAddrs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case "postgres":
+		logger.Info("Utilizing PostgreSQL DB")
+		database, err = postgres.New(postgres.Config{
+			URL:      userConfig.PostgresURL,
+			MaxConns: userConfig.PostgresMaxConns,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case "boltdb":
+		logger.Info("Utilizing BoltDB")
+		database, err = boltdb.New(userConfig.DataDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	webhookDeadLetters, err := newWebhookDeadLetterStore(database)
+	if err != nil {
+		return nil, fmt.Errorf("initializing webhook dead letters: %w", err)
+	}
+	webhookClients.Http.DeadLetters = webhookDeadLetters
+
+	// distributedDB is set when commands, job output and cancellations are
+	// shared with other replicas through Redis.
+	var distributedDB *redis.RedisDB
+	// replicaName identifies this replica to the others with distributed
+	// execution.
+	var replicaName string
+	if userConfig.EnableDistributedExecution {
+		var ok bool
+		if distributedDB, ok = database.(*redis.RedisDB); !ok {
+			return nil, fmt.Errorf("distributed execution requires the redis locking database, got %q", userConfig.LockingDBType)
+		}
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("getting hostname to name this replica: %w", err)
+		}
+		replicaName = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+		logger.Info("Sharing commands, job output and cancellations with other replicas")
 	}
 
 	var projectCmdOutputHandler jobs.ProjectCommandOutputHandler
@@ -450,9 +562,13 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 			projectCmdOutput,
 			logger,
 		)
+		if distributedDB != nil {
+			projectCmdOutputHandler = redis.NewJobOutputRelay(distributedDB, projectCmdOutputHandler, logger)
+		}
 	}
 
 	distribution := terraform.NewDistribution(userConfig.DefaultTFDistribution)
+	processTracker := runtimemodels.NewProcessTracker()
 
 	terraformClient, err := tfclient.NewClient(
 		logger,
@@ -466,7 +582,8 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		userConfig.TFDownloadURL,
 		userConfig.TFDownload,
 		userConfig.UseTFPluginCache,
-		projectCmdOutputHandler)
+		projectCmdOutputHandler,
+		processTracker)
 	// The flag.Lookup call is to detect if we're running in a unit test. If we
 	// are, then we don't error out because we don't have/want terraform
 	// installed on our CI system where the unit tests run.
@@ -489,57 +606,31 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 			CatalogPath:  userConfig.LanguageConfigFile,
 		},
 	)
-
-	var lockingClient locking.Locker
-	var applyLockingClient locking.ApplyLocker
-	var database db.Database
-
-	switch dbtype := userConfig.LockingDBType; dbtype {
-	case "redis":
-		var clusterAddrs []string
-		if userConfig.RedisClusterAddresses != "" {
-			for addr := range strings.SplitSeq(userConfig.RedisClusterAddresses, ",") {
-				trimmed := strings.TrimSpace(addr)
-				if trimmed == "" {
-					continue
-				}
-				clusterAddrs = append(clusterAddrs, trimmed)
-			}
-		}
-		switch {
-		case len(clusterAddrs) > 0:
-			logger.Info("Utilizing Redis DB in cluster mode, addresses: %s", strings.Join(clusterAddrs, ", "))
-		default:
-			logger.Info("Utilizing Redis DB in single-node mode, host: %s, port: %d", userConfig.RedisHost, userConfig.RedisPort)
-		}
-		database, err = redis.NewWithConfig(redis.Config{
-			Hostname:           userConfig.RedisHost,
-			Port:               userConfig.RedisPort,
-			Password:           userConfig.RedisPassword,
-			Username:           userConfig.RedisUsername,
-			TLSEnabled:         userConfig.RedisTLSEnabled,
-			InsecureSkipVerify: userConfig.RedisInsecureSkipVerify,
-			DB:                 userConfig.RedisDB,
-			ClusterAddresses:   clusterAddrs,
-		})
-		if err != nil {
-			return nil, err
-		}
-	case "boltdb":
-		logger.Info("Utilizing BoltDB")
-		database, err = boltdb.New(userConfig.DataDir)
-		if err != nil {
-			return nil, err
-		}
+	if userConfig.GithubCheckRuns && githubClient != nil {
+		commitStatusUpdater.CheckRunUpdater = githubClient
+		commitStatusUpdater.Renderer = markdownRenderer
+		commitStatusUpdater.ApplyDisabled = disableApply
 	}
 
 	noOpLocker := locking.NewNoOpLocker()
-	if userConfig.DisableRepoLocking {
+	var lockQueuePlanner *events.LockQueuePlanner
+	switch {
+	case userConfig.DisableRepoLocking:
 		logger.Info("Repo Locking is disabled")
 		lockingClient = noOpLocker
-	} else {
+	case userConfig.EnableLockQueue:
+		logger.Info("Queueing pull requests that are waiting for locks")
+		lockQueuePlanner = &events.LockQueuePlanner{Logger: logger}
+		lockingClient = locking.NewQueueingClient(database, lockQueuePlanner, logger)
+	default:
 		lockingClient = locking.NewClient(database)
 	}
+	if len(webhooksManager.LifecycleWebhooks) > 0 && !userConfig.DisableRepoLocking {
+		lockingClient = locking.NewNotifyingLocker(lockingClient, &events.LockWebhookNotifier{
+			Webhooks: webhooksManager,
+			Logger:   logger,
+		})
+	}
 	disableGlobalApplyLock := userConfig.DisableGlobalApplyLock
 
 	applyLockingClient = locking.NewApplyClient(database, disableApply, disableGlobalApplyLock)
@@ -557,6 +648,11 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		statsScope,
 		logger,
 	)
+	if distributedDB != nil {
+		// Drift detection schedules, the lock reaper and plan garbage
+		// collection only run on the replica holding their lease.
+		scheduledExecutorService.SetLease(redis.NewJobLease(distributedDB, replicaName))
+	}
 
 	// provide fresh tokens before clone from the GitHub Apps integration, proxy workingDir
 	if githubAppEnabled {
@@ -662,6 +758,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		DefaultTFVersion:        defaultTfVersion,
 		TerraformBinDir:         terraformBinDir,
 		ProjectCmdOutputHandler: projectCmdOutputHandler,
+		ProcessTracker:          processTracker,
 	}
 	drainer := &events.Drainer{}
 	statusController := &controllers.StatusController{
@@ -692,6 +789,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		Router:              router,
 	}
 	var planStore runtime.PlanStore
+	var hasSeparatePlanDir bool
 	if userConfig.EnableExternalStores {
 		psCfg := globalCfg.ExternalStores.PlanStore
 		if psCfg.Type == "" {
@@ -711,9 +809,46 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 			if err != nil {
 				return nil, fmt.Errorf("initializing S3 plan store: %w", err)
 			}
+		case "gcs":
+			logger.Info("initializing GCS plan store (bucket=%s)", psCfg.GCS.Bucket)
+			planStore, err = runtime.NewGCSPlanStore(runtime.GCSPlanStoreConfig{
+				Bucket:   psCfg.GCS.Bucket,
+				Prefix:   psCfg.GCS.Prefix,
+				Endpoint: psCfg.GCS.Endpoint,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("initializing GCS plan store: %w", err)
+			}
+		case "azure":
+			logger.Info("initializing Azure Blob Storage plan store (account=%s, container=%s)", psCfg.Azure.Account, psCfg.Azure.Container)
+			planStore, err = runtime.NewAzurePlanStore(runtime.AzurePlanStoreConfig{
+				Account:   psCfg.Azure.Account,
+				Container: psCfg.Azure.Container,
+				Prefix:    psCfg.Azure.Prefix,
+				Endpoint:  psCfg.Azure.Endpoint,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("initializing Azure Blob Storage plan store: %w", err)
+			}
 		default:
 			return nil, fmt.Errorf("unsupported plan store type %q", psCfg.Type)
 		}
+		if enc := psCfg.Encryption; enc.KeyProvider != "" {
+			var keys runtime.KeyProvider
+			switch enc.KeyProvider {
+			case "local":
+				keys, err = runtime.NewLocalKeyProvider(enc.KeyFile)
+			case "aws_kms":
+				keys, err = runtime.NewAWSKMSKeyProvider(enc.KMSKeyID, enc.KMSRegion)
+			default:
+				err = fmt.Errorf("unsupported key provider %q", enc.KeyProvider)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("initializing plan store encryption: %w", err)
+			}
+			logger.Info("encrypting plans in the plan store (key_provider=%s)", enc.KeyProvider)
+			planStore = runtime.NewEncryptedPlanStore(planStore, keys, logger)
+		}
 	} else {
 		local := &runtime.LocalPlanStore{}
 		// A plan store dir outside the data dir survives the loss of a
@@ -721,12 +856,78 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		// without an external store.
 		if userConfig.SharePlanDir != "" && filepath.Clean(userConfig.SharePlanDir) != filepath.Clean(userConfig.DataDir) {
 			local.SeparatePlanDir = userConfig.SharePlanDir
+			hasSeparatePlanDir = true
 		}
 		planStore = local
 	}
+	if userConfig.PlanSigningKeyFile != "" {
+		key, err := runtime.LoadPlanSigningKey(userConfig.PlanSigningKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("initializing plan signing: %w", err)
+		}
+		logger.Info("signing plan files and verifying them before apply")
+		planStore = runtime.NewSignedPlanStore(planStore, key, logger)
+	}
 
 	deleteLockCommand.PlanStore = planStore
 
+	if userConfig.EnablePlanGC {
+		// Plans in checkouts under the data dir are removed with the
+		// checkouts, so only external stores and a separate plan dir hold
+		// plans that need collecting.
+		lister, ok := planStore.(planstore.PlanLister)
+		if !ok || (!userConfig.EnableExternalStores && !hasSeparatePlanDir) {
+			return nil, fmt.Errorf("--enable-plan-gc requires --enable-external-stores or a --share-plan-dir outside --data-dir")
+		}
+		gc := &planstore.GarbageCollector{
+			Store:     lister,
+			VCSClient: vcsClient,
+			Retention: time.Duration(userConfig.PlanGCRetentionDays) * 24 * time.Hour,
+			DryRun:    userConfig.PlanGCDryRun,
+			Scope:     statsScope,
+			Logger:    logger,
+		}
+		// Stored plans don't record which VCS host their repo is on, so
+		// pull requests can only be looked up with a single VCS host.
+		if len(supportedVCSHosts) == 1 {
+			gc.VCSHost = &models.VCSHost{Type: supportedVCSHosts[0]}
+		} else {
+			logger.Warn("plan garbage collection only removes plans older than --plan-gc-retention-days when more than one VCS host is configured")
+		}
+		logger.Info("plan garbage collection is enabled (retention=%d days, dry_run=%t)", userConfig.PlanGCRetentionDays, userConfig.PlanGCDryRun)
+		scheduledExecutorService.AddJob(scheduled.JobDefinition{
+			Job:    gc,
+			Period: time.Hour,
+			Name:   planstore.GCJobName,
+		})
+	}
+
+	if globalCfg.HasLockTTL() {
+		if userConfig.DisableRepoLocking {
+			logger.Warn("repo_locks ttl in the server-side repo config is ignored because repo locking is disabled")
+		} else {
+			lockExpiredSender, err := webhooks.NewLockExpiredWebhookSender(webhooksConfig, webhookClients)
+			if err != nil {
+				return nil, fmt.Errorf("initializing lock_expired webhooks: %w", err)
+			}
+			scheduledExecutorService.AddJob(scheduled.JobDefinition{
+				Job: &events.LockReaper{
+					Locker:         lockingClient,
+					WorkingDir:     workingDir,
+					PlanStore:      planStore,
+					GlobalCfg:      globalCfg,
+					VCSClient:      vcsClient,
+					WebhookSender:  lockExpiredSender,
+					ExecutableName: userConfig.ExecutableName,
+					Scope:          statsScope,
+					Logger:         logger,
+				},
+				Period: events.LockReaperPeriod,
+				Name:   events.LockReaperJobName,
+			})
+		}
+	}
+
 	pullClosedExecutor := events.NewInstrumentedPullClosedExecutor(
 		statsScope,
 		logger,
@@ -800,7 +1001,10 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		),
 	}
 
-	cancellationTracker := events.NewCancellationTracker()
+	var cancellationTracker events.CancellationTracker = events.NewCancellationTracker()
+	if distributedDB != nil {
+		cancellationTracker = redis.NewCancellationTracker(distributedDB, logger)
+	}
 
 	projectCommandRunner := &events.DefaultProjectCommandRunner{
 		VcsClient:        vcsClient,
@@ -839,12 +1043,16 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		StateRmStepRunner:         runtime.NewStateRmStepRunner(terraformClient, defaultTfDistribution, defaultTfVersion, planStore),
 		WorkingDir:                workingDir,
 		Webhooks:                  webhooksManager,
+		LifecycleWebhooks:         webhooksManager,
 		WorkingDirLocker:          workingDirLocker,
 		ProjectJobURLGenerator:    router,
 		CommandRequirementHandler: applyRequirementHandler,
 		CancellationTracker:       cancellationTracker,
+		ProcessTracker:            processTracker,
 		ApplyPlanValidator:        &events.DefaultApplyPlanValidator{PullStatusFetcher: database, LivePullHeadFetcher: livePullHeadFetcher},
 		PlanStore:                 planStore,
+		DefaultPlanTimeout:        time.Duration(userConfig.PlanTimeoutSeconds) * time.Second,
+		DefaultApplyTimeout:       time.Duration(userConfig.ApplyTimeoutSeconds) * time.Second,
 	}
 
 	dbUpdater := &events.DBUpdater{
@@ -907,6 +1115,13 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		pullReqStatusFetcher,
 		userConfig.PendingApplyStatus,
 	)
+	if userConfig.AutoplanSupersede {
+		var autoplanRegistry events.AutoplanRegistry
+		if distributedDB != nil {
+			autoplanRegistry = redis.NewAutoplanRegistry(distributedDB, logger)
+		}
+		planCommandRunner.AutoplanSuperseder = events.NewAutoplanSuperseder(processTracker, time.Duration(userConfig.CancelGracePeriodSeconds)*time.Second, autoplanRegistry)
+	}
 
 	applyCommandRunner := events.NewApplyCommandRunner(
 		vcsClient,
@@ -977,6 +1192,17 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		pullUpdater,
 		workingDirLocker,
 		userConfig.SilenceNoProjects,
+		time.Duration(userConfig.CancelGracePeriodSeconds)*time.Second,
+	)
+	var cancelRelay *redis.CancelRelay
+	if distributedDB != nil {
+		cancelRelay = redis.NewCancelRelay(distributedDB, cancelCommandRunner.CancelRelayed, logger)
+		cancelCommandRunner.Relay = cancelRelay
+	}
+
+	driftCommandRunner := events.NewDriftCommandRunner(
+		vcsClient,
+		markdownRenderer,
 	)
 
 	commentCommandRunnerByCmd := map[command.Name]events.CommentCommandRunner{
@@ -988,6 +1214,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		command.Import:          importCommandRunner,
 		command.State:           stateCommandRunner,
 		command.Cancel:          cancelCommandRunner,
+		command.Drift:           driftCommandRunner,
 	}
 
 	var teamAllowlistChecker command.TeamAllowlistChecker
@@ -1040,7 +1267,12 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		TeamAllowlistChecker:           teamAllowlistChecker,
 		VarFileAllowlistChecker:        varFileAllowlistChecker,
 		CommitStatusUpdater:            commitStatusUpdater,
+		LifecycleWebhooks:              webhooksManager,
 	}
+	if lockQueuePlanner != nil {
+		lockQueuePlanner.CommandRunner = commandRunner
+	}
+
 	repoAllowlist, err := events.NewRepoAllowlistChecker(userConfig.RepoAllowlist)
 	if err != nil {
 		return nil, err
@@ -1102,23 +1334,82 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		PullStatusFetcher:               database,
 		LivePullHeadFetcher:             livePullHeadFetcher,
 		SilenceVCSStatusNoProjects:      userConfig.SilenceVCSStatusNoProjects,
+		WebhookDeadLetters:              webhookDeadLetters,
+		MergeQueueApply:                 userConfig.GithubMergeQueueApply,
 	}
 
+	var driftController *controllers.DriftController
 	if userConfig.EnableDriftDetection {
 		logger.Info("Drift detection is enabled")
-		driftStorage := drift.NewInMemoryStorage()
+		driftStorage, err := newDriftStorage(database, time.Duration(userConfig.DriftRetentionDays)*24*time.Hour)
+		if err != nil {
+			return nil, fmt.Errorf("initializing drift storage: %w", err)
+		}
+		if compactor, ok := driftStorage.(drift.Compactor); ok {
+			scheduledExecutorService.AddJob(scheduled.JobDefinition{
+				Job:    &drift.CompactionJob{Compactor: compactor, Logger: logger},
+				Period: time.Hour,
+			})
+		}
 		apiController.DriftStorage = driftStorage
-		apiController.RemediationService = drift.NewInMemoryRemediationService(driftStorage)
+
+		remediationStore, err := newRemediationStore(database, time.Duration(userConfig.DriftRemediationRetention)*24*time.Hour)
+		if err != nil {
+			return nil, fmt.Errorf("initializing drift remediation store: %w", err)
+		}
+		if compactor, ok := remediationStore.(drift.Compactor); ok {
+			scheduledExecutorService.AddJob(scheduled.JobDefinition{
+				Job:    &drift.CompactionJob{Compactor: compactor, Logger: logger},
+				Period: time.Hour,
+			})
+		}
+		driftMetrics := drift.NewMetrics(statsScope)
+		apiController.DriftMetrics = driftMetrics
+		apiController.RemediationService = drift.NewRemediationService(driftStorage, remediationStore, driftMetrics, router)
+
+		driftController = &controllers.DriftController{
+			AtlantisVersion:     config.AtlantisVersion,
+			AtlantisURL:         parsedURL,
+			Logger:              logger,
+			DriftStorage:        driftStorage,
+			RemediationService:  apiController.RemediationService,
+			DriftTemplate:       web_templates.DriftTemplate,
+			RemediationTemplate: web_templates.DriftRemediationTemplate,
+		}
 
 		driftWebhookSender, err := webhooks.NewDriftWebhookSender(webhooksConfig, webhookClients)
 		if err != nil {
 			return nil, fmt.Errorf("initializing drift webhooks: %w", err)
 		}
 		apiController.DriftWebhookSender = driftWebhookSender
+
+		driftCommandRunner.Detector = apiController
+
+		driftScheduler, err := drift.NewScheduler(globalCfg, apiController, logger, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("initializing drift detection schedules: %w", err)
+		}
+		if driftScheduler.Len() > 0 {
+			logger.Info("Scheduled drift detection is enabled for %d schedule(s)", driftScheduler.Len())
+			scheduledExecutorService.AddJob(scheduled.JobDefinition{
+				Job:    driftScheduler,
+				Period: drift.SchedulerPeriod,
+				Name:   drift.SchedulerJobName,
+			})
+		}
+	} else if slices.ContainsFunc(globalCfg.Repos, func(r valid.Repo) bool { return r.DriftDetection != nil }) {
+		logger.Warn("drift_detection schedules in the server-side repo config are ignored because drift detection is not enabled")
+	}
+
+	var eventsCommandRunner events.CommandRunner = commandRunner
+	var workQueue *redis.WorkQueue
+	if distributedDB != nil {
+		workQueue = redis.NewWorkQueue(distributedDB, commandRunner, replicaName, userConfig.DistributedWorkerConcurrency, logger)
+		eventsCommandRunner = workQueue
 	}
 
 	eventsController := &events_controllers.VCSEventsController{
-		CommandRunner:                   commandRunner,
+		CommandRunner:                   eventsCommandRunner,
 		PullCleaner:                     pullClosedExecutor,
 		Parser:                          eventParser,
 		CommentParser:                   commentParser,
@@ -1141,6 +1432,10 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		AzureDevopsRequestValidator:     &events_controllers.DefaultAzureDevopsRequestValidator{},
 		GiteaWebhookSecret:              []byte(userConfig.GiteaWebhookSecret),
 	}
+	if userConfig.GithubMergeQueue {
+		logger.Info("GitHub merge queue support is enabled")
+		eventsController.MergeGroupRunner = apiController
+	}
 	githubAppController := &controllers.GithubAppController{
 		AtlantisURL:         parsedURL,
 		Logger:              logger,
@@ -1169,6 +1464,7 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		JobsController:                 jobsController,
 		StatusController:               statusController,
 		APIController:                  apiController,
+		DriftController:                driftController,
 		IndexTemplate:                  web_templates.IndexTemplate,
 		LockDetailTemplate:             web_templates.LockTemplate,
 		ProjectJobsTemplate:            web_templates.ProjectJobsTemplate,
@@ -1183,6 +1479,8 @@ func NewServer(userConfig UserConfig, config Config) (*Server, error) {
 		WebPassword:                    userConfig.WebPassword,
 		ScheduledExecutorService:       scheduledExecutorService,
 		EnableProfilingAPI:             userConfig.EnableProfilingAPI,
+		WorkQueue:                      workQueue,
+		CancelRelay:                    cancelRelay,
 		database:                       database,
 	}
 
@@ -1215,6 +1513,11 @@ func (s *Server) SetupRoutes() {
 	s.Router.HandleFunc("/api/drift/remediate/{id}", s.APIController.GetRemediationResult).Methods("GET")
 	s.Router.HandleFunc("/api/drift/remediate", s.APIController.ListRemediationResults).Methods("GET")
 	s.Router.HandleFunc("/api/drift/remediate", s.APIController.Remediate).Methods("POST")
+	s.Router.HandleFunc("/api/webhooks/dead-letters", s.APIController.ListWebhookDeadLetters).Methods("GET")
+	if s.DriftController != nil {
+		s.Router.HandleFunc("/drift", s.DriftController.Get).Methods("GET")
+		s.Router.HandleFunc("/drift/remediations/{id}", s.DriftController.GetRemediation).Methods("GET").Name(DriftRemediationViewRouteName)
+	}
 	s.Router.HandleFunc("/github-app/exchange-code", s.GithubAppController.ExchangeCode).Methods("GET")
 	s.Router.HandleFunc("/github-app/setup", s.GithubAppController.New).Methods("GET")
 	s.Router.HandleFunc("/locks", s.LocksController.DeleteLock).Methods("DELETE").Queries("id", "{id:.*}")
@@ -1270,6 +1573,13 @@ func (s *Server) Start() error {
 		s.ProjectCmdOutputHandler.Handle()
 	}()
 
+	if s.WorkQueue != nil {
+		go s.WorkQueue.Start()
+	}
+	if s.CancelRelay != nil {
+		go s.CancelRelay.Start()
+	}
+
 	tlsConfig := &tls.Config{GetCertificate: s.GetSSLCertificate, MinVersion: tls.VersionTLS12}
 
 	server := &http.Server{Addr: fmt.Sprintf(":%d", s.Port), Handler: n, TLSConfig: tlsConfig, ReadHeaderTimeout: 10 * time.Second}
@@ -1290,6 +1600,13 @@ func (s *Server) Start() error {
 	<-stop
 
 	s.Logger.Warn("Received interrupt. Waiting for in-progress operations to complete")
+	if s.WorkQueue != nil {
+		// Leave queued commands for the other replicas.
+		s.WorkQueue.Stop()
+	}
+	if s.CancelRelay != nil {
+		s.CancelRelay.Stop()
+	}
 	s.waitForDrain()
 
 	// flush stats before shutdown
@@ -1329,6 +1646,54 @@ func (s *Server) waitForDrain() {
 	}
 }
 
+// newDriftStorage returns drift storage backed by the same database used for
+// locking, so drift results survive restarts. It falls back to in-memory
+// storage for database types without a drift implementation.
+func newDriftStorage(database db.Database, retention time.Duration) (drift.Storage, error) {
+	switch d := database.(type) {
+	case *boltdb.BoltDB:
+		return boltdb.NewDriftStorage(d, retention)
+	case *redis.RedisDB:
+		return redis.NewDriftStorage(d, retention), nil
+	case *postgres.PostgresDB:
+		return postgres.NewDriftStorage(d, retention), nil
+	default:
+		return drift.NewInMemoryStorage(), nil
+	}
+}
+
+// newWebhookDeadLetterStore returns a webhook dead letter store backed by the
+// same database used for locking. It falls back to in-memory storage for
+// database types without a dead letter store implementation.
+func newWebhookDeadLetterStore(database db.Database) (webhooks.DeadLetterStore, error) {
+	switch d := database.(type) {
+	case *boltdb.BoltDB:
+		return boltdb.NewWebhookDeadLetterStore(d)
+	case *redis.RedisDB:
+		return redis.NewWebhookDeadLetterStore(d), nil
+	case *postgres.PostgresDB:
+		return postgres.NewWebhookDeadLetterStore(d), nil
+	default:
+		return webhooks.NewInMemoryDeadLetterStore(), nil
+	}
+}
+
+// newRemediationStore returns a remediation history store backed by the same
+// database used for locking. It falls back to in-memory storage for database
+// types without a remediation store implementation.
+func newRemediationStore(database db.Database, retention time.Duration) (drift.RemediationStore, error) {
+	switch d := database.(type) {
+	case *boltdb.BoltDB:
+		return boltdb.NewRemediationStore(d, retention)
+	case *redis.RedisDB:
+		return redis.NewRemediationStore(d, retention), nil
+	case *postgres.PostgresDB:
+		return postgres.NewRemediationStore(d, retention), nil
+	default:
+		return drift.NewInMemoryRemediationStore(), nil
+	}
+}
+
 // closeDatabase attempts to close the database, waiting up to the given timeout.
 func (s *Server) closeDatabase(timeout time.Duration) error {
 	if s.database == nil {
@@ -1395,6 +1760,7 @@ func (s *Server) Index(w http.ResponseWriter, _ *http.Request) {
 		ApplyLock:        applyLockData,
 		AtlantisVersion:  s.AtlantisVersion,
 		CleanedBasePath:  s.AtlantisURL.Path,
+		DriftEnabled:     s.DriftController != nil,
 	})
 	if err != nil {
 		s.Logger.Err("%s", err.Error())
@@ -395,3 +395,32 @@ func TestSetupRoutes_APIRoutesRegistered(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupRoutes_DriftDashboardRoutes(t *testing.T) {
+	newServer := func(driftController *controllers.DriftController) *server.Server {
+		s := &server.Server{
+			Router:              mux.NewRouter(),
+			APIController:       &controllers.APIController{},
+			StatusController:    &controllers.StatusController{},
+			LocksController:     &controllers.LocksController{},
+			GithubAppController: &controllers.GithubAppController{},
+			JobsController:      &controllers.JobsController{},
+			VCSEventsController: &events_controllers.VCSEventsController{},
+			DriftController:     driftController,
+			Logger:              logging.NewNoopLogger(t),
+		}
+		s.SetupRoutes()
+		return s
+	}
+
+	for _, path := range []string{"/drift", "/drift/remediations/some-id"} {
+		req, err := http.NewRequest("GET", path, nil)
+		Ok(t, err)
+
+		var match mux.RouteMatch
+		Assert(t, newServer(&controllers.DriftController{}).Router.Match(req, &match),
+			"route %s should be registered when drift detection is enabled", path)
+		Assert(t, !newServer(nil).Router.Match(req, &match),
+			"route %s should not be registered when drift detection is disabled", path)
+	}
+}
@@ -17,52 +17,67 @@ import (
 // The mapstructure tags correspond to flags in cmd/server.go and are used when
 // the config is parsed from a YAML file.
 type UserConfig struct {
-	AllowForkPRs                bool   `mapstructure:"allow-fork-prs"`
-	AllowCommands               string `mapstructure:"allow-commands"`
-	BlockedExtraArgs            string `mapstructure:"blocked-extra-args"`
-	AtlantisURL                 string `mapstructure:"atlantis-url"`
-	AutoDiscoverModeFlag        string `mapstructure:"autodiscover-mode"`
-	Automerge                   bool   `mapstructure:"automerge"`
-	AutomergeMethod             string `mapstructure:"automerge-method"`
-	AutoplanFileList            string `mapstructure:"autoplan-file-list"`
-	AutoplanModules             bool   `mapstructure:"autoplan-modules"`
-	AutoplanModulesFromProjects string `mapstructure:"autoplan-modules-from-projects"`
-	AzureDevopsToken            string `mapstructure:"azuredevops-token"`
-	AzureDevopsUser             string `mapstructure:"azuredevops-user"`
-	AzureDevopsWebhookPassword  string `mapstructure:"azuredevops-webhook-password"`
-	AzureDevopsWebhookUser      string `mapstructure:"azuredevops-webhook-user"`
-	AzureDevOpsHostname         string `mapstructure:"azuredevops-hostname"`
-	BitbucketApiUser            string `mapstructure:"bitbucket-api-user"`
-	BitbucketBaseURL            string `mapstructure:"bitbucket-base-url"`
-	BitbucketToken              string `mapstructure:"bitbucket-token"`
-	BitbucketUser               string `mapstructure:"bitbucket-user"`
-	BitbucketWebhookSecret      string `mapstructure:"bitbucket-webhook-secret"`
-	CheckoutDepth               int    `mapstructure:"checkout-depth"`
-	CheckoutStrategy            string `mapstructure:"checkout-strategy"`
-	DataDir                     string `mapstructure:"data-dir"`
-	SharePlanDir                string `mapstructure:"share-plan-dir"`
-	DisableApplyAll             bool   `mapstructure:"disable-apply-all"`
-	DisableAutoplan             bool   `mapstructure:"disable-autoplan"`
-	DisableAutoplanLabel        string `mapstructure:"disable-autoplan-label"`
-	DisableAutomergeLabel       string `mapstructure:"disable-automerge-label"`
-	DisableMarkdownFolding      bool   `mapstructure:"disable-markdown-folding"`
-	DisableRepoLocking          bool   `mapstructure:"disable-repo-locking"`
-	DisableGlobalApplyLock      bool   `mapstructure:"disable-global-apply-lock"`
-	DisableUnlockLabel          string `mapstructure:"disable-unlock-label"`
-	DiscardApprovalOnPlanFlag   bool   `mapstructure:"discard-approval-on-plan"`
-	EmojiReaction               string `mapstructure:"emoji-reaction"`
-	EnablePolicyChecksFlag      bool   `mapstructure:"enable-policy-checks"`
-	EnableRegExpCmd             bool   `mapstructure:"enable-regexp-cmd"`
-	EnableProfilingAPI          bool   `mapstructure:"enable-profiling-api"`
-	EnableDiffMarkdownFormat    bool   `mapstructure:"enable-diff-markdown-format"`
-	EnableDriftDetection        bool   `mapstructure:"enable-drift-detection"`
-	EnableDriftRemediation      bool   `mapstructure:"enable-drift-remediation"`
-	ExecutableName              string `mapstructure:"executable-name"`
+	AllowForkPRs                 bool   `mapstructure:"allow-fork-prs"`
+	AllowCommands                string `mapstructure:"allow-commands"`
+	ApplyTimeoutSeconds          int    `mapstructure:"apply-timeout-seconds"`
+	BlockedExtraArgs             string `mapstructure:"blocked-extra-args"`
+	AtlantisURL                  string `mapstructure:"atlantis-url"`
+	AutoDiscoverModeFlag         string `mapstructure:"autodiscover-mode"`
+	Automerge                    bool   `mapstructure:"automerge"`
+	AutomergeMethod              string `mapstructure:"automerge-method"`
+	AutoplanFileList             string `mapstructure:"autoplan-file-list"`
+	AutoplanModules              bool   `mapstructure:"autoplan-modules"`
+	AutoplanModulesFromProjects  string `mapstructure:"autoplan-modules-from-projects"`
+	AutoplanSupersede            bool   `mapstructure:"autoplan-supersede"`
+	AzureDevopsToken             string `mapstructure:"azuredevops-token"`
+	AzureDevopsUser              string `mapstructure:"azuredevops-user"`
+	AzureDevopsWebhookPassword   string `mapstructure:"azuredevops-webhook-password"`
+	AzureDevopsWebhookUser       string `mapstructure:"azuredevops-webhook-user"`
+	AzureDevOpsHostname          string `mapstructure:"azuredevops-hostname"`
+	BitbucketApiUser             string `mapstructure:"bitbucket-api-user"`
+	BitbucketBaseURL             string `mapstructure:"bitbucket-base-url"`
+	BitbucketToken               string `mapstructure:"bitbucket-token"`
+	BitbucketUser                string `mapstructure:"bitbucket-user"`
+	BitbucketWebhookSecret       string `mapstructure:"bitbucket-webhook-secret"`
+	CancelGracePeriodSeconds     int    `mapstructure:"cancel-grace-period-seconds"`
+	CheckoutDepth                int    `mapstructure:"checkout-depth"`
+	CheckoutStrategy             string `mapstructure:"checkout-strategy"`
+	DataDir                      string `mapstructure:"data-dir"`
+	SharePlanDir                 string `mapstructure:"share-plan-dir"`
+	DisableApplyAll              bool   `mapstructure:"disable-apply-all"`
+	DisableAutoplan              bool   `mapstructure:"disable-autoplan"`
+	DisableAutoplanLabel         string `mapstructure:"disable-autoplan-label"`
+	DisableAutomergeLabel        string `mapstructure:"disable-automerge-label"`
+	DisableMarkdownFolding       bool   `mapstructure:"disable-markdown-folding"`
+	DisableRepoLocking           bool   `mapstructure:"disable-repo-locking"`
+	DisableGlobalApplyLock       bool   `mapstructure:"disable-global-apply-lock"`
+	DisableUnlockLabel           string `mapstructure:"disable-unlock-label"`
+	DiscardApprovalOnPlanFlag    bool   `mapstructure:"discard-approval-on-plan"`
+	EmojiReaction                string `mapstructure:"emoji-reaction"`
+	EnablePolicyChecksFlag       bool   `mapstructure:"enable-policy-checks"`
+	EnableRegExpCmd              bool   `mapstructure:"enable-regexp-cmd"`
+	EnableProfilingAPI           bool   `mapstructure:"enable-profiling-api"`
+	EnableDiffMarkdownFormat     bool   `mapstructure:"enable-diff-markdown-format"`
+	EnableDriftDetection         bool   `mapstructure:"enable-drift-detection"`
+	EnableDriftRemediation       bool   `mapstructure:"enable-drift-remediation"`
+	DriftRemediationRetention    int    `mapstructure:"drift-remediation-retention-days"`
+	DriftRetentionDays           int    `mapstructure:"drift-retention-days"`
+	EnableDistributedExecution   bool   `mapstructure:"enable-distributed-execution"`
+	DistributedWorkerConcurrency int    `mapstructure:"distributed-worker-concurrency"`
+	EnableLockQueue              bool   `mapstructure:"enable-lock-queue"`
+	EnablePlanGC                 bool   `mapstructure:"enable-plan-gc"`
+	PlanGCDryRun                 bool   `mapstructure:"plan-gc-dry-run"`
+	PlanGCRetentionDays          int    `mapstructure:"plan-gc-retention-days"`
+	PlanTimeoutSeconds           int    `mapstructure:"plan-timeout-seconds"`
+	ExecutableName               string `mapstructure:"executable-name"`
 	// Fail and do not run the Atlantis command request if any of the pre workflow hooks error.
 	FailOnPreWorkflowHookError      bool   `mapstructure:"fail-on-pre-workflow-hook-error"`
 	HideUnchangedPlanComments       bool   `mapstructure:"hide-unchanged-plan-comments"`
 	GithubAllowMergeableBypassApply bool   `mapstructure:"gh-allow-mergeable-bypass-apply"`
+	GithubCheckRuns                 bool   `mapstructure:"gh-check-runs"`
 	GithubHostname                  string `mapstructure:"gh-hostname"`
+	GithubMergeQueue                bool   `mapstructure:"gh-merge-queue"`
+	GithubMergeQueueApply           bool   `mapstructure:"gh-merge-queue-apply"`
 	GithubToken                     string `mapstructure:"gh-token"`
 	GithubTokenFile                 string `mapstructure:"gh-token-file"`
 	GithubUser                      string `mapstructure:"gh-user"`
@@ -97,12 +112,15 @@ type UserConfig struct {
 	LanguageConfigFile              string `mapstructure:"language-config-file"`
 	ParallelPoolSize                int    `mapstructure:"parallel-pool-size"`
 	ParallelPlan                    bool   `mapstructure:"parallel-plan"`
+	PlanSigningKeyFile              string `mapstructure:"plan-signing-key-file"`
 	ParallelApply                   bool   `mapstructure:"parallel-apply"`
 	PendingApplyStatus              bool   `mapstructure:"pending-apply-status"`
 	StatsNamespace                  string `mapstructure:"stats-namespace"`
 	PlanDrafts                      bool   `mapstructure:"allow-draft-prs"`
 	EnableExternalStores            bool   `mapstructure:"enable-external-stores"`
 	Port                            int    `mapstructure:"port"`
+	PostgresMaxConns                int    `mapstructure:"postgres-max-conns"`
+	PostgresURL                     string `mapstructure:"postgres-url"`
 	QuietPolicyChecks               bool   `mapstructure:"quiet-policy-checks"`
 	RedisDB                         int    `mapstructure:"redis-db"`
 	RedisHost                       string `mapstructure:"redis-host"`
@@ -143,6 +161,7 @@ type UserConfig struct {
 	DefaultTFVersion           string          `mapstructure:"default-tf-version"`
 	Webhooks                   []WebhookConfig `mapstructure:"webhooks" flag:"false"`
 	WebhookHttpHeaders         string          `mapstructure:"webhook-http-headers"`
+	WebhookHttpMaxRetries      int             `mapstructure:"webhook-http-max-retries"`
 	WebBasicAuth               bool            `mapstructure:"web-basic-auth"`
 	WebUsername                string          `mapstructure:"web-username"`
 	WebPassword                string          `mapstructure:"web-password"`